@@ -0,0 +1,61 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"go-gin-api-server/config"
+	"go-gin-api-server/internal/database"
+	"go-gin-api-server/internal/migration"
+	"go-gin-api-server/internal/repository"
+	"go-gin-api-server/pkg/logger"
+	"os"
+)
+
+// Applying migrations is left to the migrate CLI (scripts/migrate.sh, Dockerfile.migrate);
+// this tool only holds read-only actions that need the app's own config
+func main() {
+	action := flag.String("action", "check", "migration action: check")
+	path := flag.String("path", "migrations", "directory holding the migration files")
+	flag.Parse()
+
+	switch *action {
+	case "check":
+		os.Exit(check(*path))
+	default:
+		fmt.Fprintf(os.Stderr, "unknown action %q, use scripts/migrate.sh to apply migrations\n", *action)
+		os.Exit(migration.ExitError)
+	}
+}
+
+// check exits 0 when the database is at the latest migration, 1 when migrations are
+// pending or the last run left it dirty, 2 when the state can't be read
+func check(path string) int {
+	cfg := config.LoadConfig()
+	if err := logger.Init(cfg.Env); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to initialize logger: %v\n", err)
+		return migration.ExitError
+	}
+	if err := database.InitDatabase(cfg.Database); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to connect to database: %v\n", err)
+		return migration.ExitError
+	}
+	defer func() {
+		if err := database.CloseDatabase(); err != nil {
+			fmt.Fprintf(os.Stderr, "failed to close database: %v\n", err)
+		}
+	}()
+
+	status, err := migration.Check(migration.DirSource(path), repository.NewHealthRepository())
+	switch {
+	case err != nil:
+		fmt.Fprintf(os.Stderr, "failed to check migrations: %v\n", err)
+	case status.Dirty:
+		fmt.Printf("database is dirty at version %d, fix the failed migration and force the version\n", status.Current)
+	case len(status.Pending) > 0:
+		fmt.Printf("database at version %d, %d pending up to %d: %v\n",
+			status.Current, len(status.Pending), status.Latest, status.Pending)
+	default:
+		fmt.Printf("database is up to date at version %d\n", status.Current)
+	}
+	return migration.ExitCode(status, err)
+}
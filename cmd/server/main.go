@@ -2,7 +2,6 @@ package main
 
 import (
 	"context"
-	"fmt"
 	"go-gin-api-server/config"
 	"go-gin-api-server/internal/database"
 	"go-gin-api-server/internal/server"
@@ -12,7 +11,6 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
-	"time"
 
 	"go.uber.org/zap"
 )
@@ -39,16 +37,13 @@ func main() {
 		}
 	}()
 
-	router := server.NewServer(cfg)
+	// cancelled on shutdown to stop background jobs
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
 
-	srv := &http.Server{
-		Addr:              fmt.Sprintf(":%s", cfg.Port),
-		Handler:           router,
-		ReadHeaderTimeout: 10 * time.Second,
-		ReadTimeout:       30 * time.Second,
-		WriteTimeout:      30 * time.Second,
-		IdleTimeout:       120 * time.Second,
-	}
+	router, closeServer := server.NewServerWithContext(jobsCtx, cfg)
+
+	srv := server.NewHTTPServer(cfg, router)
 
 	go func() {
 		logger.Log.Info("Server is running on",
@@ -67,14 +62,17 @@ func main() {
 
 	logger.Log.Info("Shutting down server...")
 
-	// wait for 5 seconds before shutting down
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	// wait for in-flight requests before shutting down
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
 	defer cancel()
 
 	// shutdown server
 	if err := srv.Shutdown(ctx); err != nil {
 		logger.Log.Fatal("Forced to shutdown", zap.Error(err))
 	}
+	stopJobs()
+	// dispatch queued events while the database is still open
+	closeServer()
 
 	logger.Log.Info("Server exited gracefully")
 }
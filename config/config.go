@@ -3,8 +3,11 @@ package config
 import (
 	"fmt"
 	"log"
+	"net/netip"
 	"net/url"
 	"os"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -15,19 +18,194 @@ const (
 )
 
 type Config struct {
-	Env      string
-	Port     string
-	LogLevel string
-	JWT      JWTConfig
-	Database DatabaseConfig
+	Env       string
+	Port      string
+	LogLevel  string
+	Server    ServerConfig
+	JWT       JWTConfig
+	Auth      AuthConfig
+	Profile   ProfileConfig
+	Post      PostConfig
+	Export    ExportConfig
+	Welcome   WelcomePostConfig
+	Users     UsersConfig
+	RateLimit RateLimitConfig
+	Database  DatabaseConfig
+	Security  SecurityConfig
+}
+
+type ServerConfig struct {
+	ReadHeaderTimeout time.Duration
+	ReadTimeout       time.Duration
+	WriteTimeout      time.Duration
+	IdleTimeout       time.Duration
+	ShutdownTimeout   time.Duration
+	// MaxHeaderBytes caps request header size, together with ReadHeaderTimeout it mitigates slow-loris
+	MaxHeaderBytes int
+	// MaxBodyBytes caps the request body read by the JSON schema check; zero or less reads
+	// bodies of any size
+	MaxBodyBytes int64
+	// LatencyBudget logs a warning for requests slower than this; zero disables the warning
+	LatencyBudget time.Duration
+	// PrettyJSON indents JSON responses for debugging; defaults on in development and is
+	// always off in production
+	PrettyJSON bool
+	// APIVersion is sent as X-API-Version on every response; empty omits the header
+	APIVersion string
 }
 
 type JWTConfig struct {
-	Secret                 string
+	Secret string
+	// KeyID names Secret in the kid header of issued tokens; empty omits the header
+	KeyID string
+	// PreviousKeys kid -> secret of retired keys whose tokens are still accepted; to rotate,
+	// move the current KeyID/Secret here and set a new pair. A secret that was used without
+	// a KeyID goes under "", its tokens carry no kid
+	PreviousKeys           map[string]string
 	AccessTokenExpiration  time.Duration
 	RefreshTokenExpiration time.Duration
 }
 
+type AuthConfig struct {
+	// SelfReactivateOnLogin reactivates a self-deactivated account on successful login
+	SelfReactivateOnLogin bool
+	// DefaultRole is assigned to newly registered users; only "user" and "moderator" are
+	// honoured, anything else falls back to "user" so admin can never be granted by config
+	DefaultRole string
+	// BlockDisposableEmails rejects registration with an email on DisposableEmailDomains
+	BlockDisposableEmails bool
+	// DisposableEmailDomains lowercased, subdomains of a listed domain match too
+	DisposableEmailDomains []string
+	// ImpersonationTTL is how long an admin's login-as token lasts; zero uses the access
+	// token lifetime
+	ImpersonationTTL time.Duration
+	// APIKeyDefaultTTL expires API keys created without expires_at after this; zero
+	// leaves them valid until revoked
+	APIKeyDefaultTTL time.Duration
+	// APIKeyLastUsedInterval debounces last_used_at writes, a busy key would otherwise
+	// write on every request
+	APIKeyLastUsedInterval time.Duration
+	// RefreshedTokenInBody also returns an auto-refreshed access token in JSON object
+	// bodies as new_access_token; the X-New-Access-Token header is always sent
+	RefreshedTokenInBody bool
+	// password complexity rules for new passwords, checked on registration
+	PasswordMinLength     int
+	PasswordRequireDigit  bool
+	PasswordRequireUpper  bool
+	PasswordRequireSymbol bool
+}
+
+type ProfileConfig struct {
+	// ShowBirthDate exposes birth date on the public user profile
+	ShowBirthDate bool
+	// CacheTTL caches public profile lookups; zero disables the cache
+	CacheTTL time.Duration
+	// StaleTTL keeps the last good profile this long to serve, flagged stale, while the
+	// database is unavailable; zero disables the fallback
+	StaleTTL time.Duration
+}
+
+type PostConfig struct {
+	// content length bounds in bytes, checked after trimming whitespace
+	ContentMinLength int
+	ContentMaxLength int
+	// TitleMaxLength caps the optional title in bytes after trimming; zero means no cap
+	TitleMaxLength int
+	// CollectValidationErrors reports every content and title failure in one response
+	// instead of stopping at the first
+	CollectValidationErrors bool
+	// ArchiveAfter archives posts older than this, leaving them out of the default
+	// listings; zero disables archiving
+	ArchiveAfter time.Duration
+	// ArchiveInterval is how often the archiving job runs, zero or negative uses 1h
+	ArchiveInterval time.Duration
+	// PreviewLength is the content length in characters of the compact list view
+	PreviewLength int
+	// MaxMentions caps the distinct @mentions notified per post; zero means no cap
+	MaxMentions int
+	// RejectExcessMentions rejects a new post with more than MaxMentions mentions
+	// instead of notifying only the first MaxMentions
+	RejectExcessMentions bool
+	// RequireIfMatch rejects PATCH and PUT requests without an If-Match header; when false the
+	// header is optional and only checked when sent
+	RequireIfMatch bool
+	// StaleTTL keeps the last good post read by ID this long to serve, flagged stale, while
+	// the database is unavailable; zero disables the fallback
+	StaleTTL time.Duration
+}
+
+type ExportConfig struct {
+	// BatchSize rows read per repository call, bounds the memory an export holds
+	BatchSize int
+	// PageSize caps the posts and notifications written per export response, the rest is
+	// fetched with the returned continuation token; zero exports everything at once
+	PageSize int
+}
+
+// DefaultWelcomePostTemplate is used when WELCOME_POST_TEMPLATE is not set
+const DefaultWelcomePostTemplate = "Hi everyone, I'm {{.Name}} and I just joined!"
+
+type WelcomePostConfig struct {
+	// Enabled creates a welcome post for every newly registered user
+	Enabled bool
+	// Template is a text/template rendered with .Name and .Username
+	Template string
+	// AuthorID posts as a system account; empty posts as the new user
+	AuthorID string
+}
+
+// Access levels for the user lookup routes (/users/username/:username, /users/email/:email)
+const (
+	LookupAccessAuthenticated = "authenticated"
+	LookupAccessAdmin         = "admin"
+	LookupAccessDisabled      = "disabled"
+)
+
+type UsersConfig struct {
+	// LookupAccess controls who can use the user lookup routes, see LookupAccess* constants
+	LookupAccess string
+	// MaxBlocks caps how many users one user can block; zero means no cap
+	MaxBlocks int
+}
+
+type RateLimitConfig struct {
+	// ProfileRequests is the per-IP request budget for batch profile lookups; zero disables limiting
+	ProfileRequests int
+	ProfileWindow   time.Duration
+	// ReadAnonymousRequests and ReadAuthenticatedRequests are the budgets for the public
+	// post and profile reads, per IP for anonymous callers and per user ID for signed-in ones; zero
+	// disables limiting for that kind of caller
+	ReadAnonymousRequests     int
+	ReadAuthenticatedRequests int
+	ReadWindow                time.Duration
+	// HeavyConcurrency caps concurrent expensive queries (post search, offset listings with
+	// counts and the like) across all clients; requests over it get 503, zero disables the cap
+	HeavyConcurrency int
+	// GlobalConcurrency caps in-flight requests across the whole server, health checks,
+	// the WebSocket and the export excepted; requests over it get 503, zero disables the cap
+	GlobalConcurrency int
+}
+
+type SecurityConfig struct {
+	HeadersEnabled bool
+	NoSniff        bool
+	FrameOptions   string
+	ReferrerPolicy string
+	HSTSEnabled    bool
+	HSTSMaxAge     time.Duration
+	// reject POST/PUT/PATCH bodies that aren't JSON with 415
+	EnforceJSONContentType bool
+	// MaxQueryBytes rejects longer raw query strings with 414; zero disables the check
+	MaxQueryBytes int
+	// TrustedProxies CIDRs/IPs whose X-Forwarded-For is used for the client IP; empty ignores
+	// the header and uses the peer address
+	TrustedProxies []string
+	// AdminAllowCIDRs restricts /api/v1/admin to these ranges, empty allows any address;
+	// AdminDenyCIDRs is checked first and always wins
+	AdminAllowCIDRs []netip.Prefix
+	AdminDenyCIDRs  []netip.Prefix
+}
+
 type DatabaseConfig struct {
 	Host     string
 	Port     string
@@ -36,6 +214,15 @@ type DatabaseConfig struct {
 	DBName   string
 	SSLMode  string
 	URL      string
+	// LogLevel for SQL logging: debug logs every query, info/warn only slow ones, error
+	// only failures, silent nothing; defaults to LogLevel in development, warn in
+	// production (slow queries point at missing indexes) and silent otherwise
+	LogLevel string
+	// SlowQueryThreshold logs queries slower than this as warnings; zero disables it
+	SlowQueryThreshold time.Duration
+	// LogParams includes bound values in logged SQL, off by default so personal data and
+	// secrets stay out of the logs
+	LogParams bool
 }
 
 var AppConfig *Config
@@ -43,7 +230,11 @@ var AppConfig *Config
 func LoadConfig() *Config {
 	env := getEnv("APP_ENV", Development)
 	databaseURL := getEnv("DATABASE_URL", "")
+	logLevel := getEnv("LOG_LEVEL", "debug")
 	dbConfig := parseDatabaseURL(databaseURL)
+	dbConfig.LogLevel = getEnv("DB_LOG_LEVEL", defaultSQLLogLevel(env, logLevel))
+	dbConfig.SlowQueryThreshold = getDurationEnv("DB_SLOW_QUERY_THRESHOLD", 200*time.Millisecond)
+	dbConfig.LogParams = getBoolEnv("DB_LOG_PARAMS", false)
 
 	fmt.Printf("=====================DATABASE CONFIG=========================\n")
 	fmt.Printf("APP_ENV: %s\n", env)
@@ -58,13 +249,101 @@ func LoadConfig() *Config {
 	AppConfig = &Config{
 		Env:      env,
 		Port:     getEnv("PORT", "8080"),
-		LogLevel: getEnv("LOG_LEVEL", "debug"),
+		LogLevel: logLevel,
+		Server: ServerConfig{
+			ReadHeaderTimeout: getDurationEnv("SERVER_READ_HEADER_TIMEOUT", 10*time.Second),
+			ReadTimeout:       getDurationEnv("SERVER_READ_TIMEOUT", 30*time.Second),
+			WriteTimeout:      getDurationEnv("SERVER_WRITE_TIMEOUT", 30*time.Second),
+			IdleTimeout:       getDurationEnv("SERVER_IDLE_TIMEOUT", 120*time.Second),
+			ShutdownTimeout:   getDurationEnv("SERVER_SHUTDOWN_TIMEOUT", 5*time.Second),
+			MaxHeaderBytes:    getIntEnv("SERVER_MAX_HEADER_BYTES", 1<<20),
+			MaxBodyBytes:      int64(getIntEnv("SERVER_MAX_BODY_BYTES", 1<<20)),
+			LatencyBudget:     getDurationEnv("SERVER_LATENCY_BUDGET", time.Second),
+			PrettyJSON:        env != Production && getBoolEnv("SERVER_PRETTY_JSON", env == Development),
+			APIVersion:        getEnv("API_VERSION", "v1"),
+		},
 		JWT: JWTConfig{
 			Secret:                 getEnv("JWT_SECRET", "your-secret-key-change-in-production"),
+			KeyID:                  getEnv("JWT_KEY_ID", ""),
+			PreviousKeys:           getKeyMapEnv("JWT_PREVIOUS_KEYS"),
 			AccessTokenExpiration:  getDurationEnv("JWT_ACCESS_TOKEN_EXPIRATION", 15*time.Minute),
 			RefreshTokenExpiration: getDurationEnv("JWT_REFRESH_TOKEN_EXPIRATION", 7*24*time.Hour),
 		},
+		Auth: AuthConfig{
+			SelfReactivateOnLogin: getBoolEnv("AUTH_SELF_REACTIVATE_ON_LOGIN", true),
+			DefaultRole:           getEnv("AUTH_DEFAULT_ROLE", "user"),
+			BlockDisposableEmails: getBoolEnv("AUTH_BLOCK_DISPOSABLE_EMAILS", false),
+			DisposableEmailDomains: getDomainListEnv(
+				"AUTH_DISPOSABLE_EMAIL_DOMAINS", "AUTH_DISPOSABLE_EMAIL_DOMAINS_FILE"),
+			ImpersonationTTL:       getDurationEnv("AUTH_IMPERSONATION_TTL", 10*time.Minute),
+			RefreshedTokenInBody:   getBoolEnv("AUTH_REFRESHED_TOKEN_IN_BODY", false),
+			APIKeyDefaultTTL:       getDurationEnv("AUTH_API_KEY_DEFAULT_TTL", 0),
+			APIKeyLastUsedInterval: getDurationEnv("AUTH_API_KEY_LAST_USED_INTERVAL", time.Minute),
+			PasswordMinLength:      getIntEnv("AUTH_PASSWORD_MIN_LENGTH", 6),
+			PasswordRequireDigit:   getBoolEnv("AUTH_PASSWORD_REQUIRE_DIGIT", false),
+			PasswordRequireUpper:   getBoolEnv("AUTH_PASSWORD_REQUIRE_UPPER", false),
+			PasswordRequireSymbol:  getBoolEnv("AUTH_PASSWORD_REQUIRE_SYMBOL", false),
+		},
+		Profile: ProfileConfig{
+			ShowBirthDate: getBoolEnv("PROFILE_SHOW_BIRTH_DATE", true),
+			CacheTTL:      getDurationEnv("PROFILE_CACHE_TTL", time.Minute),
+			StaleTTL:      getDurationEnv("PROFILE_STALE_TTL", 0),
+		},
+		Post: PostConfig{
+			ContentMinLength: getIntEnv("POST_CONTENT_MIN_LENGTH", 10),
+			ContentMaxLength: getIntEnv("POST_CONTENT_MAX_LENGTH", 255),
+			TitleMaxLength:   getIntEnv("POST_TITLE_MAX_LENGTH", 100),
+
+			CollectValidationErrors: getBoolEnv("POST_COLLECT_VALIDATION_ERRORS", false),
+			RequireIfMatch:          getBoolEnv("POST_REQUIRE_IF_MATCH", false),
+			PreviewLength:           getIntEnv("POST_PREVIEW_LENGTH", 80),
+			ArchiveAfter:            getDurationEnv("POST_ARCHIVE_AFTER", 0),
+			ArchiveInterval:         getPositiveDurationEnv("POST_ARCHIVE_INTERVAL", time.Hour),
+			MaxMentions:             getIntEnv("POST_MAX_MENTIONS", 10),
+			RejectExcessMentions:    getBoolEnv("POST_REJECT_EXCESS_MENTIONS", false),
+			StaleTTL:                getDurationEnv("POST_STALE_TTL", 0),
+		},
+		Export: ExportConfig{
+			BatchSize: getIntEnv("EXPORT_BATCH_SIZE", 100),
+			PageSize:  getIntEnv("EXPORT_PAGE_SIZE", 5000),
+		},
+		Welcome: WelcomePostConfig{
+			Enabled:  getBoolEnv("WELCOME_POST_ENABLED", false),
+			Template: getEnv("WELCOME_POST_TEMPLATE", DefaultWelcomePostTemplate),
+			AuthorID: getEnv("WELCOME_POST_AUTHOR_ID", ""),
+		},
+		Users: UsersConfig{
+			// 生產環境預設僅管理員可查詢，避免洩漏 email 與帳號枚舉
+			LookupAccess: getEnv("USER_LOOKUP_ACCESS", defaultLookupAccess(env)),
+			MaxBlocks:    getIntEnv("USER_MAX_BLOCKS", 1000),
+		},
+		RateLimit: RateLimitConfig{
+			ProfileRequests:   getIntEnv("RATE_LIMIT_PROFILE_REQUESTS", 60),
+			ProfileWindow:     getDurationEnv("RATE_LIMIT_PROFILE_WINDOW", time.Minute),
+			HeavyConcurrency:  getIntEnv("RATE_LIMIT_HEAVY_CONCURRENCY", 8),
+			GlobalConcurrency: getIntEnv("RATE_LIMIT_GLOBAL_CONCURRENCY", 0),
+
+			ReadAnonymousRequests:     getIntEnv("RATE_LIMIT_READ_ANON_REQUESTS", 60),
+			ReadAuthenticatedRequests: getIntEnv("RATE_LIMIT_READ_AUTH_REQUESTS", 300),
+			ReadWindow:                getDurationEnv("RATE_LIMIT_READ_WINDOW", time.Minute),
+		},
 		Database: dbConfig,
+		Security: SecurityConfig{
+			HeadersEnabled: getBoolEnv("SECURITY_HEADERS_ENABLED", true),
+			NoSniff:        getBoolEnv("SECURITY_NOSNIFF", true),
+			FrameOptions:   getEnv("SECURITY_FRAME_OPTIONS", "DENY"),
+			ReferrerPolicy: getEnv("SECURITY_REFERRER_POLICY", "strict-origin-when-cross-origin"),
+			// HSTS 只在生產環境（TLS 終止於前端代理）預設開啟
+			HSTSEnabled: getBoolEnv("SECURITY_HSTS_ENABLED", env == Production),
+			HSTSMaxAge:  getDurationEnv("SECURITY_HSTS_MAX_AGE", 365*24*time.Hour),
+
+			EnforceJSONContentType: getBoolEnv("SECURITY_ENFORCE_JSON_CONTENT_TYPE", true),
+			MaxQueryBytes:          getIntEnv("SECURITY_MAX_QUERY_BYTES", 2048),
+
+			TrustedProxies:  getListEnv("SECURITY_TRUSTED_PROXIES"),
+			AdminAllowCIDRs: getCIDRListEnv("SECURITY_ADMIN_ALLOW_CIDRS"),
+			AdminDenyCIDRs:  getCIDRListEnv("SECURITY_ADMIN_DENY_CIDRS"),
+		},
 	}
 
 	// 生產環境安全檢查
@@ -81,11 +360,53 @@ func LoadTestConfig() *Config {
 		Env:      Test,
 		Port:     "8080",
 		LogLevel: "error", // 測試時減少日誌輸出
+		Server: ServerConfig{
+			ReadHeaderTimeout: 10 * time.Second,
+			ReadTimeout:       30 * time.Second,
+			WriteTimeout:      30 * time.Second,
+			IdleTimeout:       120 * time.Second,
+			ShutdownTimeout:   5 * time.Second,
+			MaxHeaderBytes:    1 << 20,
+			MaxBodyBytes:      1 << 20,
+			LatencyBudget:     time.Second,
+			APIVersion:        "v1",
+		},
 		JWT: JWTConfig{
 			Secret:                 "test-secret-key",
 			AccessTokenExpiration:  15 * time.Minute,
 			RefreshTokenExpiration: 7 * 24 * time.Hour,
 		},
+		Auth: AuthConfig{
+			SelfReactivateOnLogin: true,
+			DefaultRole:           "user",
+		},
+		Profile: ProfileConfig{
+			ShowBirthDate: true,
+			CacheTTL:      0, // 測試時關閉快取，避免測試間互相影響
+		},
+		Post: PostConfig{
+			ContentMinLength: 10,
+			ContentMaxLength: 255,
+			TitleMaxLength:   100,
+		},
+		Export: ExportConfig{
+			BatchSize: 100,
+			PageSize:  0,
+		},
+		Welcome: WelcomePostConfig{
+			Enabled:  false,
+			Template: DefaultWelcomePostTemplate,
+		},
+		Users: UsersConfig{
+			LookupAccess: LookupAccessAuthenticated,
+			MaxBlocks:    1000,
+		},
+		RateLimit: RateLimitConfig{
+			ProfileRequests:   0,
+			ProfileWindow:     time.Minute,
+			HeavyConcurrency:  0,
+			GlobalConcurrency: 0,
+		},
 		Database: DatabaseConfig{
 			Host:     getEnv("DB_HOST", "localhost"),
 			Port:     getEnv("TEST_DB_PORT", "5433"),
@@ -93,8 +414,39 @@ func LoadTestConfig() *Config {
 			Password: getEnv("DB_PASSWORD", "password"),
 			DBName:   getEnv("TEST_DB_NAME", "gin_api_server_test"),
 			SSLMode:  getEnv("DB_SSLMODE", "disable"),
+			LogLevel: "silent",
 		},
+		Security: SecurityConfig{
+			HeadersEnabled: true,
+			NoSniff:        true,
+			FrameOptions:   "DENY",
+			ReferrerPolicy: "strict-origin-when-cross-origin",
+			HSTSEnabled:    false,
+			HSTSMaxAge:     365 * 24 * time.Hour,
+
+			EnforceJSONContentType: true,
+			MaxQueryBytes:          2048,
+		},
+	}
+}
+
+// defaultSQLLogLevel every query is only logged in development, following the app log
+// level; production keeps slow queries and failures
+func defaultSQLLogLevel(env, logLevel string) string {
+	switch env {
+	case Development:
+		return logLevel
+	case Production:
+		return "warn"
+	}
+	return "silent"
+}
+
+func defaultLookupAccess(env string) string {
+	if env == Production {
+		return LookupAccessAdmin
 	}
+	return LookupAccessAuthenticated
 }
 
 func getEnv(key, fallback string) string {
@@ -113,6 +465,104 @@ func getDurationEnv(key string, fallback time.Duration) time.Duration {
 	return fallback
 }
 
+// getPositiveDurationEnv is getDurationEnv for values that must be above zero, such as
+// ticker intervals; zero or negative falls back too
+func getPositiveDurationEnv(key string, fallback time.Duration) time.Duration {
+	if duration := getDurationEnv(key, fallback); duration > 0 {
+		return duration
+	}
+	return fallback
+}
+
+func getIntEnv(key string, fallback int) int {
+	if value := os.Getenv(key); value != "" {
+		if i, err := strconv.Atoi(value); err == nil {
+			return i
+		}
+	}
+	return fallback
+}
+
+// getKeyMapEnv parses comma separated id:value pairs, skipping malformed entries; an
+// empty id (":value") is kept under "", for a key that was never given an id
+func getKeyMapEnv(key string) map[string]string {
+	result := map[string]string{}
+	for _, pair := range strings.Split(os.Getenv(key), ",") {
+		id, value, ok := strings.Cut(strings.TrimSpace(pair), ":")
+		if !ok || value == "" {
+			continue
+		}
+		result[id] = value
+	}
+	return result
+}
+
+// getDomainListEnv merges the comma separated domains in key with the file named by fileKey
+// (one domain per line, # starts a comment); entries are lowercased and deduplicated
+func getDomainListEnv(key, fileKey string) []string {
+	entries := strings.Split(os.Getenv(key), ",")
+	if path := os.Getenv(fileKey); path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			log.Fatalf("%s: %v", fileKey, err)
+		}
+		for _, line := range strings.Split(string(data), "\n") {
+			line, _, _ = strings.Cut(line, "#")
+			entries = append(entries, line)
+		}
+	}
+
+	seen := map[string]bool{}
+	var domains []string
+	for _, entry := range entries {
+		domain := strings.ToLower(strings.TrimSpace(entry))
+		if domain == "" || seen[domain] {
+			continue
+		}
+		seen[domain] = true
+		domains = append(domains, domain)
+	}
+	return domains
+}
+
+// getListEnv splits a comma separated value, dropping empty entries
+func getListEnv(key string) []string {
+	var list []string
+	for _, entry := range strings.Split(os.Getenv(key), ",") {
+		if entry = strings.TrimSpace(entry); entry != "" {
+			list = append(list, entry)
+		}
+	}
+	return list
+}
+
+// getCIDRListEnv parses comma separated CIDRs, a bare IP is a single-address range;
+// an invalid entry is fatal so a typo can't silently open or lock out the admin routes
+func getCIDRListEnv(key string) []netip.Prefix {
+	var prefixes []netip.Prefix
+	for _, entry := range getListEnv(key) {
+		prefix, err := netip.ParsePrefix(entry)
+		if err != nil {
+			addr, addrErr := netip.ParseAddr(entry)
+			if addrErr != nil {
+				log.Fatalf("%s: invalid CIDR %q", key, entry)
+			}
+			prefix = netip.PrefixFrom(addr, addr.BitLen())
+		}
+		prefixes = append(prefixes, prefix.Masked())
+	}
+	return prefixes
+}
+
+func getBoolEnv(key string, fallback bool) bool {
+	if value := os.Getenv(key); value != "" {
+		if b, err := strconv.ParseBool(value); err == nil {
+			return b
+		}
+	}
+	return fallback
+}
+
 // parseDatabaseURL 解析 DATABASE_URL
 func parseDatabaseURL(databaseURL string) DatabaseConfig {
 	u, err := url.Parse(databaseURL)
@@ -165,4 +615,9 @@ func validateProductionConfig(cfg *Config) {
 	if len(cfg.JWT.Secret) < 32 {
 		log.Fatal("JWT_SECRET must be at least 32 characters in production")
 	}
+	for id, secret := range cfg.JWT.PreviousKeys {
+		if len(secret) < 32 {
+			log.Fatalf("JWT_PREVIOUS_KEYS secret %q must be at least 32 characters in production", id)
+		}
+	}
 }
@@ -1,6 +1,9 @@
 package integration
 
 import (
+	"fmt"
+	"go-gin-api-server/config"
+	"go-gin-api-server/internal/events"
 	"go-gin-api-server/internal/handler"
 	"go-gin-api-server/internal/middleware"
 	"go-gin-api-server/internal/model"
@@ -25,19 +28,30 @@ func setupIntegrationAuthRouter(db *gorm.DB) *gin.Engine {
 	userRepo := repository.NewUserRepositoryWithDB(db)
 	authRepo := repository.NewAuthRepositoryWithDB(db)
 
+	auditRepo := repository.NewAuditRepositoryWithDB(db)
+	apiKeyRepo := repository.NewAPIKeyRepositoryWithDB(db)
+	revokedTokenRepo := repository.NewRevokedTokenRepositoryWithDB(db)
+
 	// Setup services
-	authService := service.NewAuthService(userRepo, authRepo, globalJWTManager)
+	authService := service.NewAuthServiceWithDeps(userRepo, authRepo, globalJWTManager, config.AuthConfig{
+		SelfReactivateOnLogin: true,
+		DefaultRole:           string(model.RoleUser),
+	}, service.AuthServiceDeps{Denylist: revokedTokenRepo, Audit: auditRepo})
+	apiKeyService := service.NewAPIKeyService(apiKeyRepo)
 
 	// Setup handlers
 	authHandler := handler.NewAuthHandler(authService, logger.Log)
+	apiKeyHandler := handler.NewAPIKeyHandler(apiKeyService, logger.Log)
 
 	// Setup middleware
-	authMiddleware := middleware.NewAuthMiddleware(authService, logger.Log)
+	authMiddleware := middleware.NewAuthMiddlewareWithDenylist(authService, revokedTokenRepo, logger.Log)
 	rbacMiddleware := middleware.NewRBACMiddleware(logger.Log)
+	apiKeyMiddleware := middleware.NewAPIKeyMiddleware(apiKeyService, logger.Log)
 
 	// Setup router
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
+	router.Use(apiKeyMiddleware.Authenticate())
 
 	// Register custom validator
 	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
@@ -45,8 +59,9 @@ func setupIntegrationAuthRouter(db *gorm.DB) *gin.Engine {
 	}
 
 	// Register routes
-	authHandler.RegisterRoutes(router)
+	authHandler.RegisterRoutes(router, 0)
 	authHandler.RegisterProtectedRoutes(router, authMiddleware, rbacMiddleware)
+	apiKeyHandler.RegisterProtectedRoutes(router, authMiddleware, rbacMiddleware)
 
 	return router
 }
@@ -159,6 +174,250 @@ func TestAuthIntegration_AuthLifecycle(t *testing.T) {
 	assert.Equal(t, http.StatusOK, deactivateResp.Code)
 }
 
+func TestAuthIntegration_Impersonate(t *testing.T) {
+	db := setup()
+	defer teardown(db)
+	router := setupIntegrationAuthRouter(db)
+
+	user := createTestUser(t, db)
+	admin := createTestUser(t, db, map[string]interface{}{
+		"username": "adminuser",
+		"email":    "admin@example.com",
+		"role":     model.RoleAdmin,
+	})
+	adminToken := createTestToken(t, admin)
+	userToken := createTestToken(t, user)
+
+	t.Run("AdminGetsMarkedToken", func(t *testing.T) {
+		// run
+		resp := makeHTTPRequest(t, router, "POST", "/api/v1/admin/users/"+user.ID+"/impersonate", nil, adminToken.AccessToken)
+
+		// assert
+		assert.Equal(t, http.StatusOK, resp.Code)
+		var response model.ImpersonationResponse
+		parseJSONResponse(t, resp, &response)
+		assert.Equal(t, user.ID, response.UserID)
+		assert.NotContains(t, resp.Body.String(), "refresh_token")
+
+		claims := validateJWTToken(t, response.AccessToken)
+		assert.Equal(t, user.ID, claims.UserID)
+		assert.Equal(t, model.RoleUser, claims.Role)
+		assert.True(t, claims.Impersonated())
+		assert.Equal(t, admin.ID, claims.ImpersonatorID)
+
+		var audits []model.AuditLog
+		assert.NoError(t, db.Where("action = ?", model.AuditActionImpersonate).Find(&audits).Error)
+		if assert.Len(t, audits, 1) {
+			assert.Equal(t, admin.ID, audits[0].ActorID)
+			assert.Equal(t, model.AuditTargetUser, audits[0].TargetType)
+			assert.Equal(t, user.ID, audits[0].TargetID)
+		}
+	})
+
+	t.Run("NonAdminForbidden", func(t *testing.T) {
+		resp := makeHTTPRequest(t, router, "POST", "/api/v1/admin/users/"+admin.ID+"/impersonate", nil, userToken.AccessToken)
+		assert.Equal(t, http.StatusForbidden, resp.Code)
+	})
+
+	t.Run("AdminTargetForbidden", func(t *testing.T) {
+		resp := makeHTTPRequest(t, router, "POST", "/api/v1/admin/users/"+admin.ID+"/impersonate", nil, adminToken.AccessToken)
+		assert.Equal(t, http.StatusForbidden, resp.Code)
+	})
+
+	t.Run("ImpersonationTokenCannotDeactivate", func(t *testing.T) {
+		// setup
+		resp := makeHTTPRequest(t, router, "POST", "/api/v1/admin/users/"+user.ID+"/impersonate", nil, adminToken.AccessToken)
+		assert.Equal(t, http.StatusOK, resp.Code)
+		var impersonation model.ImpersonationResponse
+		parseJSONResponse(t, resp, &impersonation)
+
+		// run
+		blocked := makeHTTPRequest(t, router, "POST", "/api/v1/auth/users/"+user.ID+"/deactivate", nil, impersonation.AccessToken)
+		allowed := makeHTTPRequest(t, router, "POST", "/api/v1/auth/users/"+user.ID+"/deactivate", nil, userToken.AccessToken)
+
+		// assert
+		assert.Equal(t, http.StatusForbidden, blocked.Code)
+		assert.Equal(t, http.StatusOK, allowed.Code)
+	})
+
+	t.Run("ImpersonationTokenCannotRefresh", func(t *testing.T) {
+		// setup
+		resp := makeHTTPRequest(t, router, "POST", "/api/v1/admin/users/"+user.ID+"/impersonate", nil, adminToken.AccessToken)
+		assert.Equal(t, http.StatusOK, resp.Code)
+		var impersonation model.ImpersonationResponse
+		parseJSONResponse(t, resp, &impersonation)
+
+		// run: the impersonation token planted as the refresh cookie
+		refresh := makeHTTPRequestWithCookie(t, router, "POST", "/api/v1/auth/refresh", nil, impersonation.AccessToken)
+
+		// assert
+		assert.Equal(t, http.StatusUnauthorized, refresh.Code)
+		assert.NotContains(t, refresh.Body.String(), "refresh_token")
+	})
+
+	t.Run("ImpersonationTokenCannotImpersonate", func(t *testing.T) {
+		// setup
+		resp := makeHTTPRequest(t, router, "POST", "/api/v1/admin/users/"+user.ID+"/impersonate", nil, adminToken.AccessToken)
+		assert.Equal(t, http.StatusOK, resp.Code)
+		var impersonation model.ImpersonationResponse
+		parseJSONResponse(t, resp, &impersonation)
+
+		// run
+		chained := makeHTTPRequest(t, router, "POST", "/api/v1/admin/users/"+user.ID+"/impersonate", nil, impersonation.AccessToken)
+
+		// assert
+		assert.Equal(t, http.StatusForbidden, chained.Code)
+	})
+
+	t.Run("APIKeyCannotImpersonate", func(t *testing.T) {
+		// setup
+		resp := makeHTTPRequest(t, router, "POST", "/api/v1/auth/api-keys", map[string]interface{}{
+			"name":   "admin key",
+			"scopes": []string{"read", "write"},
+		}, adminToken.AccessToken)
+		assert.Equal(t, http.StatusCreated, resp.Code)
+		var created model.CreateAPIKeyResponse
+		parseJSONResponse(t, resp, &created)
+
+		// run
+		req, err := http.NewRequest("POST", "/api/v1/admin/users/"+user.ID+"/impersonate", nil)
+		assert.NoError(t, err)
+		req.Header.Set("Authorization", "ApiKey "+created.Key)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		// assert
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+}
+
+func TestAuthIntegration_RevokeToken(t *testing.T) {
+	db := setup()
+	defer teardown(db)
+	defer db.Exec("DELETE FROM revoked_tokens")
+	// two instances behind the load balancer, sharing only the database
+	instanceA := setupIntegrationAuthRouter(db)
+	instanceB := setupIntegrationAuthRouter(db)
+
+	user := createTestUser(t, db)
+	admin := createTestUser(t, db, map[string]interface{}{
+		"username": "adminuser",
+		"email":    "admin@example.com",
+		"role":     model.RoleAdmin,
+	})
+	adminToken := createTestToken(t, admin)
+
+	t.Run("RevokedOnEveryInstance", func(t *testing.T) {
+		userToken := createTestToken(t, user)
+		assert.Equal(t, http.StatusOK, makeHTTPRequest(t, instanceB, "GET", "/api/v1/auth/api-keys", nil, userToken.AccessToken).Code)
+
+		// run
+		resp := makeHTTPRequest(t, instanceA, "POST", "/api/v1/admin/tokens/revoke", model.RevokeTokenRequest{Token: userToken.AccessToken}, adminToken.AccessToken)
+
+		// assert
+		assert.Equal(t, http.StatusNoContent, resp.Code)
+		for _, instance := range []*gin.Engine{instanceA, instanceB} {
+			assert.Equal(t, http.StatusUnauthorized, makeHTTPRequest(t, instance, "GET", "/api/v1/auth/api-keys", nil, userToken.AccessToken).Code)
+		}
+	})
+
+	t.Run("ImpersonationTokenKeptUntilItsOwnExpiry", func(t *testing.T) {
+		// setup
+		resp := makeHTTPRequest(t, instanceA, "POST", "/api/v1/admin/users/"+user.ID+"/impersonate", nil, adminToken.AccessToken)
+		assert.Equal(t, http.StatusOK, resp.Code)
+		var impersonation model.ImpersonationResponse
+		parseJSONResponse(t, resp, &impersonation)
+		claims := validateJWTToken(t, impersonation.AccessToken)
+
+		// run
+		resp = makeHTTPRequest(t, instanceB, "POST", "/api/v1/admin/tokens/revoke", model.RevokeTokenRequest{Token: impersonation.AccessToken}, adminToken.AccessToken)
+
+		// assert
+		assert.Equal(t, http.StatusNoContent, resp.Code)
+		var revoked model.RevokedToken
+		assert.NoError(t, db.Where("jti = ?", claims.ID).First(&revoked).Error)
+		assert.WithinDuration(t, claims.ExpiresAt.Time, revoked.ExpiresAt, time.Second)
+		assert.Equal(t, http.StatusUnauthorized, makeHTTPRequest(t, instanceA, "GET", "/api/v1/auth/api-keys", nil, impersonation.AccessToken).Code)
+	})
+}
+
+func TestAuthIntegration_APIKeys(t *testing.T) {
+	db := setup()
+	defer teardown(db)
+	router := setupIntegrationAuthRouter(db)
+
+	user := createTestUser(t, db)
+	userToken := createTestToken(t, user)
+
+	createKey := func(name string) model.CreateAPIKeyResponse {
+		resp := makeHTTPRequest(t, router, "POST", "/api/v1/auth/api-keys", map[string]interface{}{
+			"name":   name,
+			"scopes": []string{"read", "write"},
+		}, userToken.AccessToken)
+		assert.Equal(t, http.StatusCreated, resp.Code)
+		var created model.CreateAPIKeyResponse
+		parseJSONResponse(t, resp, &created)
+		return created
+	}
+	withAPIKey := func(method, url, key string) *httptest.ResponseRecorder {
+		req, err := http.NewRequest(method, url, nil)
+		assert.NoError(t, err)
+		req.Header.Set("Authorization", "ApiKey "+key)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("CreateStoresOnlyHash", func(t *testing.T) {
+		created := createKey("hash check")
+
+		var stored model.APIKey
+		assert.NoError(t, db.First(&stored, created.ID).Error)
+		assert.Equal(t, utils.HashAPIKey(created.Key), stored.KeyHash)
+		assert.NotContains(t, stored.KeyHash, created.Key)
+	})
+
+	t.Run("KeyCannotManageKeys", func(t *testing.T) {
+		created := createKey("no management")
+
+		resp := withAPIKey("GET", "/api/v1/auth/api-keys", created.Key)
+		assert.Equal(t, http.StatusForbidden, resp.Code)
+	})
+
+	t.Run("UnknownKeyUnauthorized", func(t *testing.T) {
+		resp := withAPIKey("POST", "/api/v1/auth/users/"+user.ID+"/deactivate", "gak_unknown")
+		assert.Equal(t, http.StatusUnauthorized, resp.Code)
+	})
+
+	t.Run("RevokedKeyUnauthorized", func(t *testing.T) {
+		created := createKey("revoked")
+
+		// run
+		revoke := makeHTTPRequest(t, router, "DELETE", fmt.Sprintf("/api/v1/auth/api-keys/%d", created.ID), nil, userToken.AccessToken)
+		resp := withAPIKey("POST", "/api/v1/auth/users/"+user.ID+"/deactivate", created.Key)
+
+		// assert
+		assert.Equal(t, http.StatusNoContent, revoke.Code)
+		assert.Equal(t, http.StatusUnauthorized, resp.Code)
+	})
+
+	t.Run("ValidKeyCannotWriteUnscopedRoute", func(t *testing.T) {
+		created := createKey("valid")
+
+		// run
+		resp := withAPIKey("POST", "/api/v1/auth/users/"+user.ID+"/deactivate", created.Key)
+
+		// assert: the key authenticates, but deactivation declares no scope
+		assert.Equal(t, http.StatusForbidden, resp.Code)
+		var stored model.APIKey
+		assert.NoError(t, db.First(&stored, created.ID).Error)
+		assert.NotNil(t, stored.LastUsedAt)
+		var storedUser model.User
+		assert.NoError(t, db.First(&storedUser, "id = ?", user.ID).Error)
+		assert.True(t, storedUser.IsActive)
+	})
+}
+
 func TestAuthIntegration_Unauthorized(t *testing.T) {
 	db := setup()
 	defer teardown(db)
@@ -290,3 +549,82 @@ func TestAuthIntegration_AutoRefresh(t *testing.T) {
 		t.Logf("Auto-refresh worked! New access token generated: %s...", newAccessToken[:20])
 	})
 }
+
+// setupIntegrationWelcomeRouter wires registration to the welcome post hook through the event bus;
+// close the returned bus to flush queued events before asserting
+func setupIntegrationWelcomeRouter(t *testing.T, db *gorm.DB, welcomeCfg config.WelcomePostConfig) (*gin.Engine, *events.Bus) {
+	userRepo := repository.NewUserRepositoryWithDB(db)
+	authRepo := repository.NewAuthRepositoryWithDB(db)
+	postRepo := repository.NewPostRepositoryWithDB(db)
+
+	bus := events.NewBus(logger.Log)
+	cfg := config.LoadTestConfig()
+	authService := service.NewAuthServiceWithDeps(userRepo, authRepo, globalJWTManager, cfg.Auth, service.AuthServiceDeps{Bus: bus})
+	postService := service.NewPostServiceWithConfig(postRepo, cfg.Post)
+
+	if welcomeCfg.Enabled {
+		welcomePostService, err := service.NewWelcomePostService(postService, welcomeCfg)
+		assert.NoError(t, err)
+		bus.Subscribe(func(event events.Event) {
+			assert.NoError(t, welcomePostService.HandleEvent(event))
+		}, events.TypeUserRegistered)
+	}
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		utils.RegisterCustomValidators(v)
+	}
+	handler.NewAuthHandler(authService, logger.Log).RegisterRoutes(router, 0)
+
+	return router, bus
+}
+
+func TestAuthIntegration_WelcomePost(t *testing.T) {
+	register := func(t *testing.T, router *gin.Engine) string {
+		birthDate := time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC)
+		registerResp := makeHTTPRequest(t, router, "POST", "/api/v1/auth/register", &model.RegisterRequest{
+			Name:      "Test User",
+			Username:  "testuser",
+			Email:     "test@example.com",
+			Password:  "password123",
+			BirthDate: &birthDate,
+		}, "")
+		assert.Equal(t, http.StatusCreated, registerResp.Code)
+
+		var tokens model.TokenResponse
+		parseJSONResponse(t, registerResp, &tokens)
+		return validateJWTToken(t, tokens.AccessToken).UserID
+	}
+
+	t.Run("Enabled", func(t *testing.T) {
+		db := setup()
+		defer teardown(db)
+		router, bus := setupIntegrationWelcomeRouter(t, db, config.WelcomePostConfig{
+			Enabled:  true,
+			Template: "Hi everyone, I'm {{.Name}} (@{{.Username}})",
+		})
+
+		userID := register(t, router)
+		bus.Close()
+
+		var posts []model.Post
+		assert.NoError(t, db.Where("author_id = ?", userID).Find(&posts).Error)
+		if assert.Len(t, posts, 1) {
+			assert.Equal(t, "Hi everyone, I'm Test User (@testuser)", posts[0].Content)
+		}
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		db := setup()
+		defer teardown(db)
+		router, bus := setupIntegrationWelcomeRouter(t, db, config.LoadTestConfig().Welcome)
+
+		userID := register(t, router)
+		bus.Close()
+
+		var count int64
+		assert.NoError(t, db.Model(&model.Post{}).Where("author_id = ?", userID).Count(&count).Error)
+		assert.Equal(t, int64(0), count)
+	})
+}
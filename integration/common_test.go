@@ -178,6 +178,11 @@ func createTestUser(t *testing.T, db *gorm.DB, overrides ...map[string]interface
 		&email,
 		&birthDate,
 	)
+	if len(overrides) > 0 {
+		if val, ok := overrides[0]["role"]; ok {
+			user.Role = val.(model.UserRole)
+		}
+	}
 
 	userRepo := repository.NewUserRepositoryWithDB(db)
 	createdUser, err := userRepo.Create(user)
@@ -0,0 +1,99 @@
+package integration
+
+import (
+	"fmt"
+	"go-gin-api-server/internal/handler"
+	"go-gin-api-server/internal/middleware"
+	"go-gin-api-server/internal/model"
+	"go-gin-api-server/internal/repository"
+	"go-gin-api-server/internal/service"
+	"go-gin-api-server/pkg/logger"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func setupIntegrationExportRouter(db *gorm.DB) *gin.Engine {
+	// Setup dependencies
+	userRepo := repository.NewUserRepositoryWithDB(db)
+	authRepo := repository.NewAuthRepositoryWithDB(db)
+	postRepo := repository.NewPostRepositoryWithDB(db)
+	notificationRepo := repository.NewNotificationRepositoryWithDB(db)
+
+	// Setup services
+	authService := service.NewAuthService(userRepo, authRepo, globalJWTManager)
+	exportService := service.NewExportService(userRepo, postRepo, notificationRepo)
+
+	// Setup handlers and middleware
+	exportHandler := handler.NewExportHandler(exportService, logger.Log)
+	authMiddleware := middleware.NewAuthMiddleware(authService, logger.Log)
+	rbacMiddleware := middleware.NewRBACMiddleware(logger.Log)
+
+	// Setup router
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	exportHandler.RegisterProtectedRoutes(r, authMiddleware, rbacMiddleware, middleware.NewConcurrencyLimitMiddleware(0, logger.Log))
+
+	return r
+}
+
+func TestExportIntegration_ExportMyData(t *testing.T) {
+	db := setup()
+	defer teardown(db)
+	router := setupIntegrationExportRouter(db)
+
+	user := createTestUser(t, db)
+	other := createTestUser(t, db, map[string]interface{}{
+		"username": "otheruser",
+		"email":    "other@example.com",
+	})
+	token := createTestToken(t, user).AccessToken
+
+	// seed data across entities, the other user's rows must not leak into the export
+	postRepo := repository.NewPostRepositoryWithDB(db)
+	notificationRepo := repository.NewNotificationRepositoryWithDB(db)
+	var postIDs []uint64
+	for i := 0; i < 3; i++ {
+		created, err := postRepo.Create(&model.Post{Content: fmt.Sprintf("Exported post %02d", i), AuthorID: user.ID})
+		assert.NoError(t, err)
+		postIDs = append(postIDs, created.ID)
+	}
+	_, err := postRepo.SetHidden(postIDs[0], true)
+	assert.NoError(t, err)
+	assert.NoError(t, db.Model(&model.Post{}).Where("id = ?", postIDs[1]).UpdateColumn("archived", true).Error)
+	_, err = postRepo.Create(&model.Post{Content: "Someone else's post", AuthorID: other.ID})
+	assert.NoError(t, err)
+	for _, postID := range postIDs[:2] {
+		_, err := notificationRepo.Create(&model.Notification{UserID: user.ID, ActorID: other.ID, Type: model.NotificationLike, PostID: &postID})
+		assert.NoError(t, err)
+	}
+	_, err = notificationRepo.Create(&model.Notification{UserID: other.ID, ActorID: user.ID, Type: model.NotificationMention})
+	assert.NoError(t, err)
+
+	t.Run("OwnData", func(t *testing.T) {
+		resp := makeHTTPRequest(t, router, "GET", "/api/v1/users/me/export", nil, token)
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Contains(t, resp.Header().Get("Content-Disposition"), "attachment")
+
+		var export model.UserExport
+		parseJSONResponse(t, resp, &export)
+		assert.Equal(t, user.ID, export.Profile.ID)
+		assert.Equal(t, *user.Email, *export.Profile.Email)
+		assert.Len(t, export.Posts, 3) // hidden and archived posts included
+		for _, post := range export.Posts {
+			assert.Equal(t, user.ID, post.AuthorID)
+		}
+		assert.Len(t, export.Notifications, 2)
+		for _, notification := range export.Notifications {
+			assert.Equal(t, user.ID, notification.UserID)
+		}
+	})
+
+	t.Run("Unauthorized", func(t *testing.T) {
+		resp := makeHTTPRequest(t, router, "GET", "/api/v1/users/me/export", nil, "")
+		assert.Equal(t, http.StatusUnauthorized, resp.Code)
+	})
+}
@@ -9,7 +9,10 @@ import (
 	"go-gin-api-server/internal/service"
 	"go-gin-api-server/pkg/logger"
 	"go-gin-api-server/pkg/utils"
+	"net/url"
+	"slices"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/gin-gonic/gin/binding"
@@ -43,10 +46,10 @@ func setupIntegrationPostRouter(db *gorm.DB) *gin.Engine {
 	}
 
 	// 註冊公開路由
-	postHandler.RegisterRoutes(r)
+	postHandler.RegisterRoutes(r, authMiddleware, rbacMiddleware, nil, nil)
 
 	// 註冊受保護的路由
-	postHandler.RegisterProtectedRoutes(r, authMiddleware, rbacMiddleware)
+	postHandler.RegisterProtectedRoutes(r, authMiddleware, rbacMiddleware, nil, 0)
 
 	return r
 }
@@ -68,10 +71,11 @@ func TestPostIntegration_PostLifecycle(t *testing.T) {
 	createResp := makeHTTPRequest(t, router, "POST", "/api/v1/posts", createReq, accessToken)
 	assert.Equal(t, 201, createResp.Code)
 
-	var createdPost model.Post
+	var createdPost model.PostResponse
 	parseJSONResponse(t, createResp, &createdPost)
 	assert.Equal(t, "Test Post Content", createdPost.Content)
 	assert.Equal(t, user.ID, createdPost.AuthorID)
+	assert.Equal(t, user.Name, createdPost.Author.Name) // the real author, not the deleted placeholder
 	postID := createdPost.ID
 
 	// 3. 獲取 post
@@ -93,9 +97,10 @@ func TestPostIntegration_PostLifecycle(t *testing.T) {
 	updateResp := makeHTTPRequest(t, router, "PATCH", fmt.Sprintf("/api/v1/posts/%d", postID), updateReq, accessToken)
 	assert.Equal(t, 200, updateResp.Code)
 
-	var updatedPost model.Post
+	var updatedPost model.PostResponse
 	parseJSONResponse(t, updateResp, &updatedPost)
 	assert.Equal(t, "Updated Post Content", updatedPost.Content)
+	assert.Equal(t, user.Name, updatedPost.Author.Name)
 
 	// 5. 刪除 post
 	deleteResp := makeHTTPRequest(t, router, "DELETE", fmt.Sprintf("/api/v1/posts/%d", postID), nil, accessToken)
@@ -161,7 +166,7 @@ func TestPostIntegration_UpdatePost_Unauthorized(t *testing.T) {
 	createResp := makeHTTPRequest(t, router, "POST", "/api/v1/posts", createReq, token.AccessToken)
 	assert.Equal(t, 201, createResp.Code)
 
-	var createdPost model.Post
+	var createdPost model.PostResponse
 	parseJSONResponse(t, createResp, &createdPost)
 
 	// 測試未認證的更新請求
@@ -206,6 +211,9 @@ func TestPostIntegration_GetPosts_CursorPagination(t *testing.T) {
 	var firstPage model.CursorResponse[model.PostResponse]
 	parseJSONResponse(t, getResp, &firstPage)
 	assert.Len(t, firstPage.Data, 2)
+	for _, post := range firstPage.Data {
+		assert.NotNil(t, post.Author)
+	}
 	assert.True(t, firstPage.HasMore)
 	assert.NotEmpty(t, firstPage.Next)
 
@@ -282,9 +290,261 @@ func TestPostIntegration_GetPosts_AuthorFilter(t *testing.T) {
 	getResp := makeHTTPRequest(t, router, "GET", fmt.Sprintf("/api/v1/posts?limit=10&author_id=%s", user1.ID), nil, "")
 	assert.Equal(t, 200, getResp.Code)
 
-	var response model.CursorResponse[model.Post]
+	var response model.CursorResponse[model.PostResponse]
 	parseJSONResponse(t, getResp, &response)
 	assert.Len(t, response.Data, 1)
 	assert.Equal(t, "User1 Post", response.Data[0].Content)
 	assert.Equal(t, user1.ID, response.Data[0].AuthorID)
+	if assert.NotNil(t, response.Data[0].Author) {
+		assert.Equal(t, user1.ID, response.Data[0].Author.ID)
+		assert.Equal(t, user1.Name, response.Data[0].Author.Name)
+	}
+}
+
+func TestPostIntegration_GetPosts_SearchTitle(t *testing.T) {
+	db := setup()
+	defer teardown(db)
+	router := setupIntegrationPostRouter(db)
+
+	user := createTestUser(t, db)
+	accessToken := createTestToken(t, user).AccessToken
+
+	// 有標題與無標題的貼文
+	titledResp := makeHTTPRequest(t, router, "POST", "/api/v1/posts", map[string]interface{}{
+		"title":   "Weekly golang digest",
+		"content": "Links I read this week",
+	}, accessToken)
+	assert.Equal(t, 201, titledResp.Code)
+	var titled model.PostResponse
+	parseJSONResponse(t, titledResp, &titled)
+	if assert.NotNil(t, titled.Title) {
+		assert.Equal(t, "Weekly golang digest", *titled.Title)
+	}
+
+	untitledResp := makeHTTPRequest(t, router, "POST", "/api/v1/posts", map[string]interface{}{
+		"content": "A post without any title",
+	}, accessToken)
+	assert.Equal(t, 201, untitledResp.Code)
+	var untitled model.PostResponse
+	parseJSONResponse(t, untitledResp, &untitled)
+	assert.Nil(t, untitled.Title)
+
+	// 搜尋同時比對標題與內容
+	getResp := makeHTTPRequest(t, router, "GET", "/api/v1/posts?q=golang", nil, "")
+	assert.Equal(t, 200, getResp.Code)
+
+	var response model.CursorResponse[model.PostResponse]
+	parseJSONResponse(t, getResp, &response)
+	if assert.Len(t, response.Data, 1) {
+		assert.Equal(t, titled.ID, response.Data[0].ID)
+	}
+}
+
+func TestPostIntegration_GetPosts_AuthorFilterCursorPagination(t *testing.T) {
+	db := setup()
+	defer teardown(db)
+	router := setupIntegrationPostRouter(db)
+
+	author := createTestUser(t, db)
+	noise := createTestUser(t, db, map[string]interface{}{
+		"username": "noise",
+		"email":    "noise@example.com",
+	})
+	authorToken := createTestToken(t, author).AccessToken
+	noiseToken := createTestToken(t, noise).AccessToken
+
+	// 交錯建立 30 篇作者貼文與其他作者的雜訊貼文
+	const authorPostCount = 30
+	for i := 0; i < authorPostCount; i++ {
+		createResp := makeHTTPRequest(t, router, "POST", "/api/v1/posts", map[string]interface{}{
+			"content": fmt.Sprintf("Author post %02d", i),
+		}, authorToken)
+		assert.Equal(t, 201, createResp.Code)
+
+		if i%2 == 0 {
+			noiseResp := makeHTTPRequest(t, router, "POST", "/api/v1/posts", map[string]interface{}{
+				"content": fmt.Sprintf("Noise post %02d", i),
+			}, noiseToken)
+			assert.Equal(t, 201, noiseResp.Code)
+		}
+	}
+
+	// 製造時間戳碰撞：每 4 個連續 id 共用同一個 created_at（跨作者），順序仍由 id 決定
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	err := db.Exec("UPDATE posts SET created_at = ? + (id / 4) * INTERVAL '1 second'", base).Error
+	assert.NoError(t, err)
+
+	var expectedIDs []uint64
+	err = db.Model(&model.Post{}).
+		Where("author_id = ?", author.ID).
+		Order("id DESC").
+		Pluck("id", &expectedIDs).Error
+	assert.NoError(t, err)
+	assert.Len(t, expectedIDs, authorPostCount)
+
+	// 以作者過濾逐頁讀取
+	var gotIDs []uint64
+	cursor := ""
+	for page := 0; page < authorPostCount; page++ {
+		path := fmt.Sprintf("/api/v1/posts?limit=7&author_id=%s", author.ID)
+		if cursor != "" {
+			path += "&cursor=" + url.QueryEscape(cursor)
+		}
+
+		getResp := makeHTTPRequest(t, router, "GET", path, nil, "")
+		assert.Equal(t, 200, getResp.Code)
+
+		var response model.CursorResponse[model.PostResponse]
+		parseJSONResponse(t, getResp, &response)
+		for _, post := range response.Data {
+			assert.Equal(t, author.ID, post.AuthorID)
+			if assert.NotNil(t, post.Author) {
+				assert.Equal(t, author.ID, post.Author.ID)
+			}
+			gotIDs = append(gotIDs, post.ID)
+		}
+
+		if !response.HasMore {
+			break
+		}
+		cursor = response.Next
+	}
+
+	// 恰好 30 篇、無重複無遺漏、依 created_at DESC, id DESC 排序
+	assert.Len(t, gotIDs, authorPostCount)
+	assert.Equal(t, expectedIDs, gotIDs)
+	assert.True(t, slices.IsSortedFunc(gotIDs, func(a, b uint64) int {
+		if a > b {
+			return -1
+		}
+		if a < b {
+			return 1
+		}
+		return 0
+	}))
+}
+
+func TestPostIntegration_GetPosts_DeleteBetweenPages(t *testing.T) {
+	db := setup()
+	defer teardown(db)
+	router := setupIntegrationPostRouter(db)
+
+	author := createTestUser(t, db)
+	authorToken := createTestToken(t, author).AccessToken
+
+	fetchPage := func(cursor string) model.CursorResponse[model.PostResponse] {
+		path := fmt.Sprintf("/api/v1/posts?limit=2&author_id=%s", author.ID)
+		if cursor != "" {
+			path += "&cursor=" + url.QueryEscape(cursor)
+		}
+		getResp := makeHTTPRequest(t, router, "GET", path, nil, "")
+		assert.Equal(t, 200, getResp.Code)
+
+		var response model.CursorResponse[model.PostResponse]
+		parseJSONResponse(t, getResp, &response)
+		return response
+	}
+	deletePost := func(id uint64) {
+		deleteResp := makeHTTPRequest(t, router, "DELETE", fmt.Sprintf("/api/v1/posts/%d", id), nil, authorToken)
+		assert.Equal(t, 204, deleteResp.Code)
+	}
+
+	// 建立 3 篇貼文：第一頁 2 篇，第 3 篇為 look-ahead
+	var ids []uint64
+	for i := 0; i < 3; i++ {
+		createResp := makeHTTPRequest(t, router, "POST", "/api/v1/posts", map[string]interface{}{
+			"content": fmt.Sprintf("Paginated post %02d", i),
+		}, authorToken)
+		assert.Equal(t, 201, createResp.Code)
+
+		var created model.PostResponse
+		parseJSONResponse(t, createResp, &created)
+		ids = append(ids, created.ID)
+	}
+
+	first := fetchPage("")
+	assert.Len(t, first.Data, 2)
+	assert.True(t, first.HasMore)
+	assert.Equal(t, ids[2], first.Data[0].ID)
+	assert.Equal(t, ids[1], first.Data[1].ID)
+
+	// 刪除 look-ahead 與游標所指的貼文後，舊游標仍可使用
+	deletePost(ids[0])
+	deletePost(ids[1])
+
+	second := fetchPage(first.Next)
+	assert.NotNil(t, second.Data)
+	assert.Empty(t, second.Data)
+	assert.False(t, second.HasMore)
+	assert.Empty(t, second.Next)
+
+	// 之後新增的貼文較新，不會出現在舊游標之後
+	createResp := makeHTTPRequest(t, router, "POST", "/api/v1/posts", map[string]interface{}{
+		"content": "Paginated post newest",
+	}, authorToken)
+	assert.Equal(t, 201, createResp.Code)
+
+	again := fetchPage(first.Next)
+	assert.Empty(t, again.Data)
+	assert.False(t, again.HasMore)
+}
+
+func TestPostIntegration_ModerationList_HiddenPosts(t *testing.T) {
+	db := setup()
+	defer teardown(db)
+	router := setupIntegrationPostRouter(db)
+
+	author := createTestUser(t, db)
+	moderator := createTestUser(t, db, map[string]interface{}{
+		"username": "moderator",
+		"email":    "moderator@example.com",
+		"role":     model.RoleModerator,
+	})
+	authorToken := createTestToken(t, author).AccessToken
+	moderatorToken := createTestToken(t, moderator).AccessToken
+
+	var ids []uint64
+	for i := 0; i < 2; i++ {
+		createResp := makeHTTPRequest(t, router, "POST", "/api/v1/posts", map[string]interface{}{
+			"content": fmt.Sprintf("Moderation post %02d", i),
+		}, authorToken)
+		assert.Equal(t, 201, createResp.Code)
+
+		var created model.PostResponse
+		parseJSONResponse(t, createResp, &created)
+		ids = append(ids, created.ID)
+	}
+	hiddenID := ids[1]
+
+	hideResp := makeHTTPRequest(t, router, "POST", fmt.Sprintf("/api/v1/posts/%d/hide", hiddenID), nil, moderatorToken)
+	assert.Equal(t, 200, hideResp.Code)
+
+	// 公開列表不含隱藏貼文，即使帶上審核參數
+	publicResp := makeHTTPRequest(t, router, "GET", "/api/v1/posts?status=hidden&include_hidden=true&hidden_only=true", nil, "")
+	assert.Equal(t, 200, publicResp.Code)
+	var public model.CursorResponse[model.PostResponse]
+	parseJSONResponse(t, publicResp, &public)
+	assert.Len(t, public.Data, 1)
+	assert.Equal(t, ids[0], public.Data[0].ID)
+
+	// 審核者可以只列出隱藏貼文
+	moderationResp := makeHTTPRequest(t, router, "GET", "/api/v1/admin/posts?status=hidden", nil, moderatorToken)
+	assert.Equal(t, 200, moderationResp.Code)
+	var hidden model.PaginatedResponse[model.PostResponse]
+	parseJSONResponse(t, moderationResp, &hidden)
+	assert.Equal(t, 1, hidden.Total)
+	assert.Len(t, hidden.Data, 1)
+	assert.Equal(t, hiddenID, hidden.Data[0].ID)
+	assert.Equal(t, model.HiddenPostWarning, hidden.Data[0].Warning)
+
+	// 預設列出全部
+	allResp := makeHTTPRequest(t, router, "GET", "/api/v1/admin/posts", nil, moderatorToken)
+	assert.Equal(t, 200, allResp.Code)
+	var all model.PaginatedResponse[model.PostResponse]
+	parseJSONResponse(t, allResp, &all)
+	assert.Equal(t, 2, all.Total)
+
+	// 一般使用者無法使用審核列表
+	forbiddenResp := makeHTTPRequest(t, router, "GET", "/api/v1/admin/posts?status=hidden", nil, authorToken)
+	assert.Equal(t, 403, forbiddenResp.Code)
 }
@@ -1,6 +1,7 @@
 package integration
 
 import (
+	"go-gin-api-server/config"
 	"go-gin-api-server/internal/handler"
 	"go-gin-api-server/internal/middleware"
 	"go-gin-api-server/internal/model"
@@ -20,6 +21,10 @@ import (
 )
 
 func setupIntegrationUserRouter(db *gorm.DB) *gin.Engine {
+	return setupIntegrationUserRouterWithConfig(db, config.LoadTestConfig().Users)
+}
+
+func setupIntegrationUserRouterWithConfig(db *gorm.DB, usersCfg config.UsersConfig) *gin.Engine {
 	// Setup dependencies
 	userRepo := repository.NewUserRepositoryWithDB(db)
 	authRepo := repository.NewAuthRepositoryWithDB(db)
@@ -29,7 +34,7 @@ func setupIntegrationUserRouter(db *gorm.DB) *gin.Engine {
 	authService := service.NewAuthService(userRepo, authRepo, globalJWTManager)
 
 	// Setup handlers
-	userHandler := handler.NewUserHandler(userService, logger.Log)
+	userHandler := handler.NewUserHandlerWithConfig(userService, usersCfg, logger.Log)
 
 	// Setup middleware
 	authMiddleware := middleware.NewAuthMiddleware(authService, logger.Log)
@@ -45,7 +50,7 @@ func setupIntegrationUserRouter(db *gorm.DB) *gin.Engine {
 	}
 
 	// Register routes
-	userHandler.RegisterRoutes(r)
+	userHandler.RegisterRoutes(r, authMiddleware, nil, nil)
 	userHandler.RegisterProtectedRoutes(r, authMiddleware, rbacMiddleware)
 
 	return r
@@ -130,3 +135,123 @@ func TestUserIntegration_Unauthorized(t *testing.T) {
 		assert.Equal(t, http.StatusUnauthorized, resp.Code)
 	})
 }
+
+func TestUserIntegration_LookupAccess(t *testing.T) {
+	t.Run("AdminOnly", func(t *testing.T) {
+		db := setup()
+		defer teardown(db)
+		router := setupIntegrationUserRouterWithConfig(db, config.UsersConfig{LookupAccess: config.LookupAccessAdmin})
+
+		user := createTestUser(t, db)
+		admin := createTestUser(t, db, map[string]interface{}{
+			"username": "adminuser",
+			"email":    "admin@example.com",
+			"role":     model.RoleAdmin,
+		})
+		userToken := createTestToken(t, user).AccessToken
+		adminToken := createTestToken(t, admin).AccessToken
+
+		// regular user is forbidden, even for their own account
+		resp := makeHTTPRequest(t, router, "GET", "/api/v1/users/username/"+*user.Username, nil, userToken)
+		assert.Equal(t, http.StatusForbidden, resp.Code)
+		resp = makeHTTPRequest(t, router, "GET", "/api/v1/users/email/"+*user.Email, nil, userToken)
+		assert.Equal(t, http.StatusForbidden, resp.Code)
+
+		// admin can look users up
+		resp = makeHTTPRequest(t, router, "GET", "/api/v1/users/username/"+*user.Username, nil, adminToken)
+		assert.Equal(t, http.StatusOK, resp.Code)
+		resp = makeHTTPRequest(t, router, "GET", "/api/v1/users/email/"+*user.Email, nil, adminToken)
+		assert.Equal(t, http.StatusOK, resp.Code)
+
+		// get by ID is unaffected
+		resp = makeHTTPRequest(t, router, "GET", "/api/v1/users/"+user.ID, nil, userToken)
+		assert.Equal(t, http.StatusOK, resp.Code)
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		db := setup()
+		defer teardown(db)
+		router := setupIntegrationUserRouterWithConfig(db, config.UsersConfig{LookupAccess: config.LookupAccessDisabled})
+
+		admin := createTestUser(t, db, map[string]interface{}{
+			"role": model.RoleAdmin,
+		})
+		adminToken := createTestToken(t, admin).AccessToken
+
+		resp := makeHTTPRequest(t, router, "GET", "/api/v1/users/username/"+*admin.Username, nil, adminToken)
+		assert.Equal(t, http.StatusNotFound, resp.Code)
+		resp = makeHTTPRequest(t, router, "GET", "/api/v1/users/email/"+*admin.Email, nil, adminToken)
+		assert.Equal(t, http.StatusNotFound, resp.Code)
+	})
+}
+
+func TestUserIntegration_RecordVisibility(t *testing.T) {
+	db := setup()
+	defer teardown(db)
+	router := setupIntegrationUserRouter(db)
+
+	user := createTestUser(t, db)
+	other := createTestUser(t, db, map[string]interface{}{
+		"username": "otheruser",
+		"email":    "other@example.com",
+	})
+	admin := createTestUser(t, db, map[string]interface{}{
+		"username": "adminuser",
+		"email":    "admin@example.com",
+		"role":     model.RoleAdmin,
+	})
+	userToken := createTestToken(t, user).AccessToken
+	otherToken := createTestToken(t, other).AccessToken
+	adminToken := createTestToken(t, admin).AccessToken
+
+	lookups := map[string]string{
+		"ByID":       "/api/v1/users/" + user.ID,
+		"ByUsername": "/api/v1/users/username/" + *user.Username,
+		"ByEmail":    "/api/v1/users/email/" + *user.Email,
+	}
+
+	t.Run("Self_FullRecord", func(t *testing.T) {
+		for name, path := range lookups {
+			resp := makeHTTPRequest(t, router, "GET", path, nil, userToken)
+			assert.Equal(t, http.StatusOK, resp.Code, name)
+
+			var body map[string]interface{}
+			parseJSONResponse(t, resp, &body)
+			assert.Equal(t, user.ID, body["id"], name)
+			assert.Equal(t, *user.Email, body["email"], name)
+		}
+	})
+
+	t.Run("OtherUser_ReducedProfile", func(t *testing.T) {
+		for _, name := range []string{"ByID", "ByUsername"} {
+			resp := makeHTTPRequest(t, router, "GET", lookups[name], nil, otherToken)
+			assert.Equal(t, http.StatusOK, resp.Code, name)
+
+			var body map[string]interface{}
+			parseJSONResponse(t, resp, &body)
+			assert.Equal(t, *user.Username, body["username"], name)
+			assert.NotContains(t, body, "email", name)
+			assert.NotContains(t, body, "id", name)
+		}
+	})
+
+	t.Run("OtherUser_EmailForbidden", func(t *testing.T) {
+		resp := makeHTTPRequest(t, router, "GET", lookups["ByEmail"], nil, otherToken)
+		assert.Equal(t, http.StatusForbidden, resp.Code)
+
+		// unknown emails look the same, so the endpoint can't be used to probe registrations
+		resp = makeHTTPRequest(t, router, "GET", "/api/v1/users/email/nobody@example.com", nil, otherToken)
+		assert.Equal(t, http.StatusForbidden, resp.Code)
+	})
+
+	t.Run("Admin_FullRecord", func(t *testing.T) {
+		for name, path := range lookups {
+			resp := makeHTTPRequest(t, router, "GET", path, nil, adminToken)
+			assert.Equal(t, http.StatusOK, resp.Code, name)
+
+			var body map[string]interface{}
+			parseJSONResponse(t, resp, &body)
+			assert.Equal(t, *user.Email, body["email"], name)
+		}
+	})
+}
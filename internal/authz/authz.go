@@ -0,0 +1,13 @@
+package authz
+
+import "go-gin-api-server/internal/model"
+
+// IsOwner reports whether the actor owns the resource; empty IDs never match
+func IsOwner(actorID string, ownerID string) bool {
+	return actorID != "" && actorID == ownerID
+}
+
+// IsOwnerOrAdmin reports whether the actor owns the resource or is an admin
+func IsOwnerOrAdmin(actorID string, actorRole model.UserRole, ownerID string) bool {
+	return actorRole.IsAdmin() || IsOwner(actorID, ownerID)
+}
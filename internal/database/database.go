@@ -29,7 +29,8 @@ func InitDatabase(cfg config.DatabaseConfig) error {
 	// 配置 GORM
 	var err error
 	DB, err = gorm.Open(postgres.Open(dsn), &gorm.Config{
-		Logger: logger.NewGormLogger(),
+		Logger:         logger.NewGormLogger(logger.Log, logger.ParseGormLogLevel(cfg.LogLevel), cfg.SlowQueryThreshold, cfg.LogParams),
+		TranslateError: true, // 將唯一鍵等約束錯誤轉為 gorm.ErrDuplicatedKey 等
 		NowFunc: func() time.Time {
 			return time.Now().UTC().Truncate(time.Microsecond)
 		},
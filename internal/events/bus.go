@@ -0,0 +1,150 @@
+package events
+
+import (
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+// defaultBufferSize is the number of events queued before Publish blocks
+const defaultBufferSize = 256
+
+// Handler reacts to a published event
+type Handler func(event Event)
+
+type subscription struct {
+	handler Handler
+	types   map[string]struct{}
+}
+
+// matches reports whether the subscription wants the given event type
+func (s subscription) matches(eventType string) bool {
+	if len(s.types) == 0 {
+		return true
+	}
+	_, ok := s.types[eventType]
+	return ok
+}
+
+// Bus is an in-process publish/subscribe event bus.
+// Publish enqueues onto a buffered channel and returns; a single worker
+// dispatches events to subscribers in publish order.
+type Bus struct {
+	mu            sync.RWMutex
+	subscriptions map[uint64]subscription
+	nextID        uint64
+
+	// closeMu guards queue/closed separately from subscriptions so a
+	// publisher blocked on a full queue never stalls the dispatch worker
+	closeMu sync.RWMutex
+	queue   chan Event
+	closed  bool
+	done    chan struct{}
+	logger  *zap.Logger
+}
+
+// NewBus creates an event bus and starts its dispatch worker
+func NewBus(logger *zap.Logger) *Bus {
+	return NewBusWithBuffer(defaultBufferSize, logger)
+}
+
+// NewBusWithBuffer creates an event bus with the given queue size
+func NewBusWithBuffer(bufferSize int, logger *zap.Logger) *Bus {
+	b := &Bus{
+		subscriptions: make(map[uint64]subscription),
+		queue:         make(chan Event, bufferSize),
+		done:          make(chan struct{}),
+		logger:        logger,
+	}
+	go b.run()
+	return b
+}
+
+// Subscribe registers a handler for the given event types (all types if none)
+// and returns a function that removes it
+func (b *Bus) Subscribe(handler Handler, types ...string) (unsubscribe func()) {
+	sub := subscription{handler: handler}
+	if len(types) > 0 {
+		sub.types = make(map[string]struct{}, len(types))
+		for _, t := range types {
+			sub.types[t] = struct{}{}
+		}
+	}
+
+	b.mu.Lock()
+	id := b.nextID
+	b.nextID++
+	b.subscriptions[id] = sub
+	b.mu.Unlock()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			b.mu.Lock()
+			delete(b.subscriptions, id)
+			b.mu.Unlock()
+		})
+	}
+}
+
+// Publish queues the event for dispatch; it blocks only when the queue is full.
+// Events published after Close are dropped.
+func (b *Bus) Publish(event Event) {
+	b.closeMu.RLock()
+	defer b.closeMu.RUnlock()
+
+	if b.closed {
+		b.logger.Warn("Event published after bus closed", zap.String("type", event.Type()))
+		return
+	}
+	b.queue <- event
+}
+
+// Close stops accepting events and waits until queued events are dispatched
+func (b *Bus) Close() {
+	b.closeMu.Lock()
+	if b.closed {
+		b.closeMu.Unlock()
+		return
+	}
+	b.closed = true
+	close(b.queue)
+	b.closeMu.Unlock()
+
+	<-b.done
+}
+
+func (b *Bus) run() {
+	defer close(b.done)
+
+	for event := range b.queue {
+		b.dispatch(event)
+	}
+}
+
+func (b *Bus) dispatch(event Event) {
+	b.mu.RLock()
+	handlers := make([]Handler, 0, len(b.subscriptions))
+	for _, sub := range b.subscriptions {
+		if sub.matches(event.Type()) {
+			handlers = append(handlers, sub.handler)
+		}
+	}
+	b.mu.RUnlock()
+
+	for _, handler := range handlers {
+		b.safeCall(handler, event)
+	}
+}
+
+// safeCall keeps the worker alive when a subscriber panics
+func (b *Bus) safeCall(handler Handler, event Event) {
+	defer func() {
+		if r := recover(); r != nil {
+			b.logger.Error("Event handler panicked",
+				zap.String("type", event.Type()),
+				zap.Any("panic", r))
+		}
+	}()
+	handler(event)
+}
@@ -0,0 +1,66 @@
+package events
+
+import (
+	"go-gin-api-server/internal/model"
+	"time"
+)
+
+// Event types
+const (
+	TypePostCreated     = "post.created"
+	TypePostLiked       = "post.liked"
+	TypeUserDeactivated = "user.deactivated"
+	TypeUserRegistered  = "user.registered"
+)
+
+// Event is a domain event published after a successful write
+type Event interface {
+	Type() string
+}
+
+// PostCreated is published after a post is persisted
+type PostCreated struct {
+	PostID    uint64    `json:"post_id"`
+	AuthorID  string    `json:"author_id"`
+	Content   string    `json:"content"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (e PostCreated) Type() string {
+	return TypePostCreated
+}
+
+// PostLiked is published after a user likes a post
+type PostLiked struct {
+	PostID   uint64    `json:"post_id"`
+	AuthorID string    `json:"author_id"`
+	UserID   string    `json:"user_id"`
+	LikedAt  time.Time `json:"liked_at"`
+}
+
+func (e PostLiked) Type() string {
+	return TypePostLiked
+}
+
+// UserDeactivated is published after an account is deactivated
+type UserDeactivated struct {
+	UserID        string                   `json:"user_id"`
+	DeactivatedBy model.DeactivationSource `json:"deactivated_by"`
+	ActorID       string                   `json:"actor_id"`
+}
+
+func (e UserDeactivated) Type() string {
+	return TypeUserDeactivated
+}
+
+// UserRegistered is published after an account and its credentials are created
+type UserRegistered struct {
+	UserID       string    `json:"user_id"`
+	Name         string    `json:"name"`
+	Username     string    `json:"username,omitempty"`
+	RegisteredAt time.Time `json:"registered_at"`
+}
+
+func (e UserRegistered) Type() string {
+	return TypeUserRegistered
+}
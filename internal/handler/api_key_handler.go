@@ -0,0 +1,165 @@
+package handler
+
+import (
+	"errors"
+	"go-gin-api-server/internal/middleware"
+	"go-gin-api-server/internal/model"
+	"go-gin-api-server/internal/service"
+	"go-gin-api-server/pkg/apperrors"
+	"go-gin-api-server/pkg/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type APIKeyHandler struct {
+	service service.APIKeyService
+	logger  *zap.Logger
+}
+
+func NewAPIKeyHandler(service service.APIKeyService, logger *zap.Logger) *APIKeyHandler {
+	return &APIKeyHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (h *APIKeyHandler) RegisterProtectedRoutes(r *gin.Engine, authMiddleware *middleware.AuthMiddleware, rbacMiddleware *middleware.RBACMiddleware) {
+	// Key management needs an interactive login, neither an API key nor an impersonation token
+	protected := r.Group("/api/v1/auth/api-keys")
+	protected.Use(authMiddleware.RequireAuth())
+	protected.Use(rbacMiddleware.ForbidAPIKey())
+	protected.Use(rbacMiddleware.ForbidImpersonation())
+	{
+		protected.POST("", h.CreateAPIKey)
+		protected.GET("", h.ListAPIKeys)
+		protected.DELETE("/:id", h.RevokeAPIKey)
+	}
+
+	// Admin view of any user's keys, for key hygiene reviews
+	admin := r.Group("/api/v1/admin/users")
+	admin.Use(authMiddleware.RequireAuth())
+	admin.Use(rbacMiddleware.RequireAdmin())
+	{
+		admin.GET("/:id/api-keys", h.ListUserAPIKeys)
+	}
+}
+
+// CreateAPIKey issues a key for the caller (requires authentication); the key is only
+// returned in this response, afterwards just its prefix is shown
+//
+// Example:
+//
+//	POST /api/v1/auth/api-keys
+//	{
+//	  "name": "billing sync",
+//	  "scopes": ["read", "write"],
+//	  "expires_at": "2027-01-01T00:00:00Z"
+//	}
+func (h *APIKeyHandler) CreateAPIKey(c *gin.Context) {
+	var req model.CreateAPIKeyRequest
+	if err := BindJSON(c, &req); err != nil {
+		return
+	}
+
+	userID, err := GetUserID(c)
+	if err != nil {
+		h.handleAPIKeyError(c, err, "CreateAPIKey")
+		return
+	}
+
+	response, err := h.service.Create(userID, req)
+	if err != nil {
+		h.handleAPIKeyError(c, err, "CreateAPIKey")
+		return
+	}
+
+	utils.RespondJSON(c, http.StatusCreated, response)
+}
+
+// ListAPIKeys lists the caller's keys, revoked ones included (requires authentication)
+//
+// Example:
+//
+//	GET /api/v1/auth/api-keys
+func (h *APIKeyHandler) ListAPIKeys(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		h.handleAPIKeyError(c, err, "ListAPIKeys")
+		return
+	}
+
+	response, err := h.service.List(userID)
+	if err != nil {
+		h.handleAPIKeyError(c, err, "ListAPIKeys")
+		return
+	}
+
+	utils.RespondJSON(c, http.StatusOK, response)
+}
+
+// ListUserAPIKeys lists another user's keys with their last use and expiry (requires admin)
+//
+// Example:
+//
+//	GET /api/v1/admin/users/550e8400-e29b-41d4-a716-446655440000/api-keys
+func (h *APIKeyHandler) ListUserAPIKeys(c *gin.Context) {
+	userID, err := bindUserID(c)
+	if err != nil {
+		return
+	}
+
+	response, err := h.service.List(userID)
+	if err != nil {
+		h.handleAPIKeyError(c, err, "ListUserAPIKeys")
+		return
+	}
+
+	utils.RespondJSON(c, http.StatusOK, response)
+}
+
+// RevokeAPIKey revokes one of the caller's keys, requests with it get 401 from then on
+// (requires authentication)
+//
+// Example:
+//
+//	DELETE /api/v1/auth/api-keys/42
+func (h *APIKeyHandler) RevokeAPIKey(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		h.handleAPIKeyError(c, apperrors.ErrValidation, "RevokeAPIKey")
+		return
+	}
+
+	userID, err := GetUserID(c)
+	if err != nil {
+		h.handleAPIKeyError(c, err, "RevokeAPIKey")
+		return
+	}
+
+	if err := h.service.Revoke(id, userID); err != nil {
+		h.handleAPIKeyError(c, err, "RevokeAPIKey")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *APIKeyHandler) handleAPIKeyError(c *gin.Context, err error, operation string) {
+	switch {
+	case errors.Is(err, apperrors.ErrNotFound):
+		h.logger.Info("API key not found", zap.String("operation", operation), zap.Error(err))
+		utils.RespondError(c, http.StatusNotFound, "API key not found")
+	case errors.Is(err, apperrors.ErrValidation):
+		h.logger.Info("Validation error", zap.String("operation", operation), zap.Error(err))
+		utils.RespondError(c, http.StatusBadRequest, "Validation failed")
+	case errors.Is(err, apperrors.ErrUnauthorized):
+		h.logger.Info("Unauthorized", zap.String("operation", operation), zap.Error(err))
+		utils.RespondError(c, http.StatusUnauthorized, "Unauthorized")
+	default:
+		h.logger.Error("Unexpected error", zap.String("operation", operation), zap.Error(err))
+		utils.RespondError(c, http.StatusInternalServerError, "Internal server error")
+	}
+}
@@ -1,32 +1,50 @@
 package handler
 
 import (
+	"errors"
+	"go-gin-api-server/config"
 	"go-gin-api-server/internal/middleware"
 	"go-gin-api-server/internal/model"
 	"go-gin-api-server/internal/service"
 	"go-gin-api-server/pkg/apperrors"
+	"go-gin-api-server/pkg/jsonschema"
+	"go-gin-api-server/pkg/utils"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 )
 
+// refreshCookieName holds the refresh token, read back by RefreshToken and the auth middleware
+const refreshCookieName = "gin_api_refresh_token"
+
 type AuthHandler struct {
 	authService service.AuthService
+	cfg         config.JWTConfig
 	logger      *zap.Logger
 }
 
 func NewAuthHandler(authService service.AuthService, logger *zap.Logger) *AuthHandler {
+	return NewAuthHandlerWithConfig(authService, config.JWTConfig{
+		RefreshTokenExpiration: 7 * 24 * time.Hour,
+	}, logger)
+}
+
+// NewAuthHandlerWithConfig 創建 refresh cookie 有效期與 refresh token 一致的 AuthHandler
+func NewAuthHandlerWithConfig(authService service.AuthService, cfg config.JWTConfig, logger *zap.Logger) *AuthHandler {
 	return &AuthHandler{
 		authService: authService,
+		cfg:         cfg,
 		logger:      logger,
 	}
 }
 
-func (h *AuthHandler) RegisterRoutes(r *gin.Engine) {
+// RegisterRoutes registers the public auth routes, maxBodyBytes caps the register body
+func (h *AuthHandler) RegisterRoutes(r *gin.Engine, maxBodyBytes int64) {
 	auth := r.Group("/api/v1/auth")
 	{
-		auth.POST("/register", h.Register)
+		auth.POST("/register", middleware.ValidateJSONSchema(model.RegisterRequestSchema, maxBodyBytes), h.Register)
 		auth.POST("/login", h.Login)
 		auth.POST("/refresh", h.RefreshToken)
 	}
@@ -42,10 +60,30 @@ func (h *AuthHandler) RegisterProtectedRoutes(r *gin.Engine, authMiddleware *mid
 		admin.POST("/users/:id/activate", h.ActivateUser)
 	}
 
+	// Admin-only token revocation
+	tokens := r.Group("/api/v1/admin/tokens")
+	tokens.Use(authMiddleware.RequireAuth())
+	tokens.Use(rbacMiddleware.RequireAdmin())
+	{
+		tokens.POST("/revoke", h.RevokeToken)
+	}
+
+	// Admin-only login-as for support, every use is audited; needs an interactive admin
+	// session, so neither an API key nor an impersonation token can mint one
+	impersonation := r.Group("/api/v1/admin/users")
+	impersonation.Use(authMiddleware.RequireAuth())
+	impersonation.Use(rbacMiddleware.RequireAdmin())
+	impersonation.Use(rbacMiddleware.ForbidAPIKey())
+	impersonation.Use(rbacMiddleware.ForbidImpersonation())
+	{
+		impersonation.POST("/:id/impersonate", h.Impersonate)
+	}
+
 	// Admin or owner routes
 	adminOrOwner := r.Group("/api/v1/auth")
 	adminOrOwner.Use(authMiddleware.RequireAuth())
 	adminOrOwner.Use(rbacMiddleware.RequireOwnershipOrAdmin())
+	adminOrOwner.Use(rbacMiddleware.ForbidImpersonation())
 	{
 		// Admin can deactivate any user, user can deactivate themselves
 		adminOrOwner.POST("/users/:id/deactivate", h.DeactivateUser)
@@ -64,9 +102,7 @@ func (h *AuthHandler) Register(c *gin.Context) {
 		return
 	}
 
-	// 設置 refresh token 到 cookie（7天有效期）
-	c.SetCookie("gin_api_refresh_token", tokenResponse.RefreshToken,
-		7*24*60*60, "/api", "", true, true) // 7天，限制路徑，Secure, HttpOnly
+	h.setRefreshCookie(c, tokenResponse.RefreshToken)
 
 	h.handleAuthSuccess(c, tokenResponse, http.StatusCreated)
 }
@@ -83,16 +119,14 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		return
 	}
 
-	// 設置 refresh token 到 cookie（7天有效期）
-	c.SetCookie("gin_api_refresh_token", tokenResponse.RefreshToken,
-		7*24*60*60, "/api", "", true, true) // 7天，限制路徑，Secure, HttpOnly
+	h.setRefreshCookie(c, tokenResponse.RefreshToken)
 
 	h.handleAuthSuccess(c, tokenResponse, http.StatusOK)
 }
 
 func (h *AuthHandler) RefreshToken(c *gin.Context) {
 	// 從 cookie 中獲取 refresh token
-	refreshToken, err := c.Cookie("gin_api_refresh_token")
+	refreshToken, err := c.Cookie(refreshCookieName)
 	if err != nil {
 		refreshToken = ""
 	}
@@ -103,15 +137,22 @@ func (h *AuthHandler) RefreshToken(c *gin.Context) {
 		return
 	}
 
-	// 更新 refresh token 到 cookie（7天有效期）
-	c.SetCookie("gin_api_refresh_token", tokenResponse.RefreshToken,
-		7*24*60*60, "/api", "", true, true) // 7天，限制路徑，Secure, HttpOnly
+	h.setRefreshCookie(c, tokenResponse.RefreshToken)
 
 	h.handleAuthSuccess(c, tokenResponse, http.StatusOK)
 }
 
+// setRefreshCookie 設置 refresh token 到 cookie，有效期與 refresh token 相同（限制路徑，Secure, HttpOnly）
+func (h *AuthHandler) setRefreshCookie(c *gin.Context, refreshToken string) {
+	c.SetCookie(refreshCookieName, refreshToken,
+		int(h.cfg.RefreshTokenExpiration.Seconds()), "/api", "", true, true)
+}
+
 func (h *AuthHandler) ActivateUser(c *gin.Context) {
-	userID := c.Param("id")
+	userID, err := bindUserID(c)
+	if err != nil {
+		return
+	}
 
 	user, err := h.authService.ActivateUser(userID)
 	if err != nil {
@@ -123,9 +164,18 @@ func (h *AuthHandler) ActivateUser(c *gin.Context) {
 }
 
 func (h *AuthHandler) DeactivateUser(c *gin.Context) {
-	userID := c.Param("id")
+	userID, err := bindUserID(c)
+	if err != nil {
+		return
+	}
+
+	actorID, err := GetUserID(c)
+	if err != nil {
+		h.handleAuthError(c, err, "DeactivateUser")
+		return
+	}
 
-	user, err := h.authService.DeactivateUser(userID)
+	user, err := h.authService.DeactivateUser(userID, actorID)
 	if err != nil {
 		h.handleAuthError(c, err, "DeactivateUser")
 		return
@@ -134,54 +184,120 @@ func (h *AuthHandler) DeactivateUser(c *gin.Context) {
 	h.handleAuthSuccess(c, user, http.StatusOK)
 }
 
+// RevokeToken kills a single leaked access token before it expires (requires admin)
+//
+// Example:
+//
+//	POST /api/v1/admin/tokens/revoke
+//	{
+//	  "token": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+//	}
+func (h *AuthHandler) RevokeToken(c *gin.Context) {
+	var req model.RevokeTokenRequest
+	if err := BindJSON(c, &req); err != nil {
+		return
+	}
+
+	if err := h.authService.RevokeAccessToken(req.Token); err != nil {
+		h.handleAuthError(c, err, "RevokeToken")
+		return
+	}
+
+	h.handleAuthSuccess(c, nil, http.StatusNoContent)
+}
+
+// Impersonate issues a short-lived access token to act as another user, for reproducing
+// what they see (requires admin). The token carries impersonator_id and can't be refreshed
+//
+// Example:
+//
+//	POST /api/v1/admin/users/550e8400-e29b-41d4-a716-446655440000/impersonate
+func (h *AuthHandler) Impersonate(c *gin.Context) {
+	userID, err := bindUserID(c)
+	if err != nil {
+		return
+	}
+
+	adminID, err := GetUserID(c)
+	if err != nil {
+		h.handleAuthError(c, err, "Impersonate")
+		return
+	}
+
+	response, err := h.authService.Impersonate(userID, adminID)
+	if errors.Is(err, apperrors.ErrNotFound) {
+		utils.RespondError(c, http.StatusNotFound, "User not found")
+		return
+	}
+	if err != nil {
+		h.handleAuthError(c, err, "Impersonate")
+		return
+	}
+
+	h.logger.Info("Admin impersonating user", zap.String("admin_id", adminID), zap.String("user_id", userID))
+	h.handleAuthSuccess(c, response, http.StatusOK)
+}
+
+// passwordPolicyMessages client-facing messages for unmet password rules
+var passwordPolicyMessages = map[error]string{
+	apperrors.ErrPasswordTooShort:      "Password is too short",
+	apperrors.ErrPasswordMissingDigit:  "Password must contain a digit",
+	apperrors.ErrPasswordMissingUpper:  "Password must contain an uppercase letter",
+	apperrors.ErrPasswordMissingSymbol: "Password must contain a symbol",
+}
+
+// passwordPolicyDetails maps unmet password rules to per-field details
+func passwordPolicyDetails(errs []error) []jsonschema.FieldError {
+	details := make([]jsonschema.FieldError, 0, len(errs))
+	for _, err := range errs {
+		message, ok := passwordPolicyMessages[err]
+		if !ok {
+			message = err.Error()
+		}
+		details = append(details, jsonschema.FieldError{Field: "password", Message: message})
+	}
+	return details
+}
+
 func (h *AuthHandler) handleAuthError(c *gin.Context, err error, _ string) {
+	var validationErrs *apperrors.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		h.logger.Info("Password does not meet policy", zap.Error(err))
+		utils.RespondFieldErrors(c, http.StatusBadRequest, "Validation failed", passwordPolicyDetails(validationErrs.Errs))
+		return
+	}
+
 	switch err {
 	case apperrors.ErrValidation:
 		h.logger.Error("Invalid request format", zap.Error(err))
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Invalid request format",
-		})
+		utils.RespondError(c, http.StatusBadRequest, "Invalid request format")
 	case apperrors.ErrUserExists:
 		h.logger.Error("User already exists", zap.Error(err))
-		c.JSON(http.StatusConflict, gin.H{
-			"error": "User already exists",
-		})
+		utils.RespondError(c, http.StatusConflict, "User already exists")
 	case apperrors.ErrUserUnderAge:
 		h.logger.Error("User under age", zap.Error(err))
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "User under age",
-		})
+		utils.RespondError(c, http.StatusBadRequest, "User under age")
 	case apperrors.ErrUnauthorized:
 		h.logger.Error("Unauthorized", zap.Error(err))
-		c.JSON(http.StatusUnauthorized, gin.H{
-			"error": "Unauthorized",
-		})
+		utils.RespondError(c, http.StatusUnauthorized, "Unauthorized")
 	case apperrors.ErrForbidden:
 		h.logger.Error("Forbidden", zap.Error(err))
-		c.JSON(http.StatusForbidden, gin.H{
-			"error": "Forbidden",
-		})
+		utils.RespondError(c, http.StatusForbidden, "Forbidden")
 	case apperrors.ErrInvalidToken:
 		h.logger.Error("Invalid token", zap.Error(err))
-		c.JSON(http.StatusUnauthorized, gin.H{
-			"error": "Invalid token",
-		})
+		utils.RespondError(c, http.StatusUnauthorized, "Invalid token")
 	case apperrors.ErrExpiredToken:
 		h.logger.Error("Token has expired", zap.Error(err))
-		c.JSON(http.StatusUnauthorized, gin.H{
-			"error": "Token has expired",
-		})
+		utils.RespondError(c, http.StatusUnauthorized, "Token has expired")
 	default:
 		h.logger.Error("Internal server error", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Internal server error",
-		})
+		utils.RespondError(c, http.StatusInternalServerError, "Internal server error")
 	}
 }
 
 func (h *AuthHandler) handleAuthSuccess(c *gin.Context, data interface{}, statusCode int) {
 	if data != nil {
-		c.JSON(statusCode, data)
+		utils.RespondJSON(c, statusCode, data)
 	} else {
 		c.Status(statusCode)
 	}
@@ -0,0 +1,128 @@
+package handler
+
+import (
+	"errors"
+	"go-gin-api-server/internal/middleware"
+	"go-gin-api-server/internal/service"
+	"go-gin-api-server/pkg/apperrors"
+	"go-gin-api-server/pkg/utils"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type BlockHandler struct {
+	service service.BlockService
+	logger  *zap.Logger
+}
+
+func NewBlockHandler(service service.BlockService, logger *zap.Logger) *BlockHandler {
+	return &BlockHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (h *BlockHandler) RegisterProtectedRoutes(r *gin.Engine, authMiddleware *middleware.AuthMiddleware, rbacMiddleware *middleware.RBACMiddleware) {
+	// Any authenticated user manages their own blocks
+	protected := r.Group("/api/v1/users")
+	protected.Use(authMiddleware.RequireAuth())
+	{
+		protected.GET("/me/blocks", h.ListBlocked)
+		protected.POST("/:id/block", h.BlockUser)
+		protected.DELETE("/:id/block", h.UnblockUser)
+	}
+}
+
+// BlockUser hides the user's posts from the caller and the caller's posts from them
+// (requires authentication)
+//
+// Example:
+//
+//	POST /api/v1/users/550e8400-e29b-41d4-a716-446655440000/block
+func (h *BlockHandler) BlockUser(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		h.handleBlockError(c, err, "BlockUser")
+		return
+	}
+
+	targetID, err := bindUserID(c)
+	if err != nil {
+		return
+	}
+
+	if err := h.service.Block(userID, targetID); err != nil {
+		h.handleBlockError(c, err, "BlockUser")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// UnblockUser removes the caller's block on the user (requires authentication)
+//
+// Example:
+//
+//	DELETE /api/v1/users/550e8400-e29b-41d4-a716-446655440000/block
+func (h *BlockHandler) UnblockUser(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		h.handleBlockError(c, err, "UnblockUser")
+		return
+	}
+
+	targetID, err := bindUserID(c)
+	if err != nil {
+		return
+	}
+
+	if err := h.service.Unblock(userID, targetID); err != nil {
+		h.handleBlockError(c, err, "UnblockUser")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListBlocked lists the users the caller has blocked (requires authentication)
+//
+// Example:
+//
+//	GET /api/v1/users/me/blocks
+func (h *BlockHandler) ListBlocked(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		h.handleBlockError(c, err, "ListBlocked")
+		return
+	}
+
+	response, err := h.service.ListBlocked(userID)
+	if err != nil {
+		h.handleBlockError(c, err, "ListBlocked")
+		return
+	}
+
+	utils.RespondJSON(c, http.StatusOK, response)
+}
+
+func (h *BlockHandler) handleBlockError(c *gin.Context, err error, operation string) {
+	switch {
+	case errors.Is(err, apperrors.ErrNotFound):
+		h.logger.Info("User not found", zap.String("operation", operation), zap.Error(err))
+		utils.RespondError(c, http.StatusNotFound, "User not found")
+	case errors.Is(err, apperrors.ErrValidation):
+		h.logger.Info("Validation error", zap.String("operation", operation), zap.Error(err))
+		utils.RespondError(c, http.StatusBadRequest, "Validation failed")
+	case errors.Is(err, apperrors.ErrLimitExceeded):
+		h.logger.Info("Block limit reached", zap.String("operation", operation), zap.Error(err))
+		utils.RespondError(c, http.StatusBadRequest, "Block limit reached, unblock someone first")
+	case errors.Is(err, apperrors.ErrUnauthorized):
+		h.logger.Info("Unauthorized", zap.String("operation", operation), zap.Error(err))
+		utils.RespondError(c, http.StatusUnauthorized, "Unauthorized")
+	default:
+		h.logger.Error("Unexpected error", zap.String("operation", operation), zap.Error(err))
+		utils.RespondError(c, http.StatusInternalServerError, "Internal server error")
+	}
+}
@@ -1,17 +1,55 @@
 package handler
 
 import (
+	"go-gin-api-server/pkg/utils"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
+// RespondCreated writes a 201 with a Location header pointing to the new resource
+func RespondCreated(c *gin.Context, location string, data interface{}) {
+	c.Header("Location", location)
+	utils.RespondJSON(c, http.StatusCreated, data)
+}
+
+// MethodNotAllowed is the NoMethod handler, gin has already set the Allow header
+func MethodNotAllowed(c *gin.Context) {
+	utils.RespondError(c, http.StatusMethodNotAllowed, "Method not allowed")
+}
+
+// StaleWarning is the Warning header sent with data served from cache while the database is unavailable
+const StaleWarning = `110 - "Response is Stale"`
+
+// MarkStale sends StaleWarning when stale is set
+func MarkStale(c *gin.Context, stale bool) {
+	if stale {
+		c.Header("Warning", StaleWarning)
+	}
+}
+
+// NotModifiedSince sets Last-Modified and reports whether If-Modified-Since is at or after it.
+// HTTP dates have one-second precision, so a change within the same second goes unnoticed.
+func NotModifiedSince(c *gin.Context, lastModified time.Time) bool {
+	if lastModified.IsZero() {
+		return false
+	}
+
+	lastModified = lastModified.UTC().Truncate(time.Second)
+	c.Header("Last-Modified", lastModified.Format(http.TimeFormat))
+
+	since, err := http.ParseTime(c.GetHeader("If-Modified-Since"))
+	if err != nil {
+		return false
+	}
+	return !lastModified.After(since)
+}
+
 // BindJSON error handling
 func BindJSON(c *gin.Context, obj interface{}) error {
 	if err := c.ShouldBindJSON(obj); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Invalid request format",
-		})
+		utils.RespondError(c, http.StatusBadRequest, "Invalid request format")
 		return err
 	}
 	return nil
@@ -19,9 +57,7 @@ func BindJSON(c *gin.Context, obj interface{}) error {
 
 func BindQuery(c *gin.Context, obj interface{}) error {
 	if err := c.ShouldBindQuery(obj); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Invalid request format",
-		})
+		utils.RespondError(c, http.StatusBadRequest, "Invalid request format")
 		return err
 	}
 	return nil
@@ -29,9 +65,7 @@ func BindQuery(c *gin.Context, obj interface{}) error {
 
 func BindUri(c *gin.Context, obj interface{}) error {
 	if err := c.ShouldBindUri(obj); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Invalid request format",
-		})
+		utils.RespondError(c, http.StatusBadRequest, "Invalid request format")
 		return err
 	}
 	return nil
@@ -35,6 +35,12 @@ func GetUserRole(c *gin.Context) (model.UserRole, error) {
 	return userRole, nil
 }
 
+// GetImpersonatorID returns the admin acting as the caller, ok is false for a normal login
+func GetImpersonatorID(c *gin.Context) (string, bool) {
+	impersonatorID := c.GetString("impersonator_id")
+	return impersonatorID, impersonatorID != ""
+}
+
 func GetUserIDAndRole(c *gin.Context) (string, model.UserRole, error) {
 	userID, err := GetUserID(c)
 	if err != nil {
@@ -0,0 +1,89 @@
+package handler
+
+import (
+	"errors"
+	"go-gin-api-server/internal/middleware"
+	"go-gin-api-server/internal/model"
+	"go-gin-api-server/internal/service"
+	"go-gin-api-server/pkg/apperrors"
+	"go-gin-api-server/pkg/utils"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type ExportHandler struct {
+	service service.ExportService
+	logger  *zap.Logger
+}
+
+func NewExportHandler(service service.ExportService, logger *zap.Logger) *ExportHandler {
+	return &ExportHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (h *ExportHandler) RegisterProtectedRoutes(r *gin.Engine, authMiddleware *middleware.AuthMiddleware, rbacMiddleware *middleware.RBACMiddleware, heavyLimit *middleware.ConcurrencyLimitMiddleware) {
+	// Exports are always scoped to the authenticated user
+	protected := r.Group("/api/v1/users/me")
+	protected.Use(authMiddleware.RequireAuth())
+	{
+		// exempt from the global cap since it streams, so the heavy query cap bounds it
+		protected.GET("/export", heavyLimit.Limit(1), h.ExportMyData)
+	}
+}
+
+// ExportMyData streams the current user's profile, posts and notifications as one JSON
+// document (model.UserExport); a large export is split into pages, each carrying the
+// continuation token for the next one in "next"
+//
+// Examples:
+//
+//	GET /api/v1/users/me/export
+//	GET /api/v1/users/me/export?continuation=eyJzZWN0aW9uIjoibm90aWZpY2F0aW9ucyIsIm9mZnNldCI6MTAwfQ==
+func (h *ExportHandler) ExportMyData(c *gin.Context) {
+	var req model.ExportRequest
+	if err := BindQuery(c, &req); err != nil {
+		return
+	}
+
+	userID, err := GetUserID(c)
+	if err != nil {
+		h.handleExportError(c, err)
+		return
+	}
+
+	c.Header("Content-Type", "application/json; charset=utf-8")
+	c.Header("Content-Disposition", `attachment; filename="export.json"`)
+	c.Status(http.StatusOK)
+
+	if err := h.service.Export(userID, req.Continuation, c.Writer); err != nil {
+		// once the body has started the status is sent, the client gets a truncated document
+		if c.Writer.Written() {
+			h.logger.Error("Export aborted mid-stream", zap.String("user_id", userID), zap.Error(err))
+			c.Abort()
+			return
+		}
+		c.Writer.Header().Del("Content-Disposition")
+		h.handleExportError(c, err)
+	}
+}
+
+func (h *ExportHandler) handleExportError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, apperrors.ErrUnauthorized):
+		h.logger.Info("Unauthorized", zap.Error(err))
+		utils.RespondError(c, http.StatusUnauthorized, "Unauthorized")
+	case errors.Is(err, apperrors.ErrValidation):
+		h.logger.Info("Invalid continuation token", zap.Error(err))
+		utils.RespondError(c, http.StatusBadRequest, "Invalid continuation token")
+	case errors.Is(err, apperrors.ErrNotFound):
+		h.logger.Info("User not found", zap.Error(err))
+		utils.RespondError(c, http.StatusNotFound, "User not found")
+	default:
+		h.logger.Error("Internal server error", zap.Error(err))
+		utils.RespondError(c, http.StatusInternalServerError, "Internal server error")
+	}
+}
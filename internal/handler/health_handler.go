@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"go-gin-api-server/internal/middleware"
+	"go-gin-api-server/internal/model"
+	"go-gin-api-server/internal/service"
+	"go-gin-api-server/pkg/utils"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type HealthHandler struct {
+	service service.HealthService
+	logger  *zap.Logger
+}
+
+func NewHealthHandler(service service.HealthService, logger *zap.Logger) *HealthHandler {
+	return &HealthHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (h *HealthHandler) RegisterProtectedRoutes(r *gin.Engine, authMiddleware *middleware.AuthMiddleware, rbacMiddleware *middleware.RBACMiddleware) {
+	// Admin-only routes - the report reveals pool stats, versions and configuration
+	admin := r.Group("/api/v1/admin/health")
+	admin.Use(authMiddleware.RequireAuth())
+	admin.Use(rbacMiddleware.RequireAdmin())
+	{
+		admin.GET("", h.GetHealthDetails)
+	}
+}
+
+// GetHealthDetails reports dependency versions, DB pool stats, the migration version and
+// the configured feature flags; 503 when a dependency can't be read (requires admin)
+//
+// Example:
+//
+//	GET /api/v1/admin/health
+func (h *HealthHandler) GetHealthDetails(c *gin.Context) {
+	details := h.service.Details()
+
+	status := http.StatusOK
+	if details.Status != model.HealthStatusOK {
+		h.logger.Warn("Health degraded", zap.Strings("errors", details.Errors))
+		status = http.StatusServiceUnavailable
+	}
+
+	utils.RespondJSON(c, status, details)
+}
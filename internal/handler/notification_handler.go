@@ -0,0 +1,167 @@
+package handler
+
+import (
+	"errors"
+	"go-gin-api-server/internal/middleware"
+	"go-gin-api-server/internal/model"
+	"go-gin-api-server/internal/service"
+	"go-gin-api-server/pkg/apperrors"
+	"go-gin-api-server/pkg/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type NotificationHandler struct {
+	service service.NotificationService
+	logger  *zap.Logger
+}
+
+func NewNotificationHandler(service service.NotificationService, logger *zap.Logger) *NotificationHandler {
+	return &NotificationHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (h *NotificationHandler) RegisterProtectedRoutes(r *gin.Engine, authMiddleware *middleware.AuthMiddleware, rbacMiddleware *middleware.RBACMiddleware) {
+	// Notifications are always scoped to the authenticated user
+	protected := r.Group("/api/v1/notifications")
+	protected.Use(authMiddleware.RequireAuth())
+	{
+		protected.GET("", h.GetNotifications)
+		protected.GET("/mentions", h.GetMentions)
+		protected.GET("/unread-count", h.GetUnreadCount)
+		protected.POST("/:id/read", h.MarkRead)
+	}
+}
+
+// GetNotifications retrieves the current user's notifications with cursor pagination, newest first
+//
+// Examples:
+//
+//	GET /api/v1/notifications?limit=20
+//	GET /api/v1/notifications?status=unread&cursor=AXsiaWQiOiI0MiIsImNyZWF0ZWRfYXQiOiIyMDI0LTAxLTAxVDA4OjAwOjAwWiJ9
+func (h *NotificationHandler) GetNotifications(c *gin.Context) {
+	var listReq model.NotificationListRequest
+	if err := BindQuery(c, &listReq); err != nil {
+		return
+	}
+
+	userID, err := GetUserID(c)
+	if err != nil {
+		h.handleNotificationError(c, err, "GetNotifications")
+		return
+	}
+
+	response, err := h.service.List(userID, listReq)
+	if err != nil {
+		h.handleNotificationError(c, err, "GetNotifications")
+		return
+	}
+
+	h.handleNotificationSuccess(c, response, http.StatusOK)
+}
+
+// GetMentions is GetNotifications limited to mention notifications, for a mentions tab
+//
+// Examples:
+//
+//	GET /api/v1/notifications/mentions?limit=20
+//	GET /api/v1/notifications/mentions?status=unread&cursor=AXsiaWQiOiI0MiIsImNyZWF0ZWRfYXQiOiIyMDI0LTAxLTAxVDA4OjAwOjAwWiJ9
+func (h *NotificationHandler) GetMentions(c *gin.Context) {
+	var listReq model.NotificationListRequest
+	if err := BindQuery(c, &listReq); err != nil {
+		return
+	}
+	mention := model.NotificationMention
+	listReq.Type = &mention
+
+	userID, err := GetUserID(c)
+	if err != nil {
+		h.handleNotificationError(c, err, "GetMentions")
+		return
+	}
+
+	response, err := h.service.List(userID, listReq)
+	if err != nil {
+		h.handleNotificationError(c, err, "GetMentions")
+		return
+	}
+
+	h.handleNotificationSuccess(c, response, http.StatusOK)
+}
+
+// GetUnreadCount returns how many notifications the current user hasn't read
+//
+// Example:
+//
+//	GET /api/v1/notifications/unread-count
+func (h *NotificationHandler) GetUnreadCount(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		h.handleNotificationError(c, err, "GetUnreadCount")
+		return
+	}
+
+	count, err := h.service.UnreadCount(userID)
+	if err != nil {
+		h.handleNotificationError(c, err, "GetUnreadCount")
+		return
+	}
+
+	h.handleNotificationSuccess(c, model.UnreadCountResponse{Count: count}, http.StatusOK)
+}
+
+// MarkRead marks one of the current user's notifications as read
+//
+// Example:
+//
+//	POST /api/v1/notifications/123/read
+func (h *NotificationHandler) MarkRead(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		h.handleNotificationError(c, apperrors.ErrValidation, "MarkRead")
+		return
+	}
+
+	userID, err := GetUserID(c)
+	if err != nil {
+		h.handleNotificationError(c, err, "MarkRead")
+		return
+	}
+
+	if err := h.service.MarkRead(id, userID); err != nil {
+		h.handleNotificationError(c, err, "MarkRead")
+		return
+	}
+
+	h.handleNotificationSuccess(c, nil, http.StatusNoContent)
+}
+
+func (h *NotificationHandler) handleNotificationError(c *gin.Context, err error, operation string) {
+	switch {
+	case errors.Is(err, apperrors.ErrNotFound):
+		h.logger.Info("Notification not found", zap.String("operation", operation), zap.Error(err))
+		utils.RespondError(c, http.StatusNotFound, "Notification not found")
+	case errors.Is(err, apperrors.ErrValidation):
+		h.logger.Info("Validation error", zap.String("operation", operation), zap.Error(err))
+		utils.RespondError(c, http.StatusBadRequest, "Validation failed")
+	case errors.Is(err, apperrors.ErrUnauthorized):
+		h.logger.Info("Unauthorized", zap.String("operation", operation), zap.Error(err))
+		utils.RespondError(c, http.StatusUnauthorized, "Unauthorized")
+	default:
+		h.logger.Error("Unexpected error", zap.String("operation", operation), zap.Error(err))
+		utils.RespondError(c, http.StatusInternalServerError, "Internal server error")
+	}
+}
+
+func (h *NotificationHandler) handleNotificationSuccess(c *gin.Context, data interface{}, statusCode int) {
+	if data != nil {
+		utils.RespondJSON(c, statusCode, data)
+	} else {
+		c.Status(statusCode)
+	}
+}
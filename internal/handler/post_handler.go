@@ -2,12 +2,17 @@ package handler
 
 import (
 	"errors"
+	"fmt"
 	"go-gin-api-server/internal/middleware"
 	"go-gin-api-server/internal/model"
 	"go-gin-api-server/internal/service"
 	"go-gin-api-server/pkg/apperrors"
+	"go-gin-api-server/pkg/jsonschema"
+	"go-gin-api-server/pkg/querybind"
+	"go-gin-api-server/pkg/utils"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
@@ -25,22 +30,61 @@ func NewPostHandler(service service.PostService, logger *zap.Logger) *PostHandle
 	}
 }
 
-func (h *PostHandler) RegisterRoutes(r *gin.Engine) {
+func (h *PostHandler) RegisterRoutes(r *gin.Engine, authMiddleware *middleware.AuthMiddleware, rbacMiddleware *middleware.RBACMiddleware, readLimit *middleware.RateLimitMiddleware, heavyLimit *middleware.ConcurrencyLimitMiddleware) {
+	// Public routes - optional auth lets authors and moderators see hidden posts, and
+	// gives signed-in readers their own (higher) rate limit budget; API keys need posts:read
 	router := r.Group("/api/v1")
+	router.Use(rbacMiddleware.RequireScope(model.APIKeyScopePostsRead))
+	router.Use(authMiddleware.OptionalAuth())
+	if readLimit != nil {
+		router.Use(readLimit.LimitByClient())
+	}
 	{
-		router.GET("/posts", h.GetPosts)
+		// a search (?q=) scans title and content with ILIKE, keep it off the DB under load
+		listPosts := []gin.HandlerFunc{h.GetPosts}
+		if heavyLimit != nil {
+			listPosts = append([]gin.HandlerFunc{heavyLimit.LimitQuery(1, "q")}, listPosts...)
+		}
+		router.GET("/posts", listPosts...)
+		router.GET("/posts/limits", h.GetPostLimits)
 		router.GET("/posts/:id", h.GetPostByID)
+		router.GET("/posts/slug/:slug", h.GetPostBySlug)
 	}
 }
 
-func (h *PostHandler) RegisterProtectedRoutes(r *gin.Engine, authMiddleware *middleware.AuthMiddleware, rbacMiddleware *middleware.RBACMiddleware) {
+func (h *PostHandler) RegisterProtectedRoutes(r *gin.Engine, authMiddleware *middleware.AuthMiddleware, rbacMiddleware *middleware.RBACMiddleware, heavyLimit *middleware.ConcurrencyLimitMiddleware, maxBodyBytes int64) {
 	// Basic protected routes - require authentication
 	protected := r.Group("/api/v1/posts")
-	protected.Use(authMiddleware.RequireAuth())
 	{
-		protected.POST("", h.CreatePost)
-		protected.PATCH("/:id", h.UpdatePost)
-		protected.DELETE("/:id", h.DeletePost)
+		// API keys need posts:write to change posts and posts:read for revisions, JWT sessions
+		// always pass; the scope check runs before RequireAuth, which rejects API key writes
+		// on every other route
+		postsWrite := rbacMiddleware.RequireScope(model.APIKeyScopePostsWrite)
+		postsRead := rbacMiddleware.RequireScope(model.APIKeyScopePostsRead)
+		requireAuth := authMiddleware.RequireAuth()
+
+		protected.POST("", postsWrite, requireAuth, middleware.ValidateJSONSchema(model.CreatePostSchema, maxBodyBytes), h.CreatePost)
+		protected.POST("/validate", requireAuth, h.ValidatePost)
+		protected.POST("/preview", requireAuth, h.PreviewPost)
+		protected.PATCH("/:id", postsWrite, requireAuth, h.UpdatePost)
+		protected.PUT("/:id", postsWrite, requireAuth, h.ReplacePost)
+		protected.DELETE("/:id", postsWrite, requireAuth, h.DeletePost)
+		protected.GET("/:id/revisions", postsRead, requireAuth, h.GetPostRevisions)
+		protected.POST("/:id/hide", requireAuth, h.HidePost)
+	}
+
+	// Moderation routes - moderators and admins
+	moderation := r.Group("/api/v1/admin/posts")
+	moderation.Use(authMiddleware.RequireAuth())
+	moderation.Use(rbacMiddleware.RequireModerator())
+	{
+		// the offset listing counts every matching row, keep it off the DB under load
+		listPaged := []gin.HandlerFunc{h.GetPostsPaged}
+		if heavyLimit != nil {
+			listPaged = append([]gin.HandlerFunc{heavyLimit.Limit(1)}, listPaged...)
+		}
+		moderation.GET("", listPaged...)
+		moderation.DELETE("/:id", h.AdminDeletePost)
 	}
 }
 
@@ -51,24 +95,104 @@ func (h *PostHandler) RegisterProtectedRoutes(r *gin.Engine, authMiddleware *mid
 //	GET /api/v1/posts?limit=10
 //	GET /api/v1/posts?limit=10&cursor=eyJpZCI6IjEiLCJjcmVhdGVkX2F0IjoiMjAyNC0wMS0wMVQwODowMDowMFoifQ==
 //	GET /api/v1/posts?limit=10&author_id=user123
+//	GET /api/v1/posts?order=asc&created_after=2024-01-01T00:00:00Z&created_before=2024-02-01T00:00:00Z
+//	GET /api/v1/posts?limit=10 (If-Modified-Since: Mon, 01 Jan 2024 08:00:00 GMT)
+//	GET /api/v1/posts?limit=50&view=compact
 func (h *PostHandler) GetPosts(c *gin.Context) {
 	// Parse cursor request parameters
-	var cursorReq model.CursorRequest
-	if err := BindQuery(c, &cursorReq); err != nil {
-		h.handlePostError(c, apperrors.ErrValidation, "GetPosts")
+	// limit: omitted/0 uses the default, 1..100 as-is, anything else is a 400
+	var listReq model.PostListRequest
+	if err := BindQuery(c, &listReq); err != nil {
+		return
+	}
+
+	query, err := querybind.Parse(c.Request.URL.Query(), querybind.Options{SortKeys: model.PostSortKeys})
+	if err != nil {
+		h.handlePostError(c, err, "GetPosts")
+		return
+	}
+	listReq.Query = query
+	// anonymous callers have no blocks to apply
+	listReq.ViewerID, _ = GetUserID(c)
+
+	if listReq.View == model.PostViewCompact {
+		h.getPostsCompact(c, listReq)
 		return
 	}
 
 	// Get posts with cursor pagination
-	response, err := h.service.List(cursorReq)
+	response, err := h.service.List(listReq)
 	if err != nil {
 		h.handlePostError(c, err, "GetPosts")
 		return
 	}
 
+	// pollers send back Last-Modified as If-Modified-Since and get 304 while the page is unchanged
+	if NotModifiedSince(c, latestUpdatedAt(response.Data)) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
 	h.handlePostSuccess(c, response, http.StatusOK)
 }
 
+// getPostsCompact is GetPosts for view=compact, summaries instead of full posts
+func (h *PostHandler) getPostsCompact(c *gin.Context, listReq model.PostListRequest) {
+	response, err := h.service.ListCompact(listReq)
+	if err != nil {
+		h.handlePostError(c, err, "GetPosts")
+		return
+	}
+
+	var latest time.Time
+	for _, summary := range response.Data {
+		if summary.UpdatedAt.After(latest) {
+			latest = summary.UpdatedAt
+		}
+	}
+	if NotModifiedSince(c, latest) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	h.handlePostSuccess(c, response, http.StatusOK)
+}
+
+// GetPostsPaged retrieves an offset-paginated list of posts with the total count, hidden
+// posts included (requires moderator or admin)
+//
+// Examples:
+//
+//	GET /api/v1/admin/posts?page=1&page_size=20
+//	GET /api/v1/admin/posts?page=2&page_size=20&author_id=user123
+//	GET /api/v1/admin/posts?status=hidden
+//	GET /api/v1/admin/posts?include_deleted=true (admin only)
+func (h *PostHandler) GetPostsPaged(c *gin.Context) {
+	var pageReq model.PostModerationListRequest
+	if err := BindQuery(c, &pageReq); err != nil {
+		return
+	}
+	// the service only honors include_deleted for admins
+	pageReq.CallerRole, _ = GetUserRole(c)
+
+	response, err := h.service.ListPaged(pageReq)
+	if err != nil {
+		h.handlePostError(c, err, "GetPostsPaged")
+		return
+	}
+
+	h.handlePostSuccess(c, response, http.StatusOK)
+}
+
+// GetPostLimits returns the configured write limits for posts
+//
+// Example:
+//
+//	GET /api/v1/posts/limits
+func (h *PostHandler) GetPostLimits(c *gin.Context) {
+	h.handlePostSuccess(c, h.service.Limits(), http.StatusOK)
+}
+
 // GetPostByID retrieves a single post by its ID
 //
 // Example:
@@ -82,12 +206,40 @@ func (h *PostHandler) GetPostByID(c *gin.Context) {
 		return
 	}
 
-	found, err := h.service.GetByID(id)
+	// anonymous viewers have no user ID or role
+	viewerID := c.GetString("user_id")
+	viewerRole, _ := GetUserRole(c)
+
+	found, err := h.service.GetByID(id, viewerID, viewerRole)
 	if err != nil {
 		h.handlePostError(c, err, "GetPostByID")
 		return
 	}
 
+	// clients send the ETag back as If-Match on PATCH to avoid lost updates
+	c.Header("ETag", found.ETag())
+	MarkStale(c, found.Stale)
+	h.handlePostSuccess(c, found, http.StatusOK)
+}
+
+// GetPostBySlug retrieves a single post by its slug
+//
+// Example:
+//
+//	GET /api/v1/posts/slug/hello-world
+func (h *PostHandler) GetPostBySlug(c *gin.Context) {
+	slug := c.Param("slug")
+
+	// anonymous viewers have no user ID or role
+	viewerID := c.GetString("user_id")
+	viewerRole, _ := GetUserRole(c)
+
+	found, err := h.service.GetBySlug(slug, viewerID, viewerRole)
+	if err != nil {
+		h.handlePostError(c, err, "GetPostBySlug")
+		return
+	}
+
 	h.handlePostSuccess(c, found, http.StatusOK)
 }
 
@@ -116,14 +268,16 @@ func (h *PostHandler) CreatePost(c *gin.Context) {
 		return
 	}
 
-	h.handlePostSuccess(c, created, http.StatusCreated)
+	RespondCreated(c, fmt.Sprintf("/api/v1/posts/%d", created.ID), model.NewPostResponse(*created))
 }
 
-// UpdatePost updates an existing post (requires authentication and ownership)
+// UpdatePost partially updates an existing post (requires authentication and ownership);
+// omitted fields keep their current values, use ReplacePost to replace the whole post.
+// An If-Match header with the post's ETag makes the update conditional, 412 when stale
 //
 // Example:
 //
-//	PATCH /api/v1/posts/123
+//	PATCH /api/v1/posts/123 (If-Match: "123-1704096000000000")
 //	{
 //	  "content": "Updated post content"
 //	}
@@ -135,24 +289,79 @@ func (h *PostHandler) UpdatePost(c *gin.Context) {
 		return
 	}
 
-	var update model.Post
-	if err := BindJSON(c, &update); err != nil {
+	var req model.UpdatePostRequest
+	if err := BindJSON(c, &req); err != nil {
 		return
 	}
 
+	// an empty content and a nil title are what the service treats as "not provided"
+	update := model.Post{Title: req.Title}
+	if req.Content != nil {
+		update.Content = *req.Content
+	}
+
 	userID, err := GetUserID(c)
 	if err != nil {
 		h.handlePostError(c, err, "UpdatePost")
 		return
 	}
 
-	updated, err := h.service.Update(id, &update, userID)
+	var updated *model.Post
+	if ifMatch := c.GetHeader("If-Match"); ifMatch != "" {
+		updated, err = h.service.UpdateIfMatch(id, &update, userID, ifMatch)
+	} else {
+		updated, err = h.service.Update(id, &update, userID)
+	}
 	if err != nil {
 		h.handlePostError(c, err, "UpdatePost")
 		return
 	}
 
-	h.handlePostSuccess(c, updated, http.StatusOK)
+	c.Header("ETag", updated.ETag())
+	h.handlePostSuccess(c, model.NewPostResponse(*updated), http.StatusOK)
+}
+
+// ReplacePost replaces an existing post (requires authentication and ownership);
+// every editable field is required, unlike the partial UpdatePost
+//
+// Example:
+//
+//	PUT /api/v1/posts/123
+//	{
+//	  "content": "Replacement post content"
+//	}
+func (h *PostHandler) ReplacePost(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		h.handlePostError(c, apperrors.ErrValidation, "ReplacePost")
+		return
+	}
+
+	var req model.ReplacePostRequest
+	if err := BindJSON(c, &req); err != nil {
+		return
+	}
+
+	userID, err := GetUserID(c)
+	if err != nil {
+		h.handlePostError(c, err, "ReplacePost")
+		return
+	}
+
+	replacement := &model.Post{Title: req.Title, Content: req.Content}
+	var replaced *model.Post
+	if ifMatch := c.GetHeader("If-Match"); ifMatch != "" {
+		replaced, err = h.service.ReplaceIfMatch(id, replacement, userID, ifMatch)
+	} else {
+		replaced, err = h.service.Replace(id, replacement, userID)
+	}
+	if err != nil {
+		h.handlePostError(c, err, "ReplacePost")
+		return
+	}
+
+	c.Header("ETag", replaced.ETag())
+	h.handlePostSuccess(c, model.NewPostResponse(*replaced), http.StatusOK)
 }
 
 // DeletePost deletes a post (requires authentication and ownership)
@@ -182,56 +391,272 @@ func (h *PostHandler) DeletePost(c *gin.Context) {
 	h.handlePostSuccess(c, nil, http.StatusNoContent)
 }
 
+// ValidatePost checks draft content without creating a post (requires authentication)
+//
+// Example:
+//
+//	POST /api/v1/posts/validate
+//	{
+//	  "content": "Draft content"
+//	}
+func (h *PostHandler) ValidatePost(c *gin.Context) {
+	var req model.ValidatePostRequest
+	if err := BindJSON(c, &req); err != nil {
+		return
+	}
+
+	errs := h.service.ValidateContent(req.Content)
+	response := model.PostValidationResponse{
+		Valid:  len(errs) == 0,
+		Errors: make([]string, 0, len(errs)),
+	}
+	for _, err := range errs {
+		response.Errors = append(response.Errors, postContentErrorMessage(err))
+	}
+
+	h.handlePostSuccess(c, response, http.StatusOK)
+}
+
+// PreviewPost shows what creating the draft would store: the normalized title and content,
+// the slug, the mentions that would be notified and any validation errors; nothing is
+// written (requires authentication)
+//
+// Example:
+//
+//	POST /api/v1/posts/preview
+//	{
+//	  "title": "  Hello  ",
+//	  "content": "Draft content for @alice"
+//	}
+func (h *PostHandler) PreviewPost(c *gin.Context) {
+	var req model.PreviewPostRequest
+	if err := BindJSON(c, &req); err != nil {
+		return
+	}
+
+	preview, err := h.service.Preview(&model.Post{Title: req.Title, Content: req.Content})
+	if preview == nil {
+		h.handlePostError(c, err, "PreviewPost")
+		return
+	}
+
+	preview.Errors = []string{}
+	if err != nil {
+		errs := []error{err}
+		var validationErrs *apperrors.ValidationErrors
+		if errors.As(err, &validationErrs) {
+			errs = validationErrs.Errs
+		}
+		for _, e := range errs {
+			message := postContentErrorMessage(e)
+			if message == "" {
+				message = "Validation failed"
+			}
+			preview.Errors = append(preview.Errors, message)
+		}
+	}
+
+	h.handlePostSuccess(c, preview, http.StatusOK)
+}
+
+// AdminDeletePost removes any post regardless of ownership and records an audit entry
+// (requires moderator or admin)
+//
+// Example:
+//
+//	DELETE /api/v1/admin/posts/123
+func (h *PostHandler) AdminDeletePost(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		h.handlePostError(c, apperrors.ErrValidation, "AdminDeletePost")
+		return
+	}
+
+	actorID, role, err := GetUserIDAndRole(c)
+	if err != nil {
+		h.handlePostError(c, err, "AdminDeletePost")
+		return
+	}
+
+	if err := h.service.AdminDelete(id, actorID, role); err != nil {
+		h.handlePostError(c, err, "AdminDeletePost")
+		return
+	}
+
+	h.logger.Info("Post deleted by moderator", zap.Uint64("post_id", id), zap.String("actor_id", actorID))
+	h.handlePostSuccess(c, nil, http.StatusNoContent)
+}
+
+// GetPostRevisions retrieves a post's edit history (requires the author, a moderator or an admin)
+//
+// Example:
+//
+//	GET /api/v1/posts/123/revisions
+func (h *PostHandler) GetPostRevisions(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		h.handlePostError(c, apperrors.ErrValidation, "GetPostRevisions")
+		return
+	}
+
+	userID, role, err := GetUserIDAndRole(c)
+	if err != nil {
+		h.handlePostError(c, err, "GetPostRevisions")
+		return
+	}
+
+	revisions, err := h.service.ListRevisions(id, userID, role)
+	if err != nil {
+		h.handlePostError(c, err, "GetPostRevisions")
+		return
+	}
+
+	h.handlePostSuccess(c, revisions, http.StatusOK)
+}
+
+// HidePost hides or unhides a post (requires moderator or admin)
+//
+// Examples:
+//
+//	POST /api/v1/posts/123/hide
+//	POST /api/v1/posts/123/hide
+//	{
+//	  "hidden": false
+//	}
+func (h *PostHandler) HidePost(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		h.handlePostError(c, apperrors.ErrValidation, "HidePost")
+		return
+	}
+
+	// body is optional, an empty request hides the post
+	var req model.SetPostHiddenRequest
+	if c.Request.ContentLength > 0 {
+		if err := BindJSON(c, &req); err != nil {
+			return
+		}
+	}
+	hidden := true
+	if req.Hidden != nil {
+		hidden = *req.Hidden
+	}
+
+	role, err := GetUserRole(c)
+	if err != nil {
+		h.handlePostError(c, err, "HidePost")
+		return
+	}
+
+	updated, err := h.service.SetHidden(id, hidden, role)
+	if err != nil {
+		h.handlePostError(c, err, "HidePost")
+		return
+	}
+
+	h.handlePostSuccess(c, model.NewPostResponse(*updated), http.StatusOK)
+}
+
 // Helper functions
 
+// postContentMessages client-facing messages for content validation failures
+var postContentMessages = map[error]string{
+	apperrors.ErrPostContentTooLong:        "Post content is too long",
+	apperrors.ErrPostContentTooShort:       "Post content is too short",
+	apperrors.ErrPostContentControlChars:   "Post content contains invalid characters",
+	apperrors.ErrPostContentSensitiveWords: "Post content contains inappropriate language",
+	apperrors.ErrPostTitleEmpty:            "Post title must not be empty",
+	apperrors.ErrPostTitleTooLong:          "Post title is too long",
+	apperrors.ErrPostTitleControlChars:     "Post title contains invalid characters",
+	apperrors.ErrPostTooManyMentions:       "Post mentions too many users",
+}
+
+// latestUpdatedAt returns the newest UpdatedAt on the page, zero for an empty page
+func latestUpdatedAt(posts []model.PostResponse) time.Time {
+	var latest time.Time
+	for _, post := range posts {
+		if post.UpdatedAt.After(latest) {
+			latest = post.UpdatedAt
+		}
+	}
+	return latest
+}
+
+func isPostContentError(err error) bool {
+	return postContentErrorMessage(err) != ""
+}
+
+func postContentErrorMessage(err error) string {
+	for target, message := range postContentMessages {
+		if errors.Is(err, target) {
+			return message
+		}
+	}
+	return ""
+}
+
+// postValidationDetails maps collected content and title failures to per-field details
+func postValidationDetails(errs []error) []jsonschema.FieldError {
+	details := make([]jsonschema.FieldError, 0, len(errs))
+	for _, err := range errs {
+		field := "content"
+		if errors.Is(err, apperrors.ErrPostTitleEmpty) ||
+			errors.Is(err, apperrors.ErrPostTitleTooLong) ||
+			errors.Is(err, apperrors.ErrPostTitleControlChars) {
+			field = "title"
+		}
+		message := postContentErrorMessage(err)
+		if message == "" {
+			message = err.Error()
+		}
+		details = append(details, jsonschema.FieldError{Field: field, Message: message})
+	}
+	return details
+}
+
 func (h *PostHandler) handlePostError(c *gin.Context, err error, operation string) {
+	var validationErrs *apperrors.ValidationErrors
 	switch {
+	case errors.As(err, &validationErrs):
+		h.logger.Info("Invalid post content", zap.String("operation", operation), zap.Error(err))
+		utils.RespondFieldErrors(c, http.StatusBadRequest, "Validation failed", postValidationDetails(validationErrs.Errs))
 	case errors.Is(err, apperrors.ErrNotFound):
 		h.logger.Info("Post not found", zap.String("operation", operation), zap.Error(err))
-		c.JSON(http.StatusNotFound, gin.H{
-			"error": "Post not found",
-		})
+		utils.RespondError(c, http.StatusNotFound, "Post not found")
+	// content errors may also wrap ErrValidation, the specific message wins
+	case isPostContentError(err):
+		h.logger.Info("Invalid post content", zap.String("operation", operation), zap.Error(err))
+		utils.RespondError(c, http.StatusBadRequest, postContentErrorMessage(err))
 	case errors.Is(err, apperrors.ErrValidation):
 		h.logger.Info("Validation error", zap.String("operation", operation), zap.Error(err))
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Validation failed",
-		})
+		utils.RespondError(c, http.StatusBadRequest, "Validation failed")
 	case errors.Is(err, apperrors.ErrForbidden):
 		h.logger.Info("Permission denied", zap.String("operation", operation), zap.Error(err))
-		c.JSON(http.StatusForbidden, gin.H{
-			"error": "Permission denied",
-		})
+		utils.RespondError(c, http.StatusForbidden, "Permission denied")
 	case errors.Is(err, apperrors.ErrUnauthorized):
 		h.logger.Info("Unauthorized", zap.String("operation", operation), zap.Error(err))
-		c.JSON(http.StatusUnauthorized, gin.H{
-			"error": "Unauthorized",
-		})
-	case errors.Is(err, apperrors.ErrPostContentTooLong):
-		h.logger.Info("Post content too long", zap.String("operation", operation), zap.Error(err))
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Post content is too long",
-		})
-	case errors.Is(err, apperrors.ErrPostContentTooShort):
-		h.logger.Info("Post content too short", zap.String("operation", operation), zap.Error(err))
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Post content is too short",
-		})
-	case errors.Is(err, apperrors.ErrPostContentSensitiveWords):
-		h.logger.Info("Post content contains sensitive words", zap.String("operation", operation), zap.Error(err))
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Post content contains inappropriate language",
-		})
+		utils.RespondError(c, http.StatusUnauthorized, "Unauthorized")
+	case errors.Is(err, apperrors.ErrPreconditionFailed):
+		h.logger.Info("Stale If-Match", zap.String("operation", operation), zap.Error(err))
+		utils.RespondError(c, http.StatusPreconditionFailed, "Post was modified, fetch it again and retry")
+	case errors.Is(err, apperrors.ErrPreconditionRequired):
+		h.logger.Info("Missing If-Match", zap.String("operation", operation), zap.Error(err))
+		utils.RespondError(c, http.StatusPreconditionRequired, "If-Match header is required")
+	case errors.Is(err, apperrors.ErrConflict):
+		h.logger.Warn("Post slug conflict", zap.String("operation", operation), zap.Error(err))
+		utils.RespondError(c, http.StatusConflict, "Post slug already exists, please retry")
+	case errors.Is(err, apperrors.ErrUnavailable):
+		h.logger.Error("Database unavailable", zap.String("operation", operation), zap.Error(err))
+		utils.RespondError(c, http.StatusServiceUnavailable, "Service temporarily unavailable")
 	default:
 		h.logger.Error("Unexpected error", zap.String("operation", operation), zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Internal server error",
-		})
+		utils.RespondError(c, http.StatusInternalServerError, "Internal server error")
 	}
 }
 
 func (h *PostHandler) handlePostSuccess(c *gin.Context, data interface{}, statusCode int) {
 	if data != nil {
-		c.JSON(statusCode, data)
+		utils.RespondJSON(c, statusCode, data)
 	} else {
 		c.Status(statusCode)
 	}
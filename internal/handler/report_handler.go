@@ -0,0 +1,128 @@
+package handler
+
+import (
+	"errors"
+	"go-gin-api-server/internal/middleware"
+	"go-gin-api-server/internal/model"
+	"go-gin-api-server/internal/service"
+	"go-gin-api-server/pkg/apperrors"
+	"go-gin-api-server/pkg/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type ReportHandler struct {
+	service service.ReportService
+	logger  *zap.Logger
+}
+
+func NewReportHandler(service service.ReportService, logger *zap.Logger) *ReportHandler {
+	return &ReportHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (h *ReportHandler) RegisterProtectedRoutes(r *gin.Engine, authMiddleware *middleware.AuthMiddleware, rbacMiddleware *middleware.RBACMiddleware) {
+	// Any authenticated user can report a post
+	protected := r.Group("/api/v1/posts")
+	protected.Use(authMiddleware.RequireAuth())
+	{
+		protected.POST("/:id/report", h.CreateReport)
+	}
+
+	// Moderator routes
+	moderator := r.Group("/api/v1/reports")
+	moderator.Use(authMiddleware.RequireAuth())
+	moderator.Use(rbacMiddleware.RequireModerator())
+	{
+		moderator.GET("", h.GetReports)
+	}
+}
+
+// CreateReport reports a post for moderation (requires authentication)
+//
+// Example:
+//
+//	POST /api/v1/posts/123/report
+//	{
+//	  "reason": "Spam"
+//	}
+func (h *ReportHandler) CreateReport(c *gin.Context) {
+	postID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		h.handleReportError(c, apperrors.ErrValidation, "CreateReport")
+		return
+	}
+
+	var req model.CreateReportRequest
+	if err := BindJSON(c, &req); err != nil {
+		return
+	}
+
+	userID, err := GetUserID(c)
+	if err != nil {
+		h.handleReportError(c, err, "CreateReport")
+		return
+	}
+
+	report, err := h.service.Create(postID, userID, req)
+	if err != nil {
+		h.handleReportError(c, err, "CreateReport")
+		return
+	}
+
+	h.handleReportSuccess(c, report, http.StatusCreated)
+}
+
+// GetReports lists reports for moderators with cursor pagination, oldest first
+//
+// Examples:
+//
+//	GET /api/v1/reports?limit=20
+//	GET /api/v1/reports?status=pending&cursor=AXsiaWQiOiI0MiIsImNyZWF0ZWRfYXQiOiIyMDI0LTAxLTAxVDA4OjAwOjAwWiJ9
+func (h *ReportHandler) GetReports(c *gin.Context) {
+	var req model.ReportListRequest
+	if err := BindQuery(c, &req); err != nil {
+		return
+	}
+
+	response, err := h.service.List(req)
+	if err != nil {
+		h.handleReportError(c, err, "GetReports")
+		return
+	}
+
+	h.handleReportSuccess(c, response, http.StatusOK)
+}
+
+func (h *ReportHandler) handleReportError(c *gin.Context, err error, operation string) {
+	switch {
+	case errors.Is(err, apperrors.ErrNotFound):
+		h.logger.Info("Post not found", zap.String("operation", operation), zap.Error(err))
+		utils.RespondError(c, http.StatusNotFound, "Post not found")
+	case errors.Is(err, apperrors.ErrConflict):
+		h.logger.Info("Duplicate report", zap.String("operation", operation), zap.Error(err))
+		utils.RespondError(c, http.StatusConflict, "Post already reported")
+	case errors.Is(err, apperrors.ErrValidation):
+		h.logger.Info("Validation error", zap.String("operation", operation), zap.Error(err))
+		utils.RespondError(c, http.StatusBadRequest, "Validation failed")
+	case errors.Is(err, apperrors.ErrUnauthorized):
+		h.logger.Info("Unauthorized", zap.String("operation", operation), zap.Error(err))
+		utils.RespondError(c, http.StatusUnauthorized, "Unauthorized")
+	default:
+		h.logger.Error("Unexpected error", zap.String("operation", operation), zap.Error(err))
+		utils.RespondError(c, http.StatusInternalServerError, "Internal server error")
+	}
+}
+
+func (h *ReportHandler) handleReportSuccess(c *gin.Context, data interface{}, statusCode int) {
+	if data != nil {
+		utils.RespondJSON(c, statusCode, data)
+	} else {
+		c.Status(statusCode)
+	}
+}
@@ -0,0 +1,126 @@
+package handler
+
+import (
+	"errors"
+	"go-gin-api-server/internal/middleware"
+	"go-gin-api-server/internal/model"
+	"go-gin-api-server/internal/service"
+	"go-gin-api-server/pkg/apperrors"
+	"go-gin-api-server/pkg/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type RoleRequestHandler struct {
+	service service.RoleRequestService
+	logger  *zap.Logger
+}
+
+func NewRoleRequestHandler(service service.RoleRequestService, logger *zap.Logger) *RoleRequestHandler {
+	return &RoleRequestHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (h *RoleRequestHandler) RegisterProtectedRoutes(r *gin.Engine, authMiddleware *middleware.AuthMiddleware, rbacMiddleware *middleware.RBACMiddleware) {
+	// Any authenticated user can request a higher role
+	protected := r.Group("/api/v1/auth")
+	protected.Use(authMiddleware.RequireAuth())
+	protected.Use(rbacMiddleware.ForbidImpersonation())
+	{
+		protected.POST("/role-requests", h.CreateRoleRequest)
+	}
+
+	// Admin routes
+	admin := r.Group("/api/v1/admin/role-requests")
+	admin.Use(authMiddleware.RequireAuth())
+	admin.Use(rbacMiddleware.RequireAdmin())
+	{
+		admin.POST("/:id/approve", h.ApproveRoleRequest)
+	}
+}
+
+// CreateRoleRequest asks an admin to grant the caller a higher role (requires authentication)
+//
+// Example:
+//
+//	POST /api/v1/auth/role-requests
+//	{
+//	  "role": "moderator",
+//	  "reason": "I help keep the community tidy"
+//	}
+func (h *RoleRequestHandler) CreateRoleRequest(c *gin.Context) {
+	var req model.CreateRoleRequestRequest
+	if err := BindJSON(c, &req); err != nil {
+		return
+	}
+
+	userID, err := GetUserID(c)
+	if err != nil {
+		h.handleRoleRequestError(c, err, "CreateRoleRequest")
+		return
+	}
+
+	request, err := h.service.Create(userID, req)
+	if err != nil {
+		h.handleRoleRequestError(c, err, "CreateRoleRequest")
+		return
+	}
+
+	utils.RespondJSON(c, http.StatusCreated, request)
+}
+
+// ApproveRoleRequest grants the requested role (requires admin); the new role shows up
+// in the user's token on the next refresh
+//
+// Example:
+//
+//	POST /api/v1/admin/role-requests/42/approve
+func (h *RoleRequestHandler) ApproveRoleRequest(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		h.handleRoleRequestError(c, apperrors.ErrValidation, "ApproveRoleRequest")
+		return
+	}
+
+	reviewerID, reviewerRole, err := GetUserIDAndRole(c)
+	if err != nil {
+		h.handleRoleRequestError(c, err, "ApproveRoleRequest")
+		return
+	}
+
+	request, err := h.service.Approve(id, reviewerID, reviewerRole)
+	if err != nil {
+		h.handleRoleRequestError(c, err, "ApproveRoleRequest")
+		return
+	}
+
+	utils.RespondJSON(c, http.StatusOK, request)
+}
+
+func (h *RoleRequestHandler) handleRoleRequestError(c *gin.Context, err error, operation string) {
+	switch {
+	case errors.Is(err, apperrors.ErrNotFound):
+		h.logger.Info("Role request not found", zap.String("operation", operation), zap.Error(err))
+		utils.RespondError(c, http.StatusNotFound, "Role request not found")
+	case errors.Is(err, apperrors.ErrConflict):
+		h.logger.Info("Role request conflict", zap.String("operation", operation), zap.Error(err))
+		utils.RespondError(c, http.StatusConflict, "Role request already pending or reviewed")
+	case errors.Is(err, apperrors.ErrValidation):
+		h.logger.Info("Validation error", zap.String("operation", operation), zap.Error(err))
+		utils.RespondError(c, http.StatusBadRequest, "Validation failed")
+	case errors.Is(err, apperrors.ErrUnauthorized):
+		h.logger.Info("Unauthorized", zap.String("operation", operation), zap.Error(err))
+		utils.RespondError(c, http.StatusUnauthorized, "Unauthorized")
+	case errors.Is(err, apperrors.ErrForbidden):
+		h.logger.Info("Forbidden", zap.String("operation", operation), zap.Error(err))
+		utils.RespondError(c, http.StatusForbidden, "Forbidden")
+	default:
+		h.logger.Error("Unexpected error", zap.String("operation", operation), zap.Error(err))
+		utils.RespondError(c, http.StatusInternalServerError, "Internal server error")
+	}
+}
@@ -2,10 +2,13 @@ package handler
 
 import (
 	"errors"
+	"go-gin-api-server/config"
+	"go-gin-api-server/internal/authz"
 	"go-gin-api-server/internal/middleware"
 	"go-gin-api-server/internal/model"
 	"go-gin-api-server/internal/service"
 	"go-gin-api-server/pkg/apperrors"
+	"go-gin-api-server/pkg/utils"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -14,19 +17,45 @@ import (
 
 type UserHandler struct {
 	service service.UserService
+	cfg     config.UsersConfig
 	logger  *zap.Logger
 }
 
 func NewUserHandler(service service.UserService, logger *zap.Logger) *UserHandler {
+	return NewUserHandlerWithConfig(service, config.UsersConfig{
+		LookupAccess: config.LookupAccessAuthenticated,
+	}, logger)
+}
+
+// NewUserHandlerWithConfig 創建可設定查詢路由存取權限的 UserHandler
+func NewUserHandlerWithConfig(service service.UserService, cfg config.UsersConfig, logger *zap.Logger) *UserHandler {
 	return &UserHandler{
 		service: service,
+		cfg:     cfg,
 		logger:  logger,
 	}
 }
 
-func (h *UserHandler) RegisterRoutes(r *gin.Engine) {
-	// Public routes - only safe user queries
-	r.GET("/api/v1/users/profile/:username", h.GetUserProfile)
+func (h *UserHandler) RegisterRoutes(r *gin.Engine, authMiddleware *middleware.AuthMiddleware, rateLimitMiddleware *middleware.RateLimitMiddleware, readLimit *middleware.RateLimitMiddleware) {
+	// Public routes - only safe user queries; optional auth gives signed-in readers their
+	// own rate limit budget instead of sharing one per IP
+	public := r.Group("/api/v1/users/profile")
+	public.Use(authMiddleware.OptionalAuth())
+	if readLimit != nil {
+		public.Use(readLimit.LimitByClient())
+	}
+	{
+		public.GET("/:username", h.GetUserProfile)
+	}
+
+	// batch lookups stay limited per IP to deter scraping
+	profiles := r.Group("/api/v1/users/profiles")
+	if rateLimitMiddleware != nil {
+		profiles.Use(rateLimitMiddleware.LimitByIP())
+	}
+	{
+		profiles.POST("", h.GetUserProfiles)
+	}
 }
 
 func (h *UserHandler) RegisterProtectedRoutes(r *gin.Engine, authMiddleware *middleware.AuthMiddleware, rbacMiddleware *middleware.RBACMiddleware) {
@@ -34,16 +63,32 @@ func (h *UserHandler) RegisterProtectedRoutes(r *gin.Engine, authMiddleware *mid
 	protected := r.Group("/api/v1/users")
 	protected.Use(authMiddleware.RequireAuth())
 	{
-		// Get user info (sensitive data) - any authenticated user
+		// Get user info - full record for self or admin, public profile otherwise
 		protected.GET("/:id", h.GetUserByID)
+	}
+
+	// Lookup routes - access depends on config (authenticated, admin-only or disabled)
+	switch h.cfg.LookupAccess {
+	case config.LookupAccessDisabled:
+	case config.LookupAccessAuthenticated:
 		protected.GET("/username/:username", h.GetUserByUsername)
 		protected.GET("/email/:email", h.GetUserByEmail)
+	default:
+		// admin, unknown values fail closed
+		lookup := r.Group("/api/v1/users")
+		lookup.Use(authMiddleware.RequireAuth())
+		lookup.Use(rbacMiddleware.RequireAdmin())
+		{
+			lookup.GET("/username/:username", h.GetUserByUsername)
+			lookup.GET("/email/:email", h.GetUserByEmail)
+		}
 	}
 
 	// Admin-only routes
 	admin := r.Group("/api/v1/users")
 	admin.Use(authMiddleware.RequireAuth())
 	admin.Use(rbacMiddleware.RequireAdmin())
+	admin.Use(rbacMiddleware.ForbidImpersonation())
 	{
 		// Only admin can delete users
 		admin.DELETE("/:id", h.DeleteUser)
@@ -78,16 +123,43 @@ func (h *UserHandler) GetUserProfile(c *gin.Context) {
 		h.handleUserError(c, err, "GetUserProfile")
 		return
 	}
+	MarkStale(c, publicInfo.Stale)
 	h.handleSuccess(c, publicInfo, http.StatusOK)
 }
 
+// GetUserProfiles Get the public profiles of up to 100 users by ID, unknown IDs are omitted
+//
+// Example:
+//
+//	POST /api/v1/users/profiles
+//	{
+//		"ids": ["550e8400-e29b-41d4-a716-446655440000", "6f1c2b7e-3d4a-4b5c-8e9f-0a1b2c3d4e5f"]
+//	}
+func (h *UserHandler) GetUserProfiles(c *gin.Context) {
+	var req model.UserProfilesRequest
+	if err := BindJSON(c, &req); err != nil {
+		return
+	}
+
+	profiles, err := h.service.GetUserProfiles(req.IDs)
+	if err != nil {
+		h.handleUserError(c, err, "GetUserProfiles")
+		return
+	}
+	h.handleSuccess(c, model.UserProfilesResponse{Data: profiles}, http.StatusOK)
+}
+
 // GetUserByID Get user by ID
 //
 // Example:
 //
 //	GET /api/v1/users/550e8400-e29b-41d4-a716-446655440000
 func (h *UserHandler) GetUserByID(c *gin.Context) {
-	id := c.Param("id")
+	id, err := bindUserID(c)
+	if err != nil {
+		return
+	}
+
 	user, err := h.service.GetUserByID(id)
 
 	if err != nil {
@@ -95,7 +167,7 @@ func (h *UserHandler) GetUserByID(c *gin.Context) {
 		return
 	}
 
-	h.handleSuccess(c, user, http.StatusOK)
+	h.respondUser(c, user)
 }
 
 // GetUserByUsername Get user by username
@@ -117,7 +189,7 @@ func (h *UserHandler) GetUserByUsername(c *gin.Context) {
 		h.handleUserError(c, err, "GetUserByUsername")
 		return
 	}
-	h.handleSuccess(c, user, http.StatusOK)
+	h.respondUser(c, user)
 }
 
 // GetUserByEmail Get user by email
@@ -134,7 +206,20 @@ func (h *UserHandler) GetUserByEmail(c *gin.Context) {
 		return
 	}
 
+	callerID, callerRole, err := GetUserIDAndRole(c)
+	if err != nil {
+		h.handleUserError(c, err, "GetUserByEmail")
+		return
+	}
+
 	user, err := h.service.GetUserByEmail(req.Email)
+	// 非本人也非管理員時一律回 403，不透露該 email 是否已註冊
+	if !callerRole.IsAdmin() {
+		if errors.Is(err, apperrors.ErrNotFound) || (err == nil && !authz.IsOwner(callerID, user.ID)) {
+			h.handleUserError(c, apperrors.ErrForbidden, "GetUserByEmail")
+			return
+		}
+	}
 	if err != nil {
 		h.handleUserError(c, err, "GetUserByEmail")
 		return
@@ -149,20 +234,23 @@ func (h *UserHandler) GetUserByEmail(c *gin.Context) {
 //	PATCH /api/v1/users/550e8400-e29b-41d4-a716-446655440000
 //	{
 //		"name": "New Name",
+//		"username": "new_username",
 //		"birth_date": "1990-01-01T00:00:00Z"
 //	}
 func (h *UserHandler) UpdateUserProfile(c *gin.Context) {
-	userID := c.Param("id")
+	userID, err := bindUserID(c)
+	if err != nil {
+		return
+	}
+
 	var update model.UpdateUserProfileRequest
 
 	if err := BindJSON(c, &update); err != nil {
 		return
 	}
 
-	if update.Name == "" && update.BirthDate == nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "No update fields provided",
-		})
+	if update.Name == "" && update.Username == nil && update.BirthDate == nil {
+		utils.RespondError(c, http.StatusBadRequest, "No update fields provided")
 		return
 	}
 
@@ -181,9 +269,12 @@ func (h *UserHandler) UpdateUserProfile(c *gin.Context) {
 //
 //	DELETE /api/v1/users/550e8400-e29b-41d4-a716-446655440000
 func (h *UserHandler) DeleteUser(c *gin.Context) {
-	userID := c.Param("id")
-	err := h.service.DeleteUser(userID)
+	userID, err := bindUserID(c)
 	if err != nil {
+		return
+	}
+
+	if err := h.service.DeleteUser(userID); err != nil {
 		h.handleUserError(c, err, "DeleteUser")
 		return
 	}
@@ -192,49 +283,66 @@ func (h *UserHandler) DeleteUser(c *gin.Context) {
 
 // Helper functions
 
+// bindUserID reads the :id path param, answering 400 for anything but a UUID so a
+// malformed ID never reaches the repository
+func bindUserID(c *gin.Context) (string, error) {
+	var req struct {
+		ID string `uri:"id" binding:"required,uuid"`
+	}
+
+	if err := BindUri(c, &req); err != nil {
+		return "", err
+	}
+	return req.ID, nil
+}
+
+// respondUser returns the full record to the user themselves and admins, and the public profile to everyone else
+func (h *UserHandler) respondUser(c *gin.Context, user *model.User) {
+	callerID, callerRole, err := GetUserIDAndRole(c)
+	if err != nil {
+		h.handleUserError(c, err, "respondUser")
+		return
+	}
+
+	if authz.IsOwnerOrAdmin(callerID, callerRole, user.ID) {
+		h.handleSuccess(c, user, http.StatusOK)
+		return
+	}
+	h.handleSuccess(c, h.service.ToUserProfile(user), http.StatusOK)
+}
+
 func (h *UserHandler) handleUserError(c *gin.Context, err error, _ string) {
 	switch {
 	case errors.Is(err, apperrors.ErrNotFound):
 		h.logger.Error("User not found", zap.Error(err))
-		c.JSON(http.StatusNotFound, gin.H{
-			"error": "User not found",
-		})
+		utils.RespondError(c, http.StatusNotFound, "User not found")
 	case errors.Is(err, apperrors.ErrValidation):
 		h.logger.Error("Validation failed", zap.Error(err))
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Validation failed",
-		})
+		utils.RespondError(c, http.StatusBadRequest, "Validation failed")
 	case errors.Is(err, apperrors.ErrUserExists):
 		h.logger.Error("User already exists", zap.Error(err))
-		c.JSON(http.StatusConflict, gin.H{
-			"error": "User already exists",
-		})
+		utils.RespondError(c, http.StatusConflict, "User already exists")
 	case errors.Is(err, apperrors.ErrUserUnderAge):
 		h.logger.Error("User under age", zap.Error(err))
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "User under age",
-		})
+		utils.RespondError(c, http.StatusBadRequest, "User under age")
 	case errors.Is(err, apperrors.ErrUnauthorized):
 		h.logger.Error("Unauthorized", zap.Error(err))
-		c.JSON(http.StatusUnauthorized, gin.H{
-			"error": "Unauthorized",
-		})
+		utils.RespondError(c, http.StatusUnauthorized, "Unauthorized")
 	case errors.Is(err, apperrors.ErrForbidden):
 		h.logger.Error("Forbidden", zap.Error(err))
-		c.JSON(http.StatusForbidden, gin.H{
-			"error": "Forbidden",
-		})
+		utils.RespondError(c, http.StatusForbidden, "Forbidden")
+	case errors.Is(err, apperrors.ErrUnavailable):
+		h.logger.Error("Database unavailable", zap.Error(err))
+		utils.RespondError(c, http.StatusServiceUnavailable, "Service temporarily unavailable")
 	default:
 		h.logger.Error("Internal server error", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Internal server error",
-		})
+		utils.RespondError(c, http.StatusInternalServerError, "Internal server error")
 	}
 }
 
 func (h *UserHandler) handleSuccess(c *gin.Context, data interface{}, statusCode int) {
 	if data != nil {
-		c.JSON(statusCode, data)
+		utils.RespondJSON(c, statusCode, data)
 	} else {
 		c.Status(statusCode)
 	}
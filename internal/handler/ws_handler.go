@@ -0,0 +1,158 @@
+package handler
+
+import (
+	"go-gin-api-server/internal/events"
+	"go-gin-api-server/internal/middleware"
+	"go-gin-api-server/internal/service"
+	"go-gin-api-server/pkg/utils"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"golang.org/x/net/websocket"
+)
+
+const (
+	// wsSendBuffer is the number of pending messages per connection before it is treated as too slow
+	wsSendBuffer = 32
+	wsWriteWait  = 10 * time.Second
+)
+
+// wsMessage is the envelope pushed to WebSocket clients
+type wsMessage struct {
+	Type string `json:"type"`
+	Data any    `json:"data,omitempty"`
+}
+
+type WebSocketHandler struct {
+	bus          *events.Bus
+	blockService service.BlockService
+	logger       *zap.Logger
+}
+
+func NewWebSocketHandler(bus *events.Bus, blockService service.BlockService, logger *zap.Logger) *WebSocketHandler {
+	return &WebSocketHandler{
+		bus:          bus,
+		blockService: blockService,
+		logger:       logger,
+	}
+}
+
+func (h *WebSocketHandler) RegisterProtectedRoutes(r *gin.Engine, authMiddleware *middleware.AuthMiddleware, rbacMiddleware *middleware.RBACMiddleware) {
+	protected := r.Group("/api/v1")
+	protected.Use(authMiddleware.RequireAuth())
+	{
+		protected.GET("/ws", h.Connect)
+	}
+}
+
+// Connect upgrades the request to a WebSocket and streams real-time events to the user
+//
+// Example:
+//
+//	GET /api/v1/ws
+//	Authorization: Bearer <access_token>
+//	Upgrade: websocket
+func (h *WebSocketHandler) Connect(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		utils.RespondError(c, http.StatusUnauthorized, "Unauthorized")
+		c.Abort()
+		return
+	}
+
+	// Handshake is left nil so Origin is not enforced; the route is already behind RequireAuth
+	server := websocket.Server{
+		Handler: func(conn *websocket.Conn) {
+			h.serve(conn, userID)
+		},
+	}
+	server.ServeHTTP(c.Writer, c.Request)
+}
+
+// serve pumps events to a single connection until the client disconnects or falls behind
+func (h *WebSocketHandler) serve(conn *websocket.Conn, userID string) {
+	defer conn.Close()
+
+	send := make(chan wsMessage, wsSendBuffer)
+	done := make(chan struct{})
+	var closeOnce sync.Once
+	closeConn := func() {
+		closeOnce.Do(func() { close(done) })
+	}
+
+	unsubscribe := h.bus.Subscribe(func(event events.Event) {
+		if !h.shouldDeliver(event, userID) {
+			return
+		}
+
+		// backpressure: never block the publisher, drop clients that can't keep up
+		select {
+		case send <- wsMessage{Type: event.Type(), Data: event}:
+		case <-done:
+		default:
+			h.logger.Warn("WebSocket client too slow, closing connection", zap.String("user_id", userID))
+			closeConn()
+		}
+	}, events.TypePostCreated)
+	defer unsubscribe()
+
+	// read loop only detects disconnects, clients are not expected to send anything
+	go func() {
+		var discard string
+		for {
+			if err := websocket.Message.Receive(conn, &discard); err != nil {
+				closeConn()
+				return
+			}
+		}
+	}()
+
+	if !h.write(conn, wsMessage{Type: "connected"}) {
+		return
+	}
+
+	for {
+		select {
+		case msg := <-send:
+			if !h.write(conn, msg) {
+				return
+			}
+		case <-done:
+			return
+		}
+	}
+}
+
+func (h *WebSocketHandler) write(conn *websocket.Conn, msg wsMessage) bool {
+	if err := conn.SetWriteDeadline(time.Now().Add(wsWriteWait)); err != nil {
+		return false
+	}
+	if err := websocket.JSON.Send(conn, msg); err != nil {
+		h.logger.Debug("WebSocket write failed", zap.Error(err))
+		return false
+	}
+	return true
+}
+
+// shouldDeliver decides whether an event is relevant to the connected user
+func (h *WebSocketHandler) shouldDeliver(event events.Event, userID string) bool {
+	switch e := event.(type) {
+	case events.PostCreated:
+		// no follow graph yet, so new posts go to everyone except the author and users
+		// on either side of a block with them
+		if e.AuthorID == userID {
+			return false
+		}
+		blocked, err := h.blockService.IsBlocked(userID, e.AuthorID)
+		if err != nil {
+			h.logger.Error("Failed to check block before delivering event", zap.String("user_id", userID), zap.Error(err))
+			return false
+		}
+		return !blocked
+	default:
+		return false
+	}
+}
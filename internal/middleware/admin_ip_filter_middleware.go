@@ -0,0 +1,67 @@
+package middleware
+
+import (
+	"go-gin-api-server/config"
+	"go-gin-api-server/pkg/utils"
+	"net/http"
+	"net/netip"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// AdminPathPrefix is the path every admin route is registered under
+const AdminPathPrefix = "/api/v1/admin"
+
+// AdminIPFilterMiddleware rejects admin requests from addresses outside cfg.AdminAllowCIDRs
+// or inside cfg.AdminDenyCIDRs with 403; other paths, and every path when both lists are
+// empty, pass through. The address is c.ClientIP(), so X-Forwarded-For only counts when
+// the request came through one of the router's trusted proxies.
+func AdminIPFilterMiddleware(cfg config.SecurityConfig, logger *zap.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if (len(cfg.AdminAllowCIDRs) == 0 && len(cfg.AdminDenyCIDRs) == 0) || !isAdminPath(c.Request.URL.Path) {
+			c.Next()
+			return
+		}
+
+		if !adminIPAllowed(cfg, c.ClientIP()) {
+			logger.Warn("Admin request from disallowed IP",
+				zap.String("ip", c.ClientIP()),
+				zap.String("path", c.Request.URL.Path))
+			utils.RespondError(c, http.StatusForbidden, "Access denied from this IP")
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+func isAdminPath(path string) bool {
+	return path == AdminPathPrefix || strings.HasPrefix(path, AdminPathPrefix+"/")
+}
+
+// adminIPAllowed checks the deny list first; an unparseable address is never allowed
+func adminIPAllowed(cfg config.SecurityConfig, ip string) bool {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap() // ::ffff:10.0.0.1 matches 10.0.0.0/8
+
+	for _, prefix := range cfg.AdminDenyCIDRs {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+	if len(cfg.AdminAllowCIDRs) == 0 {
+		return true
+	}
+	for _, prefix := range cfg.AdminAllowCIDRs {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,18 @@
+package middleware
+
+import (
+	"go-gin-api-server/config"
+
+	"github.com/gin-gonic/gin"
+)
+
+// APIVersionMiddleware sets X-API-Version on every response so clients and gateways can
+// tell which version served it; an empty cfg.APIVersion leaves the header off
+func APIVersionMiddleware(cfg config.ServerConfig) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if cfg.APIVersion != "" {
+			c.Header("X-API-Version", cfg.APIVersion)
+		}
+		c.Next()
+	}
+}
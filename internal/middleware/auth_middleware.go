@@ -1,8 +1,11 @@
 package middleware
 
 import (
+	"go-gin-api-server/config"
+	"go-gin-api-server/internal/model"
 	"go-gin-api-server/internal/service"
 	"go-gin-api-server/pkg/apperrors"
+	"go-gin-api-server/pkg/utils"
 	"net/http"
 	"strings"
 
@@ -12,18 +15,38 @@ import (
 
 type AuthMiddleware struct {
 	authService service.AuthService
+	denylist    utils.TokenDenylist
+	cfg         config.AuthConfig
 	logger      *zap.Logger
 }
 
 func NewAuthMiddleware(authService service.AuthService, logger *zap.Logger) *AuthMiddleware {
+	return NewAuthMiddlewareWithDenylist(authService, nil, logger)
+}
+
+// NewAuthMiddlewareWithDenylist 創建會拒絕已撤銷 access token 的 AuthMiddleware（denylist 為 nil 則不檢查）
+func NewAuthMiddlewareWithDenylist(authService service.AuthService, denylist utils.TokenDenylist, logger *zap.Logger) *AuthMiddleware {
+	return NewAuthMiddlewareWithConfig(authService, denylist, config.AuthConfig{}, logger)
+}
+
+// NewAuthMiddlewareWithConfig 創建可設定自動刷新 token 回傳方式的 AuthMiddleware
+func NewAuthMiddlewareWithConfig(authService service.AuthService, denylist utils.TokenDenylist, cfg config.AuthConfig, logger *zap.Logger) *AuthMiddleware {
 	return &AuthMiddleware{
 		authService: authService,
+		denylist:    denylist,
+		cfg:         cfg,
 		logger:      logger,
 	}
 }
 
 func (m *AuthMiddleware) RequireAuth() gin.HandlerFunc {
 	return func(c *gin.Context) {
+		// 0. already authenticated by APIKeyMiddleware
+		if authenticatedByAPIKey(c) {
+			m.continueAPIKeyRequest(c, "RequireAuth")
+			return
+		}
+
 		// 1. get Authorization header
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
@@ -52,10 +75,16 @@ func (m *AuthMiddleware) RequireAuth() gin.HandlerFunc {
 			m.handleAuthError(c, err, "Token validation failed")
 			return
 		}
+		if revoked, err := m.isRevoked(claims); err != nil || revoked {
+			if err == nil {
+				err = apperrors.ErrInvalidToken
+			}
+			m.handleAuthError(c, err, "Token revoked")
+			return
+		}
 
 		// 4. store user ID, role to context
-		c.Set("user_id", claims.UserID)
-		c.Set("user_role", claims.Role)
+		setIdentity(c, claims)
 		c.Next()
 	}
 }
@@ -63,6 +92,11 @@ func (m *AuthMiddleware) RequireAuth() gin.HandlerFunc {
 // OptionalAuth 可選認證的中間件（用於某些需要知道用戶身份但不需要強制登錄的場景）
 func (m *AuthMiddleware) OptionalAuth() gin.HandlerFunc {
 	return func(c *gin.Context) {
+		if authenticatedByAPIKey(c) {
+			m.continueAPIKeyRequest(c, "OptionalAuth")
+			return
+		}
+
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
 			// 沒有token，繼續執行但不設置user_id
@@ -90,10 +124,17 @@ func (m *AuthMiddleware) OptionalAuth() gin.HandlerFunc {
 			c.Next()
 			return
 		}
+		if revoked, err := m.isRevoked(claims); err != nil || revoked {
+			// the denylist can't be read, treat the token as revoked
+			if err != nil {
+				m.logger.Error("Failed to check token denylist", zap.Error(err))
+			}
+			c.Next()
+			return
+		}
 
 		// token is valid, set user ID and role to context
-		c.Set("user_id", claims.UserID)
-		c.Set("user_role", claims.Role)
+		setIdentity(c, claims)
 		c.Next()
 	}
 }
@@ -117,6 +158,9 @@ func (m *AuthMiddleware) tryAutoRefresh(c *gin.Context) bool {
 	// 3. 在響應頭中設置新的 access token
 	c.Header("X-New-Access-Token", newAccessToken)
 	c.Header("X-Token-Type", "Bearer")
+	if m.cfg.RefreshedTokenInBody {
+		c.Set(utils.RefreshedTokenKey, newAccessToken)
+	}
 
 	// 4. 驗證新的 access token 並設置 user_id
 	claims, err := m.authService.ValidateToken(newAccessToken)
@@ -125,34 +169,55 @@ func (m *AuthMiddleware) tryAutoRefresh(c *gin.Context) bool {
 		return false
 	}
 
+	setIdentity(c, claims)
+	c.Next()
+	return true
+}
+
+// setIdentity stores the caller from validated claims in the context; impersonator_id is
+// only set for an admin's login-as token
+func setIdentity(c *gin.Context, claims *model.Claims) {
 	c.Set("user_id", claims.UserID)
 	c.Set("user_role", claims.Role)
+	if claims.Impersonated() {
+		c.Set("impersonator_id", claims.ImpersonatorID)
+	}
+}
+
+// isRevoked 檢查 access token 的 jti 是否已被撤銷
+func (m *AuthMiddleware) isRevoked(claims *model.Claims) (bool, error) {
+	if m.denylist == nil {
+		return false, nil
+	}
+	return m.denylist.IsRevoked(claims.ID)
+}
+
+// continueAPIKeyRequest lets a request authenticated by APIKeyMiddleware through, unless
+// it writes on a route that granted no scope
+func (m *AuthMiddleware) continueAPIKeyRequest(c *gin.Context, operation string) {
+	if !apiKeyRequestAllowed(c) {
+		m.handleAuthError(c, apperrors.ErrForbidden, operation)
+		return
+	}
 	c.Next()
-	return true
 }
 
 // handleAuthError 處理認證錯誤
 func (m *AuthMiddleware) handleAuthError(c *gin.Context, err error, operation string) {
 	switch err {
 	case apperrors.ErrInvalidToken:
-		c.JSON(http.StatusUnauthorized, gin.H{
-			"error": "Invalid token",
-		})
+		utils.RespondError(c, http.StatusUnauthorized, "Invalid token")
 	case apperrors.ErrExpiredToken:
-		c.JSON(http.StatusUnauthorized, gin.H{
-			"error": "Token has expired",
-		})
+		utils.RespondError(c, http.StatusUnauthorized, "Token has expired")
 	case apperrors.ErrUnauthorized:
-		c.JSON(http.StatusUnauthorized, gin.H{
-			"error": "Unauthorized",
-		})
+		utils.RespondError(c, http.StatusUnauthorized, "Unauthorized")
+	case apperrors.ErrForbidden:
+		utils.RespondError(c, http.StatusForbidden, "API keys can't be used on this route")
 	default:
 		m.logger.Error("Unexpected error in auth middleware",
 			zap.String("operation", operation),
 			zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Internal server error",
-		})
+		utils.RespondError(c, http.StatusInternalServerError, "Internal server error")
 	}
 	c.Abort()
 }
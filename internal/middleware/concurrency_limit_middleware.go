@@ -0,0 +1,96 @@
+package middleware
+
+import (
+	"go-gin-api-server/pkg/utils"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"golang.org/x/sync/semaphore"
+)
+
+// ErrCodeOverloaded is the error code sent when the concurrency limit is exhausted
+const ErrCodeOverloaded = "OVERLOADED"
+
+// ConcurrencyLimitMiddleware caps how many expensive requests hit the database at once
+//
+// Requests over capacity are rejected with 503 immediately instead of queueing, so a
+// burst of heavy queries can't pile up behind the connection pool.
+type ConcurrencyLimitMiddleware struct {
+	sem      *semaphore.Weighted
+	capacity int64
+	logger   *zap.Logger
+}
+
+// NewConcurrencyLimitMiddleware creates a limiter shared by every route it is applied to;
+// a capacity of zero or less disables limiting
+func NewConcurrencyLimitMiddleware(capacity int64, logger *zap.Logger) *ConcurrencyLimitMiddleware {
+	m := &ConcurrencyLimitMiddleware{
+		capacity: capacity,
+		logger:   logger,
+	}
+	if capacity > 0 {
+		m.sem = semaphore.NewWeighted(capacity)
+	}
+	return m
+}
+
+// Limit holds weight units of capacity for the rest of the request
+func (m *ConcurrencyLimitMiddleware) Limit(weight int64) gin.HandlerFunc {
+	// a weight above capacity could never be acquired
+	if weight > m.capacity {
+		weight = m.capacity
+	}
+
+	return func(c *gin.Context) {
+		if m.sem == nil {
+			c.Next()
+			return
+		}
+
+		if !m.sem.TryAcquire(weight) {
+			m.logger.Warn("Concurrency limit exhausted",
+				zap.String("path", c.FullPath()),
+				zap.Int64("weight", weight),
+				zap.Int64("capacity", m.capacity))
+			SetRetryAfter(c, time.Second)
+			utils.RespondErrorCode(c, http.StatusServiceUnavailable, ErrCodeOverloaded, "Server is busy, please retry")
+			c.Abort()
+			return
+		}
+		defer m.sem.Release(weight)
+
+		c.Next()
+	}
+}
+
+// LimitExcept is Limit for router-wide use; requests to exemptPaths (health checks) skip
+// the limiter so probes still answer while the server is saturated
+func (m *ConcurrencyLimitMiddleware) LimitExcept(weight int64, exemptPaths ...string) gin.HandlerFunc {
+	limit := m.Limit(weight)
+
+	return func(c *gin.Context) {
+		for _, path := range exemptPaths {
+			if c.Request.URL.Path == path {
+				c.Next()
+				return
+			}
+		}
+		limit(c)
+	}
+}
+
+// LimitQuery is Limit for requests that carry the query parameter param, the same route
+// without it (a plain cursor listing rather than a search) isn't limited
+func (m *ConcurrencyLimitMiddleware) LimitQuery(weight int64, param string) gin.HandlerFunc {
+	limit := m.Limit(weight)
+
+	return func(c *gin.Context) {
+		if c.Query(param) == "" {
+			c.Next()
+			return
+		}
+		limit(c)
+	}
+}
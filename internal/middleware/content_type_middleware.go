@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"go-gin-api-server/config"
+	"go-gin-api-server/pkg/utils"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// JSONContentTypeMiddleware rejects write requests whose body isn't JSON with 415
+//
+// requests without a body (e.g. POST /posts/:id/hide) pass through, so optional
+// bodies keep working
+func JSONContentTypeMiddleware(cfg config.SecurityConfig) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !cfg.EnforceJSONContentType || !hasWriteBody(c.Request) {
+			c.Next()
+			return
+		}
+
+		if !isJSONContentType(c.ContentType()) {
+			utils.RespondError(c, http.StatusUnsupportedMediaType, "Content-Type must be application/json")
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+func hasWriteBody(r *http.Request) bool {
+	switch r.Method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch:
+	default:
+		return false
+	}
+	// ContentLength is -1 when unknown (chunked)
+	return r.ContentLength != 0
+}
+
+// isJSONContentType accepts application/json and structured +json types
+func isJSONContentType(contentType string) bool {
+	contentType = strings.ToLower(contentType)
+	return contentType == "application/json" ||
+		(strings.HasPrefix(contentType, "application/") && strings.HasSuffix(contentType, "+json"))
+}
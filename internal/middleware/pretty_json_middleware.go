@@ -0,0 +1,18 @@
+package middleware
+
+import (
+	"go-gin-api-server/config"
+	"go-gin-api-server/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PrettyJSONMiddleware makes utils.RespondJSON indent responses when cfg.PrettyJSON is set
+func PrettyJSONMiddleware(cfg config.ServerConfig) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if cfg.PrettyJSON {
+			c.Set(utils.PrettyJSONKey, true)
+		}
+		c.Next()
+	}
+}
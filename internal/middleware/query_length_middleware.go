@@ -0,0 +1,23 @@
+package middleware
+
+import (
+	"go-gin-api-server/config"
+	"go-gin-api-server/pkg/utils"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MaxQueryLengthMiddleware rejects requests whose raw query string is longer than
+// cfg.MaxQueryBytes with 414, before forged cursors and the like reach any decoding
+func MaxQueryLengthMiddleware(cfg config.SecurityConfig) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if cfg.MaxQueryBytes <= 0 || len(c.Request.URL.RawQuery) <= cfg.MaxQueryBytes {
+			c.Next()
+			return
+		}
+
+		utils.RespondError(c, http.StatusRequestURITooLong, "Query string too long")
+		c.Abort()
+	}
+}
@@ -0,0 +1,127 @@
+package middleware
+
+import (
+	"go-gin-api-server/pkg/utils"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// RateLimitMiddleware provides per-client fixed-window rate limiting
+type RateLimitMiddleware struct {
+	limit     int
+	authLimit int // budget per authenticated user for LimitByClient
+	window    time.Duration
+	logger    *zap.Logger
+}
+
+// NewRateLimitMiddleware creates a rate limiter allowing limit requests per window
+func NewRateLimitMiddleware(limit int, window time.Duration, logger *zap.Logger) *RateLimitMiddleware {
+	return NewTieredRateLimitMiddleware(limit, limit, window, logger)
+}
+
+// NewTieredRateLimitMiddleware creates a rate limiter whose LimitByClient allows anonLimit
+// requests per window for each anonymous IP and authLimit for each authenticated user
+func NewTieredRateLimitMiddleware(anonLimit, authLimit int, window time.Duration, logger *zap.Logger) *RateLimitMiddleware {
+	return &RateLimitMiddleware{
+		limit:     anonLimit,
+		authLimit: authLimit,
+		window:    window,
+		logger:    logger,
+	}
+}
+
+type rateLimitWindow struct {
+	start time.Time
+	count int
+}
+
+// LimitByIP limits requests per client IP; each call keeps its own counters
+func (m *RateLimitMiddleware) LimitByIP() gin.HandlerFunc {
+	return m.limitBy(func(c *gin.Context) (string, int) {
+		return c.ClientIP(), m.limit
+	})
+}
+
+// LimitByClient limits authenticated requests per user ID and anonymous ones per IP, each
+// with its own budget; it must run after OptionalAuth so user_id is set. Each call keeps
+// its own counters
+func (m *RateLimitMiddleware) LimitByClient() gin.HandlerFunc {
+	return m.limitBy(func(c *gin.Context) (string, int) {
+		if userID := c.GetString("user_id"); userID != "" {
+			return "user:" + userID, m.authLimit
+		}
+		return "ip:" + c.ClientIP(), m.limit
+	})
+}
+
+// limitBy counts requests per key; a zero or negative limit lets the key through
+func (m *RateLimitMiddleware) limitBy(keyOf func(c *gin.Context) (key string, limit int)) gin.HandlerFunc {
+	var mu sync.Mutex
+	windows := make(map[string]*rateLimitWindow)
+	lastSweep := time.Now()
+
+	return func(c *gin.Context) {
+		key, limit := keyOf(c)
+		if limit <= 0 {
+			c.Next()
+			return
+		}
+
+		now := time.Now()
+
+		mu.Lock()
+		// drop stale windows so idle clients don't accumulate
+		if now.Sub(lastSweep) >= m.window {
+			for k, w := range windows {
+				if now.Sub(w.start) >= m.window {
+					delete(windows, k)
+				}
+			}
+			lastSweep = now
+		}
+
+		w, ok := windows[key]
+		if !ok || now.Sub(w.start) >= m.window {
+			w = &rateLimitWindow{start: now}
+			windows[key] = w
+		}
+		w.count++
+		exceeded := w.count > limit
+		retryAfter := w.start.Add(m.window).Sub(now)
+		mu.Unlock()
+
+		if exceeded {
+			m.logger.Warn("Rate limit exceeded",
+				zap.String("ip", c.ClientIP()),
+				zap.String("user_id", c.GetString("user_id")),
+				zap.String("path", c.FullPath()))
+			RespondTooManyRequests(c, retryAfter)
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// RespondTooManyRequests aborts with 429; every limiter answers through it so clients
+// always get a Retry-After for when the limiter next admits them
+func RespondTooManyRequests(c *gin.Context, retryAfter time.Duration) {
+	SetRetryAfter(c, retryAfter)
+	utils.RespondError(c, http.StatusTooManyRequests, "Too many requests")
+	c.Abort()
+}
+
+// SetRetryAfter sets Retry-After in whole seconds, rounded up and at least 1 so clients
+// never retry immediately
+func SetRetryAfter(c *gin.Context, retryAfter time.Duration) {
+	seconds := int64((retryAfter + time.Second - 1) / time.Second)
+	if seconds < 1 {
+		seconds = 1
+	}
+	c.Header("Retry-After", strconv.FormatInt(seconds, 10))
+}
@@ -1,8 +1,10 @@
 package middleware
 
 import (
+	"go-gin-api-server/internal/authz"
 	"go-gin-api-server/internal/model"
 	"go-gin-api-server/pkg/apperrors"
+	"go-gin-api-server/pkg/utils"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -45,6 +47,30 @@ func (r *RBACMiddleware) RequireAdmin() gin.HandlerFunc {
 	}
 }
 
+// RequireModerator requires moderator or admin role to access
+func (r *RBACMiddleware) RequireModerator() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role, exists := c.Get("user_role")
+		if !exists {
+			r.handleRBACError(c, apperrors.ErrUnauthorized, "RequireModerator")
+			return
+		}
+
+		userRole, ok := role.(model.UserRole)
+		if !ok {
+			r.handleRBACError(c, apperrors.ErrUnauthorized, "RequireModerator")
+			return
+		}
+
+		if !userRole.CanModerate() {
+			r.handleRBACError(c, apperrors.ErrForbidden, "RequireModerator")
+			return
+		}
+
+		c.Next()
+	}
+}
+
 // RequireOwnershipOrAdmin requires user to be the resource owner or admin
 func (r *RBACMiddleware) RequireOwnershipOrAdmin() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -77,7 +103,7 @@ func (r *RBACMiddleware) RequireOwnershipOrAdmin() gin.HandlerFunc {
 		}
 
 		// Allow if user is admin or owns the resource
-		if userRole.IsAdmin() || userIDStr == userID {
+		if authz.IsOwnerOrAdmin(userIDStr, userRole, userID) {
 			c.Next()
 			return
 		}
@@ -103,7 +129,7 @@ func (r *RBACMiddleware) RequireOwnership() gin.HandlerFunc {
 			return
 		}
 
-		if userIDStr != userID {
+		if !authz.IsOwner(userIDStr, userID) {
 			r.handleRBACError(c, apperrors.ErrForbidden, "RequireOwnership")
 			return
 		}
@@ -112,24 +138,71 @@ func (r *RBACMiddleware) RequireOwnership() gin.HandlerFunc {
 	}
 }
 
+// ForbidImpersonation rejects requests made with an impersonation token, so support staff
+// can't escalate roles or remove accounts on behalf of the user they are viewing as
+func (r *RBACMiddleware) ForbidImpersonation() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetString("impersonator_id") != "" {
+			r.handleRBACError(c, apperrors.ErrForbidden, "ForbidImpersonation")
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// ForbidAPIKey requires an interactive login, so a leaked API key can't be used to mint
+// or revoke keys
+func (r *RBACMiddleware) ForbidAPIKey() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if authenticatedByAPIKey(c) {
+			r.handleRBACError(c, apperrors.ErrForbidden, "ForbidAPIKey")
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// RequireScope requires an API key to grant scope; JWT sessions carry every scope of
+// their user and pass. It must run before RequireAuth, which rejects API key writes on
+// routes without a granted scope
+func (r *RBACMiddleware) RequireScope(scope model.APIKeyScope) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !authenticatedByAPIKey(c) {
+			c.Next()
+			return
+		}
+
+		scopes, ok := c.Get("api_key_scopes")
+		if !ok {
+			r.handleRBACError(c, apperrors.ErrForbidden, "RequireScope")
+			return
+		}
+
+		granted, ok := scopes.(model.APIKeyScopes)
+		if !ok || !granted.Grants(scope) {
+			r.handleRBACError(c, apperrors.ErrForbidden, "RequireScope")
+			return
+		}
+
+		c.Set("api_key_scope_granted", true)
+		c.Next()
+	}
+}
+
 // handleRBACError handles RBAC-related errors
 func (r *RBACMiddleware) handleRBACError(c *gin.Context, err error, operation string) {
 	switch err {
 	case apperrors.ErrUnauthorized:
-		c.JSON(http.StatusUnauthorized, gin.H{
-			"error": "Unauthorized",
-		})
+		utils.RespondError(c, http.StatusUnauthorized, "Unauthorized")
 	case apperrors.ErrForbidden:
-		c.JSON(http.StatusForbidden, gin.H{
-			"error": "Access denied",
-		})
+		utils.RespondError(c, http.StatusForbidden, "Access denied")
 	default:
 		r.logger.Error("Unexpected error in RBAC middleware",
 			zap.String("operation", operation),
 			zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Internal server error",
-		})
+		utils.RespondError(c, http.StatusInternalServerError, "Internal server error")
 	}
 	c.Abort()
 }
@@ -0,0 +1,36 @@
+package middleware
+
+import (
+	"fmt"
+	"go-gin-api-server/config"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SecurityHeadersMiddleware sets common security response headers based on config
+func SecurityHeadersMiddleware(cfg config.SecurityConfig) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !cfg.HeadersEnabled {
+			c.Next()
+			return
+		}
+
+		if cfg.NoSniff {
+			c.Header("X-Content-Type-Options", "nosniff")
+		}
+		if cfg.FrameOptions != "" {
+			c.Header("X-Frame-Options", cfg.FrameOptions)
+		}
+		if cfg.ReferrerPolicy != "" {
+			c.Header("Referrer-Policy", cfg.ReferrerPolicy)
+		}
+
+		// HSTS only makes sense when served over TLS
+		if cfg.HSTSEnabled && cfg.HSTSMaxAge > 0 {
+			c.Header("Strict-Transport-Security",
+				fmt.Sprintf("max-age=%d; includeSubDomains", int64(cfg.HSTSMaxAge.Seconds())))
+		}
+
+		c.Next()
+	}
+}
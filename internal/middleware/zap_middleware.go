@@ -1,14 +1,15 @@
 package middleware
 
 import (
-	"go-gin-api-server/pkg/logger"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 )
 
-func GinZapMiddleware() gin.HandlerFunc {
+// GinZapMiddleware logs every request and warns when one exceeds the latency budget
+// (zero disables the warning); the response itself is never touched
+func GinZapMiddleware(latencyBudget time.Duration, logger *zap.Logger) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		path := c.Request.URL.Path
 		method := c.Request.Method
@@ -17,15 +18,17 @@ func GinZapMiddleware() gin.HandlerFunc {
 		c.Next()
 
 		duration := time.Since(startTime)
-		if duration > 1*time.Second {
-			logger.Log.Warn("slow request",
+		if latencyBudget > 0 && duration > latencyBudget {
+			logger.Warn("slow request",
 				zap.String("method", method),
 				zap.String("path", path),
+				zap.String("route", c.FullPath()),
 				zap.Int("status", c.Writer.Status()),
 				zap.Duration("duration", duration),
+				zap.Duration("budget", latencyBudget),
 			)
 		} else {
-			logger.Log.Info("request completed",
+			logger.Info("request completed",
 				zap.String("method", method),
 				zap.String("path", path),
 				zap.Int("status", c.Writer.Status()),
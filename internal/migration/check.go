@@ -0,0 +1,105 @@
+package migration
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Exit codes returned by the check action
+const (
+	ExitUpToDate = 0
+	ExitPending  = 1 // behind the migration files or left dirty by a failed run
+	ExitError    = 2
+)
+
+// Source lists the migration versions available to apply
+type Source interface {
+	Versions() ([]uint, error)
+}
+
+// Driver reads the version golang-migrate recorded in the database;
+// repository.HealthRepository satisfies it
+type Driver interface {
+	MigrationVersion() (version uint, dirty bool, err error)
+}
+
+// Status compares the applied version with the available migrations
+type Status struct {
+	Current uint
+	Latest  uint
+	Dirty   bool
+	Pending []uint // available versions above Current, ascending
+}
+
+// UpToDate reports whether nothing is pending and the last run finished cleanly
+func (s Status) UpToDate() bool {
+	return len(s.Pending) == 0 && !s.Dirty
+}
+
+// Check is read-only: it never applies or forces a version
+func Check(source Source, driver Driver) (Status, error) {
+	versions, err := source.Versions()
+	if err != nil {
+		return Status{}, err
+	}
+
+	current, dirty, err := driver.MigrationVersion()
+	if err != nil {
+		return Status{}, err
+	}
+
+	status := Status{Current: current, Dirty: dirty}
+	for _, version := range versions {
+		if version > status.Latest {
+			status.Latest = version
+		}
+		if version > current {
+			status.Pending = append(status.Pending, version)
+		}
+	}
+	slices.Sort(status.Pending)
+	return status, nil
+}
+
+// ExitCode maps a check result to the process exit code, for CI and deploy gates
+func ExitCode(status Status, err error) int {
+	switch {
+	case err != nil:
+		return ExitError
+	case !status.UpToDate():
+		return ExitPending
+	default:
+		return ExitUpToDate
+	}
+}
+
+// DirSource reads versions from {version}_{description}.up.sql files in a directory
+type DirSource string
+
+func (d DirSource) Versions() ([]uint, error) {
+	entries, err := os.ReadDir(string(d))
+	if err != nil {
+		return nil, err
+	}
+
+	var versions []uint
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".up.sql") {
+			continue
+		}
+		prefix, _, ok := strings.Cut(filepath.Base(name), "_")
+		if !ok {
+			continue
+		}
+		version, err := strconv.ParseUint(prefix, 10, 64)
+		if err != nil {
+			continue
+		}
+		versions = append(versions, uint(version))
+	}
+	return versions, nil
+}
@@ -0,0 +1,140 @@
+package model
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// APIKeyScope limits what requests an API key can make; "read"/"write" cover every
+// resource, "<resource>:<action>" only the routes that declare that resource, reads
+// included. Write implies read
+type APIKeyScope string
+
+const (
+	APIKeyScopeRead  APIKeyScope = "read"  // safe methods only (GET, HEAD, OPTIONS)
+	APIKeyScopeWrite APIKeyScope = "write" // every method
+
+	APIKeyScopePostsRead  APIKeyScope = "posts:read"
+	APIKeyScopePostsWrite APIKeyScope = "posts:write"
+)
+
+// split returns the resource ("" for the broad scopes) and the action
+func (s APIKeyScope) split() (resource string, action string) {
+	if i := strings.LastIndex(string(s), ":"); i >= 0 {
+		return string(s[:i]), string(s[i+1:])
+	}
+	return "", string(s)
+}
+
+// APIKeyScopes is stored space separated in api_keys.scopes
+type APIKeyScopes []APIKeyScope
+
+// Grants reports whether the granted scopes cover scope, directly, through the broad
+// scope for the same action, or through write for a read
+func (s APIKeyScopes) Grants(scope APIKeyScope) bool {
+	resource, action := scope.split()
+	for _, granted := range s {
+		grantedResource, grantedAction := granted.split()
+		if grantedResource != "" && grantedResource != resource {
+			continue
+		}
+		if grantedAction == action || grantedAction == string(APIKeyScopeWrite) {
+			return true
+		}
+	}
+	return false
+}
+
+// CanWrite reports whether any write scope was granted, keys without one only get safe methods
+func (s APIKeyScopes) CanWrite() bool {
+	for _, granted := range s {
+		if _, action := granted.split(); action == string(APIKeyScopeWrite) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s APIKeyScopes) Value() (driver.Value, error) {
+	scopes := make([]string, 0, len(s))
+	for _, scope := range s {
+		scopes = append(scopes, string(scope))
+	}
+	return strings.Join(scopes, " "), nil
+}
+
+func (s *APIKeyScopes) Scan(value interface{}) error {
+	var raw string
+	switch v := value.(type) {
+	case string:
+		raw = v
+	case []byte:
+		raw = string(v)
+	case nil:
+	default:
+		return fmt.Errorf("unsupported api key scopes type %T", value)
+	}
+
+	scopes := APIKeyScopes{}
+	for _, scope := range strings.Fields(raw) {
+		scopes = append(scopes, APIKeyScope(scope))
+	}
+	*s = scopes
+	return nil
+}
+
+// APIKey authenticates server-to-server requests as UserID; only the SHA-256 of the
+// key is stored, the key itself is shown once on creation
+type APIKey struct {
+	ID         uint64       `gorm:"primaryKey" json:"id"`
+	UserID     string       `json:"user_id"`
+	Name       string       `json:"name"`
+	Prefix     string       `json:"prefix"` // first characters of the key, to tell keys apart
+	KeyHash    string       `json:"-"`
+	Scopes     APIKeyScopes `json:"scopes"`
+	LastUsedAt *time.Time   `json:"last_used_at,omitempty"`
+	ExpiresAt  *time.Time   `json:"expires_at,omitempty"` // nil never expires
+	RevokedAt  *time.Time   `json:"revoked_at,omitempty"`
+	CreatedAt  time.Time    `json:"created_at"`
+
+	// related fields
+	User *User `gorm:"foreignKey:UserID" json:"-"`
+}
+
+// GORM Hooks
+func (k *APIKey) BeforeCreate(tx *gorm.DB) error {
+	k.CreatedAt = time.Now().UTC().Truncate(time.Microsecond)
+	return nil
+}
+
+// Expired reports whether the key's expiry has passed at now
+func (k *APIKey) Expired(now time.Time) bool {
+	return k.ExpiresAt != nil && !now.Before(*k.ExpiresAt)
+}
+
+// Usable reports whether the key is neither revoked nor expired at now
+func (k *APIKey) Usable(now time.Time) bool {
+	return k.RevokedAt == nil && !k.Expired(now)
+}
+
+// CreateAPIKeyRequest scopes default to read only; expires_at must be in the future
+type CreateAPIKeyRequest struct {
+	Name      string        `json:"name" binding:"required,max=100"`
+	Scopes    []APIKeyScope `json:"scopes,omitempty" binding:"omitempty,dive,oneof=read write posts:read posts:write"`
+	ExpiresAt *time.Time    `json:"expires_at,omitempty"`
+}
+
+// CreateAPIKeyResponse is the only response that carries the plaintext key
+type CreateAPIKeyResponse struct {
+	APIKey
+	Key string `json:"key"`
+}
+
+// APIKeyListResponse the caller's keys, newest first; hashes and keys are never listed
+type APIKeyListResponse struct {
+	Data []APIKey `json:"data"`
+}
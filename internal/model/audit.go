@@ -0,0 +1,37 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type AuditAction string
+
+const (
+	AuditActionPostDelete  AuditAction = "post.delete"
+	AuditActionRoleGrant   AuditAction = "user.role_grant"
+	AuditActionImpersonate AuditAction = "user.impersonate"
+)
+
+type AuditTargetType string
+
+const (
+	AuditTargetPost AuditTargetType = "post"
+	AuditTargetUser AuditTargetType = "user"
+)
+
+// AuditLog records a privileged action taken by a moderator or admin
+type AuditLog struct {
+	ID         uint64          `gorm:"primaryKey" json:"id"`
+	ActorID    string          `json:"actor_id"`
+	Action     AuditAction     `json:"action"`
+	TargetType AuditTargetType `json:"target_type"`
+	TargetID   string          `json:"target_id"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
+
+func (a *AuditLog) BeforeCreate(tx *gorm.DB) error {
+	a.CreatedAt = time.Now().UTC().Truncate(time.Microsecond)
+	return nil
+}
@@ -11,7 +11,7 @@ import (
 type LoginRequest struct {
 	Username string `json:"username,omitempty" binding:"omitempty,min=3,max=50,username"`
 	Email    string `json:"email,omitempty" binding:"omitempty,email"`
-	Password string `json:"password" binding:"required,min=6"`
+	Password string `json:"password" binding:"required"`
 }
 
 // RegisterRequest 注册请求
@@ -20,7 +20,7 @@ type RegisterRequest struct {
 	BirthDate *time.Time `json:"birth_date,omitempty"`
 	Username  string     `json:"username,omitempty" binding:"omitempty,min=3,max=50,username"`
 	Email     string     `json:"email,omitempty" binding:"omitempty,email"`
-	Password  string     `json:"password" binding:"required,min=6"`
+	Password  string     `json:"password" binding:"required"`
 }
 
 // TokenResponse JWT token response
@@ -35,9 +35,47 @@ type TokenResponse struct {
 type Claims struct {
 	UserID string   `json:"user_id"`
 	Role   UserRole `json:"role"`
+	// ImpersonatorID is the admin acting as UserID; empty for a normal login
+	ImpersonatorID string `json:"impersonator_id,omitempty"`
+	// Type is TokenTypeRefresh for refresh tokens, empty for access tokens
+	Type string `json:"typ,omitempty"`
 	jwt.RegisteredClaims
 }
 
+// TokenTypeRefresh marks a refresh token, only those are accepted by the refresh endpoints
+const TokenTypeRefresh = "refresh"
+
+// IsRefresh reports whether the token is a refresh token
+func (c *Claims) IsRefresh() bool {
+	return c.Type == TokenTypeRefresh
+}
+
+// Impersonated reports whether the token was issued to an admin acting as the user
+func (c *Claims) Impersonated() bool {
+	return c.ImpersonatorID != ""
+}
+
+// ImpersonationResponse a short-lived access token for the target user; there is no
+// refresh token, the session ends when it expires
+type ImpersonationResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int64  `json:"expires_in"`
+	UserID      string `json:"user_id"`
+}
+
+// RevokeTokenRequest 撤銷單一 access token 請求；傳入 token 本身，denylist 才能用它真正的到期時間
+type RevokeTokenRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+// RevokedToken a denied access token, kept until the token itself expires
+type RevokedToken struct {
+	JTI       string    `gorm:"primaryKey;column:jti"`
+	ExpiresAt time.Time `gorm:"not null"`
+	CreatedAt time.Time
+}
+
 // UserCredentials 用戶認證憑證
 type UserCredentials struct {
 	ID        string    `gorm:"primaryKey;default:gen_random_uuid()" json:"id"`
@@ -0,0 +1,25 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// UserBlock BlockerID no longer sees BlockedID's posts, and vice versa
+type UserBlock struct {
+	BlockerID string    `gorm:"primaryKey" json:"blocker_id"`
+	BlockedID string    `gorm:"primaryKey" json:"blocked_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// GORM Hooks
+func (b *UserBlock) BeforeCreate(tx *gorm.DB) error {
+	b.CreatedAt = time.Now().UTC().Truncate(time.Microsecond)
+	return nil
+}
+
+// BlockedUsersResponse IDs of the users the caller has blocked
+type BlockedUsersResponse struct {
+	BlockedIDs []string `json:"blocked_ids"`
+}
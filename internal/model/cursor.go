@@ -3,17 +3,31 @@ package model
 import (
 	"encoding/base64"
 	"encoding/json"
+	"fmt"
+	"go-gin-api-server/pkg/apperrors"
 	"time"
 )
 
+// CursorVersion is the payload version written by EncodeCursor
+//
+// bump it whenever the Cursor payload changes and teach DecodeCursor how to read
+// (or reject) the previous versions
+const CursorVersion byte = 1
+
 type Cursor struct {
 	ID        string    `json:"id"`
 	CreatedAt time.Time `json:"created_at"`
 }
 
+// CursorRequest limit contract (same as PaginationRequest.PageSize):
+//   - omitted or 0: defaults to 10
+//   - 1..100: used as-is
+//   - negative or above 100: rejected by binding with 400, never silently clamped
+//
+// SetDefaults still clamps for callers that bypass binding (e.g. services called directly)
 type CursorRequest struct {
 	Cursor   string  `json:"cursor" form:"cursor"`
-	Limit    int     `json:"limit" form:"limit" binding:"min=1,max=100"`
+	Limit    int     `json:"limit" form:"limit" binding:"omitempty,min=1,max=100"`
 	AuthorID *string `json:"author_id,omitempty" form:"author_id"`
 }
 
@@ -23,6 +37,26 @@ type CursorResponse[T any] struct {
 	HasMore bool   `json:"has_more"`
 }
 
+// NewCursorResponse builds a page from rows fetched with limit+1: the extra row only
+// signals HasMore and is dropped, the next cursor points at the last row kept
+func NewCursorResponse[T any](rows []T, limit int, cursorOf func(T) Cursor) *CursorResponse[T] {
+	hasMore := len(rows) > limit
+	if hasMore {
+		rows = rows[:limit]
+	}
+
+	var next string
+	if hasMore && len(rows) > 0 {
+		next = EncodeCursor(cursorOf(rows[len(rows)-1]))
+	}
+
+	return &CursorResponse[T]{
+		Data:    rows,
+		Next:    next,
+		HasMore: hasMore,
+	}
+}
+
 // set defaults
 func (c *CursorRequest) SetDefaults() {
 	if c.Limit <= 0 {
@@ -33,21 +67,39 @@ func (c *CursorRequest) SetDefaults() {
 	}
 }
 
+// EncodeCursor encodes the cursor as base64(version byte + JSON payload)
 func EncodeCursor(cursor Cursor) string {
 	data, err := json.Marshal(cursor)
 	if err != nil {
 		return ""
 	}
-	return base64.StdEncoding.EncodeToString(data)
+	return base64.StdEncoding.EncodeToString(append([]byte{CursorVersion}, data...))
 }
 
+// DecodeCursor decodes a cursor produced by EncodeCursor, unknown versions return ErrValidation
 func DecodeCursor(cursorStr string) (Cursor, error) {
 	data, err := base64.StdEncoding.DecodeString(cursorStr)
 	if err != nil {
-		return Cursor{}, err
+		return Cursor{}, fmt.Errorf("%w: malformed cursor", apperrors.ErrValidation)
+	}
+	if len(data) == 0 {
+		return Cursor{}, fmt.Errorf("%w: empty cursor", apperrors.ErrValidation)
+	}
+
+	var payload []byte
+	switch {
+	case data[0] == '{':
+		// legacy cursors were plain JSON without a version byte, they share the v1 payload
+		payload = data
+	case data[0] == CursorVersion:
+		payload = data[1:]
+	default:
+		return Cursor{}, fmt.Errorf("%w: unsupported cursor version %d", apperrors.ErrValidation, data[0])
 	}
 
 	var cursor Cursor
-	err = json.Unmarshal(data, &cursor)
-	return cursor, err
+	if err := json.Unmarshal(payload, &cursor); err != nil {
+		return Cursor{}, fmt.Errorf("%w: malformed cursor", apperrors.ErrValidation)
+	}
+	return cursor, nil
 }
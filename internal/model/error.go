@@ -0,0 +1,24 @@
+package model
+
+import "go-gin-api-server/pkg/jsonschema"
+
+// ErrorResponse default error body
+type ErrorResponse struct {
+	Error string `json:"error"`
+	// Code machine-readable reason, only set for errors clients are expected to branch on
+	Code string `json:"code,omitempty"`
+	// Details per-field validation errors
+	Details []jsonschema.FieldError `json:"details,omitempty"`
+}
+
+// ProblemDetails RFC 7807 error body, sent when the client accepts application/problem+json
+type ProblemDetails struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+	// Code and Errors are extension members mirroring ErrorResponse.Code and Details
+	Code   string                  `json:"code,omitempty"`
+	Errors []jsonschema.FieldError `json:"errors,omitempty"`
+}
@@ -0,0 +1,70 @@
+package model
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"go-gin-api-server/pkg/apperrors"
+	"time"
+)
+
+// UserExport is the document returned by the data export, sections are streamed in
+// field order; it is what a client decodes the download into
+type UserExport struct {
+	ExportedAt    time.Time      `json:"exported_at"`
+	Profile       User           `json:"profile"`
+	Posts         []Post         `json:"posts"`
+	Notifications []Notification `json:"notifications"` // received by the user
+	// Next resumes the export where this response stopped, empty on the last page
+	Next string `json:"next,omitempty"`
+}
+
+// ExportRequest query for the data export
+type ExportRequest struct {
+	Continuation string `form:"continuation"`
+}
+
+// ExportSection is the section a paged export resumes in
+type ExportSection string
+
+const (
+	ExportSectionPosts         ExportSection = "posts"
+	ExportSectionNotifications ExportSection = "notifications"
+)
+
+// ExportContinuation is where the next export page starts
+//
+// both sections resume after Cursor, the (created_at, id) of the last item written, so
+// items created meanwhile are neither picked up nor repeated across pages
+type ExportContinuation struct {
+	Section ExportSection `json:"section"`
+	Cursor  Cursor        `json:"cursor"`
+}
+
+// EncodeExportContinuation encodes the continuation as base64 JSON
+func EncodeExportContinuation(continuation ExportContinuation) string {
+	data, err := json.Marshal(continuation)
+	if err != nil {
+		return ""
+	}
+	return base64.StdEncoding.EncodeToString(data)
+}
+
+// DecodeExportContinuation decodes a token produced by EncodeExportContinuation
+func DecodeExportContinuation(token string) (ExportContinuation, error) {
+	data, err := base64.StdEncoding.DecodeString(token)
+	if err != nil {
+		return ExportContinuation{}, fmt.Errorf("%w: malformed continuation", apperrors.ErrValidation)
+	}
+
+	var continuation ExportContinuation
+	if err := json.Unmarshal(data, &continuation); err != nil {
+		return ExportContinuation{}, fmt.Errorf("%w: malformed continuation", apperrors.ErrValidation)
+	}
+	switch continuation.Section {
+	case ExportSectionPosts, ExportSectionNotifications:
+	default:
+		return ExportContinuation{}, fmt.Errorf("%w: unknown continuation section", apperrors.ErrValidation)
+	}
+	return continuation, nil
+}
@@ -0,0 +1,33 @@
+package model
+
+import "time"
+
+// Health statuses; degraded means a dependency lookup failed
+const (
+	HealthStatusOK       = "ok"
+	HealthStatusDegraded = "degraded"
+)
+
+// HealthDetails is the admin health report for dashboards, it reveals internals
+type HealthDetails struct {
+	Status   string            `json:"status"`
+	Env      string            `json:"env"`
+	Versions map[string]string `json:"versions"` // go, gin, postgres
+	Database DatabaseHealth    `json:"database"`
+	Features map[string]bool   `json:"features"` // configured feature flags
+	Errors   []string          `json:"errors,omitempty"`
+}
+
+// DatabaseHealth is the connection pool state (sql.DBStats) plus the applied migration
+type DatabaseHealth struct {
+	MigrationVersion   uint          `json:"migration_version"`
+	MigrationDirty     bool          `json:"migration_dirty"`
+	MaxOpenConnections int           `json:"max_open_connections"`
+	OpenConnections    int           `json:"open_connections"`
+	InUse              int           `json:"in_use"`
+	Idle               int           `json:"idle"`
+	WaitCount          int64         `json:"wait_count"`
+	WaitDuration       time.Duration `json:"wait_duration_ns"`
+	MaxIdleClosed      int64         `json:"max_idle_closed"`
+	MaxLifetimeClosed  int64         `json:"max_lifetime_closed"`
+}
@@ -0,0 +1,66 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// NotificationType describes why a notification was created
+type NotificationType string
+
+const (
+	NotificationMention NotificationType = "mention"
+	NotificationLike    NotificationType = "like"
+)
+
+type Notification struct {
+	ID        uint64           `gorm:"primaryKey" json:"id"`
+	UserID    string           `gorm:"index" json:"user_id"` // recipient
+	ActorID   string           `json:"actor_id"`
+	Type      NotificationType `json:"type"`
+	PostID    *uint64          `json:"post_id,omitempty"`
+	ReadAt    *time.Time       `json:"read_at,omitempty"`
+	CreatedAt time.Time        `json:"created_at"`
+}
+
+// GORM Hooks
+func (n *Notification) BeforeCreate(tx *gorm.DB) error {
+	n.CreatedAt = time.Now().UTC().Truncate(time.Microsecond)
+	return nil
+}
+
+// NotificationReadStatus filters notifications by whether they were read
+type NotificationReadStatus string
+
+const (
+	NotificationRead   NotificationReadStatus = "read"
+	NotificationUnread NotificationReadStatus = "unread"
+)
+
+type NotificationListRequest struct {
+	CursorRequest
+	Status *NotificationReadStatus `json:"status,omitempty" form:"status" binding:"omitempty,oneof=read unread"`
+	Type   *NotificationType       `json:"-" form:"-"` // set by the handler of a per-type tab, not a query param
+}
+
+// NotificationListOptions for keyset-paginated notification query, newest first
+type NotificationListOptions struct {
+	UserID string                  `json:"user_id"`
+	Status *NotificationReadStatus `json:"status,omitempty"`
+	Type   *NotificationType       `json:"type,omitempty"`
+	Cursor Cursor                  `json:"cursor"`
+	Limit  int                     `json:"limit"`
+}
+
+// NotificationPageOptions for offset-paginated notification query
+type NotificationPageOptions struct {
+	UserID string `json:"user_id"`
+	Offset int    `json:"offset"`
+	Limit  int    `json:"limit"`
+}
+
+// UnreadCountResponse is returned by the unread-count endpoint
+type UnreadCountResponse struct {
+	Count int64 `json:"count"`
+}
@@ -1,8 +1,9 @@
 package model
 
 type PaginationRequest struct {
-	Page     int `json:"page" form:"page" binding:"min=1"`
-	PageSize int `json:"page_size" form:"page_size" binding:"min=1,max=100"`
+	Page     int     `json:"page" form:"page" binding:"omitempty,min=1"`
+	PageSize int     `json:"page_size" form:"page_size" binding:"omitempty,min=1,max=100"`
+	AuthorID *string `json:"author_id,omitempty" form:"author_id"`
 }
 
 type PaginatedResponse[T any] struct {
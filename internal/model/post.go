@@ -1,17 +1,27 @@
 package model
 
 import (
+	"encoding/json"
+	"fmt"
+	"go-gin-api-server/pkg/querybind"
+	"strconv"
+	"strings"
 	"time"
 
 	"gorm.io/gorm"
 )
 
 type Post struct {
-	ID        uint64    `gorm:"primaryKey" json:"id"`
-	Content   string    `json:"content" binding:"required,min=10"`
-	AuthorID  string    `gorm:"index" json:"author_id"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID        uint64         `gorm:"primaryKey" json:"id"`
+	Title     *string        `json:"title,omitempty"` // optional, nil for posts without a title
+	Content   string         `json:"content" binding:"required"`
+	AuthorID  string         `gorm:"index" json:"author_id"`
+	Hidden    bool           `gorm:"not null;default:false" json:"hidden"`   // hidden by a moderator, set only via SetHidden
+	Archived  bool           `gorm:"not null;default:false" json:"archived"` // set by the retention job, out of the default listings
+	Slug      *string        `gorm:"uniqueIndex" json:"slug,omitempty"`      // generated on create, never changes
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
 
 	// related fields
 	Author *User `gorm:"foreignKey:AuthorID" json:"author"`
@@ -30,10 +40,186 @@ func (p *Post) BeforeUpdate(tx *gorm.DB) error {
 	return nil
 }
 
+// ETag identifies the current version of the post; every write bumps UpdatedAt,
+// which is stored with microsecond precision
+func (p *Post) ETag() string {
+	return fmt.Sprintf(`"%d-%d"`, p.ID, p.UpdatedAt.UnixMicro())
+}
+
+// MatchesETag reports whether an If-Match header value names the current version.
+// Comparison is strong, so weak (W/) tags never match; "*" matches any version
+func (p *Post) MatchesETag(ifMatch string) bool {
+	current := p.ETag()
+	for _, tag := range strings.Split(ifMatch, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" || tag == current {
+			return true
+		}
+	}
+	return false
+}
+
+// PostRevision keeps the content a post had before an edit
+type PostRevision struct {
+	ID        uint64    `gorm:"primaryKey" json:"id"`
+	PostID    uint64    `gorm:"index" json:"post_id"`
+	Content   string    `json:"content"`
+	CreatedAt time.Time `json:"created_at"` // when the content was replaced
+}
+
+func (r *PostRevision) BeforeCreate(tx *gorm.DB) error {
+	r.CreatedAt = time.Now().UTC().Truncate(time.Microsecond)
+	return nil
+}
+
 // Post DTO
 type PostResponse struct {
 	Post
-	Author *AuthorSummary `json:"author,omitempty"`
+	Author  *AuthorSummary `json:"author"` // always set, a placeholder when the author is gone
+	Warning string         `json:"warning,omitempty"`
+	// ContentPreview is the content cut to a preview, only set on search results
+	ContentPreview string `json:"content_preview,omitempty"`
+	// Stale marks a response served from the last good read while the database was
+	// unavailable, the handler sends a Warning header for it
+	Stale bool `json:"-"`
+	// Deleted/DeletedAt flag soft-deleted posts, which only the admin include_deleted listing returns
+	Deleted   bool       `json:"deleted,omitempty"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+}
+
+// NewPostResponse wraps post for a client: the author summary, the hidden warning and the
+// deleted flags; every handler returns posts through it so the id is always a string
+func NewPostResponse(post Post) PostResponse {
+	response := PostResponse{
+		Post: post,
+	}
+	if post.Hidden {
+		response.Warning = HiddenPostWarning
+	}
+	if post.DeletedAt.Valid {
+		deletedAt := post.DeletedAt.Time
+		response.Deleted = true
+		response.DeletedAt = &deletedAt
+	}
+	if post.Author != nil {
+		response.Author = &AuthorSummary{
+			ID:       post.Author.ID,
+			Name:     post.Author.Name,
+			Username: post.Author.Username,
+		}
+	} else {
+		// the author row is gone (or failed to load), keep the response shape stable
+		response.Author = &AuthorSummary{
+			ID:   post.AuthorID,
+			Name: DeletedAuthorName,
+		}
+	}
+	return response
+}
+
+// NewPostResponses is NewPostResponse for a list
+func NewPostResponses(posts []Post) []PostResponse {
+	responses := make([]PostResponse, 0, len(posts))
+	for _, post := range posts {
+		responses = append(responses, NewPostResponse(post))
+	}
+	return responses
+}
+
+// MarshalJSON serializes id as a string, uint64 IDs can exceed JavaScript's safe integer range
+func (r PostResponse) MarshalJSON() ([]byte, error) {
+	type alias PostResponse
+	return json.Marshal(struct {
+		alias
+		ID string `json:"id"`
+	}{
+		alias: alias(r),
+		ID:    strconv.FormatUint(r.ID, 10),
+	})
+}
+
+// UnmarshalJSON accepts id as either a string or a number
+func (r *PostResponse) UnmarshalJSON(data []byte) error {
+	type alias PostResponse
+	aux := struct {
+		*alias
+		ID json.Number `json:"id"`
+	}{
+		alias: (*alias)(r),
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	if aux.ID == "" {
+		return nil
+	}
+	id, err := strconv.ParseUint(aux.ID.String(), 10, 64)
+	if err != nil {
+		return err
+	}
+	r.ID = id
+	return nil
+}
+
+// HiddenPostWarning is attached to hidden posts shown to their author or moderators
+const HiddenPostWarning = "This post has been hidden by a moderator and is only visible to its author and moderators"
+
+// SetPostHiddenRequest toggles post visibility; hidden defaults to true
+type SetPostHiddenRequest struct {
+	Hidden *bool `json:"hidden"`
+}
+
+// UpdatePostRequest PATCH body, omitted fields are left unchanged
+type UpdatePostRequest struct {
+	Title   *string `json:"title,omitempty"`
+	Content *string `json:"content,omitempty"`
+}
+
+// ReplacePostRequest PUT body, a full replacement so every editable field is required
+type ReplacePostRequest struct {
+	Title   *string `json:"title"` // optional, omitting it clears the title
+	Content string  `json:"content" binding:"required"`
+}
+
+// ValidatePostRequest draft content to check without creating a post
+type ValidatePostRequest struct {
+	Content string `json:"content"`
+}
+
+type PostValidationResponse struct {
+	Valid  bool     `json:"valid"`
+	Errors []string `json:"errors"`
+}
+
+// PreviewPostRequest draft to run through the create pipeline without storing it
+type PreviewPostRequest struct {
+	Title   *string `json:"title,omitempty"`
+	Content string  `json:"content"`
+}
+
+// MentionPreview is an @mention found in a draft; UserID is empty for unknown usernames,
+// which get no notification
+type MentionPreview struct {
+	Username string `json:"username"`
+	UserID   string `json:"user_id,omitempty"`
+}
+
+// PostPreviewResponse is what creating the draft would store and notify
+type PostPreviewResponse struct {
+	Title    *string          `json:"title,omitempty"`
+	Content  string           `json:"content"`
+	Slug     string           `json:"slug,omitempty"` // before any collision suffix, empty when invalid
+	Mentions []MentionPreview `json:"mentions"`
+	Valid    bool             `json:"valid"`
+	Errors   []string         `json:"errors"`
+}
+
+// PostLimitsResponse write limits clients can use to adapt their composer
+type PostLimitsResponse struct {
+	ContentMin int `json:"content_min"`
+	ContentMax int `json:"content_max"`
+	TitleMax   int `json:"title_max"` // zero means no cap
 }
 
 type AuthorSummary struct {
@@ -42,9 +228,92 @@ type AuthorSummary struct {
 	Username *string `json:"username,omitempty"`
 }
 
+// PostSummary is the compact list item for large feeds: no author object and the
+// content cut to a preview
+type PostSummary struct {
+	ID             uint64    `json:"id,string"` // a string like PostResponse, see its MarshalJSON
+	ContentPreview string    `json:"content_preview"`
+	AuthorID       string    `json:"author_id"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"-"` // only for Last-Modified
+}
+
+// DeletedAuthorName is shown when a post's author could not be loaded
+const DeletedAuthorName = "Deleted user"
+
+// PostStatus filters the moderation listing by visibility
+type PostStatus string
+
+const (
+	PostStatusAll     PostStatus = "all"
+	PostStatusHidden  PostStatus = "hidden"
+	PostStatusVisible PostStatus = "visible"
+
+	// PostStatusArchived lists the caller's own archived posts on the public list
+	PostStatusArchived PostStatus = "archived"
+)
+
+// PostModerationListRequest query for the moderation listing; status defaults to all
+type PostModerationListRequest struct {
+	PaginationRequest
+	Status PostStatus `json:"status,omitempty" form:"status" binding:"omitempty,oneof=all hidden visible"`
+	// IncludeDeleted adds soft-deleted posts for audit/restore, admin only
+	IncludeDeleted bool `json:"include_deleted,omitempty" form:"include_deleted"`
+	// CallerRole is the authenticated caller's role set by the handler, never bound from a request
+	CallerRole UserRole `json:"-" form:"-"`
+}
+
+// PostSortKeys sort keys accepted by the post list; keyset pagination only supports created_at
+var PostSortKeys = []string{"created_at"}
+
+// PostView selects how much of each post the list returns
+type PostView string
+
+const (
+	PostViewFull    PostView = "full"
+	PostViewCompact PostView = "compact"
+)
+
+// PostListRequest cursor request plus the sort/date-range params parsed by querybind
+type PostListRequest struct {
+	CursorRequest
+	Search string   `json:"q,omitempty" form:"q" binding:"max=100"`
+	View   PostView `json:"view,omitempty" form:"view" binding:"omitempty,oneof=full compact"`
+	// Status only acts on archived, the moderation statuses are ignored here
+	Status PostStatus      `json:"status,omitempty" form:"status"`
+	Query  querybind.Query `json:"-" form:"-"`
+	// ViewerID is the authenticated caller set by the handler, never bound from a request
+	ViewerID string `json:"-" form:"-"`
+}
+
 // ListOptions for post list query
+// IncludeHidden/HiddenOnly are set by the service for moderation only, never bound from a request
 type PostListOptions struct {
-	AuthorID *string `json:"author_id,omitempty"`
-	Limit    int     `json:"limit"`
-	Cursor   Cursor  `json:"cursor"`
+	AuthorID      *string `json:"author_id,omitempty"`
+	Search        string  `json:"search,omitempty"` // case-insensitive substring of title or content
+	Limit         int     `json:"limit"`
+	Cursor        Cursor  `json:"cursor"`
+	IncludeHidden bool    `json:"include_hidden"`
+	HiddenOnly    bool    `json:"hidden_only"` // takes precedence over IncludeHidden
+	// ViewerID hides posts by users the viewer has blocked or been blocked by; empty for anonymous
+	ViewerID string `json:"viewer_id,omitempty"`
+	// ArchivedOnly lists archived posts instead of leaving them out
+	ArchivedOnly bool `json:"archived_only,omitempty"`
+	// IncludeArchived lists archived posts along with the others, ArchivedOnly takes precedence
+	IncludeArchived bool `json:"include_archived,omitempty"`
+
+	Ascending     bool       `json:"ascending"` // oldest first; the cursor condition flips accordingly
+	CreatedAfter  *time.Time `json:"created_after,omitempty"`
+	CreatedBefore *time.Time `json:"created_before,omitempty"`
+}
+
+// PostPageOptions for offset-paginated post query
+type PostPageOptions struct {
+	AuthorID      *string `json:"author_id,omitempty"`
+	Offset        int     `json:"offset"`
+	Limit         int     `json:"limit"`
+	IncludeHidden bool    `json:"include_hidden"`
+	HiddenOnly    bool    `json:"hidden_only"` // takes precedence over IncludeHidden
+	// IncludeDeleted also returns soft-deleted rows, set by the service for admins only
+	IncludeDeleted bool `json:"include_deleted,omitempty"`
 }
@@ -0,0 +1,56 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ReportStatus tracks moderation progress of a report
+type ReportStatus string
+
+const (
+	ReportPending   ReportStatus = "pending"
+	ReportResolved  ReportStatus = "resolved"
+	ReportDismissed ReportStatus = "dismissed"
+)
+
+type Report struct {
+	ID         uint64       `gorm:"primaryKey" json:"id"`
+	ReporterID string       `json:"reporter_id"`
+	PostID     uint64       `json:"post_id"`
+	Reason     string       `json:"reason"`
+	Status     ReportStatus `json:"status" gorm:"default:pending"`
+	CreatedAt  time.Time    `json:"created_at"`
+	UpdatedAt  time.Time    `json:"updated_at"`
+}
+
+// GORM Hooks
+func (r *Report) BeforeCreate(tx *gorm.DB) error {
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	r.CreatedAt = now
+	r.UpdatedAt = now
+	return nil
+}
+
+func (r *Report) BeforeUpdate(tx *gorm.DB) error {
+	r.UpdatedAt = time.Now().UTC().Truncate(time.Microsecond)
+	return nil
+}
+
+// Report external structures
+type CreateReportRequest struct {
+	Reason string `json:"reason" binding:"required,min=3,max=500"`
+}
+
+type ReportListRequest struct {
+	CursorRequest
+	Status *ReportStatus `json:"status,omitempty" form:"status" binding:"omitempty,oneof=pending resolved dismissed"`
+}
+
+// ReportListOptions for keyset-paginated report query, oldest first
+type ReportListOptions struct {
+	Status *ReportStatus `json:"status,omitempty"`
+	Cursor Cursor        `json:"cursor"`
+	Limit  int           `json:"limit"`
+}
@@ -0,0 +1,48 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// RoleRequestStatus tracks an elevation request through admin review
+type RoleRequestStatus string
+
+const (
+	RoleRequestPending  RoleRequestStatus = "pending"
+	RoleRequestApproved RoleRequestStatus = "approved"
+	RoleRequestRejected RoleRequestStatus = "rejected"
+)
+
+// RoleRequest is a user's request to be elevated to Role, granted when an admin approves it
+type RoleRequest struct {
+	ID         uint64            `gorm:"primaryKey" json:"id"`
+	UserID     string            `json:"user_id"`
+	Role       UserRole          `json:"role"` // requested role
+	Reason     string            `json:"reason"`
+	Status     RoleRequestStatus `json:"status" gorm:"default:pending"`
+	ReviewedBy *string           `json:"reviewed_by,omitempty"`
+	ReviewedAt *time.Time        `json:"reviewed_at,omitempty"`
+	CreatedAt  time.Time         `json:"created_at"`
+	UpdatedAt  time.Time         `json:"updated_at"`
+}
+
+// GORM Hooks
+func (r *RoleRequest) BeforeCreate(tx *gorm.DB) error {
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	r.CreatedAt = now
+	r.UpdatedAt = now
+	return nil
+}
+
+func (r *RoleRequest) BeforeUpdate(tx *gorm.DB) error {
+	r.UpdatedAt = time.Now().UTC().Truncate(time.Microsecond)
+	return nil
+}
+
+// CreateRoleRequestRequest asks for a role above the caller's current one
+type CreateRoleRequestRequest struct {
+	Role   UserRole `json:"role" binding:"required,oneof=moderator admin"`
+	Reason string   `json:"reason" binding:"max=500"`
+}
@@ -0,0 +1,31 @@
+package model
+
+import "go-gin-api-server/pkg/jsonschema"
+
+// JSON Schemas checked by middleware.ValidateJSONSchema before binding; they mirror the
+// binding tags so clients get every field error at once instead of a generic 400
+
+// RegisterRequestSchema schema for RegisterRequest
+var RegisterRequestSchema = jsonschema.MustCompile(`{
+	"type": "object",
+	"required": ["name", "password"],
+	"additionalProperties": false,
+	"properties": {
+		"name": {"type": "string", "minLength": 3},
+		"birth_date": {"type": ["string", "null"], "format": "date-time"},
+		"username": {"type": "string", "minLength": 3, "maxLength": 50, "pattern": "^[a-zA-Z][a-zA-Z0-9_-]*$"},
+		"email": {"type": "string", "format": "email"},
+		"password": {"type": "string"}
+	}
+}`)
+
+// CreatePostSchema schema for the body of a post create
+var CreatePostSchema = jsonschema.MustCompile(`{
+	"type": "object",
+	"required": ["content"],
+	"additionalProperties": false,
+	"properties": {
+		"title": {"type": ["string", "null"]},
+		"content": {"type": "string"}
+	}
+}`)
@@ -18,7 +18,8 @@ type User struct {
 	UpdatedAt time.Time `json:"updated_at"`
 
 	// new fields
-	Role UserRole `json:"role" gorm:"default:user"`
+	Role          UserRole            `json:"role" gorm:"default:user"`
+	DeactivatedBy *DeactivationSource `json:"deactivated_by,omitempty"`
 
 	// related fields
 	UserCredentials *UserCredentials `gorm:"foreignKey:UserID" json:"-"`
@@ -52,22 +53,64 @@ func (u *User) BeforeUpdate(tx *gorm.DB) error {
 type UserRole string
 
 const (
-	RoleUser  UserRole = "user"
-	RoleAdmin UserRole = "admin"
+	RoleUser      UserRole = "user"
+	RoleModerator UserRole = "moderator"
+	RoleAdmin     UserRole = "admin"
 )
 
 func (r UserRole) IsAdmin() bool {
 	return r == RoleAdmin
 }
 
+// Rank orders roles by privilege, unknown roles rank below RoleUser
+func (r UserRole) Rank() int {
+	switch r {
+	case RoleUser:
+		return 1
+	case RoleModerator:
+		return 2
+	case RoleAdmin:
+		return 3
+	}
+	return 0
+}
+
+// CanModerate reports whether the role may act on reported content (moderators and admins)
+func (r UserRole) CanModerate() bool {
+	return r == RoleModerator || r == RoleAdmin
+}
+
+// DeactivationSource records who deactivated an account
+type DeactivationSource string
+
+const (
+	DeactivatedBySelf  DeactivationSource = "self"
+	DeactivatedByAdmin DeactivationSource = "admin"
+)
+
 // User external structures
 type UpdateUserProfileRequest struct {
 	Name      string     `json:"name,omitempty" binding:"omitempty,min=3"`
+	Username  *string    `json:"username,omitempty" binding:"omitempty,min=3,max=50,username"`
 	BirthDate *time.Time `json:"birth_date,omitempty"`
 }
 
+// UserProfilesRequest bulk profile lookup by user IDs, at most 100 per request
+type UserProfilesRequest struct {
+	IDs []string `json:"ids" binding:"required,min=1,max=100,dive,uuid"`
+}
+
+// UserProfilesResponse public profiles of the found users, in request order
+type UserProfilesResponse struct {
+	Data []UserProfile `json:"data"`
+}
+
 type UserProfile struct {
+	// ID is only filled in by the bulk lookup, where callers need to match profiles to IDs
+	ID        string     `json:"id,omitempty"`
 	Name      string     `json:"name"`
 	Username  *string    `json:"username,omitempty"`
 	BirthDate *time.Time `json:"birth_date,omitempty"`
+	// Stale marks a profile served from the last good read while the database was unavailable
+	Stale bool `json:"-" gorm:"-"`
 }
@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"errors"
+	"go-gin-api-server/internal/database"
+	"go-gin-api-server/internal/model"
+	"go-gin-api-server/pkg/apperrors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type APIKeyRepository interface {
+	Create(key *model.APIKey) (*model.APIKey, error)
+	ListByUser(userID string) ([]model.APIKey, error)
+	FindByHash(keyHash string) (*model.APIKey, error)
+	Revoke(id uint64, userID string) error
+	TouchLastUsed(id uint64, usedAt time.Time) error
+}
+
+type apiKeyRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewAPIKeyRepository() APIKeyRepository {
+	return &apiKeyRepositoryImpl{
+		db: database.GetDB(),
+	}
+}
+
+func NewAPIKeyRepositoryWithDB(db *gorm.DB) APIKeyRepository {
+	return &apiKeyRepositoryImpl{
+		db: db,
+	}
+}
+
+func (r *apiKeyRepositoryImpl) Create(key *model.APIKey) (*model.APIKey, error) {
+	if err := r.db.Create(key).Error; err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// ListByUser returns the user's keys newest first, revoked ones included
+func (r *apiKeyRepositoryImpl) ListByUser(userID string) ([]model.APIKey, error) {
+	keys := []model.APIKey{}
+	if err := r.db.Where("user_id = ?", userID).
+		Order("created_at DESC, id DESC").
+		Find(&keys).Error; err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// FindByHash loads the key with its owner, whose current role and status apply
+func (r *apiKeyRepositoryImpl) FindByHash(keyHash string) (*model.APIKey, error) {
+	var key model.APIKey
+	if err := r.db.Preload("User").
+		Where("key_hash = ?", keyHash).
+		First(&key).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrNotFound
+		}
+		return nil, err
+	}
+	return &key, nil
+}
+
+// Revoke marks one of the user's keys revoked; someone else's key or one already
+// revoked is ErrNotFound
+func (r *apiKeyRepositoryImpl) Revoke(id uint64, userID string) error {
+	result := r.db.Model(&model.APIKey{}).
+		Where("id = ? AND user_id = ? AND revoked_at IS NULL", id, userID).
+		Update("revoked_at", time.Now().UTC().Truncate(time.Microsecond))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return apperrors.ErrNotFound
+	}
+	return nil
+}
+
+func (r *apiKeyRepositoryImpl) TouchLastUsed(id uint64, usedAt time.Time) error {
+	return r.db.Model(&model.APIKey{}).
+		Where("id = ?", id).
+		Update("last_used_at", usedAt.UTC().Truncate(time.Microsecond)).Error
+}
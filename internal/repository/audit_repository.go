@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"go-gin-api-server/internal/database"
+	"go-gin-api-server/internal/model"
+
+	"gorm.io/gorm"
+)
+
+// AuditRepository records privileged actions that change no other row; actions with a
+// side effect write their entry in the same transaction instead (see DeleteWithAudit)
+type AuditRepository interface {
+	Create(entry *model.AuditLog) error
+}
+
+type auditRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewAuditRepository() AuditRepository {
+	return &auditRepositoryImpl{
+		db: database.GetDB(),
+	}
+}
+
+func NewAuditRepositoryWithDB(db *gorm.DB) AuditRepository {
+	return &auditRepositoryImpl{
+		db: db,
+	}
+}
+
+func (r *auditRepositoryImpl) Create(entry *model.AuditLog) error {
+	return r.db.Create(entry).Error
+}
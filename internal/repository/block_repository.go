@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"errors"
+	"go-gin-api-server/internal/database"
+	"go-gin-api-server/internal/model"
+	"go-gin-api-server/pkg/apperrors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type BlockRepository interface {
+	Block(blockerID, blockedID string) error
+	Unblock(blockerID, blockedID string) error
+	IsBlocked(userID, otherID string) (bool, error)
+	HasBlocked(blockerID, blockedID string) (bool, error)
+	ListBlockedIDs(blockerID string) ([]string, error)
+	CountBlocked(blockerID string) (int64, error)
+}
+
+type blockRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewBlockRepository() BlockRepository {
+	return &blockRepositoryImpl{
+		db: database.GetDB(),
+	}
+}
+
+func NewBlockRepositoryWithDB(db *gorm.DB) BlockRepository {
+	return &blockRepositoryImpl{
+		db: db,
+	}
+}
+
+// Block is idempotent, blocking someone already blocked is a no-op
+func (r *blockRepositoryImpl) Block(blockerID, blockedID string) error {
+	block := &model.UserBlock{BlockerID: blockerID, BlockedID: blockedID}
+	if err := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(block).Error; err != nil {
+		// the blocked user doesn't exist
+		if errors.Is(err, gorm.ErrForeignKeyViolated) {
+			return apperrors.ErrNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// Unblock is idempotent, removing a block that doesn't exist is a no-op
+func (r *blockRepositoryImpl) Unblock(blockerID, blockedID string) error {
+	return r.db.Where("blocker_id = ? AND blocked_id = ?", blockerID, blockedID).
+		Delete(&model.UserBlock{}).Error
+}
+
+// IsBlocked reports a block in either direction, neither side may interact with the other
+func (r *blockRepositoryImpl) IsBlocked(userID, otherID string) (bool, error) {
+	var count int64
+	err := r.db.Model(&model.UserBlock{}).
+		Where("(blocker_id = ? AND blocked_id = ?) OR (blocker_id = ? AND blocked_id = ?)",
+			userID, otherID, otherID, userID).
+		Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// HasBlocked reports whether blockerID has blocked blockedID, one direction only
+func (r *blockRepositoryImpl) HasBlocked(blockerID, blockedID string) (bool, error) {
+	var count int64
+	err := r.db.Model(&model.UserBlock{}).
+		Where("blocker_id = ? AND blocked_id = ?", blockerID, blockedID).
+		Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// ListBlockedIDs the users blockerID has blocked, most recent first
+func (r *blockRepositoryImpl) ListBlockedIDs(blockerID string) ([]string, error) {
+	ids := []string{}
+	err := r.db.Model(&model.UserBlock{}).
+		Where("blocker_id = ?", blockerID).
+		Order("created_at DESC").
+		Pluck("blocked_id", &ids).Error
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// CountBlocked the number of users blockerID has blocked
+func (r *blockRepositoryImpl) CountBlocked(blockerID string) (int64, error) {
+	var count int64
+	err := r.db.Model(&model.UserBlock{}).
+		Where("blocker_id = ?", blockerID).
+		Count(&count).Error
+	return count, err
+}
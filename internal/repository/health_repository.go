@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"database/sql"
+	"go-gin-api-server/internal/database"
+
+	"gorm.io/gorm"
+)
+
+type HealthRepository interface {
+	PoolStats() (sql.DBStats, error)
+	MigrationVersion() (version uint, dirty bool, err error)
+	ServerVersion() (string, error)
+}
+
+type healthRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewHealthRepository() HealthRepository {
+	return &healthRepositoryImpl{
+		db: database.GetDB(),
+	}
+}
+
+func NewHealthRepositoryWithDB(db *gorm.DB) HealthRepository {
+	return &healthRepositoryImpl{
+		db: db,
+	}
+}
+
+func (r *healthRepositoryImpl) PoolStats() (sql.DBStats, error) {
+	sqlDB, err := r.db.DB()
+	if err != nil {
+		return sql.DBStats{}, err
+	}
+	return sqlDB.Stats(), nil
+}
+
+// MigrationVersion reads the version golang-migrate recorded in schema_migrations
+func (r *healthRepositoryImpl) MigrationVersion() (uint, bool, error) {
+	var row struct {
+		Version uint
+		Dirty   bool
+	}
+	if err := r.db.Raw("SELECT version, dirty FROM schema_migrations LIMIT 1").Scan(&row).Error; err != nil {
+		return 0, false, err
+	}
+	return row.Version, row.Dirty, nil
+}
+
+func (r *healthRepositoryImpl) ServerVersion() (string, error) {
+	var version string
+	if err := r.db.Raw("SHOW server_version").Scan(&version).Error; err != nil {
+		return "", err
+	}
+	return version, nil
+}
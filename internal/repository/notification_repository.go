@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"errors"
+	"go-gin-api-server/internal/database"
+	"go-gin-api-server/internal/model"
+	"go-gin-api-server/pkg/apperrors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type NotificationRepository interface {
+	Create(notification *model.Notification) (*model.Notification, error)
+	List(opts model.NotificationListOptions) ([]model.Notification, error)
+	ListPagedWithCount(opts model.NotificationPageOptions) ([]model.Notification, int64, error)
+	CountUnread(userID string) (int64, error)
+	MarkRead(id uint64, userID string) error
+}
+
+type notificationRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewNotificationRepository() NotificationRepository {
+	return &notificationRepositoryImpl{
+		db: database.GetDB(),
+	}
+}
+
+func NewNotificationRepositoryWithDB(db *gorm.DB) NotificationRepository {
+	return &notificationRepositoryImpl{
+		db: db,
+	}
+}
+
+func (r *notificationRepositoryImpl) Create(notification *model.Notification) (*model.Notification, error) {
+	if err := r.db.Create(notification).Error; err != nil {
+		return nil, err
+	}
+	return notification, nil
+}
+
+// List returns one keyset page of the user's notifications, newest first
+func (r *notificationRepositoryImpl) List(opts model.NotificationListOptions) ([]model.Notification, error) {
+	if opts.Limit < 0 {
+		return nil, apperrors.ErrValidation
+	}
+
+	query := r.db.Model(&model.Notification{}).Where("user_id = ?", opts.UserID)
+	if opts.Status != nil {
+		switch *opts.Status {
+		case model.NotificationRead:
+			query = query.Where("read_at IS NOT NULL")
+		case model.NotificationUnread:
+			query = query.Where("read_at IS NULL")
+		}
+	}
+	if opts.Type != nil {
+		query = query.Where("type = ?", *opts.Type)
+	}
+
+	cursorID, err := parseCursorID(opts.Cursor)
+	if err != nil {
+		return nil, err
+	}
+	if cursorID > 0 {
+		query = query.Where("(created_at, id) < (?, ?)", opts.Cursor.CreatedAt, cursorID)
+	}
+
+	notifications := []model.Notification{}
+	err = query.Order("created_at DESC, id DESC").
+		Limit(opts.Limit).
+		Find(&notifications).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return notifications, nil
+}
+
+// ListPagedWithCount offset listing of the user's notifications with the total count
+func (r *notificationRepositoryImpl) ListPagedWithCount(opts model.NotificationPageOptions) ([]model.Notification, int64, error) {
+	if opts.Limit < 0 || opts.Offset < 0 {
+		return nil, 0, apperrors.ErrValidation
+	}
+
+	query := r.db.Model(&model.Notification{}).Where("user_id = ?", opts.UserID)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	notifications := []model.Notification{}
+	err := query.Order("created_at DESC, id DESC").
+		Offset(opts.Offset).
+		Limit(opts.Limit).
+		Find(&notifications).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return notifications, total, nil
+}
+
+func (r *notificationRepositoryImpl) CountUnread(userID string) (int64, error) {
+	var count int64
+	err := r.db.Model(&model.Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		Count(&count).Error
+	return count, err
+}
+
+// MarkRead marks the notification read; only the recipient can mark it
+func (r *notificationRepositoryImpl) MarkRead(id uint64, userID string) error {
+	var notification model.Notification
+	err := r.db.Select("id", "read_at").
+		Where("id = ? AND user_id = ?", id, userID).
+		First(&notification).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return apperrors.ErrNotFound
+		}
+		return err
+	}
+
+	// already read, keep the original timestamp
+	if notification.ReadAt != nil {
+		return nil
+	}
+
+	return r.db.Model(&model.Notification{}).
+		Where("id = ?", id).
+		Update("read_at", time.Now().UTC().Truncate(time.Microsecond)).Error
+}
@@ -1,28 +1,61 @@
 package repository
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"errors"
+	"go-gin-api-server/internal/authz"
 	"go-gin-api-server/internal/database"
 	"go-gin-api-server/internal/model"
 	"go-gin-api-server/pkg/apperrors"
 	"strconv"
+	"strings"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type PostRepository interface {
 	Create(post *model.Post) (*model.Post, error)
 	List(opts model.PostListOptions) ([]model.Post, error)
+	ListSummaries(opts model.PostListOptions, contentLength int) ([]model.Post, error)
+	ListPagedWithCount(opts model.PostPageOptions) ([]model.Post, int64, error)
+	IteratePostsByAuthor(authorID string, from model.Cursor, batchSize int, fn func(batch []model.Post) error) error
 	FindByID(id uint64) (*model.Post, error)
+	FindBySlug(slug string) (*model.Post, error)
 	Update(id uint64, post *model.Post) (*model.Post, error)
+	UpdateIfMatch(id uint64, post *model.Post, ifMatch string) (*model.Post, error)
+	Replace(id uint64, post *model.Post) (*model.Post, error)
+	ReplaceIfMatch(id uint64, post *model.Post, ifMatch string) (*model.Post, error)
 	Delete(id uint64) error
+	DeleteWithAudit(id uint64, entry *model.AuditLog) error
+	SetHidden(id uint64, hidden bool) (*model.Post, error)
+	ArchiveOlderThan(cutoff time.Time) (int64, error)
+	ListRevisions(postID uint64) ([]model.PostRevision, error)
 	CheckPermission(id uint64, currentUserID string) error
 }
 
+// replaceablePostColumns are the columns a full replacement writes, zero values included;
+// author, visibility and slug are never client-editable
+var replaceablePostColumns = []string{"title", "content"}
+
+// ErrStopIteration returned by an iterate callback ends the iteration early without an error
+var ErrStopIteration = errors.New("stop iteration")
+
+// maxSlugAttempts bounds the suffixed retries after a slug collision
+const maxSlugAttempts = 5
+
 type postRepositoryImpl struct {
 	db *gorm.DB
 }
 
+// postWithTotalCount is a post row carrying the window-function total
+type postWithTotalCount struct {
+	model.Post
+	TotalCount int64
+}
+
 func NewPostRepository() PostRepository {
 	return &postRepositoryImpl{
 		db: database.GetDB(),
@@ -35,47 +68,259 @@ func NewPostRepositoryWithDB(db *gorm.DB) PostRepository {
 	}
 }
 
+// Create inserts the post; a slug collision is retried with a random suffix
+//
+// the unique index is the source of truth for slugs, each attempt runs in its own
+// (sub)transaction so a rejected insert doesn't abort an enclosing transaction
 func (r *postRepositoryImpl) Create(post *model.Post) (*model.Post, error) {
-	if err := r.db.Create(post).Error; err != nil {
-		return nil, err
+	if post.Slug == nil {
+		if err := r.db.Create(post).Error; err != nil {
+			return nil, err
+		}
+		return r.withAuthor(post)
 	}
-	return post, nil
+
+	base := *post.Slug
+	for attempt := 0; attempt < maxSlugAttempts; attempt++ {
+		slug := base
+		if attempt > 0 {
+			suffix, err := randomSlugSuffix()
+			if err != nil {
+				return nil, err
+			}
+			slug = base + "-" + suffix
+		}
+		post.Slug = &slug
+
+		err := r.db.Transaction(func(tx *gorm.DB) error {
+			return tx.Create(post).Error
+		})
+		if err == nil {
+			return r.withAuthor(post)
+		}
+		if !errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, err
+		}
+	}
+
+	return nil, apperrors.ErrConflict
+}
+
+func randomSlugSuffix() (string, error) {
+	b := make([]byte, 3)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
 }
 
 func (r *postRepositoryImpl) List(opts model.PostListOptions) ([]model.Post, error) {
 	var posts []model.Post
 
+	query, err := r.listQuery(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := query.Preload("Author", selectAuthorSummary).Find(&posts).Error; err != nil {
+		return nil, err
+	}
+	return posts, nil
+}
+
+// ListSummaries is List for the compact view: same filters and order, but only the
+// summary columns and the first contentLength characters of content, no author
+func (r *postRepositoryImpl) ListSummaries(opts model.PostListOptions, contentLength int) ([]model.Post, error) {
+	var posts []model.Post
+
+	if contentLength < 0 {
+		return nil, apperrors.ErrValidation
+	}
+	query, err := r.listQuery(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	err = query.Select("id, author_id, created_at, updated_at, substring(content, 1, ?) AS content", contentLength).
+		Find(&posts).Error
+	if err != nil {
+		return nil, err
+	}
+	return posts, nil
+}
+
+// listQuery applies the List filters, cursor, order and limit
+func (r *postRepositoryImpl) listQuery(opts model.PostListOptions) (*gorm.DB, error) {
 	// validate negative
 	if opts.Limit < 0 {
 		return nil, apperrors.ErrValidation
 	}
 
-	query := r.db.Preload("Author").
-		Order("created_at DESC, id DESC").
+	order, cmp := "created_at DESC, id DESC", "<"
+	if opts.Ascending {
+		order, cmp = "created_at ASC, id ASC", ">"
+	}
+
+	query := r.db.Model(&model.Post{}).
+		Order(order).
 		Limit(opts.Limit)
 
+	// predicates follow the index column order: the author_id equality, then the
+	// (created_at, id) range, so (author_id, created_at DESC, id DESC) serves author pages
+	if opts.AuthorID != nil {
+		query = query.Where("author_id = ?", *opts.AuthorID)
+	}
+
 	// handle cursor pagination
-	if opts.Cursor.ID != "" {
-		cursorID, err := strconv.ParseInt(opts.Cursor.ID, 10, 64)
-		if err != nil {
-			return nil, apperrors.ErrValidation
-		}
+	cursorID, err := parseCursorID(opts.Cursor)
+	if err != nil {
+		return nil, err
+	}
+	// only add WHERE condition when cursorID > 0
+	// a row comparison, unlike the equivalent OR form, is a single range condition the
+	// (created_at DESC, id DESC) indexes can seek on
+	if cursorID > 0 {
+		query = query.Where("(created_at, id) "+cmp+" (?, ?)", opts.Cursor.CreatedAt, cursorID)
+	}
 
-		// only add WHERE condition when cursorID > 0
-		if cursorID > 0 {
-			query = query.Where("(created_at < ?) OR (created_at = ? AND id < ?)", opts.Cursor.CreatedAt, opts.Cursor.CreatedAt, cursorID)
-		}
+	// add optional filter
+	if opts.CreatedAfter != nil {
+		query = query.Where("created_at > ?", *opts.CreatedAfter)
+	}
+	if opts.CreatedBefore != nil {
+		query = query.Where("created_at < ?", *opts.CreatedBefore)
+	}
+	if opts.Search != "" {
+		pattern := "%" + escapeLike(opts.Search) + "%"
+		query = query.Where("(title ILIKE ? OR content ILIKE ?)", pattern, pattern)
+	}
+	if opts.ViewerID != "" {
+		// blocks hide posts both ways, each side is served by a user_blocks index
+		query = query.Where(`NOT EXISTS (SELECT 1 FROM user_blocks b WHERE
+			(b.blocker_id = ? AND b.blocked_id = posts.author_id) OR
+			(b.blocked_id = ? AND b.blocker_id = posts.author_id))`, opts.ViewerID, opts.ViewerID)
+	}
+	if opts.ArchivedOnly || !opts.IncludeArchived {
+		query = query.Where("archived = ?", opts.ArchivedOnly)
+	}
+	return filterHidden(query, opts.IncludeHidden, opts.HiddenOnly), nil
+}
+
+// ListPagedWithCount returns one page of posts together with the total number of
+// matching posts, computed in the same query via COUNT(*) OVER().
+func (r *postRepositoryImpl) ListPagedWithCount(opts model.PostPageOptions) ([]model.Post, int64, error) {
+	// validate negative
+	if opts.Offset < 0 || opts.Limit < 0 {
+		return nil, 0, apperrors.ErrValidation
+	}
+
+	var rows []postWithTotalCount
+
+	db := r.db
+	if opts.IncludeDeleted {
+		db = db.Unscoped()
 	}
 
+	query := db.Model(&model.Post{}).
+		Select("posts.*, COUNT(*) OVER() AS total_count").
+		Order("created_at DESC, id DESC").
+		Offset(opts.Offset).
+		Limit(opts.Limit)
+
 	// add optional filter
 	if opts.AuthorID != nil {
 		query = query.Where("author_id = ?", *opts.AuthorID)
 	}
+	query = filterHidden(query, opts.IncludeHidden, opts.HiddenOnly)
 
-	if err := query.Find(&posts).Error; err != nil {
-		return nil, err
+	if err := query.Scan(&rows).Error; err != nil {
+		return nil, 0, err
 	}
-	return posts, nil
+
+	posts := make([]model.Post, 0, len(rows))
+	for _, row := range rows {
+		posts = append(posts, row.Post)
+	}
+
+	// a page past the end carries no rows, so the total has to be counted separately
+	var total int64
+	if len(rows) > 0 {
+		total = rows[0].TotalCount
+	} else {
+		countQuery := db.Model(&model.Post{})
+		if opts.AuthorID != nil {
+			countQuery = countQuery.Where("author_id = ?", *opts.AuthorID)
+		}
+		countQuery = filterHidden(countQuery, opts.IncludeHidden, opts.HiddenOnly)
+		if err := countQuery.Count(&total).Error; err != nil {
+			return nil, 0, err
+		}
+	}
+
+	if err := r.loadAuthors(posts); err != nil {
+		return nil, 0, err
+	}
+
+	return posts, total, nil
+}
+
+// IteratePostsByAuthor hands fn the author's posts, hidden and archived ones included, newest
+// first and at most batchSize at a time, so only one batch is held in memory; iteration starts after
+// from, a zero cursor starts at the newest post
+func (r *postRepositoryImpl) IteratePostsByAuthor(authorID string, from model.Cursor, batchSize int, fn func(batch []model.Post) error) error {
+	if batchSize <= 0 {
+		return apperrors.ErrValidation
+	}
+
+	cursor := from
+	for {
+		posts, err := r.List(model.PostListOptions{
+			AuthorID:        &authorID,
+			Limit:           batchSize,
+			Cursor:          cursor,
+			IncludeHidden:   true,
+			IncludeArchived: true,
+		})
+		if err != nil {
+			return err
+		}
+		if len(posts) == 0 {
+			return nil
+		}
+
+		if err := fn(posts); err != nil {
+			if errors.Is(err, ErrStopIteration) {
+				return nil
+			}
+			return err
+		}
+		if len(posts) < batchSize {
+			return nil
+		}
+
+		last := posts[len(posts)-1]
+		cursor = model.Cursor{ID: strconv.FormatUint(last.ID, 10), CreatedAt: last.CreatedAt}
+	}
+}
+
+// authorSummaryColumns are the user columns list responses render as model.AuthorSummary
+var authorSummaryColumns = []string{"id", "name", "username"}
+
+// selectAuthorSummary narrows an author lookup to authorSummaryColumns; as a Preload
+// condition it still runs once per page as a single IN query
+func selectAuthorSummary(db *gorm.DB) *gorm.DB {
+	return db.Select(authorSummaryColumns)
+}
+
+// filterHidden applies the visibility filter shared by the list queries
+func filterHidden(query *gorm.DB, includeHidden bool, hiddenOnly bool) *gorm.DB {
+	switch {
+	case hiddenOnly:
+		return query.Where("hidden = ?", true)
+	case !includeHidden:
+		return query.Where("hidden = ?", false)
+	}
+	return query
 }
 
 func (r *postRepositoryImpl) FindByID(id uint64) (*model.Post, error) {
@@ -91,26 +336,105 @@ func (r *postRepositoryImpl) FindByID(id uint64) (*model.Post, error) {
 	return &post, nil
 }
 
+func (r *postRepositoryImpl) FindBySlug(slug string) (*model.Post, error) {
+	var post model.Post
+	if err := r.db.Preload("Author").
+		Where("slug = ?", slug).
+		First(&post).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrNotFound
+		}
+		return nil, err
+	}
+	return &post, nil
+}
+
+// Update applies the non-zero fields and, when the content changes, records the previous
+// content as a revision in the same transaction
 func (r *postRepositoryImpl) Update(id uint64, updated *model.Post) (*model.Post, error) {
-	result := r.db.Model(&model.Post{}).
-		Where("id = ?", id).
-		Updates(updated)
+	return r.update(id, updated, nil, "")
+}
 
-	if result.Error != nil {
-		return nil, result.Error
-	}
-	if result.RowsAffected == 0 {
-		return nil, apperrors.ErrNotFound
-	}
+// UpdateIfMatch is Update that only writes while the post still matches the If-Match
+// value, ErrPreconditionFailed otherwise
+func (r *postRepositoryImpl) UpdateIfMatch(id uint64, updated *model.Post, ifMatch string) (*model.Post, error) {
+	return r.update(id, updated, nil, ifMatch)
+}
+
+// Replace writes every replaceable column, so zero values clear the stored ones; the
+// previous content is recorded as a revision like Update
+func (r *postRepositoryImpl) Replace(id uint64, replacement *model.Post) (*model.Post, error) {
+	return r.update(id, replacement, replaceablePostColumns, "")
+}
+
+// ReplaceIfMatch is Replace guarded by an If-Match value like UpdateIfMatch
+func (r *postRepositoryImpl) ReplaceIfMatch(id uint64, replacement *model.Post, ifMatch string) (*model.Post, error) {
+	return r.update(id, replacement, replaceablePostColumns, ifMatch)
+}
 
+// update runs Update and Replace; columns restricts the write to those columns, zero
+// values included, nil keeps the struct update that skips zero values. A non-empty
+// ifMatch is compared against the locked row, so a concurrent write in between can't be lost
+func (r *postRepositoryImpl) update(id uint64, updated *model.Post, columns []string, ifMatch string) (*model.Post, error) {
 	var post model.Post
-	if err := r.db.Where("id = ?", id).
-		First(&post).Error; err != nil {
-		return nil, apperrors.ErrNotFound
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var current model.Post
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id", "content", "updated_at").
+			Where("id = ?", id).
+			First(&current).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return apperrors.ErrNotFound
+			}
+			return err
+		}
+		if ifMatch != "" && !current.MatchesETag(ifMatch) {
+			return apperrors.ErrPreconditionFailed
+		}
+
+		contentWritten := updated.Content != "" || columns != nil
+		if contentWritten && updated.Content != current.Content {
+			revision := &model.PostRevision{
+				PostID:  id,
+				Content: current.Content,
+			}
+			if err := tx.Create(revision).Error; err != nil {
+				return err
+			}
+		}
+
+		query := tx.Model(&model.Post{}).Where("id = ?", id)
+		if columns != nil {
+			query = query.Select(columns)
+		}
+		result := query.Updates(updated)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return apperrors.ErrNotFound
+		}
+
+		return tx.Preload("Author", selectAuthorSummary).Where("id = ?", id).First(&post).Error
+	})
+	if err != nil {
+		return nil, err
 	}
 	return &post, nil
 }
 
+// ListRevisions returns the post's previous contents, newest first
+func (r *postRepositoryImpl) ListRevisions(postID uint64) ([]model.PostRevision, error) {
+	revisions := []model.PostRevision{}
+	err := r.db.Where("post_id = ?", postID).
+		Order("created_at DESC, id DESC").
+		Find(&revisions).Error
+	if err != nil {
+		return nil, err
+	}
+	return revisions, nil
+}
+
 func (r *postRepositoryImpl) Delete(id uint64) error {
 	result := r.db.Where("id = ?", id).Delete(&model.Post{})
 	if result.Error != nil {
@@ -122,19 +446,122 @@ func (r *postRepositoryImpl) Delete(id uint64) error {
 	return nil
 }
 
-func (r *postRepositoryImpl) CheckPermission(id uint64, userID string) error {
-	var count int64
-	err := r.db.Model(&model.Post{}).
-		Where("id = ? AND author_id = ?", id, userID).
-		Count(&count).Error
+// DeleteWithAudit soft-deletes the post and records the audit entry in the same transaction
+func (r *postRepositoryImpl) DeleteWithAudit(id uint64, entry *model.AuditLog) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("id = ?", id).Delete(&model.Post{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return apperrors.ErrNotFound
+		}
+
+		return tx.Create(entry).Error
+	})
+}
+
+// ArchiveOlderThan archives every post created before cutoff and returns how many; an
+// UpdateColumn so archiving doesn't bump updated_at like an edit would
+func (r *postRepositoryImpl) ArchiveOlderThan(cutoff time.Time) (int64, error) {
+	result := r.db.Model(&model.Post{}).
+		Where("archived = ? AND created_at < ?", false, cutoff).
+		UpdateColumn("archived", true)
+	return result.RowsAffected, result.Error
+}
+
+// SetHidden updates only the hidden flag; a map update so false is persisted too
+func (r *postRepositoryImpl) SetHidden(id uint64, hidden bool) (*model.Post, error) {
+	result := r.db.Model(&model.Post{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"hidden":     hidden,
+			"updated_at": time.Now().UTC().Truncate(time.Microsecond),
+		})
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, apperrors.ErrNotFound
+	}
 
+	return r.FindByID(id)
+}
+
+// CheckPermission follows the read visibility rules: a post the caller can't see (missing,
+// or hidden and not theirs) is ErrNotFound like GetByID, a visible post owned by someone
+// else is ErrForbidden since its existence is already public
+func (r *postRepositoryImpl) CheckPermission(id uint64, userID string) error {
+	var post model.Post
+	err := r.db.Select("author_id", "hidden", "archived").First(&post, id).Error
 	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return apperrors.ErrNotFound
+		}
 		return err
 	}
 
-	if count == 0 {
+	if !authz.IsOwner(userID, post.AuthorID) {
+		if post.Hidden || post.Archived {
+			return apperrors.ErrNotFound
+		}
 		return apperrors.ErrForbidden
 	}
 
 	return nil
 }
+
+// withAuthor loads the author summary of a single post, as loadAuthors does for a list
+func (r *postRepositoryImpl) withAuthor(post *model.Post) (*model.Post, error) {
+	posts := []model.Post{*post}
+	if err := r.loadAuthors(posts); err != nil {
+		return nil, err
+	}
+	post.Author = posts[0].Author
+	return post, nil
+}
+
+// loadAuthors fills in the Author of each post with a single IN query
+func (r *postRepositoryImpl) loadAuthors(posts []model.Post) error {
+	if len(posts) == 0 {
+		return nil
+	}
+
+	authorIDs := make([]string, 0, len(posts))
+	for _, post := range posts {
+		authorIDs = append(authorIDs, post.AuthorID)
+	}
+
+	var authors []model.User
+	if err := selectAuthorSummary(r.db).Where("id IN ?", authorIDs).Find(&authors).Error; err != nil {
+		return err
+	}
+
+	authorsByID := make(map[string]*model.User, len(authors))
+	for i := range authors {
+		authorsByID[authors[i].ID] = &authors[i]
+	}
+
+	for i := range posts {
+		posts[i].Author = authorsByID[posts[i].AuthorID]
+	}
+
+	return nil
+}
+
+// parseCursorID returns the keyset id of a decoded cursor, zero for the first page
+func parseCursorID(cursor model.Cursor) (int64, error) {
+	if cursor.ID == "" {
+		return 0, nil
+	}
+	id, err := strconv.ParseInt(cursor.ID, 10, 64)
+	if err != nil {
+		return 0, apperrors.ErrValidation
+	}
+	return id, nil
+}
+
+// escapeLike escapes LIKE wildcards so a search term matches literally
+func escapeLike(term string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(term)
+}
@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"errors"
+	"go-gin-api-server/internal/database"
+	"go-gin-api-server/internal/model"
+	"go-gin-api-server/pkg/apperrors"
+
+	"gorm.io/gorm"
+)
+
+type ReportRepository interface {
+	Create(report *model.Report) (*model.Report, error)
+	List(opts model.ReportListOptions) ([]model.Report, error)
+}
+
+type reportRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewReportRepository() ReportRepository {
+	return &reportRepositoryImpl{
+		db: database.GetDB(),
+	}
+}
+
+func NewReportRepositoryWithDB(db *gorm.DB) ReportRepository {
+	return &reportRepositoryImpl{
+		db: db,
+	}
+}
+
+func (r *reportRepositoryImpl) Create(report *model.Report) (*model.Report, error) {
+	if err := r.db.Create(report).Error; err != nil {
+		// unique index on (reporter_id, post_id) rejects duplicate reports
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, apperrors.ErrConflict
+		}
+		// the post was deleted between the existence check and the insert
+		if errors.Is(err, gorm.ErrForeignKeyViolated) {
+			return nil, apperrors.ErrNotFound
+		}
+		return nil, err
+	}
+	return report, nil
+}
+
+// List returns one keyset page, oldest first so moderators work through the queue in order
+func (r *reportRepositoryImpl) List(opts model.ReportListOptions) ([]model.Report, error) {
+	if opts.Limit < 0 {
+		return nil, apperrors.ErrValidation
+	}
+
+	query := r.db.Model(&model.Report{})
+	if opts.Status != nil {
+		query = query.Where("status = ?", *opts.Status)
+	}
+
+	cursorID, err := parseCursorID(opts.Cursor)
+	if err != nil {
+		return nil, err
+	}
+	if cursorID > 0 {
+		query = query.Where("(created_at, id) > (?, ?)", opts.Cursor.CreatedAt, cursorID)
+	}
+
+	reports := []model.Report{}
+	err = query.Order("created_at ASC, id ASC").
+		Limit(opts.Limit).
+		Find(&reports).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return reports, nil
+}
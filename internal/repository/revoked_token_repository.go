@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"go-gin-api-server/internal/database"
+	"go-gin-api-server/internal/model"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// RevokedTokenRepository is the access token denylist shared by every instance, it
+// satisfies utils.TokenDenylist
+type RevokedTokenRepository interface {
+	Revoke(jti string, expiresAt time.Time) error
+	IsRevoked(jti string) (bool, error)
+}
+
+type revokedTokenRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewRevokedTokenRepository() RevokedTokenRepository {
+	return &revokedTokenRepositoryImpl{
+		db: database.GetDB(),
+	}
+}
+
+func NewRevokedTokenRepositoryWithDB(db *gorm.DB) RevokedTokenRepository {
+	return &revokedTokenRepositoryImpl{
+		db: db,
+	}
+}
+
+// Revoke denies jti until expiresAt; revoking again keeps the later expiry. Revocations are
+// rare, so rows of tokens that have expired since are purged here
+func (r *revokedTokenRepositoryImpl) Revoke(jti string, expiresAt time.Time) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("expires_at <= ?", time.Now()).Delete(&model.RevokedToken{}).Error; err != nil {
+			return err
+		}
+
+		token := &model.RevokedToken{JTI: jti, ExpiresAt: expiresAt}
+		return tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "jti"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{"expires_at": gorm.Expr("GREATEST(revoked_tokens.expires_at, EXCLUDED.expires_at)")}),
+		}).Create(token).Error
+	})
+}
+
+// IsRevoked reports whether jti is denied, expired rows count as not revoked
+func (r *revokedTokenRepositoryImpl) IsRevoked(jti string) (bool, error) {
+	if jti == "" {
+		return false, nil
+	}
+
+	var count int64
+	err := r.db.Model(&model.RevokedToken{}).
+		Where("jti = ? AND expires_at > ?", jti, time.Now()).
+		Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
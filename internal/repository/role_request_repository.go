@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"errors"
+	"go-gin-api-server/internal/database"
+	"go-gin-api-server/internal/model"
+	"go-gin-api-server/pkg/apperrors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type RoleRequestRepository interface {
+	Create(request *model.RoleRequest) (*model.RoleRequest, error)
+	Approve(id uint64, reviewerID string) (*model.RoleRequest, error)
+}
+
+type roleRequestRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewRoleRequestRepository() RoleRequestRepository {
+	return &roleRequestRepositoryImpl{
+		db: database.GetDB(),
+	}
+}
+
+func NewRoleRequestRepositoryWithDB(db *gorm.DB) RoleRequestRepository {
+	return &roleRequestRepositoryImpl{
+		db: db,
+	}
+}
+
+func (r *roleRequestRepositoryImpl) Create(request *model.RoleRequest) (*model.RoleRequest, error) {
+	if err := r.db.Create(request).Error; err != nil {
+		// partial unique index on user_id allows one pending request per user
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, apperrors.ErrConflict
+		}
+		return nil, err
+	}
+	return request, nil
+}
+
+// Approve marks a pending request approved, grants the requested role and records an
+// audit entry in one transaction; a request that is no longer pending is a conflict
+func (r *roleRequestRepositoryImpl) Approve(id uint64, reviewerID string) (*model.RoleRequest, error) {
+	var request model.RoleRequest
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ?", id).
+			First(&request).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return apperrors.ErrNotFound
+			}
+			return err
+		}
+		if request.Status != model.RoleRequestPending {
+			return apperrors.ErrConflict
+		}
+
+		now := time.Now().UTC().Truncate(time.Microsecond)
+		request.Status = model.RoleRequestApproved
+		request.ReviewedBy = &reviewerID
+		request.ReviewedAt = &now
+		if err := tx.Model(&request).
+			Select("status", "reviewed_by", "reviewed_at", "updated_at").
+			Updates(&request).Error; err != nil {
+			return err
+		}
+
+		result := tx.Model(&model.User{}).
+			Where("id = ?", request.UserID).
+			Updates(map[string]interface{}{
+				"role":       request.Role,
+				"updated_at": now,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return apperrors.ErrNotFound
+		}
+
+		return tx.Create(&model.AuditLog{
+			ActorID:    reviewerID,
+			Action:     model.AuditActionRoleGrant,
+			TargetType: model.AuditTargetUser,
+			TargetID:   request.UserID,
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &request, nil
+}
@@ -15,7 +15,9 @@ type UserRepository interface {
 	FindByID(id string) (*model.User, error)
 	FindByUsername(username string) (*model.User, error)
 	FindByEmail(email string) (*model.User, error)
+	FindProfilesByIDs(ids []string) ([]model.UserProfile, error)
 	Update(id string, user *model.User) (*model.User, error)
+	UpdateStatus(id string, isActive bool, deactivatedBy *model.DeactivationSource) (*model.User, error)
 	Delete(id string) error
 }
 
@@ -115,8 +117,61 @@ func (r *userRepositoryImpl) FindByEmail(email string) (*model.User, error) {
 	return &user, nil
 }
 
+// profileColumns are the user columns that make up a public profile
+var profileColumns = []string{"id", "name", "username", "birth_date"}
+
+// FindProfilesByIDs loads the public profile columns of the given users in one IN query;
+// unknown IDs are simply missing from the result
+func (r *userRepositoryImpl) FindProfilesByIDs(ids []string) ([]model.UserProfile, error) {
+	profiles := []model.UserProfile{}
+	if len(ids) == 0 {
+		return profiles, nil
+	}
+
+	if err := r.db.Model(&model.User{}).
+		Select(profileColumns).
+		Where("id IN ?", ids).
+		Find(&profiles).Error; err != nil {
+		return nil, err
+	}
+
+	return profiles, nil
+}
+
 func (r *userRepositoryImpl) Update(id string, updated *model.User) (*model.User, error) {
 	result := r.db.Model(&model.User{}).Where("id = ?", id).Updates(updated)
+	if result.Error != nil {
+		// unique index on username/email is the source of truth under concurrent updates
+		if errors.Is(result.Error, gorm.ErrDuplicatedKey) {
+			return nil, apperrors.ErrUserExists
+		}
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, apperrors.ErrNotFound
+	}
+
+	var user model.User
+	if err := r.db.
+		Where("id = ?", id).
+		First(&user).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+// UpdateStatus sets the active flag explicitly, since Updates with a struct skips zero values
+func (r *userRepositoryImpl) UpdateStatus(id string, isActive bool, deactivatedBy *model.DeactivationSource) (*model.User, error) {
+	result := r.db.Model(&model.User{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"is_active":      isActive,
+			"deactivated_by": deactivatedBy,
+		})
 	if result.Error != nil {
 		return nil, result.Error
 	}
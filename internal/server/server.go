@@ -1,21 +1,33 @@
 package server
 
 import (
+	"context"
+	"fmt"
 	"go-gin-api-server/config"
+	"go-gin-api-server/internal/events"
 	"go-gin-api-server/internal/handler"
 	"go-gin-api-server/internal/middleware"
 	"go-gin-api-server/internal/repository"
 	"go-gin-api-server/internal/service"
 	"go-gin-api-server/pkg/logger"
 	"go-gin-api-server/pkg/utils"
+	"net/http"
 
 	"github.com/gin-gonic/gin"
 	"github.com/gin-gonic/gin/binding"
 	"github.com/go-playground/validator/v10"
+	"go.uber.org/zap"
 )
 
 // NewServer creates and configures a new Gin server
 func NewServer(cfg *config.Config) *gin.Engine {
+	router, _ := NewServerWithContext(context.Background(), cfg)
+	return router
+}
+
+// NewServerWithContext 創建 router，背景工作（如貼文封存）在 ctx 取消時停止；
+// 回傳的 close 會送出佇列中的非同步事件，須在 HTTP server 停止後、資料庫關閉前呼叫
+func NewServerWithContext(ctx context.Context, cfg *config.Config) (*gin.Engine, func()) {
 	// Set Gin mode based on environment
 	if cfg.Env == config.Production {
 		gin.SetMode(gin.ReleaseMode)
@@ -29,14 +41,42 @@ func NewServer(cfg *config.Config) *gin.Engine {
 	// Create Gin router
 	router := gin.New()
 
+	// /api/v1/posts/ redirects to /api/v1/posts (307 for writes, so the method and body are kept);
+	// a known path with an unsupported method gets 405 and an Allow header instead of 404
+	router.RedirectTrailingSlash = true
+	router.HandleMethodNotAllowed = true
+	router.NoMethod(handler.MethodNotAllowed)
+
+	// X-Forwarded-For is only honoured from these proxies when resolving c.ClientIP(); with none
+	// configured the header is ignored and the peer address is used, gin would trust everyone
+	if err := router.SetTrustedProxies(cfg.Security.TrustedProxies); err != nil {
+		logger.Log.Fatal("Invalid SECURITY_TRUSTED_PROXIES", zap.Error(err))
+	}
+	if len(cfg.Security.TrustedProxies) == 0 && (len(cfg.Security.AdminAllowCIDRs) > 0 || len(cfg.Security.AdminDenyCIDRs) > 0) {
+		logger.Log.Warn("Admin IP lists are set but SECURITY_TRUSTED_PROXIES is not, behind a proxy every request comes from the proxy address")
+	}
+
 	// Add middleware
 	router.Use(gin.Logger())
 	router.Use(gin.Recovery())
-	router.Use(middleware.GinZapMiddleware())
+	router.Use(middleware.GinZapMiddleware(cfg.Server.LatencyBudget, logger.Log))
+	router.Use(middleware.PrettyJSONMiddleware(cfg.Server))
+	router.Use(middleware.APIVersionMiddleware(cfg.Server))
+	router.Use(middleware.SecurityHeadersMiddleware(cfg.Security))
+	router.Use(middleware.JSONContentTypeMiddleware(cfg.Security))
+	router.Use(middleware.MaxQueryLengthMiddleware(cfg.Security))
+	router.Use(middleware.AdminIPFilterMiddleware(cfg.Security, logger.Log))
+
+	// cap in-flight requests server-wide before anything touches the database; health
+	// checks stay exempt so probes don't fail just because the server is busy, and so do the
+	// WebSocket and the streamed export, which would hold a slot for their whole lifetime
+	// (the export shares the heavy query cap instead)
+	globalLimit := middleware.NewConcurrencyLimitMiddleware(int64(cfg.RateLimit.GlobalConcurrency), logger.Log)
+	router.Use(globalLimit.LimitExcept(1, "/health", "/api/v1/admin/health", "/api/v1/ws", "/api/v1/users/me/export"))
 
 	// Health check endpoint
 	router.GET("/health", func(c *gin.Context) {
-		c.JSON(200, gin.H{
+		utils.RespondJSON(c, 200, gin.H{
 			"status": "ok",
 			"env":    cfg.Env,
 		})
@@ -46,33 +86,125 @@ func NewServer(cfg *config.Config) *gin.Engine {
 	userRepo := repository.NewUserRepository()
 	authRepo := repository.NewAuthRepository()
 	postRepo := repository.NewPostRepository()
+	notificationRepo := repository.NewNotificationRepository()
+	reportRepo := repository.NewReportRepository()
+	roleRequestRepo := repository.NewRoleRequestRepository()
+	blockRepo := repository.NewBlockRepository()
+	healthRepo := repository.NewHealthRepository()
+	auditRepo := repository.NewAuditRepository()
+	apiKeyRepo := repository.NewAPIKeyRepository()
+	revokedTokenRepo := repository.NewRevokedTokenRepository()
 
 	// Initialize JWT manager
-	jwtMgr := utils.NewJWTManager(cfg.JWT.Secret, cfg.JWT.AccessTokenExpiration)
+	previousKeys := make([]utils.SigningKey, 0, len(cfg.JWT.PreviousKeys))
+	for id, secret := range cfg.JWT.PreviousKeys {
+		previousKeys = append(previousKeys, utils.SigningKey{ID: id, Secret: secret})
+	}
+	jwtMgr := utils.NewJWTManagerWithKeys(utils.SigningKey{ID: cfg.JWT.KeyID, Secret: cfg.JWT.Secret},
+		previousKeys, cfg.JWT.AccessTokenExpiration, cfg.JWT.RefreshTokenExpiration)
+
+	// Initialize event bus
+	eventBus := events.NewBus(logger.Log)
 
 	// Initialize services
-	userService := service.NewUserService(userRepo)
-	authService := service.NewAuthService(userRepo, authRepo, jwtMgr)
-	postService := service.NewPostService(postRepo)
+	userService := service.NewUserServiceWithConfig(userRepo, cfg.Profile)
+	authService := service.NewAuthServiceWithDeps(userRepo, authRepo, jwtMgr, cfg.Auth, service.AuthServiceDeps{
+		Bus:      eventBus,
+		Denylist: revokedTokenRepo,
+		Audit:    auditRepo,
+	})
+	postService := service.NewPostServiceWithUsers(postRepo, userRepo, cfg.Post, eventBus)
+	notificationService := service.NewNotificationServiceWithConfig(notificationRepo, userRepo, cfg.Post)
+	reportService := service.NewReportService(reportRepo, postRepo)
+	roleRequestService := service.NewRoleRequestService(roleRequestRepo, userRepo)
+	blockService := service.NewBlockServiceWithConfig(blockRepo, userRepo, cfg.Users)
+	healthService := service.NewHealthService(healthRepo, cfg)
+	exportService := service.NewExportServiceWithConfig(userRepo, postRepo, notificationRepo, cfg.Export)
+	apiKeyService := service.NewAPIKeyServiceWithConfig(apiKeyRepo, cfg.Auth)
+
+	// Subscribe event consumers
+	eventBus.Subscribe(func(event events.Event) {
+		if err := notificationService.HandleEvent(event); err != nil {
+			logger.Log.Error("Failed to create notification",
+				zap.String("type", event.Type()),
+				zap.Error(err))
+		}
+	}, events.TypePostCreated, events.TypePostLiked)
+
+	if cfg.Welcome.Enabled {
+		welcomePostService, err := service.NewWelcomePostService(postService, cfg.Welcome)
+		if err != nil {
+			logger.Log.Error("Welcome post disabled", zap.Error(err))
+		} else {
+			eventBus.Subscribe(func(event events.Event) {
+				if err := welcomePostService.HandleEvent(event); err != nil {
+					logger.Log.Error("Failed to create welcome post",
+						zap.String("type", event.Type()),
+						zap.Error(err))
+				}
+			}, events.TypeUserRegistered)
+		}
+	}
+
+	if cfg.Post.ArchiveAfter > 0 {
+		go service.NewPostArchiver(postService, cfg.Post.ArchiveInterval, logger.Log).Run(ctx)
+	}
 
 	// Initialize handlers
-	userHandler := handler.NewUserHandler(userService, logger.Log)
-	authHandler := handler.NewAuthHandler(authService, logger.Log)
+	userHandler := handler.NewUserHandlerWithConfig(userService, cfg.Users, logger.Log)
+	authHandler := handler.NewAuthHandlerWithConfig(authService, cfg.JWT, logger.Log)
 	postHandler := handler.NewPostHandler(postService, logger.Log)
+	wsHandler := handler.NewWebSocketHandler(eventBus, blockService, logger.Log)
+	notificationHandler := handler.NewNotificationHandler(notificationService, logger.Log)
+	reportHandler := handler.NewReportHandler(reportService, logger.Log)
+	roleRequestHandler := handler.NewRoleRequestHandler(roleRequestService, logger.Log)
+	blockHandler := handler.NewBlockHandler(blockService, logger.Log)
+	exportHandler := handler.NewExportHandler(exportService, logger.Log)
+	healthHandler := handler.NewHealthHandler(healthService, logger.Log)
+	apiKeyHandler := handler.NewAPIKeyHandler(apiKeyService, logger.Log)
 
 	// Initialize middleware
-	authMiddleware := middleware.NewAuthMiddleware(authService, logger.Log)
+	authMiddleware := middleware.NewAuthMiddlewareWithConfig(authService, revokedTokenRepo, cfg.Auth, logger.Log)
 	rbacMiddleware := middleware.NewRBACMiddleware(logger.Log)
+	profileRateLimit := middleware.NewRateLimitMiddleware(cfg.RateLimit.ProfileRequests, cfg.RateLimit.ProfileWindow, logger.Log)
+	readRateLimit := middleware.NewTieredRateLimitMiddleware(cfg.RateLimit.ReadAnonymousRequests, cfg.RateLimit.ReadAuthenticatedRequests, cfg.RateLimit.ReadWindow, logger.Log)
+	heavyLimit := middleware.NewConcurrencyLimitMiddleware(int64(cfg.RateLimit.HeavyConcurrency), logger.Log)
+	apiKeyMiddleware := middleware.NewAPIKeyMiddleware(apiKeyService, logger.Log)
+
+	// "Authorization: ApiKey <key>" is resolved here for every route below, RequireAuth
+	// and OptionalAuth then treat the request as authenticated
+	router.Use(apiKeyMiddleware.Authenticate())
 
 	// Register routes
-	userHandler.RegisterRoutes(router)
-	authHandler.RegisterRoutes(router)
-	postHandler.RegisterRoutes(router)
+	userHandler.RegisterRoutes(router, authMiddleware, profileRateLimit, readRateLimit)
+	authHandler.RegisterRoutes(router, cfg.Server.MaxBodyBytes)
+	postHandler.RegisterRoutes(router, authMiddleware, rbacMiddleware, readRateLimit, heavyLimit)
 
 	// Register protected routes
 	userHandler.RegisterProtectedRoutes(router, authMiddleware, rbacMiddleware)
-	postHandler.RegisterProtectedRoutes(router, authMiddleware, rbacMiddleware)
+	postHandler.RegisterProtectedRoutes(router, authMiddleware, rbacMiddleware, heavyLimit, cfg.Server.MaxBodyBytes)
 	authHandler.RegisterProtectedRoutes(router, authMiddleware, rbacMiddleware)
+	wsHandler.RegisterProtectedRoutes(router, authMiddleware, rbacMiddleware)
+	notificationHandler.RegisterProtectedRoutes(router, authMiddleware, rbacMiddleware)
+	reportHandler.RegisterProtectedRoutes(router, authMiddleware, rbacMiddleware)
+	roleRequestHandler.RegisterProtectedRoutes(router, authMiddleware, rbacMiddleware)
+	blockHandler.RegisterProtectedRoutes(router, authMiddleware, rbacMiddleware)
+	exportHandler.RegisterProtectedRoutes(router, authMiddleware, rbacMiddleware, heavyLimit)
+	healthHandler.RegisterProtectedRoutes(router, authMiddleware, rbacMiddleware)
+	apiKeyHandler.RegisterProtectedRoutes(router, authMiddleware, rbacMiddleware)
 
-	return router
+	return router, eventBus.Close
+}
+
+// NewHTTPServer creates the http.Server with limits and timeouts taken from config
+func NewHTTPServer(cfg *config.Config, handler http.Handler) *http.Server {
+	return &http.Server{
+		Addr:              fmt.Sprintf(":%s", cfg.Port),
+		Handler:           handler,
+		ReadHeaderTimeout: cfg.Server.ReadHeaderTimeout,
+		ReadTimeout:       cfg.Server.ReadTimeout,
+		WriteTimeout:      cfg.Server.WriteTimeout,
+		IdleTimeout:       cfg.Server.IdleTimeout,
+		MaxHeaderBytes:    cfg.Server.MaxHeaderBytes,
+	}
 }
@@ -0,0 +1,114 @@
+package service
+
+import (
+	"errors"
+	"go-gin-api-server/config"
+	"go-gin-api-server/internal/model"
+	"go-gin-api-server/internal/repository"
+	"go-gin-api-server/pkg/apperrors"
+	"go-gin-api-server/pkg/utils"
+	"time"
+)
+
+type APIKeyService interface {
+	Create(userID string, req model.CreateAPIKeyRequest) (*model.CreateAPIKeyResponse, error)
+	List(userID string) (*model.APIKeyListResponse, error)
+	Revoke(id uint64, userID string) error
+	Authenticate(key string) (*model.APIKey, error)
+}
+
+type apiKeyServiceImpl struct {
+	repo repository.APIKeyRepository
+	cfg  config.AuthConfig
+}
+
+func NewAPIKeyService(repo repository.APIKeyRepository) APIKeyService {
+	return NewAPIKeyServiceWithConfig(repo, config.AuthConfig{APIKeyLastUsedInterval: time.Minute})
+}
+
+// NewAPIKeyServiceWithConfig 創建使用指定預設有效期與 last_used_at 更新間隔的 APIKeyService
+func NewAPIKeyServiceWithConfig(repo repository.APIKeyRepository, cfg config.AuthConfig) APIKeyService {
+	return &apiKeyServiceImpl{
+		repo: repo,
+		cfg:  cfg,
+	}
+}
+
+func (s *apiKeyServiceImpl) Create(userID string, req model.CreateAPIKeyRequest) (*model.CreateAPIKeyResponse, error) {
+	// business logic: a key that is already expired is useless
+	now := time.Now()
+	expiresAt := req.ExpiresAt
+	if expiresAt != nil && !expiresAt.After(now) {
+		return nil, apperrors.ErrValidation
+	}
+	if expiresAt == nil && s.cfg.APIKeyDefaultTTL > 0 {
+		defaultExpiry := now.Add(s.cfg.APIKeyDefaultTTL).UTC()
+		expiresAt = &defaultExpiry
+	}
+
+	scopes := model.APIKeyScopes(req.Scopes)
+	if len(scopes) == 0 {
+		scopes = model.APIKeyScopes{model.APIKeyScopeRead}
+	}
+
+	key, prefix, err := utils.GenerateAPIKey()
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := s.repo.Create(&model.APIKey{
+		UserID:    userID,
+		Name:      req.Name,
+		Prefix:    prefix,
+		KeyHash:   utils.HashAPIKey(key),
+		Scopes:    scopes,
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.CreateAPIKeyResponse{APIKey: *created, Key: key}, nil
+}
+
+func (s *apiKeyServiceImpl) List(userID string) (*model.APIKeyListResponse, error) {
+	keys, err := s.repo.ListByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	return &model.APIKeyListResponse{Data: keys}, nil
+}
+
+func (s *apiKeyServiceImpl) Revoke(id uint64, userID string) error {
+	return s.repo.Revoke(id, userID)
+}
+
+// Authenticate resolves a presented key to its stored record with the owner loaded;
+// expired keys are ErrExpiredToken, unknown or revoked keys and inactive owners are
+// ErrUnauthorized. last_used_at is written at most once per APIKeyLastUsedInterval
+func (s *apiKeyServiceImpl) Authenticate(key string) (*model.APIKey, error) {
+	apiKey, err := s.repo.FindByHash(utils.HashAPIKey(key))
+	if err != nil {
+		if errors.Is(err, apperrors.ErrNotFound) {
+			return nil, apperrors.ErrUnauthorized
+		}
+		return nil, err
+	}
+
+	now := time.Now()
+	if apiKey.RevokedAt == nil && apiKey.Expired(now) {
+		return nil, apperrors.ErrExpiredToken
+	}
+	if !apiKey.Usable(now) || apiKey.User == nil || !apiKey.User.IsActive {
+		return nil, apperrors.ErrUnauthorized
+	}
+
+	if apiKey.LastUsedAt == nil || now.Sub(*apiKey.LastUsedAt) >= s.cfg.APIKeyLastUsedInterval {
+		// best effort, a failed write must not fail the request
+		if err := s.repo.TouchLastUsed(apiKey.ID, now); err == nil {
+			apiKey.LastUsedAt = &now
+		}
+	}
+
+	return apiKey, nil
+}
@@ -1,11 +1,16 @@
 package service
 
 import (
+	"errors"
+	"go-gin-api-server/config"
+	"go-gin-api-server/internal/authz"
+	"go-gin-api-server/internal/events"
 	"go-gin-api-server/internal/model"
 	"go-gin-api-server/internal/repository"
 	"go-gin-api-server/pkg/apperrors"
 	"go-gin-api-server/pkg/utils"
 	"slices"
+	"strings"
 	"time"
 
 	"github.com/bytedance/gopkg/util/logger"
@@ -17,23 +22,70 @@ type AuthService interface {
 	RefreshToken(refreshToken string) (*model.TokenResponse, error)
 	RefreshAccessToken(refreshToken string) (string, error)
 	ValidateToken(tokenString string) (*model.Claims, error)
+	RevokeAccessToken(accessToken string) error
+	Impersonate(userID string, adminID string) (*model.ImpersonationResponse, error)
 
 	// User status management
 	ActivateUser(userID string) (*model.User, error)
-	DeactivateUser(userID string) (*model.User, error)
+	DeactivateUser(userID string, actorID string) (*model.User, error)
 }
 
 type authServiceImpl struct {
 	userRepo repository.UserRepository
 	authRepo repository.AuthRepository
 	jwtMgr   *utils.JWTManager
+	cfg      config.AuthConfig
+	bus      *events.Bus
+	denylist utils.TokenDenylist
+	audit    repository.AuditRepository
+
+	disposableDomains map[string]bool
 }
 
 func NewAuthService(userRepo repository.UserRepository, authRepo repository.AuthRepository, jwtMgr *utils.JWTManager) AuthService {
+	return NewAuthServiceWithConfig(userRepo, authRepo, jwtMgr, config.AuthConfig{
+		SelfReactivateOnLogin: true,
+		DefaultRole:           string(model.RoleUser),
+	})
+}
+
+// NewAuthServiceWithConfig 創建使用指定認證配置的 AuthService
+func NewAuthServiceWithConfig(userRepo repository.UserRepository, authRepo repository.AuthRepository, jwtMgr *utils.JWTManager, cfg config.AuthConfig) AuthService {
+	return NewAuthServiceWithDeps(userRepo, authRepo, jwtMgr, cfg, AuthServiceDeps{})
+}
+
+// AuthServiceDeps optional collaborators of the AuthService, a nil field turns its feature off
+type AuthServiceDeps struct {
+	// Bus publishes account status events
+	Bus *events.Bus
+	// Denylist records revoked access tokens; it only takes effect when shared with
+	// AuthMiddleware of every instance, nil uses a private in-memory one
+	Denylist utils.TokenDenylist
+	// Audit records every impersonation, nil refuses impersonation
+	Audit repository.AuditRepository
+}
+
+// NewAuthServiceWithDeps 創建使用指定認證配置與依賴的 AuthService
+func NewAuthServiceWithDeps(userRepo repository.UserRepository, authRepo repository.AuthRepository, jwtMgr *utils.JWTManager, cfg config.AuthConfig, deps AuthServiceDeps) AuthService {
+	if deps.Denylist == nil {
+		deps.Denylist = utils.NewMemoryTokenDenylist()
+	}
+
+	disposableDomains := make(map[string]bool, len(cfg.DisposableEmailDomains))
+	for _, domain := range cfg.DisposableEmailDomains {
+		disposableDomains[strings.ToLower(strings.TrimSpace(domain))] = true
+	}
+
 	return &authServiceImpl{
 		userRepo: userRepo,
 		authRepo: authRepo,
 		jwtMgr:   jwtMgr,
+		cfg:      cfg,
+		bus:      deps.Bus,
+		denylist: deps.Denylist,
+		audit:    deps.Audit,
+
+		disposableDomains: disposableDomains,
 	}
 }
 
@@ -50,6 +102,16 @@ func (s *authServiceImpl) Register(req *model.RegisterRequest) (*model.TokenResp
 		return nil, apperrors.ErrValidation
 	}
 
+	// business logic validation: check if the email is from a disposable provider
+	if req.Email != "" && s.isDisposableEmail(req.Email) {
+		return nil, apperrors.ErrValidation
+	}
+
+	// business logic validation: check the password against the complexity policy
+	if err := utils.ValidatePasswordStrength(req.Password, s.passwordPolicy()); err != nil {
+		return nil, err
+	}
+
 	// create user
 	var username, email *string
 	if req.Username != "" {
@@ -60,6 +122,7 @@ func (s *authServiceImpl) Register(req *model.RegisterRequest) (*model.TokenResp
 	}
 
 	user := model.CreateUser(req.Name, username, email, req.BirthDate)
+	user.Role = s.defaultRole()
 
 	user, err := s.userRepo.Create(user)
 	if err != nil {
@@ -91,10 +154,38 @@ func (s *authServiceImpl) Register(req *model.RegisterRequest) (*model.TokenResp
 		return nil, err
 	}
 
+	if s.bus != nil {
+		s.bus.Publish(events.UserRegistered{
+			UserID:       user.ID,
+			Name:         user.Name,
+			Username:     req.Username,
+			RegisteredAt: user.CreatedAt,
+		})
+	}
+
 	// 5. generate JWT token
 	return s.jwtMgr.GenerateToken(user)
 }
 
+// passwordPolicy builds the complexity rules for new passwords from config
+func (s *authServiceImpl) passwordPolicy() utils.PasswordPolicy {
+	return utils.PasswordPolicy{
+		MinLength:     s.cfg.PasswordMinLength,
+		RequireDigit:  s.cfg.PasswordRequireDigit,
+		RequireUpper:  s.cfg.PasswordRequireUpper,
+		RequireSymbol: s.cfg.PasswordRequireSymbol,
+	}
+}
+
+// defaultRole resolves the configured role for new users, admin and unknown values fall back to RoleUser
+func (s *authServiceImpl) defaultRole() model.UserRole {
+	switch role := model.UserRole(s.cfg.DefaultRole); role {
+	case model.RoleUser, model.RoleModerator:
+		return role
+	}
+	return model.RoleUser
+}
+
 func (s *authServiceImpl) Login(req *model.LoginRequest) (*model.TokenResponse, error) {
 	// 1. find user
 	var user *model.User
@@ -122,9 +213,16 @@ func (s *authServiceImpl) Login(req *model.LoginRequest) (*model.TokenResponse,
 		return nil, apperrors.ErrUnauthorized
 	}
 
-	// 4. check if user is active
+	// 4. check if user is active, self-deactivated users may be reactivated by logging in
 	if !user.IsActive {
-		return nil, apperrors.ErrForbidden
+		if !s.canSelfReactivate(user) {
+			return nil, apperrors.ErrForbidden
+		}
+
+		user, err = s.userRepo.UpdateStatus(user.ID, true, nil)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	// 5. generate JWT token
@@ -137,7 +235,7 @@ func (s *authServiceImpl) RefreshToken(refreshToken string) (*model.TokenRespons
 		return nil, apperrors.ErrUnauthorized
 	}
 
-	claims, err := s.jwtMgr.ValidateToken(refreshToken)
+	claims, err := s.validateRefreshToken(refreshToken)
 	if err != nil {
 		return nil, err
 	}
@@ -155,7 +253,7 @@ func (s *authServiceImpl) RefreshToken(refreshToken string) (*model.TokenRespons
 }
 
 func (s *authServiceImpl) RefreshAccessToken(refreshToken string) (string, error) {
-	claims, err := s.jwtMgr.ValidateToken(refreshToken)
+	claims, err := s.validateRefreshToken(refreshToken)
 	if err != nil {
 		return "", err
 	}
@@ -173,8 +271,94 @@ func (s *authServiceImpl) RefreshAccessToken(refreshToken string) (string, error
 	return s.jwtMgr.GenerateAccessToken(user)
 }
 
+// ValidateToken only accepts access tokens, a refresh token can't stand in for one
 func (s *authServiceImpl) ValidateToken(tokenString string) (*model.Claims, error) {
-	return s.jwtMgr.ValidateToken(tokenString)
+	claims, err := s.jwtMgr.ValidateToken(tokenString)
+	if err != nil {
+		return nil, err
+	}
+	if claims.IsRefresh() {
+		return nil, apperrors.ErrInvalidToken
+	}
+	return claims, nil
+}
+
+// validateRefreshToken accepts only refresh tokens that haven't been revoked; an access
+// token, an impersonation one in particular, can't be exchanged for a fresh session
+func (s *authServiceImpl) validateRefreshToken(refreshToken string) (*model.Claims, error) {
+	claims, err := s.jwtMgr.ValidateToken(refreshToken)
+	if err != nil {
+		return nil, err
+	}
+	if !claims.IsRefresh() || claims.Impersonated() {
+		return nil, apperrors.ErrInvalidToken
+	}
+
+	revoked, err := s.denylist.IsRevoked(claims.ID)
+	if err != nil {
+		return nil, err
+	}
+	if revoked {
+		return nil, apperrors.ErrInvalidToken
+	}
+	return claims, nil
+}
+
+// RevokeAccessToken denies the given access token by its jti until the token's own exp, which
+// for an impersonation token is ImpersonationTTL rather than the access token lifetime. A token
+// that has already expired can't be used anyway, so it's a no-op
+func (s *authServiceImpl) RevokeAccessToken(accessToken string) error {
+	claims, err := s.jwtMgr.ValidateToken(accessToken)
+	if errors.Is(err, apperrors.ErrExpiredToken) {
+		return nil
+	}
+	if err != nil || claims.ID == "" || claims.ExpiresAt == nil {
+		return apperrors.ErrValidation
+	}
+
+	return s.denylist.Revoke(claims.ID, claims.ExpiresAt.Time)
+}
+
+// Impersonate issues a short-lived, non-refreshable access token for userID on behalf of
+// adminID, recorded in the audit log first. Other admins can't be impersonated, so the
+// token never carries more privilege than a normal login of a non-admin
+func (s *authServiceImpl) Impersonate(userID string, adminID string) (*model.ImpersonationResponse, error) {
+	if s.audit == nil {
+		return nil, errors.New("impersonation requires an audit repository")
+	}
+
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.Role.IsAdmin() {
+		return nil, apperrors.ErrForbidden
+	}
+
+	if err := s.audit.Create(&model.AuditLog{
+		ActorID:    adminID,
+		Action:     model.AuditActionImpersonate,
+		TargetType: model.AuditTargetUser,
+		TargetID:   user.ID,
+	}); err != nil {
+		return nil, err
+	}
+
+	duration := s.cfg.ImpersonationTTL
+	if duration <= 0 {
+		duration = s.jwtMgr.GetTokenDuration()
+	}
+	token, err := s.jwtMgr.GenerateImpersonationToken(user, adminID, duration)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.ImpersonationResponse{
+		AccessToken: token,
+		TokenType:   "Bearer",
+		ExpiresIn:   int64(duration.Seconds()),
+		UserID:      user.ID,
+	}, nil
 }
 
 func (s *authServiceImpl) ActivateUser(userID string) (*model.User, error) {
@@ -187,8 +371,7 @@ func (s *authServiceImpl) ActivateUser(userID string) (*model.User, error) {
 		return user, nil
 	}
 
-	user.IsActive = true
-	updatedUser, err := s.userRepo.Update(userID, user)
+	updatedUser, err := s.userRepo.UpdateStatus(userID, true, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -196,22 +379,38 @@ func (s *authServiceImpl) ActivateUser(userID string) (*model.User, error) {
 	return updatedUser, nil
 }
 
-func (s *authServiceImpl) DeactivateUser(userID string) (*model.User, error) {
+func (s *authServiceImpl) DeactivateUser(userID string, actorID string) (*model.User, error) {
 	user, err := s.userRepo.FindByID(userID)
 	if err != nil {
 		return nil, err
 	}
 
-	if !user.IsActive {
+	// record who deactivated the account, only self-deactivated accounts can be reactivated on login
+	deactivatedBy := model.DeactivatedByAdmin
+	if authz.IsOwner(actorID, userID) {
+		deactivatedBy = model.DeactivatedBySelf
+	}
+
+	// an inactive account keeps its marker, except that an admin overrides a self-deactivation
+	// so the user's next login can't undo the admin's decision
+	alreadyByAdmin := user.DeactivatedBy != nil && *user.DeactivatedBy == model.DeactivatedByAdmin
+	if !user.IsActive && (deactivatedBy == model.DeactivatedBySelf || alreadyByAdmin) {
 		return user, nil
 	}
 
-	user.IsActive = false
-	updatedUser, err := s.userRepo.Update(userID, user)
+	updatedUser, err := s.userRepo.UpdateStatus(userID, false, &deactivatedBy)
 	if err != nil {
 		return nil, err
 	}
 
+	if s.bus != nil {
+		s.bus.Publish(events.UserDeactivated{
+			UserID:        userID,
+			DeactivatedBy: deactivatedBy,
+			ActorID:       actorID,
+		})
+	}
+
 	return updatedUser, nil
 }
 
@@ -230,6 +429,39 @@ func (s *authServiceImpl) isUnder13(birthDate time.Time) bool {
 	return age < 13
 }
 
+// canSelfReactivate 檢查停用的用戶是否可以透過登入重新啟用
+func (s *authServiceImpl) canSelfReactivate(user *model.User) bool {
+	if !s.cfg.SelfReactivateOnLogin {
+		return false
+	}
+	return user.DeactivatedBy != nil && *user.DeactivatedBy == model.DeactivatedBySelf
+}
+
+// isDisposableEmail 檢查 email 網域（含子網域）是否在拋棄式信箱清單中，未啟用時一律為 false
+func (s *authServiceImpl) isDisposableEmail(email string) bool {
+	if !s.cfg.BlockDisposableEmails || len(s.disposableDomains) == 0 {
+		return false
+	}
+
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return false
+	}
+
+	domain := strings.ToLower(email[at+1:])
+	for domain != "" {
+		if s.disposableDomains[domain] {
+			return true
+		}
+		_, parent, ok := strings.Cut(domain, ".")
+		if !ok {
+			break
+		}
+		domain = parent
+	}
+	return false
+}
+
 // isReservedUsername 檢查用戶名是否為保留字
 func (s *authServiceImpl) isReservedUsername(username string) bool {
 	reservedUsernames := []string{
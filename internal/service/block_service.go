@@ -0,0 +1,88 @@
+package service
+
+import (
+	"go-gin-api-server/config"
+	"go-gin-api-server/internal/model"
+	"go-gin-api-server/internal/repository"
+	"go-gin-api-server/pkg/apperrors"
+)
+
+type BlockService interface {
+	Block(blockerID, blockedID string) error
+	Unblock(blockerID, blockedID string) error
+	ListBlocked(blockerID string) (*model.BlockedUsersResponse, error)
+	// IsBlocked reports a block between the two users in either direction
+	IsBlocked(userID, otherID string) (bool, error)
+}
+
+type blockServiceImpl struct {
+	repo     repository.BlockRepository
+	userRepo repository.UserRepository
+	cfg      config.UsersConfig
+}
+
+func NewBlockService(repo repository.BlockRepository, userRepo repository.UserRepository) BlockService {
+	return NewBlockServiceWithConfig(repo, userRepo, config.UsersConfig{MaxBlocks: 1000})
+}
+
+// NewBlockServiceWithConfig 創建使用指定封鎖上限的 BlockService
+func NewBlockServiceWithConfig(repo repository.BlockRepository, userRepo repository.UserRepository, cfg config.UsersConfig) BlockService {
+	return &blockServiceImpl{
+		repo:     repo,
+		userRepo: userRepo,
+		cfg:      cfg,
+	}
+}
+
+func (s *blockServiceImpl) Block(blockerID, blockedID string) error {
+	// business logic: users can't block themselves
+	if blockerID == blockedID {
+		return apperrors.ErrValidation
+	}
+
+	if _, err := s.userRepo.FindByID(blockedID); err != nil {
+		return err
+	}
+
+	// business logic: cap the block list, unblocking frees a slot
+	if s.cfg.MaxBlocks > 0 {
+		// blocking again is a no-op that takes no new slot, so it succeeds even at the cap
+		blocked, err := s.repo.HasBlocked(blockerID, blockedID)
+		if err != nil {
+			return err
+		}
+		if blocked {
+			return nil
+		}
+
+		count, err := s.repo.CountBlocked(blockerID)
+		if err != nil {
+			return err
+		}
+		if count >= int64(s.cfg.MaxBlocks) {
+			return apperrors.ErrLimitExceeded
+		}
+	}
+
+	return s.repo.Block(blockerID, blockedID)
+}
+
+func (s *blockServiceImpl) Unblock(blockerID, blockedID string) error {
+	if blockerID == blockedID {
+		return apperrors.ErrValidation
+	}
+
+	return s.repo.Unblock(blockerID, blockedID)
+}
+
+func (s *blockServiceImpl) ListBlocked(blockerID string) (*model.BlockedUsersResponse, error) {
+	ids, err := s.repo.ListBlockedIDs(blockerID)
+	if err != nil {
+		return nil, err
+	}
+	return &model.BlockedUsersResponse{BlockedIDs: ids}, nil
+}
+
+func (s *blockServiceImpl) IsBlocked(userID, otherID string) (bool, error) {
+	return s.repo.IsBlocked(userID, otherID)
+}
@@ -0,0 +1,198 @@
+package service
+
+import (
+	"encoding/json"
+	"go-gin-api-server/config"
+	"go-gin-api-server/internal/model"
+	"go-gin-api-server/internal/repository"
+	"io"
+	"strconv"
+	"time"
+)
+
+type ExportService interface {
+	// Export streams one page of the user's data to w as a model.UserExport document;
+	// continuation is the previous page's next token, empty for the first page
+	Export(userID string, continuation string, w io.Writer) error
+}
+
+type exportServiceImpl struct {
+	userRepo         repository.UserRepository
+	postRepo         repository.PostRepository
+	notificationRepo repository.NotificationRepository
+	cfg              config.ExportConfig
+}
+
+func NewExportService(userRepo repository.UserRepository, postRepo repository.PostRepository, notificationRepo repository.NotificationRepository) ExportService {
+	return NewExportServiceWithConfig(userRepo, postRepo, notificationRepo, config.ExportConfig{
+		BatchSize: 100,
+	})
+}
+
+// NewExportServiceWithConfig 創建使用指定批次與分頁大小的 ExportService
+func NewExportServiceWithConfig(userRepo repository.UserRepository, postRepo repository.PostRepository, notificationRepo repository.NotificationRepository, cfg config.ExportConfig) ExportService {
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 100
+	}
+	return &exportServiceImpl{
+		userRepo:         userRepo,
+		postRepo:         postRepo,
+		notificationRepo: notificationRepo,
+		cfg:              cfg,
+	}
+}
+
+// Export writes nothing when the token is invalid or the user can't be loaded, so callers
+// can still respond with an error; a failure after that leaves a truncated document
+func (s *exportServiceImpl) Export(userID string, continuation string, w io.Writer) error {
+	from := model.ExportContinuation{Section: model.ExportSectionPosts}
+	if continuation != "" {
+		var err error
+		from, err = model.DecodeExportContinuation(continuation)
+		if err != nil {
+			return err
+		}
+	}
+
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		return err
+	}
+
+	out := &exportWriter{w: w, limit: s.cfg.PageSize}
+	out.raw(`{"exported_at":`)
+	out.value(time.Now().UTC().Truncate(time.Microsecond))
+	out.raw(`,"profile":`)
+	out.value(user)
+
+	out.openArray(`,"posts":`)
+	if from.Section == model.ExportSectionPosts {
+		if err := s.writePosts(out, userID, from.Cursor); err != nil {
+			return err
+		}
+	}
+	out.raw(`]`)
+
+	out.openArray(`,"notifications":`)
+	if out.next == nil {
+		var cursor model.Cursor
+		if from.Section == model.ExportSectionNotifications {
+			cursor = from.Cursor
+		}
+		if err := s.writeNotifications(out, userID, cursor); err != nil {
+			return err
+		}
+	}
+	out.raw(`]`)
+
+	if out.next != nil {
+		out.raw(`,"next":`)
+		out.value(model.EncodeExportContinuation(*out.next))
+	}
+	out.raw(`}`)
+
+	return out.err
+}
+
+// writePosts streams the user's posts batch by batch, stopping when the page is full
+func (s *exportServiceImpl) writePosts(out *exportWriter, userID string, from model.Cursor) error {
+	var last *model.Post
+	err := s.postRepo.IteratePostsByAuthor(userID, from, s.cfg.BatchSize, func(batch []model.Post) error {
+		for i := range batch {
+			if out.full() {
+				// resume after the last post written, which may be in the previous batch
+				cursor := from
+				if last != nil {
+					cursor = model.Cursor{ID: strconv.FormatUint(last.ID, 10), CreatedAt: last.CreatedAt}
+				}
+				out.next = &model.ExportContinuation{Section: model.ExportSectionPosts, Cursor: cursor}
+				return repository.ErrStopIteration
+			}
+
+			post := batch[i]
+			post.Author = nil // the profile section already carries it
+			out.item(post)
+			last = &post
+		}
+		return out.err
+	})
+	if err != nil {
+		return err
+	}
+	return out.err
+}
+
+// writeNotifications streams the received notifications batch by batch, stopping when the page is full
+func (s *exportServiceImpl) writeNotifications(out *exportWriter, userID string, cursor model.Cursor) error {
+	for {
+		notifications, err := s.notificationRepo.List(model.NotificationListOptions{
+			UserID: userID,
+			Cursor: cursor,
+			Limit:  s.cfg.BatchSize,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, notification := range notifications {
+			if out.full() {
+				// resume after the last notification written
+				out.next = &model.ExportContinuation{Section: model.ExportSectionNotifications, Cursor: cursor}
+				return out.err
+			}
+			out.item(notification)
+			cursor = model.Cursor{ID: strconv.FormatUint(notification.ID, 10), CreatedAt: notification.CreatedAt}
+		}
+		if out.err != nil || len(notifications) < s.cfg.BatchSize {
+			return out.err
+		}
+	}
+}
+
+// exportWriter writes a JSON document piece by piece and keeps the first error
+type exportWriter struct {
+	w     io.Writer
+	err   error
+	items int // items written to the open array
+	total int // items written to all arrays, checked against limit
+	limit int // zero is unlimited
+	next  *model.ExportContinuation
+}
+
+func (e *exportWriter) raw(s string) {
+	if e.err != nil {
+		return
+	}
+	_, e.err = io.WriteString(e.w, s)
+}
+
+func (e *exportWriter) value(v interface{}) {
+	if e.err != nil {
+		return
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		e.err = err
+		return
+	}
+	_, e.err = e.w.Write(data)
+}
+
+func (e *exportWriter) openArray(key string) {
+	e.raw(key + `[`)
+	e.items = 0
+}
+
+func (e *exportWriter) item(v interface{}) {
+	if e.items > 0 {
+		e.raw(`,`)
+	}
+	e.value(v)
+	e.items++
+	e.total++
+}
+
+// full reports whether the page limit is reached
+func (e *exportWriter) full() bool {
+	return e.limit > 0 && e.total >= e.limit
+}
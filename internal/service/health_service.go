@@ -0,0 +1,91 @@
+package service
+
+import (
+	"go-gin-api-server/config"
+	"go-gin-api-server/internal/model"
+	"go-gin-api-server/internal/repository"
+	"runtime"
+
+	"github.com/gin-gonic/gin"
+)
+
+type HealthService interface {
+	// Details never fails, a dependency that can't be read marks the report degraded
+	Details() *model.HealthDetails
+}
+
+type healthServiceImpl struct {
+	repo repository.HealthRepository
+	cfg  *config.Config
+}
+
+func NewHealthService(repo repository.HealthRepository, cfg *config.Config) HealthService {
+	return &healthServiceImpl{repo: repo, cfg: cfg}
+}
+
+func (s *healthServiceImpl) Details() *model.HealthDetails {
+	details := &model.HealthDetails{
+		Status: model.HealthStatusOK,
+		Env:    s.cfg.Env,
+		Versions: map[string]string{
+			"go":  runtime.Version(),
+			"gin": gin.Version,
+		},
+		Features: s.features(),
+	}
+	degrade := func(err error) {
+		details.Status = model.HealthStatusDegraded
+		details.Errors = append(details.Errors, err.Error())
+	}
+
+	if stats, err := s.repo.PoolStats(); err != nil {
+		degrade(err)
+	} else {
+		details.Database.MaxOpenConnections = stats.MaxOpenConnections
+		details.Database.OpenConnections = stats.OpenConnections
+		details.Database.InUse = stats.InUse
+		details.Database.Idle = stats.Idle
+		details.Database.WaitCount = stats.WaitCount
+		details.Database.WaitDuration = stats.WaitDuration
+		details.Database.MaxIdleClosed = stats.MaxIdleClosed
+		details.Database.MaxLifetimeClosed = stats.MaxLifetimeClosed
+	}
+
+	if version, dirty, err := s.repo.MigrationVersion(); err != nil {
+		degrade(err)
+	} else {
+		details.Database.MigrationVersion = version
+		details.Database.MigrationDirty = dirty
+		if dirty {
+			details.Status = model.HealthStatusDegraded
+		}
+	}
+
+	if version, err := s.repo.ServerVersion(); err != nil {
+		degrade(err)
+	} else {
+		details.Versions["postgres"] = version
+	}
+
+	return details
+}
+
+// features reports the configured on/off switches, zero limits count as off
+func (s *healthServiceImpl) features() map[string]bool {
+	return map[string]bool{
+		"auth_self_reactivate_on_login":      s.cfg.Auth.SelfReactivateOnLogin,
+		"auth_refreshed_token_in_body":       s.cfg.Auth.RefreshedTokenInBody,
+		"profile_show_birth_date":            s.cfg.Profile.ShowBirthDate,
+		"profile_cache":                      s.cfg.Profile.CacheTTL > 0,
+		"profile_stale_reads":                s.cfg.Profile.StaleTTL > 0,
+		"post_stale_reads":                   s.cfg.Post.StaleTTL > 0,
+		"welcome_post":                       s.cfg.Welcome.Enabled,
+		"rate_limit_profile":                 s.cfg.RateLimit.ProfileRequests > 0,
+		"rate_limit_read":                    s.cfg.RateLimit.ReadAnonymousRequests > 0 || s.cfg.RateLimit.ReadAuthenticatedRequests > 0,
+		"rate_limit_heavy_concurrency":       s.cfg.RateLimit.HeavyConcurrency > 0,
+		"rate_limit_global_concurrency":      s.cfg.RateLimit.GlobalConcurrency > 0,
+		"security_headers":                   s.cfg.Security.HeadersEnabled,
+		"security_hsts":                      s.cfg.Security.HSTSEnabled,
+		"security_enforce_json_content_type": s.cfg.Security.EnforceJSONContentType,
+	}
+}
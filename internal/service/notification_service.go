@@ -0,0 +1,159 @@
+package service
+
+import (
+	"errors"
+	"go-gin-api-server/config"
+	"go-gin-api-server/internal/events"
+	"go-gin-api-server/internal/model"
+	"go-gin-api-server/internal/repository"
+	"go-gin-api-server/pkg/apperrors"
+	"regexp"
+	"strconv"
+)
+
+// mentionPattern matches @username using the same rules as the username validator
+var mentionPattern = regexp.MustCompile(`(?:^|[^a-zA-Z0-9_@-])@([a-zA-Z][a-zA-Z0-9_-]*)`)
+
+// defaultMaxMentionsPerPost caps fan-out from a single post when no config is given
+const defaultMaxMentionsPerPost = 10
+
+type NotificationService interface {
+	List(userID string, request model.NotificationListRequest) (*model.CursorResponse[model.Notification], error)
+	UnreadCount(userID string) (int64, error)
+	MarkRead(id uint64, userID string) error
+
+	// HandleEvent turns domain events into notifications; subscribed to the event bus
+	HandleEvent(event events.Event) error
+}
+
+type notificationServiceImpl struct {
+	repo        repository.NotificationRepository
+	userRepo    repository.UserRepository
+	maxMentions int
+}
+
+func NewNotificationService(repo repository.NotificationRepository, userRepo repository.UserRepository) NotificationService {
+	return NewNotificationServiceWithConfig(repo, userRepo, config.PostConfig{MaxMentions: defaultMaxMentionsPerPost})
+}
+
+// NewNotificationServiceWithConfig 創建依 cfg.MaxMentions 限制每篇貼文提及通知數量的 NotificationService
+func NewNotificationServiceWithConfig(repo repository.NotificationRepository, userRepo repository.UserRepository, cfg config.PostConfig) NotificationService {
+	return &notificationServiceImpl{
+		repo:        repo,
+		userRepo:    userRepo,
+		maxMentions: cfg.MaxMentions,
+	}
+}
+
+func (s *notificationServiceImpl) List(userID string, request model.NotificationListRequest) (*model.CursorResponse[model.Notification], error) {
+	request.SetDefaults()
+
+	cursor, err := decodeListCursor(request.Cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	// one extra row tells whether there is a next page
+	notifications, err := s.repo.List(model.NotificationListOptions{
+		UserID: userID,
+		Status: request.Status,
+		Type:   request.Type,
+		Cursor: cursor,
+		Limit:  request.Limit + 1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return model.NewCursorResponse(notifications, request.Limit, func(n model.Notification) model.Cursor {
+		return model.Cursor{ID: strconv.FormatUint(n.ID, 10), CreatedAt: n.CreatedAt}
+	}), nil
+}
+
+func (s *notificationServiceImpl) UnreadCount(userID string) (int64, error) {
+	return s.repo.CountUnread(userID)
+}
+
+func (s *notificationServiceImpl) MarkRead(id uint64, userID string) error {
+	return s.repo.MarkRead(id, userID)
+}
+
+func (s *notificationServiceImpl) HandleEvent(event events.Event) error {
+	switch e := event.(type) {
+	case events.PostLiked:
+		return s.notifyLike(e)
+	case events.PostCreated:
+		return s.notifyMentions(e)
+	default:
+		return nil
+	}
+}
+
+// event helper methods
+
+func (s *notificationServiceImpl) notifyLike(e events.PostLiked) error {
+	// no notification for liking your own post
+	if e.UserID == e.AuthorID {
+		return nil
+	}
+
+	postID := e.PostID
+	_, err := s.repo.Create(&model.Notification{
+		UserID:  e.AuthorID,
+		ActorID: e.UserID,
+		Type:    model.NotificationLike,
+		PostID:  &postID,
+	})
+	return err
+}
+
+func (s *notificationServiceImpl) notifyMentions(e events.PostCreated) error {
+	postID := e.PostID
+	for _, username := range extractMentions(e.Content, s.maxMentions) {
+		user, err := s.userRepo.FindByUsername(username)
+		if err != nil {
+			// mentioning an unknown username is not an error
+			if errors.Is(err, apperrors.ErrNotFound) {
+				continue
+			}
+			return err
+		}
+
+		// no notification for mentioning yourself
+		if user.ID == e.AuthorID {
+			continue
+		}
+
+		if _, err := s.repo.Create(&model.Notification{
+			UserID:  user.ID,
+			ActorID: e.AuthorID,
+			Type:    model.NotificationMention,
+			PostID:  &postID,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// extractMentions returns unique mentioned usernames in order of appearance, at most
+// limit of them; zero or less means no cap
+func extractMentions(content string, limit int) []string {
+	matches := mentionPattern.FindAllStringSubmatch(content, -1)
+
+	seen := make(map[string]struct{}, len(matches))
+	usernames := make([]string, 0, len(matches))
+	for _, match := range matches {
+		username := match[1]
+		if _, ok := seen[username]; ok {
+			continue
+		}
+		seen[username] = struct{}{}
+		usernames = append(usernames, username)
+
+		if len(usernames) == limit {
+			break
+		}
+	}
+	return usernames
+}
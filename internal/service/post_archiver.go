@@ -0,0 +1,53 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// PostArchiver periodically archives posts older than the configured retention
+type PostArchiver struct {
+	postService PostService
+	interval    time.Duration
+	logger      *zap.Logger
+	now         func() time.Time
+}
+
+// NewPostArchiver 創建定期封存舊貼文的背景工作
+func NewPostArchiver(postService PostService, interval time.Duration, logger *zap.Logger) *PostArchiver {
+	return &PostArchiver{
+		postService: postService,
+		interval:    interval,
+		logger:      logger,
+		now:         time.Now,
+	}
+}
+
+// Run archives once immediately and then on every interval until ctx is cancelled
+func (a *PostArchiver) Run(ctx context.Context) {
+	ticker := time.NewTicker(a.interval)
+	defer ticker.Stop()
+
+	for {
+		a.archive()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (a *PostArchiver) archive() {
+	archived, err := a.postService.ArchivePosts(a.now())
+	if err != nil {
+		a.logger.Error("Failed to archive posts", zap.Error(err))
+		return
+	}
+	if archived > 0 {
+		a.logger.Info("Archived posts", zap.Int64("count", archived))
+	}
+}
@@ -1,61 +1,190 @@
 package service
 
 import (
+	"errors"
+	"fmt"
+	"go-gin-api-server/config"
+	"go-gin-api-server/internal/authz"
+	"go-gin-api-server/internal/events"
 	"go-gin-api-server/internal/model"
 	"go-gin-api-server/internal/repository"
 	"go-gin-api-server/pkg/apperrors"
+	"go-gin-api-server/pkg/utils"
 	"strconv"
 	"strings"
+	"time"
+	"unicode"
 )
 
 type PostService interface {
 	Create(post *model.Post) (*model.Post, error)
-	List(request model.CursorRequest) (*model.CursorResponse[model.PostResponse], error)
-	GetByID(id uint64) (*model.PostResponse, error)
+	List(request model.PostListRequest) (*model.CursorResponse[model.PostResponse], error)
+	ListCompact(request model.PostListRequest) (*model.CursorResponse[model.PostSummary], error)
+	ListPaged(request model.PostModerationListRequest) (*model.PaginatedResponse[model.PostResponse], error)
+	GetByID(id uint64, viewerID string, viewerRole model.UserRole) (*model.PostResponse, error)
+	GetBySlug(slug string, viewerID string, viewerRole model.UserRole) (*model.PostResponse, error)
 	Update(id uint64, post *model.Post, currentUserID string) (*model.Post, error)
+	UpdateIfMatch(id uint64, post *model.Post, currentUserID string, ifMatch string) (*model.Post, error)
+	Replace(id uint64, post *model.Post, currentUserID string) (*model.Post, error)
+	ReplaceIfMatch(id uint64, post *model.Post, currentUserID string, ifMatch string) (*model.Post, error)
 	Delete(id uint64, currentUserID string) error
+	ValidateContent(content string) []error
+	Preview(post *model.Post) (*model.PostPreviewResponse, error)
+	Limits() model.PostLimitsResponse
+	ListRevisions(id uint64, viewerID string, viewerRole model.UserRole) ([]model.PostRevision, error)
+
+	// Retention
+	ArchivePosts(now time.Time) (int64, error)
+
+	// Moderation
+	SetHidden(id uint64, hidden bool, callerRole model.UserRole) (*model.Post, error)
+	AdminDelete(id uint64, actorID string, role model.UserRole) error
 }
 
+const (
+	maxSlugLength = 64
+	defaultSlug   = "post" // content without any ASCII letters or digits
+
+	defaultPreviewLength = 80
+)
+
 type postServiceImpl struct {
-	repo repository.PostRepository
+	repo     repository.PostRepository
+	userRepo repository.UserRepository // resolves mentions in Preview, optional
+	cfg      config.PostConfig
+	bus      *events.Bus
+
+	// stalePosts serves GetByID from the last good read while the database is down, nil disables it
+	stalePosts *staleFallback[*model.Post]
 }
 
 func NewPostService(repo repository.PostRepository) PostService {
-	return &postServiceImpl{repo: repo}
+	return NewPostServiceWithConfig(repo, config.PostConfig{
+		ContentMinLength: 10,
+		ContentMaxLength: 255,
+		TitleMaxLength:   100,
+		PreviewLength:    defaultPreviewLength,
+		MaxMentions:      defaultMaxMentionsPerPost,
+	})
+}
+
+// NewPostServiceWithConfig 創建使用指定內容長度限制的 PostService
+func NewPostServiceWithConfig(repo repository.PostRepository, cfg config.PostConfig) PostService {
+	return NewPostServiceWithEvents(repo, cfg, nil)
+}
+
+// NewPostServiceWithEvents 創建會在寫入成功後發布領域事件的 PostService（bus 為 nil 則不發布）
+func NewPostServiceWithEvents(repo repository.PostRepository, cfg config.PostConfig, bus *events.Bus) PostService {
+	return NewPostServiceWithUsers(repo, nil, cfg, bus)
+}
+
+// NewPostServiceWithUsers 創建可在預覽時將 @提及 解析為使用者的 PostService（userRepo 為 nil 則不解析）
+func NewPostServiceWithUsers(repo repository.PostRepository, userRepo repository.UserRepository, cfg config.PostConfig, bus *events.Bus) PostService {
+	return &postServiceImpl{
+		repo:       repo,
+		userRepo:   userRepo,
+		cfg:        cfg,
+		bus:        bus,
+		stalePosts: newStaleFallback[*model.Post](cfg.StaleTTL),
+	}
 }
 
 func (s *postServiceImpl) Create(post *model.Post) (*model.Post, error) {
-	// business logic: validate content
-	if err := s.validateContent(post.Content); err != nil {
+	if err := s.preparePost(post); err != nil {
 		return nil, err
 	}
 
-	// business logic: validate sensitive words
-	if s.containsSensitiveWords(post.Content) {
-		return nil, apperrors.ErrPostContentSensitiveWords
+	created, err := s.repo.Create(post)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.bus != nil {
+		s.bus.Publish(events.PostCreated{
+			PostID:    created.ID,
+			AuthorID:  created.AuthorID,
+			Content:   created.Content,
+			CreatedAt: created.CreatedAt,
+		})
 	}
 
-	return s.repo.Create(post)
+	return created, nil
 }
 
-func (s *postServiceImpl) List(request model.CursorRequest) (*model.CursorResponse[model.PostResponse], error) {
-	// Set defaults
-	request.SetDefaults()
+// preparePost validates and normalizes a new post in place exactly as it will be stored;
+// shared by Create and Preview so a preview never disagrees with the real thing
+func (s *postServiceImpl) preparePost(post *model.Post) error {
+	// visibility is only changed through SetHidden
+	post.Hidden = false
 
-	// decode cursor
-	var cursor model.Cursor
-	if request.Cursor != "" {
-		var err error
-		cursor, err = model.DecodeCursor(request.Cursor)
-		if err != nil {
-			return nil, apperrors.ErrValidation
+	// business logic: validate content and the optional title
+	if err := s.validatePost(post, true); err != nil {
+		return err
+	}
+
+	// business logic: in reject mode too many mentions fail instead of being truncated
+	// by the notification fan-out
+	if s.cfg.RejectExcessMentions && s.cfg.MaxMentions > 0 &&
+		len(extractMentions(post.Content, s.cfg.MaxMentions+1)) > s.cfg.MaxMentions {
+		return fmt.Errorf("%w: %w", apperrors.ErrValidation, apperrors.ErrPostTooManyMentions)
+	}
+
+	// the repository resolves collisions by appending a suffix
+	slug := utils.Slugify(post.Content, maxSlugLength)
+	if slug == "" {
+		slug = defaultSlug
+	}
+	post.Slug = &slug
+	return nil
+}
+
+// Preview runs a draft through the create pipeline without storing it. The returned error
+// is the validation failure Create would report, the preview is filled in either way
+func (s *postServiceImpl) Preview(post *model.Post) (*model.PostPreviewResponse, error) {
+	draft := *post
+	err := s.preparePost(&draft)
+
+	preview := &model.PostPreviewResponse{
+		Title:    draft.Title,
+		Content:  draft.Content,
+		Mentions: []model.MentionPreview{},
+		Valid:    err == nil,
+	}
+	if err == nil {
+		preview.Slug = *draft.Slug
+	}
+
+	// the same usernames, in the same order and cap, as the notification fan-out
+	for _, username := range extractMentions(draft.Content, s.cfg.MaxMentions) {
+		mention := model.MentionPreview{Username: username}
+		if s.userRepo != nil {
+			user, lookupErr := s.userRepo.FindByUsername(username)
+			switch {
+			case lookupErr == nil:
+				mention.UserID = user.ID
+			case !errors.Is(lookupErr, apperrors.ErrNotFound):
+				return nil, lookupErr
+			}
 		}
+		preview.Mentions = append(preview.Mentions, mention)
 	}
 
-	opts := model.PostListOptions{
-		Limit:    request.Limit + 1, // Request one extra to check if there are more results
-		AuthorID: request.AuthorID,
-		Cursor:   cursor,
+	return preview, err
+}
+
+// List returns one keyset page ordered by created_at DESC, id DESC
+//
+// HasMore is computed by fetching one extra row and is a snapshot: if that row (or
+// anything after the cursor) is deleted before the next request, the next page may be
+// shorter or empty with HasMore false. The cursor only carries (created_at, id), so the
+// anchor row itself may be deleted too; pages never repeat or skip surviving posts.
+func (s *postServiceImpl) List(request model.PostListRequest) (*model.CursorResponse[model.PostResponse], error) {
+	// Set defaults
+	request.SetDefaults()
+
+	opts, err := listOptions(request)
+	if err != nil {
+		return nil, err
 	}
 
 	posts, err := s.repo.List(opts)
@@ -79,93 +208,469 @@ func (s *postServiceImpl) List(request model.CursorRequest) (*model.CursorRespon
 		})
 	}
 
-	responses := make([]model.PostResponse, 0, len(posts))
-
-	for _, post := range posts {
-		response := model.PostResponse{
-			Post: post,
-		}
-		if post.Author != nil {
-			response.Author = &model.AuthorSummary{
-				ID:       post.Author.ID,
-				Name:     post.Author.Name,
-				Username: post.Author.Username,
-			}
+	data := model.NewPostResponses(posts)
+	if opts.Search != "" {
+		// search results carry a preview for result cards
+		for i := range data {
+			data[i].ContentPreview = utils.Excerpt(data[i].Content, s.previewLength())
 		}
-		responses = append(responses, response)
 	}
 
 	return &model.CursorResponse[model.PostResponse]{
-		Data:    responses,
+		Data:    data,
 		Next:    nextCursor,
 		HasMore: hasMore,
 	}, nil
 }
 
-func (s *postServiceImpl) GetByID(id uint64) (*model.PostResponse, error) {
-	post, err := s.repo.FindByID(id)
+// ListCompact is List for the compact view: summaries without the author object, the
+// content cut to PreviewLength characters
+func (s *postServiceImpl) ListCompact(request model.PostListRequest) (*model.CursorResponse[model.PostSummary], error) {
+	request.SetDefaults()
+
+	opts, err := listOptions(request)
 	if err != nil {
 		return nil, err
 	}
-	response := model.PostResponse{
-		Post: *post,
+
+	previewLength := s.previewLength()
+
+	// one character past the preview tells whether the content was cut
+	posts, err := s.repo.ListSummaries(opts, previewLength+1)
+	if err != nil {
+		return nil, err
+	}
+
+	summaries := make([]model.PostSummary, len(posts))
+	for i, post := range posts {
+		summaries[i] = model.PostSummary{
+			ID:             post.ID,
+			ContentPreview: utils.Excerpt(post.Content, previewLength),
+			AuthorID:       post.AuthorID,
+			CreatedAt:      post.CreatedAt,
+			UpdatedAt:      post.UpdatedAt,
+		}
+	}
+
+	return model.NewCursorResponse(summaries, request.Limit, func(p model.PostSummary) model.Cursor {
+		return model.Cursor{ID: strconv.FormatUint(p.ID, 10), CreatedAt: p.CreatedAt}
+	}), nil
+}
+
+// previewLength is the configured preview length, the default when unset
+func (s *postServiceImpl) previewLength() int {
+	if s.cfg.PreviewLength <= 0 {
+		return defaultPreviewLength
+	}
+	return s.cfg.PreviewLength
+}
+
+// listOptions maps a public list request to repository options, shared by List and
+// ListCompact; status=archived narrows the list to the caller's own archived posts
+func listOptions(request model.PostListRequest) (model.PostListOptions, error) {
+	cursor, err := decodeListCursor(request.Cursor)
+	if err != nil {
+		return model.PostListOptions{}, err
+	}
+
+	opts := model.PostListOptions{
+		Limit:         request.Limit + 1, // Request one extra to check if there are more results
+		AuthorID:      request.AuthorID,
+		Search:        strings.TrimSpace(request.Search),
+		ViewerID:      request.ViewerID,
+		Cursor:        cursor,
+		Ascending:     request.Query.Ascending(),
+		CreatedAfter:  request.Query.CreatedAfter,
+		CreatedBefore: request.Query.CreatedBefore,
 	}
-	if post.Author != nil {
-		response.Author = &model.AuthorSummary{
-			ID:       post.Author.ID,
-			Name:     post.Author.Name,
-			Username: post.Author.Username,
+
+	if request.Status == model.PostStatusArchived {
+		// archived posts are only listed to their author
+		if request.ViewerID == "" {
+			return model.PostListOptions{}, apperrors.ErrUnauthorized
+		}
+		if request.AuthorID != nil && *request.AuthorID != request.ViewerID {
+			return model.PostListOptions{}, apperrors.ErrForbidden
 		}
+		opts.AuthorID = &request.ViewerID
+		opts.ArchivedOnly = true
 	}
 
+	return opts, nil
+}
+
+// decodeListCursor decodes a client cursor, empty means the first page
+func decodeListCursor(encoded string) (model.Cursor, error) {
+	if encoded == "" {
+		return model.Cursor{}, nil
+	}
+	cursor, err := model.DecodeCursor(encoded)
+	if err != nil {
+		return model.Cursor{}, apperrors.ErrValidation
+	}
+	return cursor, nil
+}
+
+// ListPaged is the moderation listing; the public List never includes hidden posts
+func (s *postServiceImpl) ListPaged(request model.PostModerationListRequest) (*model.PaginatedResponse[model.PostResponse], error) {
+	// Set defaults
+	request.SetDefaults()
+
+	// soft-deleted posts are for admin audit/restore, moderators only see live ones
+	if request.IncludeDeleted && request.CallerRole != model.RoleAdmin {
+		return nil, apperrors.ErrForbidden
+	}
+
+	// hidden posts are included by default and flagged with a warning
+	opts := model.PostPageOptions{
+		AuthorID:       request.AuthorID,
+		Offset:         request.GetOffset(),
+		Limit:          request.PageSize,
+		IncludeHidden:  request.Status != model.PostStatusVisible,
+		HiddenOnly:     request.Status == model.PostStatusHidden,
+		IncludeDeleted: request.IncludeDeleted,
+	}
+
+	posts, total, err := s.repo.ListPagedWithCount(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return model.NewPaginatedResponse(model.NewPostResponses(posts), int(total), request.Page, request.PageSize), nil
+}
+
+// GetByID returns a visible post; with StaleTTL set, a database failure serves the last
+// good read flagged Stale, or ErrUnavailable when there is none
+func (s *postServiceImpl) GetByID(id uint64, viewerID string, viewerRole model.UserRole) (*model.PostResponse, error) {
+	post, stale, err := s.stalePosts.read(strconv.FormatUint(id, 10), func() (*model.Post, error) {
+		return s.repo.FindByID(id)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := s.toVisiblePostResponse(post, viewerID, viewerRole)
+	if err != nil {
+		return nil, err
+	}
+	response.Stale = stale
+	return response, nil
+}
+
+func (s *postServiceImpl) GetBySlug(slug string, viewerID string, viewerRole model.UserRole) (*model.PostResponse, error) {
+	post, err := s.repo.FindBySlug(slug)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.toVisiblePostResponse(post, viewerID, viewerRole)
+}
+
+func (s *postServiceImpl) toVisiblePostResponse(post *model.Post, viewerID string, viewerRole model.UserRole) (*model.PostResponse, error) {
+	// hidden and archived posts don't exist for the public, only the author and
+	// moderators can see them
+	if (post.Hidden || post.Archived) && !authz.IsOwner(viewerID, post.AuthorID) && !viewerRole.CanModerate() {
+		return nil, apperrors.ErrNotFound
+	}
+
+	response := model.NewPostResponse(*post)
+
 	return &response, nil
 }
 
 func (s *postServiceImpl) Update(id uint64, post *model.Post, currentUserID string) (*model.Post, error) {
+	if s.cfg.RequireIfMatch {
+		return nil, apperrors.ErrPreconditionRequired
+	}
+	return s.update(id, post, currentUserID, "")
+}
+
+// UpdateIfMatch is Update guarded by an If-Match header value, a stale ETag returns
+// ErrPreconditionFailed so concurrent editors don't overwrite each other
+func (s *postServiceImpl) UpdateIfMatch(id uint64, post *model.Post, currentUserID string, ifMatch string) (*model.Post, error) {
+	if ifMatch == "" {
+		return s.Update(id, post, currentUserID)
+	}
+	return s.update(id, post, currentUserID, ifMatch)
+}
+
+func (s *postServiceImpl) update(id uint64, post *model.Post, currentUserID string, ifMatch string) (*model.Post, error) {
 	// business logic: validate permission
 	if err := s.repo.CheckPermission(id, currentUserID); err != nil {
 		return nil, err
 	}
 
-	// business logic: validate content
-	if post.Content != "" {
-		if err := s.validateContent(post.Content); err != nil {
-			return nil, err
-		}
+	// visibility is only changed through SetHidden (false is skipped by the struct update)
+	post.Hidden = false
+	// slugs are permanent links
+	post.Slug = nil
 
-		if s.containsSensitiveWords(post.Content) {
-			return nil, apperrors.ErrPostContentSensitiveWords
-		}
+	// business logic: validate content and title
+	if err := s.validatePost(post, post.Content != ""); err != nil {
+		return nil, err
 	}
 
+	// no-op when nothing actually changes, avoids a write, an UpdatedAt bump and a revision
+	current, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if normalizeContent(post.Content) == normalizeContent(current.Content) {
+		post.Content = "" // unchanged, skipped by the struct update
+	}
+	if post.Title != nil && current.Title != nil && *post.Title == *current.Title {
+		post.Title = nil
+	}
+	if post.Content == "" && post.Title == nil {
+		return unchanged(current, ifMatch)
+	}
+
+	s.stalePosts.forget(strconv.FormatUint(id, 10))
+	if ifMatch != "" {
+		return s.repo.UpdateIfMatch(id, post, ifMatch)
+	}
 	return s.repo.Update(id, post)
 }
 
+func (s *postServiceImpl) Replace(id uint64, post *model.Post, currentUserID string) (*model.Post, error) {
+	if s.cfg.RequireIfMatch {
+		return nil, apperrors.ErrPreconditionRequired
+	}
+	return s.replace(id, post, currentUserID, "")
+}
+
+// ReplaceIfMatch is Replace guarded by an If-Match header value like UpdateIfMatch
+func (s *postServiceImpl) ReplaceIfMatch(id uint64, post *model.Post, currentUserID string, ifMatch string) (*model.Post, error) {
+	if ifMatch == "" {
+		return s.Replace(id, post, currentUserID)
+	}
+	return s.replace(id, post, currentUserID, ifMatch)
+}
+
+// replace is the full-replacement counterpart of update: every editable field is
+// written, so content is required rather than skipped when empty
+func (s *postServiceImpl) replace(id uint64, post *model.Post, currentUserID string, ifMatch string) (*model.Post, error) {
+	// business logic: validate permission
+	if err := s.repo.CheckPermission(id, currentUserID); err != nil {
+		return nil, err
+	}
+
+	// business logic: validate content and title
+	if err := s.validatePost(post, true); err != nil {
+		return nil, err
+	}
+
+	// no-op when nothing actually changes, avoids a write, an UpdatedAt bump and a revision
+	current, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if normalizeContent(post.Content) == normalizeContent(current.Content) && sameTitle(post.Title, current.Title) {
+		return unchanged(current, ifMatch)
+	}
+
+	// only the editable fields are carried over, the repository writes just those columns
+	replacement := &model.Post{Title: post.Title, Content: post.Content}
+	s.stalePosts.forget(strconv.FormatUint(id, 10))
+	if ifMatch != "" {
+		return s.repo.ReplaceIfMatch(id, replacement, ifMatch)
+	}
+	return s.repo.Replace(id, replacement)
+}
+
+// unchanged answers an edit that writes nothing; a write checks If-Match against the locked
+// row in the repository, here there is no write so the tag is checked against current
+func unchanged(current *model.Post, ifMatch string) (*model.Post, error) {
+	if ifMatch != "" && !current.MatchesETag(ifMatch) {
+		return nil, apperrors.ErrPreconditionFailed
+	}
+	return current, nil
+}
+
 func (s *postServiceImpl) Delete(id uint64, currentUserID string) error {
 	// business logic: validate permission
 	if err := s.repo.CheckPermission(id, currentUserID); err != nil {
 		return err
 	}
 
+	s.stalePosts.forget(strconv.FormatUint(id, 10))
 	return s.repo.Delete(id)
 }
 
+func (s *postServiceImpl) ListRevisions(id uint64, viewerID string, viewerRole model.UserRole) ([]model.PostRevision, error) {
+	post, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	// business logic: edit history is only visible to the author and moderators; a post the
+	// viewer can't read at all is not found, as in GetByID, so its existence doesn't leak
+	if !authz.IsOwner(viewerID, post.AuthorID) && !viewerRole.CanModerate() {
+		if post.Hidden || post.Archived {
+			return nil, apperrors.ErrNotFound
+		}
+		return nil, apperrors.ErrForbidden
+	}
+
+	return s.repo.ListRevisions(id)
+}
+
+// ArchivePosts archives the posts older than ArchiveAfter as of now and returns how many;
+// a no-op when ArchiveAfter is zero. The caller passes the clock so jobs and tests agree
+func (s *postServiceImpl) ArchivePosts(now time.Time) (int64, error) {
+	if s.cfg.ArchiveAfter <= 0 {
+		return 0, nil
+	}
+
+	archived, err := s.repo.ArchiveOlderThan(now.Add(-s.cfg.ArchiveAfter))
+	if archived > 0 {
+		// which posts were archived isn't known, so none of the remembered ones can be trusted
+		s.stalePosts.forgetAll()
+	}
+	return archived, err
+}
+
+func (s *postServiceImpl) SetHidden(id uint64, hidden bool, callerRole model.UserRole) (*model.Post, error) {
+	// business logic: only moderators and admins can change visibility
+	if !callerRole.CanModerate() {
+		return nil, apperrors.ErrForbidden
+	}
+
+	s.stalePosts.forget(strconv.FormatUint(id, 10))
+	return s.repo.SetHidden(id, hidden)
+}
+
+func (s *postServiceImpl) AdminDelete(id uint64, actorID string, role model.UserRole) error {
+	// business logic: moderators and admins can remove any post, regardless of ownership
+	if !role.CanModerate() {
+		return apperrors.ErrForbidden
+	}
+
+	s.stalePosts.forget(strconv.FormatUint(id, 10))
+	return s.repo.DeleteWithAudit(id, &model.AuditLog{
+		ActorID:    actorID,
+		Action:     model.AuditActionPostDelete,
+		TargetType: model.AuditTargetPost,
+		TargetID:   strconv.FormatUint(id, 10),
+	})
+}
+
 // business logic validation helper methods
 
+// Limits reports the configured content bounds used by ValidateContent
+func (s *postServiceImpl) Limits() model.PostLimitsResponse {
+	return model.PostLimitsResponse{
+		ContentMin: s.cfg.ContentMinLength,
+		ContentMax: s.cfg.ContentMaxLength,
+		TitleMax:   s.cfg.TitleMaxLength,
+	}
+}
+
+// ValidateContent runs every content check used by Create and Update and returns all failures
+func (s *postServiceImpl) ValidateContent(content string) []error {
+	var errs []error
+
+	if err := s.validateContent(content); err != nil {
+		errs = append(errs, err)
+	}
+
+	if s.containsControlChars(content) {
+		errs = append(errs, apperrors.ErrPostContentControlChars)
+	}
+
+	if s.containsSensitiveWords(content) {
+		errs = append(errs, apperrors.ErrPostContentSensitiveWords)
+	}
+
+	return errs
+}
+
+// validatePost checks content (when checkContent) and normalizes the title. By default the
+// first failure is returned; with CollectValidationErrors every failure is wrapped in
+// one *apperrors.ValidationErrors
+func (s *postServiceImpl) validatePost(post *model.Post, checkContent bool) error {
+	var errs []error
+	if checkContent {
+		errs = s.ValidateContent(post.Content)
+		if len(errs) > 0 && !s.cfg.CollectValidationErrors {
+			return errs[0]
+		}
+	}
+
+	if err := s.normalizeTitle(post); err != nil {
+		errs = append(errs, err)
+	}
+
+	switch {
+	case len(errs) == 0:
+		return nil
+	case !s.cfg.CollectValidationErrors:
+		return errs[0]
+	default:
+		return &apperrors.ValidationErrors{Errs: errs}
+	}
+}
+
 func (s *postServiceImpl) validateContent(content string) error {
 	content = strings.TrimSpace(content)
 
-	if len(content) < 10 {
+	if len(content) < s.cfg.ContentMinLength {
 		return apperrors.ErrPostContentTooShort
 	}
 
-	if len(content) > 255 {
+	if len(content) > s.cfg.ContentMaxLength {
 		return apperrors.ErrPostContentTooLong
 	}
 
 	return nil
 }
 
+// normalizeTitle trims the optional title in place and validates it; nil is left alone
+func (s *postServiceImpl) normalizeTitle(post *model.Post) error {
+	if post.Title == nil {
+		return nil
+	}
+
+	title := strings.TrimSpace(*post.Title)
+	if title == "" {
+		return apperrors.ErrPostTitleEmpty
+	}
+	if s.cfg.TitleMaxLength > 0 && len(title) > s.cfg.TitleMaxLength {
+		return apperrors.ErrPostTitleTooLong
+	}
+	// unlike content, a title is a single line
+	if strings.IndexFunc(title, unicode.IsControl) >= 0 {
+		return apperrors.ErrPostTitleControlChars
+	}
+
+	post.Title = &title
+	return nil
+}
+
+// sameTitle reports whether two optional titles are equal, nil only equals nil
+func sameTitle(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// normalizeContent content compared for change detection
+func normalizeContent(content string) string {
+	return strings.TrimSpace(content)
+}
+
+// containsControlChars line breaks and tabs are allowed
+func (s *postServiceImpl) containsControlChars(content string) bool {
+	for _, r := range content {
+		if unicode.IsControl(r) && r != '\n' && r != '\r' && r != '\t' {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (s *postServiceImpl) containsSensitiveWords(content string) bool {
 	sensitiveWords := []string{
 		"violence",
@@ -0,0 +1,62 @@
+package service
+
+import (
+	"go-gin-api-server/internal/model"
+	"go-gin-api-server/internal/repository"
+	"strconv"
+)
+
+type ReportService interface {
+	Create(postID uint64, reporterID string, req model.CreateReportRequest) (*model.Report, error)
+	List(request model.ReportListRequest) (*model.CursorResponse[model.Report], error)
+}
+
+type reportServiceImpl struct {
+	repo     repository.ReportRepository
+	postRepo repository.PostRepository
+}
+
+func NewReportService(repo repository.ReportRepository, postRepo repository.PostRepository) ReportService {
+	return &reportServiceImpl{
+		repo:     repo,
+		postRepo: postRepo,
+	}
+}
+
+func (s *reportServiceImpl) Create(postID uint64, reporterID string, req model.CreateReportRequest) (*model.Report, error) {
+	// business logic: the reported post must exist
+	if _, err := s.postRepo.FindByID(postID); err != nil {
+		return nil, err
+	}
+
+	// duplicate reports are rejected by the unique index (ErrConflict)
+	return s.repo.Create(&model.Report{
+		ReporterID: reporterID,
+		PostID:     postID,
+		Reason:     req.Reason,
+		Status:     model.ReportPending,
+	})
+}
+
+func (s *reportServiceImpl) List(request model.ReportListRequest) (*model.CursorResponse[model.Report], error) {
+	request.SetDefaults()
+
+	cursor, err := decodeListCursor(request.Cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	// one extra row tells whether there is a next page
+	reports, err := s.repo.List(model.ReportListOptions{
+		Status: request.Status,
+		Cursor: cursor,
+		Limit:  request.Limit + 1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return model.NewCursorResponse(reports, request.Limit, func(r model.Report) model.Cursor {
+		return model.Cursor{ID: strconv.FormatUint(r.ID, 10), CreatedAt: r.CreatedAt}
+	}), nil
+}
@@ -0,0 +1,53 @@
+package service
+
+import (
+	"go-gin-api-server/internal/model"
+	"go-gin-api-server/internal/repository"
+	"go-gin-api-server/pkg/apperrors"
+)
+
+type RoleRequestService interface {
+	Create(userID string, req model.CreateRoleRequestRequest) (*model.RoleRequest, error)
+	Approve(id uint64, reviewerID string, reviewerRole model.UserRole) (*model.RoleRequest, error)
+}
+
+type roleRequestServiceImpl struct {
+	repo     repository.RoleRequestRepository
+	userRepo repository.UserRepository
+}
+
+func NewRoleRequestService(repo repository.RoleRequestRepository, userRepo repository.UserRepository) RoleRequestService {
+	return &roleRequestServiceImpl{
+		repo:     repo,
+		userRepo: userRepo,
+	}
+}
+
+func (s *roleRequestServiceImpl) Create(userID string, req model.CreateRoleRequestRequest) (*model.RoleRequest, error) {
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// business logic: only a role above the current one can be requested
+	if req.Role.Rank() <= user.Role.Rank() {
+		return nil, apperrors.ErrValidation
+	}
+
+	// a second pending request is rejected by the unique index (ErrConflict)
+	return s.repo.Create(&model.RoleRequest{
+		UserID: userID,
+		Role:   req.Role,
+		Reason: req.Reason,
+		Status: model.RoleRequestPending,
+	})
+}
+
+func (s *roleRequestServiceImpl) Approve(id uint64, reviewerID string, reviewerRole model.UserRole) (*model.RoleRequest, error) {
+	// permission check: only admins can grant roles
+	if !reviewerRole.IsAdmin() {
+		return nil, apperrors.ErrForbidden
+	}
+
+	return s.repo.Approve(id, reviewerID)
+}
@@ -0,0 +1,61 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"go-gin-api-server/pkg/apperrors"
+	"go-gin-api-server/pkg/cache"
+	"time"
+)
+
+// staleFallback remembers the last good result of a read so it can stand in while the
+// database is unavailable; a nil *staleFallback disables the fallback
+type staleFallback[V any] struct {
+	lastGood cache.Cache[V]
+}
+
+// newStaleFallback keeps results for ttl, zero or less returns nil
+func newStaleFallback[V any](ttl time.Duration) *staleFallback[V] {
+	if ttl <= 0 {
+		return nil
+	}
+	return &staleFallback[V]{lastGood: cache.NewMemoryCache[V](ttl)}
+}
+
+// read runs load and remembers a success under key. Any failure other than ErrNotFound
+// is taken as the database being unavailable: the remembered value is returned with
+// stale set, or ErrUnavailable when there is none.
+func (f *staleFallback[V]) read(key string, load func() (V, error)) (value V, stale bool, err error) {
+	value, err = load()
+	if f == nil {
+		return value, false, err
+	}
+
+	switch {
+	case err == nil:
+		f.lastGood.Set(key, value)
+		return value, false, nil
+	case errors.Is(err, apperrors.ErrNotFound):
+		f.lastGood.Delete(key)
+		return value, false, err
+	}
+
+	if cached, ok := f.lastGood.Get(key); ok {
+		return cached, true, nil
+	}
+	return value, false, fmt.Errorf("%w: %v", apperrors.ErrUnavailable, err)
+}
+
+// forget drops the value remembered under key, so e.g. a deleted resource isn't served
+func (f *staleFallback[V]) forget(key string) {
+	if f != nil {
+		f.lastGood.Delete(key)
+	}
+}
+
+// forgetAll drops every remembered value, for writes that don't say which keys they touched
+func (f *staleFallback[V]) forgetAll() {
+	if f != nil {
+		f.lastGood.Clear()
+	}
+}
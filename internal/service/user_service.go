@@ -1,9 +1,14 @@
 package service
 
 import (
+	"errors"
+	"go-gin-api-server/config"
 	"go-gin-api-server/internal/model"
 	"go-gin-api-server/internal/repository"
 	"go-gin-api-server/pkg/apperrors"
+	"go-gin-api-server/pkg/cache"
+	"strings"
+	"sync"
 	"time"
 )
 
@@ -13,6 +18,8 @@ type UserService interface {
 	GetUserByUsername(username string) (*model.User, error)
 	GetUserByEmail(email string) (*model.User, error)
 	GetUserProfile(username string) (*model.UserProfile, error)
+	GetUserProfiles(ids []string) ([]model.UserProfile, error)
+	ToUserProfile(user *model.User) *model.UserProfile
 	UpdateUserProfile(userID string, req model.UpdateUserProfileRequest) (*model.User, error)
 
 	// Admin operations
@@ -21,11 +28,38 @@ type UserService interface {
 
 type userServiceImpl struct {
 	repo repository.UserRepository
+	cfg  config.ProfileConfig
+
+	// profileCache caches public profiles by username; nil disables caching
+	profileCache cache.Cache[*model.UserProfile]
+	// profileKeys tracks userID -> cached username for invalidation
+	profileKeys sync.Map
+	// staleProfiles serves profiles from the last good read while the database is down, nil disables it
+	staleProfiles *staleFallback[*model.UserProfile]
 }
 
 func NewUserService(repo repository.UserRepository) UserService {
+	return NewUserServiceWithConfig(repo, config.ProfileConfig{
+		ShowBirthDate: true,
+	})
+}
+
+// NewUserServiceWithConfig 創建使用指定公開資料配置的 UserService
+func NewUserServiceWithConfig(repo repository.UserRepository, cfg config.ProfileConfig) UserService {
+	var profileCache cache.Cache[*model.UserProfile]
+	if cfg.CacheTTL > 0 {
+		profileCache = cache.NewMemoryCache[*model.UserProfile](cfg.CacheTTL)
+	}
+	return NewUserServiceWithCache(repo, cfg, profileCache)
+}
+
+// NewUserServiceWithCache 創建使用指定公開資料快取的 UserService（傳入 nil 則不快取）
+func NewUserServiceWithCache(repo repository.UserRepository, cfg config.ProfileConfig, profileCache cache.Cache[*model.UserProfile]) UserService {
 	return &userServiceImpl{
-		repo: repo,
+		repo:          repo,
+		cfg:           cfg,
+		profileCache:  profileCache,
+		staleProfiles: newStaleFallback[*model.UserProfile](cfg.StaleTTL),
 	}
 }
 
@@ -42,15 +76,85 @@ func (s *userServiceImpl) GetUserByEmail(email string) (*model.User, error) {
 }
 
 func (s *userServiceImpl) GetUserProfile(username string) (*model.UserProfile, error) {
-	user, err := s.repo.FindByUsername(username)
+	if s.profileCache != nil {
+		if profile, ok := s.profileCache.Get(username); ok {
+			return profile, nil
+		}
+	}
+
+	profile, stale, err := s.staleProfiles.read(username, func() (*model.UserProfile, error) {
+		user, err := s.repo.FindByUsername(username)
+		if err != nil {
+			return nil, err
+		}
+		if s.profileCache != nil || s.staleProfiles != nil {
+			s.profileKeys.Store(user.ID, username)
+		}
+		return s.ToUserProfile(user), nil
+	})
 	if err != nil {
 		return nil, err
 	}
-	return &model.UserProfile{
-		Name:      user.Name,
-		Username:  user.Username,
-		BirthDate: user.BirthDate,
-	}, nil
+	if stale {
+		// the remembered profile is shared, flag a copy
+		staleProfile := *profile
+		staleProfile.Stale = true
+		return &staleProfile, nil
+	}
+
+	if s.profileCache != nil {
+		s.profileCache.Set(username, profile)
+	}
+	return profile, nil
+}
+
+// GetUserProfiles returns the public profiles of the given users in request order;
+// duplicate and unknown IDs are dropped. IDs are matched lowercased, as Postgres returns them
+func (s *userServiceImpl) GetUserProfiles(ids []string) ([]model.UserProfile, error) {
+	unique := make([]string, 0, len(ids))
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		id = strings.ToLower(id)
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+
+	found, err := s.repo.FindProfilesByIDs(unique)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[string]model.UserProfile, len(found))
+	for _, profile := range found {
+		// 生日是否公開由部署配置決定
+		if !s.cfg.ShowBirthDate {
+			profile.BirthDate = nil
+		}
+		byID[profile.ID] = profile
+	}
+
+	profiles := make([]model.UserProfile, 0, len(found))
+	for _, id := range unique {
+		if profile, ok := byID[id]; ok {
+			profiles = append(profiles, profile)
+		}
+	}
+	return profiles, nil
+}
+
+// ToUserProfile reduces a full user record to its public profile
+func (s *userServiceImpl) ToUserProfile(user *model.User) *model.UserProfile {
+	profile := &model.UserProfile{
+		Name:     user.Name,
+		Username: user.Username,
+	}
+	// 生日是否公開由部署配置決定
+	if s.cfg.ShowBirthDate {
+		profile.BirthDate = user.BirthDate
+	}
+	return profile
 }
 
 func (s *userServiceImpl) CreateUser(name string, username, email *string, birthDate *time.Time) (*model.User, error) {
@@ -79,16 +183,57 @@ func (s *userServiceImpl) UpdateUserProfile(userID string, req model.UpdateUserP
 		}
 	}
 
+	// business logic validation: if updating username, check reserved names and uniqueness
+	if req.Username != nil {
+		if s.isReservedUsername(*req.Username) {
+			return nil, apperrors.ErrValidation
+		}
+
+		existing, err := s.repo.FindByUsername(*req.Username)
+		if err != nil && !errors.Is(err, apperrors.ErrNotFound) {
+			return nil, err
+		}
+		if existing != nil && existing.ID != userID {
+			return nil, apperrors.ErrUserExists
+		}
+	}
+
 	update := &model.User{
 		Name:      req.Name,
+		Username:  req.Username,
 		BirthDate: req.BirthDate,
 	}
 
-	return s.repo.Update(userID, update)
+	updated, err := s.repo.Update(userID, update)
+	if err != nil {
+		return nil, err
+	}
+
+	s.invalidateProfile(userID)
+	return updated, nil
 }
 
 func (s *userServiceImpl) DeleteUser(userID string) error {
-	return s.repo.Delete(userID)
+	if err := s.repo.Delete(userID); err != nil {
+		return err
+	}
+
+	s.invalidateProfile(userID)
+	return nil
+}
+
+// cache helper methods
+
+// invalidateProfile drops the cached and remembered public profile of the given user, if any
+func (s *userServiceImpl) invalidateProfile(userID string) {
+	username, ok := s.profileKeys.LoadAndDelete(userID)
+	if !ok {
+		return
+	}
+	if s.profileCache != nil {
+		s.profileCache.Delete(username.(string))
+	}
+	s.staleProfiles.forget(username.(string))
 }
 
 // business logic validation helper methods
@@ -0,0 +1,65 @@
+package service
+
+import (
+	"fmt"
+	"go-gin-api-server/config"
+	"go-gin-api-server/internal/events"
+	"go-gin-api-server/internal/model"
+	"strings"
+	"text/template"
+)
+
+type WelcomePostService interface {
+	// HandleEvent creates the welcome post for a new user; subscribed to the event bus
+	HandleEvent(event events.Event) error
+}
+
+type welcomePostServiceImpl struct {
+	postService PostService
+	template    *template.Template
+	authorID    string
+}
+
+// welcomePostData fields available to the welcome post template
+type welcomePostData struct {
+	Name     string
+	Username string
+}
+
+// NewWelcomePostService 創建在註冊後發布歡迎貼文的服務，模板無法解析時回傳錯誤
+func NewWelcomePostService(postService PostService, cfg config.WelcomePostConfig) (WelcomePostService, error) {
+	tmpl, err := template.New("welcome_post").Parse(cfg.Template)
+	if err != nil {
+		return nil, fmt.Errorf("parse welcome post template: %w", err)
+	}
+
+	return &welcomePostServiceImpl{
+		postService: postService,
+		template:    tmpl,
+		authorID:    cfg.AuthorID,
+	}, nil
+}
+
+func (s *welcomePostServiceImpl) HandleEvent(event events.Event) error {
+	e, ok := event.(events.UserRegistered)
+	if !ok {
+		return nil
+	}
+
+	var content strings.Builder
+	if err := s.template.Execute(&content, welcomePostData{Name: e.Name, Username: e.Username}); err != nil {
+		return fmt.Errorf("render welcome post: %w", err)
+	}
+
+	authorID := s.authorID
+	if authorID == "" {
+		authorID = e.UserID
+	}
+
+	// goes through PostService so content limits apply and PostCreated is published
+	_, err := s.postService.Create(&model.Post{
+		AuthorID: authorID,
+		Content:  content.String(),
+	})
+	return err
+}
@@ -0,0 +1,6 @@
+-- Remove deactivated_by column from users table
+-- First drop the constraint
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_deactivated_by_check;
+
+-- Then drop the column
+ALTER TABLE users DROP COLUMN IF EXISTS deactivated_by;
@@ -0,0 +1,5 @@
+-- Add deactivated_by column to users table to record who deactivated the account
+ALTER TABLE users ADD COLUMN deactivated_by VARCHAR(20);
+
+-- Add check constraint to ensure deactivated_by is either 'self' or 'admin'
+ALTER TABLE users ADD CONSTRAINT users_deactivated_by_check CHECK (deactivated_by IN ('self', 'admin'));
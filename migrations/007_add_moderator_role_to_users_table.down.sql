@@ -0,0 +1,5 @@
+-- Demote moderators before restoring the original constraint
+UPDATE users SET role = 'user' WHERE role = 'moderator';
+
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_role_check;
+ALTER TABLE users ADD CONSTRAINT users_role_check CHECK (role IN ('user', 'admin'));
@@ -0,0 +1,2 @@
+-- Drop reports table
+DROP TABLE IF EXISTS reports;
@@ -0,0 +1,21 @@
+-- Create reports table
+CREATE TABLE IF NOT EXISTS reports (
+    id BIGSERIAL PRIMARY KEY,
+    reporter_id UUID NOT NULL,
+    post_id BIGINT NOT NULL,
+    reason TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    created_at TIMESTAMP(6) WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP(6) WITH TIME ZONE DEFAULT NOW(),
+
+    -- Foreign key constraints
+    CONSTRAINT fk_reports_reporter FOREIGN KEY (reporter_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT fk_reports_post FOREIGN KEY (post_id) REFERENCES posts(id) ON DELETE CASCADE,
+    CONSTRAINT reports_status_check CHECK (status IN ('pending', 'resolved', 'dismissed'))
+);
+
+-- A user can report the same post only once
+CREATE UNIQUE INDEX IF NOT EXISTS idx_reports_reporter_post ON reports(reporter_id, post_id);
+
+-- Create indexes for moderator queues
+CREATE INDEX IF NOT EXISTS idx_reports_status_created_at ON reports(status, created_at);
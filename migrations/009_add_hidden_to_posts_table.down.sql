@@ -0,0 +1,4 @@
+-- Remove hidden column from posts table
+DROP INDEX IF EXISTS idx_posts_visible_created_at;
+
+ALTER TABLE posts DROP COLUMN IF EXISTS hidden;
@@ -0,0 +1,5 @@
+-- Add hidden column to posts table for moderation
+ALTER TABLE posts ADD COLUMN hidden BOOLEAN NOT NULL DEFAULT false;
+
+-- Public lists filter on hidden, index only the visible rows
+CREATE INDEX IF NOT EXISTS idx_posts_visible_created_at ON posts(created_at DESC, id DESC) WHERE hidden = false;
@@ -0,0 +1,2 @@
+-- Drop post_revisions table
+DROP TABLE IF EXISTS post_revisions;
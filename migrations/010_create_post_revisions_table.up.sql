@@ -0,0 +1,13 @@
+-- Create post_revisions table holding prior content of edited posts
+CREATE TABLE IF NOT EXISTS post_revisions (
+    id BIGSERIAL PRIMARY KEY,
+    post_id BIGINT NOT NULL,
+    content TEXT NOT NULL,
+    created_at TIMESTAMP(6) WITH TIME ZONE DEFAULT NOW(),
+
+    -- Foreign key constraints
+    CONSTRAINT fk_post_revisions_post FOREIGN KEY (post_id) REFERENCES posts(id) ON DELETE CASCADE
+);
+
+-- Create indexes for listing a post's history
+CREATE INDEX IF NOT EXISTS idx_post_revisions_post_id_created_at ON post_revisions(post_id, created_at DESC);
@@ -0,0 +1,4 @@
+-- Remove slug column from posts table
+DROP INDEX IF EXISTS idx_posts_slug;
+
+ALTER TABLE posts DROP COLUMN IF EXISTS slug;
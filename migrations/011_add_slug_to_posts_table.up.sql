@@ -0,0 +1,5 @@
+-- Add slug column to posts table for human-readable URLs
+ALTER TABLE posts ADD COLUMN slug VARCHAR(100);
+
+-- Uniqueness is enforced here, the repository retries with a suffix on conflict
+CREATE UNIQUE INDEX IF NOT EXISTS idx_posts_slug ON posts(slug);
@@ -0,0 +1,4 @@
+-- Remove deleted_at column from posts table
+DROP INDEX IF EXISTS idx_posts_deleted_at;
+
+ALTER TABLE posts DROP COLUMN IF EXISTS deleted_at;
@@ -0,0 +1,4 @@
+-- Add deleted_at column to posts table for soft deletion
+ALTER TABLE posts ADD COLUMN deleted_at TIMESTAMP(6) WITH TIME ZONE;
+
+CREATE INDEX IF NOT EXISTS idx_posts_deleted_at ON posts(deleted_at);
@@ -0,0 +1,4 @@
+-- Restore the single-column index and remove the composite cursor index
+CREATE INDEX IF NOT EXISTS idx_posts_created_at ON posts(created_at);
+
+DROP INDEX IF EXISTS idx_posts_created_at_id;
@@ -0,0 +1,6 @@
+-- Keyset pagination orders by (created_at DESC, id DESC); the partial index from 009 only
+-- covers visible posts, this one also serves listings that include hidden posts
+CREATE INDEX IF NOT EXISTS idx_posts_created_at_id ON posts(created_at DESC, id DESC);
+
+-- Superseded by the composite index
+DROP INDEX IF EXISTS idx_posts_created_at;
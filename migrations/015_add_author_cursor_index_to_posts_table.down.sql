@@ -0,0 +1,4 @@
+-- Restore the single-column index and remove the author cursor index
+CREATE INDEX IF NOT EXISTS idx_posts_author_id ON posts(author_id);
+
+DROP INDEX IF EXISTS idx_posts_author_created_at_id;
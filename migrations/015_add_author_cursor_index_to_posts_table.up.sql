@@ -0,0 +1,5 @@
+-- Author-filtered keyset pagination: author_id equality followed by (created_at DESC, id DESC)
+CREATE INDEX IF NOT EXISTS idx_posts_author_created_at_id ON posts(author_id, created_at DESC, id DESC);
+
+-- Superseded by the composite index (author_id is its leading column)
+DROP INDEX IF EXISTS idx_posts_author_id;
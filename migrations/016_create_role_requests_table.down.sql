@@ -0,0 +1,2 @@
+-- Drop role_requests table
+DROP TABLE IF EXISTS role_requests;
@@ -0,0 +1,21 @@
+-- Create role_requests table for self-service role elevation
+CREATE TABLE IF NOT EXISTS role_requests (
+    id BIGSERIAL PRIMARY KEY,
+    user_id UUID NOT NULL,
+    role VARCHAR(20) NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    reviewed_by UUID,
+    reviewed_at TIMESTAMP(6) WITH TIME ZONE,
+    created_at TIMESTAMP(6) WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP(6) WITH TIME ZONE DEFAULT NOW(),
+
+    -- Foreign key constraints
+    CONSTRAINT fk_role_requests_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT fk_role_requests_reviewer FOREIGN KEY (reviewed_by) REFERENCES users(id) ON DELETE SET NULL,
+    CONSTRAINT role_requests_role_check CHECK (role IN ('moderator', 'admin')),
+    CONSTRAINT role_requests_status_check CHECK (status IN ('pending', 'approved', 'rejected'))
+);
+
+-- A user can have only one pending request at a time
+CREATE UNIQUE INDEX IF NOT EXISTS idx_role_requests_user_pending ON role_requests(user_id) WHERE status = 'pending';
@@ -0,0 +1,2 @@
+-- Remove the post title
+ALTER TABLE posts DROP COLUMN IF EXISTS title;
@@ -0,0 +1,2 @@
+-- Optional post title, NULL for posts without one
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS title TEXT;
@@ -0,0 +1,16 @@
+-- Create user_blocks table, a row means blocker_id no longer wants to see blocked_id
+CREATE TABLE IF NOT EXISTS user_blocks (
+    blocker_id UUID NOT NULL,
+    blocked_id UUID NOT NULL,
+    created_at TIMESTAMP(6) WITH TIME ZONE DEFAULT NOW(),
+
+    PRIMARY KEY (blocker_id, blocked_id),
+
+    -- Foreign key constraints
+    CONSTRAINT fk_user_blocks_blocker FOREIGN KEY (blocker_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT fk_user_blocks_blocked FOREIGN KEY (blocked_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT user_blocks_not_self_check CHECK (blocker_id <> blocked_id)
+);
+
+-- Reverse lookup: who has blocked a given user
+CREATE INDEX IF NOT EXISTS idx_user_blocks_blocked_id ON user_blocks(blocked_id);
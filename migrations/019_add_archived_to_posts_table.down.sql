@@ -0,0 +1,2 @@
+-- Remove the archived flag
+ALTER TABLE posts DROP COLUMN IF EXISTS archived;
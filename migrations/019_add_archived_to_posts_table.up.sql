@@ -0,0 +1,2 @@
+-- Archived posts are old posts left out of the default listings, still readable by their author
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS archived BOOLEAN NOT NULL DEFAULT false;
@@ -0,0 +1,9 @@
+-- Create revoked_tokens table, the access token denylist shared by every instance
+CREATE TABLE IF NOT EXISTS revoked_tokens (
+    jti VARCHAR(64) PRIMARY KEY,
+    expires_at TIMESTAMP(6) WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP(6) WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Expired rows are purged periodically
+CREATE INDEX IF NOT EXISTS idx_revoked_tokens_expires_at ON revoked_tokens(expires_at);
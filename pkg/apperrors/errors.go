@@ -1,13 +1,25 @@
 package apperrors
 
-import "errors"
+import (
+	"errors"
+	"strings"
+)
 
 var (
 	// common errors
-	ErrNotFound     = errors.New("resource not found")
-	ErrValidation   = errors.New("validation error")
-	ErrUnauthorized = errors.New("unauthorized")
-	ErrForbidden    = errors.New("forbidden")
+	ErrNotFound      = errors.New("resource not found")
+	ErrValidation    = errors.New("validation error")
+	ErrUnauthorized  = errors.New("unauthorized")
+	ErrForbidden     = errors.New("forbidden")
+	ErrConflict      = errors.New("resource conflict")
+	ErrLimitExceeded = errors.New("limit exceeded") // a per-user quota is used up
+
+	// ErrUnavailable the database failed and no cached value could stand in
+	ErrUnavailable = errors.New("service temporarily unavailable")
+
+	// conditional request errors
+	ErrPreconditionFailed   = errors.New("precondition failed")   // If-Match names a stale version
+	ErrPreconditionRequired = errors.New("precondition required") // If-Match is required but missing
 
 	// user errors
 	ErrUserExists   = errors.New("user already exists")
@@ -17,8 +29,37 @@ var (
 	ErrInvalidToken = errors.New("invalid token")
 	ErrExpiredToken = errors.New("token has expired")
 
+	// password policy errors
+	ErrPasswordTooShort      = errors.New("password too short")
+	ErrPasswordMissingDigit  = errors.New("password missing digit")
+	ErrPasswordMissingUpper  = errors.New("password missing uppercase letter")
+	ErrPasswordMissingSymbol = errors.New("password missing symbol")
+
 	// post errors
 	ErrPostContentTooLong        = errors.New("post content too long")
 	ErrPostContentTooShort       = errors.New("post content too short")
 	ErrPostContentSensitiveWords = errors.New("post content contains sensitive words")
+	ErrPostContentControlChars   = errors.New("post content contains control characters")
+	ErrPostTitleEmpty            = errors.New("post title empty")
+	ErrPostTitleTooLong          = errors.New("post title too long")
+	ErrPostTitleControlChars     = errors.New("post title contains control characters")
+	ErrPostTooManyMentions       = errors.New("post mentions too many users")
 )
+
+// ValidationErrors reports several validation failures at once; errors.Is matches
+// ErrValidation as well as each collected failure
+type ValidationErrors struct {
+	Errs []error
+}
+
+func (e *ValidationErrors) Error() string {
+	messages := make([]string, len(e.Errs))
+	for i, err := range e.Errs {
+		messages[i] = err.Error()
+	}
+	return ErrValidation.Error() + ": " + strings.Join(messages, "; ")
+}
+
+func (e *ValidationErrors) Unwrap() []error {
+	return append([]error{ErrValidation}, e.Errs...)
+}
@@ -0,0 +1,82 @@
+package cache
+
+import (
+	"sync"
+	"time"
+)
+
+// Cache is a pluggable key-value cache; implementations decide expiry policy
+type Cache[V any] interface {
+	Get(key string) (V, bool)
+	Set(key string, value V)
+	Delete(key string)
+	Clear()
+}
+
+type memoryEntry[V any] struct {
+	value     V
+	expiresAt time.Time
+}
+
+// MemoryCache is an in-process cache whose entries expire after a fixed TTL
+type MemoryCache[V any] struct {
+	mu        sync.RWMutex
+	ttl       time.Duration
+	entries   map[string]memoryEntry[V]
+	lastSweep time.Time
+}
+
+// NewMemoryCache creates an in-memory cache with the given TTL
+func NewMemoryCache[V any](ttl time.Duration) *MemoryCache[V] {
+	return &MemoryCache[V]{
+		ttl:       ttl,
+		entries:   make(map[string]memoryEntry[V]),
+		lastSweep: time.Now(),
+	}
+}
+
+func (c *MemoryCache[V]) Get(key string) (V, bool) {
+	c.mu.RLock()
+	entry, ok := c.entries[key]
+	c.mu.RUnlock()
+
+	if !ok || time.Now().After(entry.expiresAt) {
+		var zero V
+		return zero, false
+	}
+	return entry.value, true
+}
+
+func (c *MemoryCache[V]) Set(key string, value V) {
+	now := time.Now()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// lazily drop expired entries (at most once per TTL) so the map doesn't grow unbounded
+	if now.Sub(c.lastSweep) >= c.ttl {
+		for k, entry := range c.entries {
+			if now.After(entry.expiresAt) {
+				delete(c.entries, k)
+			}
+		}
+		c.lastSweep = now
+	}
+
+	c.entries[key] = memoryEntry[V]{
+		value:     value,
+		expiresAt: now.Add(c.ttl),
+	}
+}
+
+func (c *MemoryCache[V]) Delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, key)
+}
+
+func (c *MemoryCache[V]) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = make(map[string]memoryEntry[V])
+}
@@ -0,0 +1,273 @@
+// Package jsonschema validates JSON documents against a subset of JSON Schema
+//
+// Supported keywords: type (a name or a list of names), properties, required,
+// additionalProperties (boolean only), items, enum, minLength, maxLength, pattern,
+// minimum, maximum and format (date-time, email). Unknown keywords are rejected at
+// compile time so a schema never silently checks less than it says.
+package jsonschema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/mail"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// FieldError is one violation, Field is the dotted path to the offending value
+// (empty for the document itself)
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// Schema is a compiled schema, safe for concurrent use
+type Schema struct {
+	Type                 typeList           `json:"type,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *bool              `json:"additionalProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Enum                 []interface{}      `json:"enum,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	Pattern              string             `json:"pattern,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	Format               string             `json:"format,omitempty"`
+
+	pattern *regexp.Regexp
+}
+
+// typeList accepts "type": "string" as well as "type": ["string", "null"]
+type typeList []string
+
+func (t *typeList) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*t = typeList{single}
+		return nil
+	}
+	var multiple []string
+	if err := json.Unmarshal(data, &multiple); err != nil {
+		return fmt.Errorf("type must be a string or an array of strings")
+	}
+	*t = multiple
+	return nil
+}
+
+var knownTypes = map[string]bool{
+	"object": true, "array": true, "string": true, "integer": true,
+	"number": true, "boolean": true, "null": true,
+}
+
+var knownFormats = map[string]bool{
+	"":          true,
+	"date-time": true,
+	"email":     true,
+}
+
+// Compile parses a schema document
+func Compile(src []byte) (*Schema, error) {
+	decoder := json.NewDecoder(bytes.NewReader(src))
+	decoder.DisallowUnknownFields()
+	var schema Schema
+	if err := decoder.Decode(&schema); err != nil {
+		return nil, fmt.Errorf("invalid schema: %w", err)
+	}
+	if err := schema.compile(""); err != nil {
+		return nil, err
+	}
+	return &schema, nil
+}
+
+// MustCompile is Compile for schemas declared in code, it panics on error
+func MustCompile(src string) *Schema {
+	schema, err := Compile([]byte(src))
+	if err != nil {
+		panic(err)
+	}
+	return schema
+}
+
+func (s *Schema) compile(path string) error {
+	for _, name := range s.Type {
+		if !knownTypes[name] {
+			return fmt.Errorf("invalid schema at %q: unknown type %q", path, name)
+		}
+	}
+	if !knownFormats[s.Format] {
+		return fmt.Errorf("invalid schema at %q: unsupported format %q", path, s.Format)
+	}
+	if s.Pattern != "" {
+		re, err := regexp.Compile(s.Pattern)
+		if err != nil {
+			return fmt.Errorf("invalid schema at %q: %w", path, err)
+		}
+		s.pattern = re
+	}
+	for name, property := range s.Properties {
+		if err := property.compile(join(path, name)); err != nil {
+			return err
+		}
+	}
+	if s.Items != nil {
+		if err := s.Items.compile(path + "[]"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Validate checks a JSON document, a document that isn't JSON is reported as a single
+// error on the root
+func (s *Schema) Validate(data []byte) []FieldError {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return []FieldError{{Message: "must be valid JSON"}}
+	}
+
+	var errs []FieldError
+	s.validate("", value, &errs)
+	return errs
+}
+
+func (s *Schema) validate(path string, value interface{}, errs *[]FieldError) {
+	report := func(format string, args ...interface{}) {
+		*errs = append(*errs, FieldError{Field: path, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if len(s.Type) > 0 && !s.matchesType(value) {
+		report("must be %s", strings.Join(s.Type, " or "))
+		return
+	}
+	if len(s.Enum) > 0 && !s.inEnum(value) {
+		report("must be one of %s", s.enumList())
+		return
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for _, name := range s.Required {
+			if _, ok := v[name]; !ok {
+				*errs = append(*errs, FieldError{Field: join(path, name), Message: "is required"})
+			}
+		}
+		// iterate in a stable order so error lists are deterministic
+		names := make([]string, 0, len(v))
+		for name := range v {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			property, ok := s.Properties[name]
+			if !ok {
+				if s.AdditionalProperties != nil && !*s.AdditionalProperties {
+					*errs = append(*errs, FieldError{Field: join(path, name), Message: "is not allowed"})
+				}
+				continue
+			}
+			property.validate(join(path, name), v[name], errs)
+		}
+	case []interface{}:
+		if s.Items != nil {
+			for i, item := range v {
+				s.Items.validate(fmt.Sprintf("%s[%d]", path, i), item, errs)
+			}
+		}
+	case string:
+		length := utf8.RuneCountInString(v)
+		if s.MinLength != nil && length < *s.MinLength {
+			report("must be at least %d characters", *s.MinLength)
+		}
+		if s.MaxLength != nil && length > *s.MaxLength {
+			report("must be at most %d characters", *s.MaxLength)
+		}
+		if s.pattern != nil && !s.pattern.MatchString(v) {
+			report("must match pattern %s", s.Pattern)
+		}
+		switch s.Format {
+		case "date-time":
+			if _, err := time.Parse(time.RFC3339, v); err != nil {
+				report("must be an RFC 3339 date-time")
+			}
+		case "email":
+			if address, err := mail.ParseAddress(v); err != nil || address.Address != v {
+				report("must be an email address")
+			}
+		}
+	case json.Number:
+		n, _ := v.Float64()
+		if s.Minimum != nil && n < *s.Minimum {
+			report("must be at least %v", *s.Minimum)
+		}
+		if s.Maximum != nil && n > *s.Maximum {
+			report("must be at most %v", *s.Maximum)
+		}
+	}
+}
+
+func (s *Schema) matchesType(value interface{}) bool {
+	for _, name := range s.Type {
+		switch v := value.(type) {
+		case map[string]interface{}:
+			if name == "object" {
+				return true
+			}
+		case []interface{}:
+			if name == "array" {
+				return true
+			}
+		case string:
+			if name == "string" {
+				return true
+			}
+		case bool:
+			if name == "boolean" {
+				return true
+			}
+		case nil:
+			if name == "null" {
+				return true
+			}
+		case json.Number:
+			if name == "number" {
+				return true
+			}
+			if _, err := v.Int64(); err == nil && name == "integer" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (s *Schema) inEnum(value interface{}) bool {
+	for _, allowed := range s.Enum {
+		if fmt.Sprint(allowed) == fmt.Sprint(value) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Schema) enumList() string {
+	values := make([]string, 0, len(s.Enum))
+	for _, allowed := range s.Enum {
+		values = append(values, fmt.Sprint(allowed))
+	}
+	return strings.Join(values, ", ")
+}
+
+func join(path string, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
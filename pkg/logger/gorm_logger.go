@@ -2,51 +2,111 @@ package logger
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"go.uber.org/zap"
+	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 )
 
 // GormLogger 自定義GORM logger使用我們的zap logger
 type GormLogger struct {
-	zapLogger *zap.Logger
+	zapLogger     *zap.Logger
+	level         logger.LogLevel
+	slowThreshold time.Duration
+	logParams     bool
 }
 
 // NewGormLogger 創建新的GORM logger實例
-func NewGormLogger() logger.Interface {
-	return &GormLogger{zapLogger: Log}
+//
+// level gates what is logged: Info logs every query at debug, Warn adds queries slower
+// than slowThreshold (zero disables the check), Error only failed queries, Silent nothing.
+// Unless logParams is set, bound values are left out and the SQL keeps its $n placeholders
+func NewGormLogger(zapLogger *zap.Logger, level logger.LogLevel, slowThreshold time.Duration, logParams bool) logger.Interface {
+	return &GormLogger{
+		zapLogger:     zapLogger,
+		level:         level,
+		slowThreshold: slowThreshold,
+		logParams:     logParams,
+	}
+}
+
+// ParseGormLogLevel maps a log level name to the GORM level: debug logs every query,
+// info and warn only slow ones, error only failures; anything else is silent
+func ParseGormLogLevel(level string) logger.LogLevel {
+	switch strings.ToLower(level) {
+	case "debug":
+		return logger.Info
+	case "info", "warn":
+		return logger.Warn
+	case "error":
+		return logger.Error
+	}
+	return logger.Silent
 }
 
 func (l *GormLogger) LogMode(level logger.LogLevel) logger.Interface {
-	return l
+	copied := *l
+	copied.level = level
+	return &copied
 }
 
 func (l *GormLogger) Info(ctx context.Context, msg string, data ...interface{}) {
-	l.zapLogger.Info(fmt.Sprintf(msg, data...))
+	if l.level >= logger.Info {
+		l.zapLogger.Info(fmt.Sprintf(msg, data...))
+	}
 }
 
 func (l *GormLogger) Warn(ctx context.Context, msg string, data ...interface{}) {
-	l.zapLogger.Warn(fmt.Sprintf(msg, data...))
+	if l.level >= logger.Warn {
+		l.zapLogger.Warn(fmt.Sprintf(msg, data...))
+	}
 }
 
 func (l *GormLogger) Error(ctx context.Context, msg string, data ...interface{}) {
-	l.zapLogger.Error(fmt.Sprintf(msg, data...))
+	if l.level >= logger.Error {
+		l.zapLogger.Error(fmt.Sprintf(msg, data...))
+	}
+}
+
+// ParamsFilter is called by GORM before it interpolates the bound values into the logged
+// SQL; dropping them keeps emails, password hashes and tokens out of the logs
+func (l *GormLogger) ParamsFilter(ctx context.Context, sql string, params ...interface{}) (string, []interface{}) {
+	if l.logParams {
+		return sql, params
+	}
+	return sql, nil
 }
 
 func (l *GormLogger) Trace(ctx context.Context, begin time.Time, fc func() (sql string, rowsAffected int64), err error) {
-	if err != nil {
+	if l.level <= logger.Silent {
+		return
+	}
+
+	elapsed := time.Since(begin)
+	switch {
+	// not found is an expected outcome, repositories turn it into apperrors.ErrNotFound
+	case err != nil && !errors.Is(err, gorm.ErrRecordNotFound) && l.level >= logger.Error:
 		sql, _ := fc()
 		l.zapLogger.Error("SQL Error",
 			zap.String("sql", sql),
-			zap.Duration("duration", time.Since(begin)),
+			zap.Duration("duration", elapsed),
 			zap.Error(err))
-	} else {
+	case l.slowThreshold > 0 && elapsed > l.slowThreshold && l.level >= logger.Warn:
+		sql, rows := fc()
+		l.zapLogger.Warn("Slow SQL Query",
+			zap.String("sql", sql),
+			zap.Int64("rows", rows),
+			zap.Duration("duration", elapsed),
+			zap.Duration("threshold", l.slowThreshold))
+	case l.level >= logger.Info:
 		sql, rows := fc()
 		l.zapLogger.Debug("SQL Query",
 			zap.String("sql", sql),
 			zap.Int64("rows", rows),
-			zap.Duration("duration", time.Since(begin)))
+			zap.Duration("duration", elapsed))
 	}
 }
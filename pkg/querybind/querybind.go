@@ -0,0 +1,116 @@
+package querybind
+
+import (
+	"fmt"
+	"go-gin-api-server/pkg/apperrors"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Order sort direction
+type Order string
+
+const (
+	Asc  Order = "asc"
+	Desc Order = "desc"
+)
+
+// Query parameter names shared by list endpoints
+const (
+	ParamSort          = "sort"
+	ParamOrder         = "order"
+	ParamCreatedAfter  = "created_after"
+	ParamCreatedBefore = "created_before"
+)
+
+// Options describes what a list endpoint accepts
+type Options struct {
+	// SortKeys allowed sort keys; the first one is the default
+	SortKeys []string
+	// DefaultOrder applies when order is omitted, Desc if empty
+	DefaultOrder Order
+	// FilterKeys query params collected into Query.Filters; anything else is left to the caller
+	FilterKeys []string
+}
+
+// Query normalized sort/filter/date-range parameters of a list request
+type Query struct {
+	Sort          string
+	Order         Order
+	CreatedAfter  *time.Time // exclusive
+	CreatedBefore *time.Time // exclusive
+	Filters       map[string]string
+}
+
+// Ascending reports whether results are sorted in ascending order
+func (q Query) Ascending() bool {
+	return q.Order == Asc
+}
+
+// Parse validates and normalizes the list query parameters.
+// Timestamps are RFC 3339; every error wraps apperrors.ErrValidation.
+func Parse(values url.Values, opts Options) (Query, error) {
+	query := Query{
+		Order:   opts.DefaultOrder,
+		Filters: map[string]string{},
+	}
+	if query.Order == "" {
+		query.Order = Desc
+	}
+	if len(opts.SortKeys) > 0 {
+		query.Sort = opts.SortKeys[0]
+	}
+
+	if sort := values.Get(ParamSort); sort != "" {
+		if !slices.Contains(opts.SortKeys, sort) {
+			return Query{}, fmt.Errorf("%w: unknown sort key %q", apperrors.ErrValidation, sort)
+		}
+		query.Sort = sort
+	}
+
+	if order := values.Get(ParamOrder); order != "" {
+		switch Order(strings.ToLower(order)) {
+		case Asc:
+			query.Order = Asc
+		case Desc:
+			query.Order = Desc
+		default:
+			return Query{}, fmt.Errorf("%w: order must be asc or desc", apperrors.ErrValidation)
+		}
+	}
+
+	var err error
+	if query.CreatedAfter, err = parseTime(values, ParamCreatedAfter); err != nil {
+		return Query{}, err
+	}
+	if query.CreatedBefore, err = parseTime(values, ParamCreatedBefore); err != nil {
+		return Query{}, err
+	}
+	if query.CreatedAfter != nil && query.CreatedBefore != nil && !query.CreatedAfter.Before(*query.CreatedBefore) {
+		return Query{}, fmt.Errorf("%w: %s must be before %s", apperrors.ErrValidation, ParamCreatedAfter, ParamCreatedBefore)
+	}
+
+	for _, key := range opts.FilterKeys {
+		if value := values.Get(key); value != "" {
+			query.Filters[key] = value
+		}
+	}
+
+	return query, nil
+}
+
+func parseTime(values url.Values, key string) (*time.Time, error) {
+	raw := values.Get(key)
+	if raw == "" {
+		return nil, nil
+	}
+
+	t, err := time.Parse(time.RFC3339Nano, raw)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s must be an RFC 3339 timestamp", apperrors.ErrValidation, key)
+	}
+	t = t.UTC()
+	return &t, nil
+}
@@ -0,0 +1,31 @@
+package utils
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+)
+
+// APIKeyPrefix marks keys issued by this server, so leaked keys are easy to scan for
+const APIKeyPrefix = "gak_"
+
+// apiKeyDisplayLength is how much of a key is kept in clear to tell keys apart
+const apiKeyDisplayLength = len(APIKeyPrefix) + 8
+
+// GenerateAPIKey returns a new random key and the prefix stored alongside its hash
+func GenerateAPIKey() (key string, prefix string, err error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", err
+	}
+	key = APIKeyPrefix + base64.RawURLEncoding.EncodeToString(b)
+	return key, key[:apiKeyDisplayLength], nil
+}
+
+// HashAPIKey is the lookup hash of a key; keys carry 256 bits of entropy, so a plain
+// SHA-256 is enough and keeps the per-request lookup cheap
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
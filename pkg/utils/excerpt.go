@@ -0,0 +1,29 @@
+package utils
+
+import (
+	"strings"
+	"unicode"
+)
+
+// ExcerptEllipsis marks content cut by Excerpt
+const ExcerptEllipsis = "…"
+
+// Excerpt keeps up to maxRunes runes of content cut at a word boundary, plus an ellipsis when cut; maxRunes <= 0 keeps it all
+func Excerpt(content string, maxRunes int) string {
+	runes := []rune(content)
+	if maxRunes <= 0 || len(runes) <= maxRunes {
+		return content
+	}
+
+	cut := maxRunes
+	if !unicode.IsSpace(runes[cut]) {
+		for i := cut - 1; i > 0; i-- {
+			if unicode.IsSpace(runes[i]) {
+				cut = i
+				break
+			}
+		}
+	}
+
+	return strings.TrimRightFunc(string(runes[:cut]), unicode.IsSpace) + ExcerptEllipsis
+}
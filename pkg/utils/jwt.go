@@ -7,21 +7,61 @@ import (
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 )
 
 const (
 	JWTIssuer = "go-gin-api-server"
 )
 
+// SigningKey an HMAC secret identified by the kid header of the tokens it signs
+type SigningKey struct {
+	ID     string
+	Secret string
+}
+
 type JWTManager struct {
-	secretKey     string
-	tokenDuration time.Duration
+	primary SigningKey
+	keys    map[string]string // kid -> secret of the named keys, the primary included
+	// secrets every verification key, the primary first; tokens without a kid are tried
+	// against all of them, they may predate JWT_KEY_ID or have been signed by an unnamed key
+	secrets         []jwt.VerificationKey
+	tokenDuration   time.Duration
+	refreshDuration time.Duration
 }
 
+// NewJWTManager refresh tokens last 168 access token lifetimes
 func NewJWTManager(secretKey string, tokenDuration time.Duration) *JWTManager {
+	return NewJWTManagerWithRefresh(secretKey, tokenDuration, tokenDuration*24*7)
+}
+
+// NewJWTManagerWithRefresh 創建使用指定 refresh token 有效期的 JWTManager
+func NewJWTManagerWithRefresh(secretKey string, tokenDuration, refreshDuration time.Duration) *JWTManager {
+	return NewJWTManagerWithKeys(SigningKey{Secret: secretKey}, nil, tokenDuration, refreshDuration)
+}
+
+// NewJWTManagerWithKeys signs with primary and still accepts tokens signed by any of the
+// verification keys, so rotating the secret doesn't log everyone out. A verification key
+// without an ID only matches tokens without a kid, e.g. the secret used before JWT_KEY_ID was set
+func NewJWTManagerWithKeys(primary SigningKey, verification []SigningKey, tokenDuration, refreshDuration time.Duration) *JWTManager {
+	keys := make(map[string]string, len(verification)+1)
+	secrets := []jwt.VerificationKey{[]byte(primary.Secret)}
+	for _, key := range verification {
+		if key.ID != "" {
+			keys[key.ID] = key.Secret
+		}
+		secrets = append(secrets, []byte(key.Secret))
+	}
+	if primary.ID != "" {
+		keys[primary.ID] = primary.Secret
+	}
+
 	return &JWTManager{
-		secretKey:     secretKey,
-		tokenDuration: tokenDuration,
+		primary:         primary,
+		keys:            keys,
+		secrets:         secrets,
+		tokenDuration:   tokenDuration,
+		refreshDuration: refreshDuration,
 	}
 }
 
@@ -34,21 +74,22 @@ func (j *JWTManager) GenerateToken(user *model.User) (*model.TokenResponse, erro
 
 	// generate refresh token
 	now := time.Now().UTC().Truncate(time.Microsecond)
-	refreshExpiresAt := now.Add(j.tokenDuration * 24 * 7) // 7 days
+	refreshExpiresAt := now.Add(j.refreshDuration)
 	refreshClaims := &model.Claims{
 		UserID: user.ID,
 		Role:   user.Role,
+		Type:   model.TokenTypeRefresh,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(refreshExpiresAt),
 			IssuedAt:  jwt.NewNumericDate(now),
 			NotBefore: jwt.NewNumericDate(now),
 			Issuer:    JWTIssuer,
 			Subject:   user.ID,
+			ID:        uuid.NewString(),
 		},
 	}
 
-	refreshToken := jwt.NewWithClaims(jwt.SigningMethodHS256, refreshClaims)
-	refreshTokenString, err := refreshToken.SignedString([]byte(j.secretKey))
+	refreshTokenString, err := j.sign(refreshClaims)
 	if err != nil {
 		return nil, err
 	}
@@ -63,10 +104,22 @@ func (j *JWTManager) GenerateToken(user *model.User) (*model.TokenResponse, erro
 
 // GenerateAccessTokenOnly
 func (j *JWTManager) GenerateAccessToken(user *model.User) (string, error) {
+	return j.sign(accessClaims(user, j.tokenDuration))
+}
+
+// GenerateImpersonationToken issues an access token for user marked with the acting
+// admin's ID, valid for duration
+func (j *JWTManager) GenerateImpersonationToken(user *model.User, impersonatorID string, duration time.Duration) (string, error) {
+	claims := accessClaims(user, duration)
+	claims.ImpersonatorID = impersonatorID
+	return j.sign(claims)
+}
+
+func accessClaims(user *model.User, duration time.Duration) *model.Claims {
 	now := time.Now().UTC().Truncate(time.Microsecond)
-	expiresAt := now.Add(j.tokenDuration)
+	expiresAt := now.Add(duration)
 
-	claims := &model.Claims{
+	return &model.Claims{
 		UserID: user.ID,
 		Role:   user.Role,
 		RegisteredClaims: jwt.RegisteredClaims{
@@ -75,17 +128,18 @@ func (j *JWTManager) GenerateAccessToken(user *model.User) (string, error) {
 			NotBefore: jwt.NewNumericDate(now),
 			Issuer:    JWTIssuer,
 			Subject:   user.ID,
+			ID:        uuid.NewString(),
 		},
 	}
+}
 
-	// generate access token
+// sign signs with the primary key and names it in the kid header
+func (j *JWTManager) sign(claims *model.Claims) (string, error) {
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString([]byte(j.secretKey))
-	if err != nil {
-		return "", err
+	if j.primary.ID != "" {
+		token.Header["kid"] = j.primary.ID
 	}
-
-	return tokenString, nil
+	return token.SignedString([]byte(j.primary.Secret))
 }
 
 // GetTokenDuration 獲取 token 有效期
@@ -100,7 +154,17 @@ func (j *JWTManager) ValidateToken(tokenString string) (*model.Claims, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, errors.New("unexpected signing method")
 		}
-		return []byte(j.secretKey), nil
+
+		// tokens issued before kid was set carry none, any verification key may have signed them
+		kid, hasKid := token.Header["kid"].(string)
+		if !hasKid {
+			return jwt.VerificationKeySet{Keys: j.secrets}, nil
+		}
+		secret, ok := j.keys[kid]
+		if !ok {
+			return nil, errors.New("unknown signing key")
+		}
+		return []byte(secret), nil
 	})
 
 	if err != nil {
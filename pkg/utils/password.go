@@ -1,9 +1,22 @@
 package utils
 
 import (
+	"go-gin-api-server/pkg/apperrors"
+	"unicode"
+	"unicode/utf8"
+
 	"golang.org/x/crypto/bcrypt"
 )
 
+// PasswordPolicy complexity rules a new password must meet; the zero value accepts anything
+type PasswordPolicy struct {
+	// MinLength in characters; zero means no minimum beyond request binding
+	MinLength     int
+	RequireDigit  bool
+	RequireUpper  bool
+	RequireSymbol bool
+}
+
 func HashPassword(password string) (string, error) {
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
 	if err != nil {
@@ -15,3 +28,38 @@ func HashPassword(password string) (string, error) {
 func CheckPassword(hashedPassword, password string) error {
 	return bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(password))
 }
+
+// ValidatePasswordStrength checks password against every rule of policy and reports all
+// unmet rules at once as *apperrors.ValidationErrors, so errors.Is matches ErrValidation
+func ValidatePasswordStrength(password string, policy PasswordPolicy) error {
+	var hasDigit, hasUpper, hasSymbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r):
+			hasSymbol = true
+		}
+	}
+
+	var errs []error
+	if utf8.RuneCountInString(password) < policy.MinLength {
+		errs = append(errs, apperrors.ErrPasswordTooShort)
+	}
+	if policy.RequireDigit && !hasDigit {
+		errs = append(errs, apperrors.ErrPasswordMissingDigit)
+	}
+	if policy.RequireUpper && !hasUpper {
+		errs = append(errs, apperrors.ErrPasswordMissingUpper)
+	}
+	if policy.RequireSymbol && !hasSymbol {
+		errs = append(errs, apperrors.ErrPasswordMissingSymbol)
+	}
+
+	if len(errs) > 0 {
+		return &apperrors.ValidationErrors{Errs: errs}
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package utils
+
+import (
+	"encoding/json"
+	"go-gin-api-server/internal/model"
+	"go-gin-api-server/pkg/jsonschema"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+const MIMEProblemJSON = "application/problem+json"
+
+// PrettyJSONKey is the context flag that makes RespondJSON indent its output
+const PrettyJSONKey = "pretty_json"
+
+// RefreshedTokenKey holds the access token the auth middleware auto-refreshed during this
+// request; when set, RespondJSON adds it to JSON object bodies as new_access_token
+const RefreshedTokenKey = "refreshed_access_token"
+
+// RespondJSON writes data as JSON, indented when PrettyJSONKey is set on the context;
+// every handler and error response goes through it so the setting applies everywhere
+func RespondJSON(c *gin.Context, status int, data interface{}) {
+	if token := c.GetString(RefreshedTokenKey); token != "" {
+		data = withRefreshedToken(data, token)
+	}
+	if c.GetBool(PrettyJSONKey) {
+		c.IndentedJSON(status, data)
+		return
+	}
+	c.JSON(status, data)
+}
+
+// RespondError writes an error body in the format the client accepts
+//
+// Accept: application/problem+json gets RFC 7807 problem details, everything else
+// gets the default {"error": message}
+func RespondError(c *gin.Context, status int, message string) {
+	respondError(c, status, model.ErrorResponse{Error: message})
+}
+
+// RespondErrorCode is RespondError with a machine-readable code added to the body
+func RespondErrorCode(c *gin.Context, status int, code string, message string) {
+	respondError(c, status, model.ErrorResponse{Error: message, Code: code})
+}
+
+// RespondFieldErrors is RespondError with per-field validation errors added to the body
+func RespondFieldErrors(c *gin.Context, status int, message string, details []jsonschema.FieldError) {
+	respondError(c, status, model.ErrorResponse{Error: message, Details: details})
+}
+
+func respondError(c *gin.Context, status int, body model.ErrorResponse) {
+	if c.NegotiateFormat(gin.MIMEJSON, MIMEProblemJSON) == MIMEProblemJSON {
+		c.Header("Content-Type", MIMEProblemJSON)
+		RespondJSON(c, status, model.ProblemDetails{
+			Type:     "about:blank",
+			Title:    http.StatusText(status),
+			Status:   status,
+			Detail:   body.Error,
+			Instance: c.Request.URL.Path,
+			Code:     body.Code,
+			Errors:   body.Details,
+		})
+		return
+	}
+
+	RespondJSON(c, status, body)
+}
+
+// withRefreshedToken prepends new_access_token to a JSON object body, keeping its own
+// fields and their order (some, like ImpersonationResponse, have a token_type of their
+// own, so none is added here); anything that isn't an object is returned unchanged and
+// the client reads the token from the X-New-Access-Token header instead
+func withRefreshedToken(data interface{}, token string) interface{} {
+	body, err := json.Marshal(data)
+	if err != nil || len(body) < 2 || body[0] != '{' {
+		return data
+	}
+
+	fields, err := json.Marshal(struct {
+		NewAccessToken string `json:"new_access_token"`
+	}{token})
+	if err != nil {
+		return data
+	}
+
+	merged := append([]byte{}, fields[:len(fields)-1]...)
+	if len(body) > 2 {
+		merged = append(merged, ',')
+	}
+	merged = append(merged, body[1:]...)
+	return json.RawMessage(merged)
+}
@@ -0,0 +1,33 @@
+package utils
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Slugify converts text to a lowercase, hyphen-separated ASCII slug of at most maxLen characters
+//
+// characters outside [a-z0-9] are collapsed into a single hyphen, so text without any
+// ASCII letters or digits yields an empty slug
+func Slugify(text string, maxLen int) string {
+	var b strings.Builder
+	pendingHyphen := false
+
+	for _, r := range strings.ToLower(text) {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			if pendingHyphen && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			pendingHyphen = false
+			b.WriteRune(r)
+			continue
+		}
+		pendingHyphen = true
+	}
+
+	slug := b.String()
+	if maxLen > 0 && len(slug) > maxLen {
+		slug = strings.TrimRight(slug[:maxLen], "-")
+	}
+	return slug
+}
@@ -0,0 +1,81 @@
+package utils
+
+import (
+	"sync"
+	"time"
+)
+
+// TokenDenylist holds revoked token IDs (jti) until the token would have expired anyway.
+// Every instance behind the load balancer must see the same entries, so production uses a
+// shared store; MemoryTokenDenylist is for a single process and tests
+type TokenDenylist interface {
+	// Revoke denies jti until expiresAt; revoking again keeps the later expiry
+	Revoke(jti string, expiresAt time.Time) error
+	// IsRevoked reports whether jti is denied, expired entries count as not revoked
+	IsRevoked(jti string) (bool, error)
+}
+
+// MemoryTokenDenylist is an in-process TokenDenylist, so it only ever grows with tokens
+// that are still usable
+type MemoryTokenDenylist struct {
+	mu      sync.Mutex
+	entries map[string]time.Time // jti -> expiry
+}
+
+func NewMemoryTokenDenylist() *MemoryTokenDenylist {
+	return &MemoryTokenDenylist{entries: make(map[string]time.Time)}
+}
+
+func (d *MemoryTokenDenylist) Revoke(jti string, expiresAt time.Time) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.purgeLocked(time.Now())
+	if current, ok := d.entries[jti]; !ok || expiresAt.After(current) {
+		d.entries[jti] = expiresAt
+	}
+	return nil
+}
+
+func (d *MemoryTokenDenylist) IsRevoked(jti string) (bool, error) {
+	if jti == "" {
+		return false, nil
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	expiresAt, ok := d.entries[jti]
+	if !ok {
+		return false, nil
+	}
+	if !time.Now().Before(expiresAt) {
+		delete(d.entries, jti)
+		return false, nil
+	}
+	return true, nil
+}
+
+// Purge drops entries whose token has expired
+func (d *MemoryTokenDenylist) Purge() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.purgeLocked(time.Now())
+}
+
+// Len 目前記錄的 jti 數量
+func (d *MemoryTokenDenylist) Len() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return len(d.entries)
+}
+
+func (d *MemoryTokenDenylist) purgeLocked(now time.Time) {
+	for jti, expiresAt := range d.entries {
+		if !now.Before(expiresAt) {
+			delete(d.entries, jti)
+		}
+	}
+}
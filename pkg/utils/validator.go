@@ -24,6 +24,14 @@ func UsernameValidator(fl validator.FieldLevel) bool {
 	return matched
 }
 
+// uuidPattern matches the canonical 8-4-4-4-12 hex form user IDs are stored in
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// UUIDValidator validate that the value is a UUID in canonical form
+func UUIDValidator(fl validator.FieldLevel) bool {
+	return uuidPattern.MatchString(fl.Field().String())
+}
+
 // UsernameOrEmailValidator validate that at least one of username or email is provided
 func UsernameOrEmailValidator(sl validator.StructLevel) {
 	username := ""
@@ -48,6 +56,9 @@ func RegisterCustomValidators(v *validator.Validate) {
 	if err != nil {
 		logger.Fatalf("Failed to register username validator: %v", err)
 	}
+	if err := v.RegisterValidation("uuid", UUIDValidator); err != nil {
+		logger.Fatalf("Failed to register uuid validator: %v", err)
+	}
 	v.RegisterStructValidation(UsernameOrEmailValidator, model.LoginRequest{})
 	v.RegisterStructValidation(UsernameOrEmailValidator, model.RegisterRequest{})
 }
@@ -0,0 +1,51 @@
+package authz
+
+import (
+	"go-gin-api-server/internal/authz"
+	"go-gin-api-server/internal/model"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	ownerID = "550e8400-e29b-41d4-a716-446655440001"
+	otherID = "550e8400-e29b-41d4-a716-446655440002"
+)
+
+func TestIsOwner(t *testing.T) {
+	t.Run("Owner", func(t *testing.T) {
+		assert.True(t, authz.IsOwner(ownerID, ownerID))
+	})
+
+	t.Run("Other", func(t *testing.T) {
+		assert.False(t, authz.IsOwner(otherID, ownerID))
+	})
+
+	t.Run("EmptyActor", func(t *testing.T) {
+		assert.False(t, authz.IsOwner("", ""))
+	})
+}
+
+func TestIsOwnerOrAdmin(t *testing.T) {
+	testCases := []struct {
+		name      string
+		actorID   string
+		actorRole model.UserRole
+		ownerID   string
+		expected  bool
+	}{
+		{"OwnerAsUser", ownerID, model.RoleUser, ownerID, true},
+		{"OwnerAsAdmin", ownerID, model.RoleAdmin, ownerID, true},
+		{"OtherAsUser", otherID, model.RoleUser, ownerID, false},
+		{"OtherAsAdmin", otherID, model.RoleAdmin, ownerID, true},
+		{"EmptyActorAsUser", "", model.RoleUser, "", false},
+		{"UnknownRole", otherID, model.UserRole("guest"), ownerID, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, authz.IsOwnerOrAdmin(tc.actorID, tc.actorRole, tc.ownerID))
+		})
+	}
+}
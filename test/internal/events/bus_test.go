@@ -0,0 +1,113 @@
+package events
+
+import (
+	"go-gin-api-server/internal/events"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+// Helper functions
+
+type eventRecorder struct {
+	mu     sync.Mutex
+	events []events.Event
+}
+
+func (r *eventRecorder) handle(event events.Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, event)
+}
+
+func (r *eventRecorder) received() []events.Event {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]events.Event(nil), r.events...)
+}
+
+// Testcases
+
+func TestBus(t *testing.T) {
+	t.Run("SubscriberReceivesEvent", func(t *testing.T) {
+		bus := events.NewBus(zap.NewNop())
+		recorder := &eventRecorder{}
+		bus.Subscribe(recorder.handle)
+
+		bus.Publish(events.PostCreated{PostID: 1})
+		bus.Close()
+
+		assert.Equal(t, []events.Event{events.PostCreated{PostID: 1}}, recorder.received())
+	})
+
+	t.Run("TypedSubscriptionFiltersEvents", func(t *testing.T) {
+		bus := events.NewBus(zap.NewNop())
+		recorder := &eventRecorder{}
+		bus.Subscribe(recorder.handle, events.TypeUserDeactivated)
+
+		bus.Publish(events.PostCreated{PostID: 1})
+		bus.Publish(events.UserDeactivated{UserID: "user-1"})
+		bus.Close()
+
+		assert.Equal(t, []events.Event{events.UserDeactivated{UserID: "user-1"}}, recorder.received())
+	})
+
+	t.Run("PreservesPublishOrder", func(t *testing.T) {
+		bus := events.NewBusWithBuffer(1, zap.NewNop())
+		recorder := &eventRecorder{}
+		bus.Subscribe(recorder.handle)
+
+		for i := uint64(1); i <= 5; i++ {
+			bus.Publish(events.PostCreated{PostID: i})
+		}
+		bus.Close()
+
+		received := recorder.received()
+		if assert.Len(t, received, 5) {
+			for i, event := range received {
+				assert.Equal(t, uint64(i+1), event.(events.PostCreated).PostID)
+			}
+		}
+	})
+
+	t.Run("UnsubscribeStopsDelivery", func(t *testing.T) {
+		bus := events.NewBus(zap.NewNop())
+		recorder := &eventRecorder{}
+		unsubscribe := bus.Subscribe(recorder.handle)
+
+		unsubscribe()
+		bus.Publish(events.PostCreated{PostID: 1})
+		bus.Close()
+
+		assert.Empty(t, recorder.received())
+	})
+
+	t.Run("PanickingHandlerDoesNotStopWorker", func(t *testing.T) {
+		bus := events.NewBus(zap.NewNop())
+		recorder := &eventRecorder{}
+		bus.Subscribe(func(event events.Event) {
+			panic("boom")
+		})
+		bus.Subscribe(recorder.handle)
+
+		bus.Publish(events.PostCreated{PostID: 1})
+		bus.Publish(events.PostCreated{PostID: 2})
+		bus.Close()
+
+		assert.Len(t, recorder.received(), 2)
+	})
+
+	t.Run("PublishAfterCloseIsDropped", func(t *testing.T) {
+		bus := events.NewBus(zap.NewNop())
+		recorder := &eventRecorder{}
+		bus.Subscribe(recorder.handle)
+
+		bus.Close()
+		bus.Publish(events.PostCreated{PostID: 1})
+		bus.Close()
+
+		assert.Empty(t, recorder.received())
+	})
+}
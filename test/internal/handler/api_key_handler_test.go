@@ -0,0 +1,156 @@
+package handler
+
+import (
+	"go-gin-api-server/internal/handler"
+	"go-gin-api-server/internal/model"
+	"go-gin-api-server/pkg/apperrors"
+	mockService "go-gin-api-server/test/mocks/service"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+// Helper functions
+
+func setupAPIKeyRouter() (*mockService.APIKeyServiceMock, *gin.Engine) {
+	gin.SetMode(gin.TestMode)
+	mockService := mockService.NewAPIKeyServiceMock()
+	apiKeyHandler := handler.NewAPIKeyHandler(mockService, zap.NewNop())
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("user_role", model.RoleUser)
+		c.Set("user_id", testUserID)
+		c.Next()
+	})
+	r.POST("/auth/api-keys", apiKeyHandler.CreateAPIKey)
+	r.GET("/auth/api-keys", apiKeyHandler.ListAPIKeys)
+	r.DELETE("/auth/api-keys/:id", apiKeyHandler.RevokeAPIKey)
+	r.GET("/admin/users/:id/api-keys", apiKeyHandler.ListUserAPIKeys)
+	return mockService, r
+}
+
+func performAPIKeyRequest(r *gin.Engine, method, path string, body interface{}) *httptest.ResponseRecorder {
+	req := createJSONHTTPRequest(method, path, body)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+// Testcases
+
+func TestCreateAPIKey(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockService, r := setupAPIKeyRouter()
+		req := model.CreateAPIKeyRequest{Name: "billing sync", Scopes: []model.APIKeyScope{model.APIKeyScopeWrite}}
+		mockService.On("Create", testUserID, req).Return(&model.CreateAPIKeyResponse{
+			APIKey: model.APIKey{ID: 1, UserID: testUserID, Name: "billing sync", Prefix: "gak_abcdefgh", KeyHash: "secret-hash"},
+			Key:    "gak_abcdefghijkl",
+		}, nil)
+
+		w := performAPIKeyRequest(r, http.MethodPost, "/auth/api-keys", req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Contains(t, w.Body.String(), `"key":"gak_abcdefghijkl"`)
+		assert.NotContains(t, w.Body.String(), "secret-hash")
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("InvalidScope", func(t *testing.T) {
+		mockService, r := setupAPIKeyRouter()
+
+		w := performAPIKeyRequest(r, http.MethodPost, "/auth/api-keys", map[string]interface{}{
+			"name":   "billing sync",
+			"scopes": []string{"admin"},
+		})
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("MissingName", func(t *testing.T) {
+		mockService, r := setupAPIKeyRouter()
+
+		w := performAPIKeyRequest(r, http.MethodPost, "/auth/api-keys", map[string]interface{}{})
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
+
+func TestListAPIKeys(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockService, r := setupAPIKeyRouter()
+		mockService.On("List", testUserID).Return(&model.APIKeyListResponse{
+			Data: []model.APIKey{{ID: 1, UserID: testUserID, Prefix: "gak_abcdefgh", KeyHash: "secret-hash"}},
+		}, nil)
+
+		w := performAPIKeyRequest(r, http.MethodGet, "/auth/api-keys", nil)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"prefix":"gak_abcdefgh"`)
+		assert.NotContains(t, w.Body.String(), "secret-hash")
+	})
+}
+
+func TestRevokeAPIKey(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockService, r := setupAPIKeyRouter()
+		mockService.On("Revoke", uint64(1), testUserID).Return(nil)
+
+		w := performAPIKeyRequest(r, http.MethodDelete, "/auth/api-keys/1", nil)
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		mockService, r := setupAPIKeyRouter()
+		mockService.On("Revoke", uint64(2), testUserID).Return(apperrors.ErrNotFound)
+
+		w := performAPIKeyRequest(r, http.MethodDelete, "/auth/api-keys/2", nil)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("InvalidID", func(t *testing.T) {
+		mockService, r := setupAPIKeyRouter()
+
+		w := performAPIKeyRequest(r, http.MethodDelete, "/auth/api-keys/abc", nil)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "Revoke", mock.Anything, mock.Anything)
+	})
+}
+
+func TestListUserAPIKeys(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockService, r := setupAPIKeyRouter()
+		mockService.On("List", otherUserID).Return(&model.APIKeyListResponse{
+			Data: []model.APIKey{{ID: 3, UserID: otherUserID, Prefix: "gak_ijklmnop", LastUsedAt: &fixedLastUsedAt}},
+		}, nil)
+
+		w := performAPIKeyRequest(r, http.MethodGet, "/admin/users/"+otherUserID+"/api-keys", nil)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"last_used_at":"2026-01-02T03:04:05Z"`)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("InvalidUserID", func(t *testing.T) {
+		mockService, r := setupAPIKeyRouter()
+
+		w := performAPIKeyRequest(r, http.MethodGet, "/admin/users/not-a-uuid/api-keys", nil)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "List", mock.Anything)
+	})
+}
+
+var fixedLastUsedAt = time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
@@ -1,9 +1,13 @@
 package handler
 
 import (
+	"encoding/json"
+	"go-gin-api-server/config"
 	"go-gin-api-server/internal/handler"
+	"go-gin-api-server/internal/middleware"
 	"go-gin-api-server/internal/model"
 	"go-gin-api-server/pkg/apperrors"
+	"go-gin-api-server/pkg/jsonschema"
 	"go-gin-api-server/pkg/utils"
 	mockService "go-gin-api-server/test/mocks/service"
 	"net/http"
@@ -35,11 +39,19 @@ func setupAuthRouter(authHandler *handler.AuthHandler) *gin.Engine {
 		utils.RegisterCustomValidators(v)
 	}
 
+	r.Use(func(c *gin.Context) {
+		c.Set("user_role", model.RoleUser)
+		c.Set("user_id", testUserID)
+		c.Next()
+	})
+
 	r.POST("/api/v1/auth/register", authHandler.Register)
 	r.POST("/api/v1/auth/login", authHandler.Login)
 	r.POST("/api/v1/auth/refresh", authHandler.RefreshToken)
 	r.POST("/api/v1/auth/users/:id/activate", authHandler.ActivateUser)
 	r.POST("/api/v1/auth/users/:id/deactivate", authHandler.DeactivateUser)
+	r.POST("/api/v1/admin/tokens/revoke", authHandler.RevokeToken)
+	r.POST("/api/v1/admin/users/:id/impersonate", authHandler.Impersonate)
 
 	return r
 }
@@ -118,6 +130,59 @@ func TestAuthHandler_Register(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, w.Code)
 		mockAuthService.AssertExpectations(t)
 	})
+
+	t.Run("ShortPasswordLeftToService", func(t *testing.T) {
+		authHandler, mockAuthService := setupTestAuthHandler()
+		registerReq := createTestRegisterRequest()
+		registerReq.Password = "abcd"
+
+		// Setup mock: binding no longer fixes a minimum, AUTH_PASSWORD_MIN_LENGTH decides
+		mockAuthService.On("Register", registerReq).Return(createTestTokenResponse(), nil)
+
+		// Setup router
+		router := setupAuthRouter(authHandler)
+
+		// Create json request
+		httpReq := createTypedJSONRequest(http.MethodPost, "/api/v1/auth/register", registerReq)
+
+		// run
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httpReq)
+
+		// Assert
+		assert.Equal(t, http.StatusCreated, w.Code)
+		mockAuthService.AssertExpectations(t)
+	})
+
+	t.Run("WeakPassword", func(t *testing.T) {
+		authHandler, mockAuthService := setupTestAuthHandler()
+		registerReq := createTestRegisterRequest()
+
+		// Setup mock
+		mockAuthService.On("Register", registerReq).Return(nil, &apperrors.ValidationErrors{
+			Errs: []error{apperrors.ErrPasswordMissingDigit, apperrors.ErrPasswordMissingSymbol},
+		})
+
+		// Setup router
+		router := setupAuthRouter(authHandler)
+
+		// Create json request
+		httpReq := createTypedJSONRequest(http.MethodPost, "/api/v1/auth/register", registerReq)
+
+		// run
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httpReq)
+
+		// Assert
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		var body model.ErrorResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		assert.Equal(t, []jsonschema.FieldError{
+			{Field: "password", Message: "Password must contain a digit"},
+			{Field: "password", Message: "Password must contain a symbol"},
+		}, body.Details)
+		mockAuthService.AssertExpectations(t)
+	})
 }
 
 func TestAuthHandler_Login(t *testing.T) {
@@ -213,6 +278,46 @@ func TestAuthHandler_RefreshToken(t *testing.T) {
 	})
 }
 
+func TestAuthHandler_RevokeToken(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		authHandler, mockAuthService := setupTestAuthHandler()
+		mockAuthService.On("RevokeAccessToken", "leaked-token").Return(nil)
+
+		router := setupAuthRouter(authHandler)
+		req := createTypedJSONRequest(http.MethodPost, "/api/v1/admin/tokens/revoke", model.RevokeTokenRequest{Token: "leaked-token"})
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		mockAuthService.AssertExpectations(t)
+	})
+
+	t.Run("MissingToken", func(t *testing.T) {
+		authHandler, mockAuthService := setupTestAuthHandler()
+
+		router := setupAuthRouter(authHandler)
+		req := createTypedJSONRequest(http.MethodPost, "/api/v1/admin/tokens/revoke", map[string]string{"jti": "leaked-jti"})
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockAuthService.AssertNotCalled(t, "RevokeAccessToken", mock.Anything)
+	})
+
+	t.Run("MalformedToken", func(t *testing.T) {
+		authHandler, mockAuthService := setupTestAuthHandler()
+		mockAuthService.On("RevokeAccessToken", "not-a-jwt").Return(apperrors.ErrValidation)
+
+		router := setupAuthRouter(authHandler)
+		req := createTypedJSONRequest(http.MethodPost, "/api/v1/admin/tokens/revoke", model.RevokeTokenRequest{Token: "not-a-jwt"})
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockAuthService.AssertExpectations(t)
+	})
+}
+
 func TestAuthHandler_ActivateUser(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
 		authHandler, mockAuthService := setupTestAuthHandler()
@@ -256,6 +361,21 @@ func TestAuthHandler_ActivateUser(t *testing.T) {
 		assert.Equal(t, http.StatusInternalServerError, w.Code)
 		mockAuthService.AssertExpectations(t)
 	})
+
+	t.Run("InvalidID", func(t *testing.T) {
+		authHandler, mockAuthService := setupTestAuthHandler()
+		router := setupAuthRouter(authHandler)
+
+		req := createTypedJSONRequest(http.MethodPost, "/api/v1/auth/users/not-a-uuid/activate", nil)
+
+		// run
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		// Assert: rejected before a uuid cast error could reach the database
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockAuthService.AssertNotCalled(t, "ActivateUser", mock.Anything)
+	})
 }
 
 func TestAuthHandler_DeactivateUser(t *testing.T) {
@@ -269,7 +389,6 @@ func TestAuthHandler_DeactivateUser(t *testing.T) {
 			"DeactivateUser",
 			mock.Anything,
 			mock.Anything,
-			mock.Anything,
 		).Return(updatedUser, nil)
 
 		// Setup router
@@ -295,7 +414,6 @@ func TestAuthHandler_DeactivateUser(t *testing.T) {
 			"DeactivateUser",
 			mock.Anything,
 			mock.Anything,
-			mock.Anything,
 		).Return(nil, apperrors.ErrNotFound)
 
 		// Setup router
@@ -312,4 +430,176 @@ func TestAuthHandler_DeactivateUser(t *testing.T) {
 		assert.Equal(t, http.StatusInternalServerError, w.Code)
 		mockAuthService.AssertExpectations(t)
 	})
+
+	t.Run("InvalidID", func(t *testing.T) {
+		authHandler, mockAuthService := setupTestAuthHandler()
+		router := setupAuthRouter(authHandler)
+
+		req := createTypedJSONRequest(http.MethodPost, "/api/v1/auth/users/not-a-uuid/deactivate", nil)
+
+		// run
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		// Assert: rejected before a uuid cast error could reach the database
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockAuthService.AssertNotCalled(t, "DeactivateUser", mock.Anything, mock.Anything)
+	})
+}
+
+func TestAuthHandler_RefreshCookie(t *testing.T) {
+	t.Run("MaxAgeMatchesRefreshExpiration", func(t *testing.T) {
+		cfg := config.JWTConfig{RefreshTokenExpiration: 48 * time.Hour}
+		requests := map[string]func(*mockService.AuthServiceMock) *http.Request{
+			"/api/v1/auth/register": func(m *mockService.AuthServiceMock) *http.Request {
+				m.On("Register", mock.Anything).Return(createTestTokenResponse(), nil)
+				return createTypedJSONRequest(http.MethodPost, "/api/v1/auth/register", createTestRegisterRequest())
+			},
+			"/api/v1/auth/login": func(m *mockService.AuthServiceMock) *http.Request {
+				m.On("Login", mock.Anything).Return(createTestTokenResponse(), nil)
+				return createTypedJSONRequest(http.MethodPost, "/api/v1/auth/login", createTestLoginRequest())
+			},
+			"/api/v1/auth/refresh": func(m *mockService.AuthServiceMock) *http.Request {
+				m.On("RefreshToken", mock.Anything).Return(createTestTokenResponse(), nil)
+				return createTypedJSONRequest(http.MethodPost, "/api/v1/auth/refresh", nil)
+			},
+		}
+
+		for path, newRequest := range requests {
+			mockAuthService := mockService.NewAuthServiceMock()
+			router := setupAuthRouter(handler.NewAuthHandlerWithConfig(mockAuthService, cfg, zap.NewNop()))
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, newRequest(mockAuthService))
+
+			cookies := w.Result().Cookies()
+			if assert.Len(t, cookies, 1, path) {
+				assert.Equal(t, "gin_api_refresh_token", cookies[0].Name, path)
+				assert.Equal(t, int(cfg.RefreshTokenExpiration.Seconds()), cookies[0].MaxAge, path)
+				assert.True(t, cookies[0].HttpOnly, path)
+			}
+		}
+	})
+}
+
+func TestAuthHandler_Impersonate(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		authHandler, mockAuthService := setupTestAuthHandler()
+		response := &model.ImpersonationResponse{AccessToken: "access-token", TokenType: "Bearer", ExpiresIn: 600, UserID: otherUserID}
+
+		// Setup mock: the caller from the context is the impersonator
+		mockAuthService.On("Impersonate", otherUserID, testUserID).Return(response, nil)
+
+		// run
+		router := setupAuthRouter(authHandler)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, createTypedJSONRequest(http.MethodPost, "/api/v1/admin/users/"+otherUserID+"/impersonate", nil))
+
+		// Assert
+		assert.Equal(t, http.StatusOK, w.Code)
+		var body model.ImpersonationResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		assert.Equal(t, *response, body)
+		mockAuthService.AssertExpectations(t)
+	})
+
+	t.Run("InvalidID", func(t *testing.T) {
+		authHandler, mockAuthService := setupTestAuthHandler()
+
+		// run
+		router := setupAuthRouter(authHandler)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, createTypedJSONRequest(http.MethodPost, "/api/v1/admin/users/not-a-uuid/impersonate", nil))
+
+		// Assert
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockAuthService.AssertNotCalled(t, "Impersonate", mock.Anything, mock.Anything)
+	})
+
+	t.Run("UserNotFound", func(t *testing.T) {
+		authHandler, mockAuthService := setupTestAuthHandler()
+		mockAuthService.On("Impersonate", NonExistentUserID, testUserID).Return(nil, apperrors.ErrNotFound)
+
+		// run
+		router := setupAuthRouter(authHandler)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, createTypedJSONRequest(http.MethodPost, "/api/v1/admin/users/"+NonExistentUserID+"/impersonate", nil))
+
+		// Assert
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("AdminTargetForbidden", func(t *testing.T) {
+		authHandler, mockAuthService := setupTestAuthHandler()
+		mockAuthService.On("Impersonate", otherUserID, testUserID).Return(nil, apperrors.ErrForbidden)
+
+		// run
+		router := setupAuthRouter(authHandler)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, createTypedJSONRequest(http.MethodPost, "/api/v1/admin/users/"+otherUserID+"/impersonate", nil))
+
+		// Assert
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+}
+
+func TestAuthHandler_ImpersonateRouteGuards(t *testing.T) {
+	setup := func() (*mockService.AuthServiceMock, *mockService.APIKeyServiceMock, *gin.Engine) {
+		gin.SetMode(gin.TestMode)
+		mockAuthService := mockService.NewAuthServiceMock()
+		mockAPIKeyService := mockService.NewAPIKeyServiceMock()
+
+		// the registered routes with their real middleware
+		r := gin.New()
+		r.Use(middleware.NewAPIKeyMiddleware(mockAPIKeyService, zap.NewNop()).Authenticate())
+		handler.NewAuthHandler(mockAuthService, zap.NewNop()).RegisterProtectedRoutes(r,
+			middleware.NewAuthMiddleware(mockAuthService, zap.NewNop()), middleware.NewRBACMiddleware(zap.NewNop()))
+		return mockAuthService, mockAPIKeyService, r
+	}
+	perform := func(r *gin.Engine, authorization string) *httptest.ResponseRecorder {
+		req := createTypedJSONRequest(http.MethodPost, "/api/v1/admin/users/"+otherUserID+"/impersonate", nil)
+		req.Header.Set("Authorization", authorization)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("AdminSession", func(t *testing.T) {
+		mockAuthService, _, r := setup()
+		mockAuthService.On("ValidateToken", "admin-token").Return(&model.Claims{UserID: testUserID, Role: model.RoleAdmin}, nil)
+		mockAuthService.On("Impersonate", otherUserID, testUserID).Return(&model.ImpersonationResponse{UserID: otherUserID}, nil)
+
+		w := perform(r, "Bearer admin-token")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("ImpersonationTokenForbidden", func(t *testing.T) {
+		mockAuthService, _, r := setup()
+		mockAuthService.On("ValidateToken", "impersonation-token").Return(&model.Claims{
+			UserID:         testUserID,
+			Role:           model.RoleAdmin,
+			ImpersonatorID: "another-admin",
+		}, nil)
+
+		w := perform(r, "Bearer impersonation-token")
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		mockAuthService.AssertNotCalled(t, "Impersonate", mock.Anything, mock.Anything)
+	})
+
+	t.Run("APIKeyForbidden", func(t *testing.T) {
+		mockAuthService, mockAPIKeyService, r := setup()
+		mockAPIKeyService.On("Authenticate", "gak_admin").Return(&model.APIKey{
+			ID:     1,
+			UserID: testUserID,
+			Scopes: model.APIKeyScopes{model.APIKeyScopeWrite},
+			User:   &model.User{ID: testUserID, Role: model.RoleAdmin, IsActive: true},
+		}, nil)
+
+		w := perform(r, "ApiKey gak_admin")
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		mockAuthService.AssertNotCalled(t, "Impersonate", mock.Anything, mock.Anything)
+	})
 }
@@ -0,0 +1,130 @@
+package handler
+
+import (
+	"go-gin-api-server/internal/handler"
+	"go-gin-api-server/internal/model"
+	"go-gin-api-server/pkg/apperrors"
+	mockService "go-gin-api-server/test/mocks/service"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+// Helper functions
+
+func setupBlockRouter() (*mockService.BlockServiceMock, *gin.Engine) {
+	gin.SetMode(gin.TestMode)
+	mockService := mockService.NewBlockServiceMock()
+	blockHandler := handler.NewBlockHandler(mockService, zap.NewNop())
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("user_role", model.RoleUser)
+		c.Set("user_id", testUserID)
+		c.Next()
+	})
+	r.GET("/users/me/blocks", blockHandler.ListBlocked)
+	r.POST("/users/:id/block", blockHandler.BlockUser)
+	r.DELETE("/users/:id/block", blockHandler.UnblockUser)
+	return mockService, r
+}
+
+// Testcases
+
+func TestBlockUser(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockService, r := setupBlockRouter()
+		mockService.On("Block", testUserID, otherUserID).Return(nil)
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, createJSONHTTPRequest(http.MethodPost, "/users/"+otherUserID+"/block", nil))
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Self", func(t *testing.T) {
+		mockService, r := setupBlockRouter()
+		mockService.On("Block", testUserID, testUserID).Return(apperrors.ErrValidation)
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, createJSONHTTPRequest(http.MethodPost, "/users/"+testUserID+"/block", nil))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("UserNotFound", func(t *testing.T) {
+		mockService, r := setupBlockRouter()
+		mockService.On("Block", testUserID, otherUserID).Return(apperrors.ErrNotFound)
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, createJSONHTTPRequest(http.MethodPost, "/users/"+otherUserID+"/block", nil))
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("InvalidID", func(t *testing.T) {
+		mockService, r := setupBlockRouter()
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, createJSONHTTPRequest(http.MethodPost, "/users/not-a-uuid/block", nil))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "Block", mock.Anything, mock.Anything)
+	})
+}
+
+func TestBlockUserLimit(t *testing.T) {
+	t.Run("LimitExceeded", func(t *testing.T) {
+		mockService, r := setupBlockRouter()
+		mockService.On("Block", testUserID, otherUserID).Return(apperrors.ErrLimitExceeded)
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, createJSONHTTPRequest(http.MethodPost, "/users/"+otherUserID+"/block", nil))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "Block limit reached")
+	})
+}
+
+func TestUnblockUser(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockService, r := setupBlockRouter()
+		mockService.On("Unblock", testUserID, otherUserID).Return(nil)
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, createJSONHTTPRequest(http.MethodDelete, "/users/"+otherUserID+"/block", nil))
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("InvalidID", func(t *testing.T) {
+		mockService, r := setupBlockRouter()
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, createJSONHTTPRequest(http.MethodDelete, "/users/not-a-uuid/block", nil))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "Unblock", mock.Anything, mock.Anything)
+	})
+}
+
+func TestListBlockedUsers(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockService, r := setupBlockRouter()
+		mockService.On("ListBlocked", testUserID).
+			Return(&model.BlockedUsersResponse{BlockedIDs: []string{otherUserID}}, nil)
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, createJSONHTTPRequest(http.MethodGet, "/users/me/blocks", nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), otherUserID)
+	})
+}
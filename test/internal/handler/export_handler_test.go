@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"errors"
+	"go-gin-api-server/internal/handler"
+	"go-gin-api-server/pkg/apperrors"
+	mockService "go-gin-api-server/test/mocks/service"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+func setupTestExportHandler() (*mockService.ExportServiceMock, *gin.Engine) {
+	mockService := mockService.NewExportServiceMock()
+	exportHandler := handler.NewExportHandler(mockService, zap.NewNop())
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("user_id", authorID)
+		c.Next()
+	})
+	r.GET("/users/me/export", exportHandler.ExportMyData)
+	return mockService, r
+}
+
+func TestExportMyData(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockService, r := setupTestExportHandler()
+		mockService.On("Export", authorID, "", mock.Anything).Return(nil, `{"posts":[]}`)
+
+		req := createTypedJSONRequest(http.MethodGet, "/users/me/export", nil)
+		response := httptest.NewRecorder()
+		r.ServeHTTP(response, req)
+
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, `{"posts":[]}`, response.Body.String())
+		assert.Contains(t, response.Header().Get("Content-Disposition"), "attachment")
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("PassesContinuation", func(t *testing.T) {
+		mockService, r := setupTestExportHandler()
+		mockService.On("Export", authorID, "abc=", mock.Anything).Return(nil, `{}`)
+
+		req := createTypedJSONRequest(http.MethodGet, "/users/me/export?continuation=abc%3D", nil)
+		response := httptest.NewRecorder()
+		r.ServeHTTP(response, req)
+
+		assert.Equal(t, http.StatusOK, response.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("InvalidContinuation", func(t *testing.T) {
+		mockService, r := setupTestExportHandler()
+		mockService.On("Export", authorID, "bad", mock.Anything).Return(apperrors.ErrValidation, "")
+
+		req := createTypedJSONRequest(http.MethodGet, "/users/me/export?continuation=bad", nil)
+		response := httptest.NewRecorder()
+		r.ServeHTTP(response, req)
+
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		mockService, r := setupTestExportHandler()
+		mockService.On("Export", authorID, "", mock.Anything).Return(apperrors.ErrNotFound, "")
+
+		req := createTypedJSONRequest(http.MethodGet, "/users/me/export", nil)
+		response := httptest.NewRecorder()
+		r.ServeHTTP(response, req)
+
+		assert.Equal(t, http.StatusNotFound, response.Code)
+		assert.Empty(t, response.Header().Get("Content-Disposition"))
+	})
+
+	t.Run("FailureMidStreamKeepsStatus", func(t *testing.T) {
+		mockService, r := setupTestExportHandler()
+		mockService.On("Export", authorID, "", mock.Anything).Return(errors.New("connection reset"), `{"posts":[`)
+
+		req := createTypedJSONRequest(http.MethodGet, "/users/me/export", nil)
+		response := httptest.NewRecorder()
+		r.ServeHTTP(response, req)
+
+		// the body had started, no error document is appended
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, `{"posts":[`, response.Body.String())
+	})
+}
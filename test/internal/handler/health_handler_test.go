@@ -0,0 +1,74 @@
+package handler
+
+import (
+	"encoding/json"
+	"go-gin-api-server/internal/handler"
+	"go-gin-api-server/internal/model"
+	mockService "go-gin-api-server/test/mocks/service"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func setupTestHealthHandler() (*mockService.HealthServiceMock, *gin.Engine) {
+	mockService := mockService.NewHealthServiceMock()
+	healthHandler := handler.NewHealthHandler(mockService, zap.NewNop())
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/admin/health", healthHandler.GetHealthDetails)
+	return mockService, r
+}
+
+func TestGetHealthDetails(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockService, r := setupTestHealthHandler()
+		mockService.On("Details").Return(&model.HealthDetails{
+			Status:   model.HealthStatusOK,
+			Versions: map[string]string{"go": "go1.24", "postgres": "16.2"},
+			Database: model.DatabaseHealth{
+				MigrationVersion:   15,
+				MaxOpenConnections: 10,
+				OpenConnections:    3,
+				InUse:              1,
+				Idle:               2,
+			},
+			Features: map[string]bool{"welcome_post": false},
+		})
+
+		req := createTypedJSONRequest(http.MethodGet, "/admin/health", nil)
+		response := httptest.NewRecorder()
+		r.ServeHTTP(response, req)
+
+		assert.Equal(t, http.StatusOK, response.Code)
+		var body map[string]interface{}
+		assert.NoError(t, json.Unmarshal(response.Body.Bytes(), &body))
+		db, ok := body["database"].(map[string]interface{})
+		assert.True(t, ok)
+		assert.EqualValues(t, 3, db["open_connections"])
+		assert.EqualValues(t, 1, db["in_use"])
+		assert.EqualValues(t, 15, db["migration_version"])
+		assert.LessOrEqual(t, db["in_use"].(float64), db["open_connections"].(float64))
+		assert.Contains(t, body, "features")
+		assert.Contains(t, body, "versions")
+	})
+
+	t.Run("Degraded", func(t *testing.T) {
+		mockService, r := setupTestHealthHandler()
+		mockService.On("Details").Return(&model.HealthDetails{
+			Status: model.HealthStatusDegraded,
+			Errors: []string{"connection refused"},
+		})
+
+		req := createTypedJSONRequest(http.MethodGet, "/admin/health", nil)
+		response := httptest.NewRecorder()
+		r.ServeHTTP(response, req)
+
+		assert.Equal(t, http.StatusServiceUnavailable, response.Code)
+		assert.Contains(t, response.Body.String(), `"status":"degraded"`)
+	})
+}
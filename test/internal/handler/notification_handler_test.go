@@ -0,0 +1,218 @@
+package handler
+
+import (
+	"encoding/json"
+	"go-gin-api-server/internal/handler"
+	"go-gin-api-server/internal/model"
+	"go-gin-api-server/pkg/apperrors"
+	mockService "go-gin-api-server/test/mocks/service"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+// Helper functions
+
+func setupNotificationRouter() (*mockService.NotificationServiceMock, *gin.Engine) {
+	gin.SetMode(gin.TestMode)
+	mockService := mockService.NewNotificationServiceMock()
+	notificationHandler := handler.NewNotificationHandler(mockService, zap.NewNop())
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("user_role", model.RoleUser)
+		c.Set("user_id", testUserID)
+		c.Next()
+	})
+	r.GET("/notifications", notificationHandler.GetNotifications)
+	r.GET("/notifications/mentions", notificationHandler.GetMentions)
+	r.GET("/notifications/unread-count", notificationHandler.GetUnreadCount)
+	r.POST("/notifications/:id/read", notificationHandler.MarkRead)
+	return mockService, r
+}
+
+// Testcases
+
+func TestGetNotifications(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockService, r := setupNotificationRouter()
+		expected := &model.CursorResponse[model.Notification]{Data: []model.Notification{{ID: 1, UserID: testUserID}}}
+		mockService.On("List", testUserID, mock.Anything).Return(expected, nil)
+
+		req, _ := http.NewRequest("GET", "/notifications?limit=10", nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response model.CursorResponse[model.Notification]
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Len(t, response.Data, 1)
+		assert.False(t, response.HasMore)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("PaginatesWithStatusFilter", func(t *testing.T) {
+		mockService, r := setupNotificationRouter()
+		next := model.EncodeCursor(model.Cursor{ID: "2", CreatedAt: time.Date(2024, 1, 1, 8, 0, 0, 0, time.UTC)})
+		unread := func(req model.NotificationListRequest) bool {
+			return req.Status != nil && *req.Status == model.NotificationUnread && req.Limit == 2
+		}
+		mockService.On("List", testUserID, mock.MatchedBy(func(req model.NotificationListRequest) bool {
+			return unread(req) && req.Cursor == ""
+		})).Return(&model.CursorResponse[model.Notification]{
+			Data:    []model.Notification{{ID: 3}, {ID: 2}},
+			Next:    next,
+			HasMore: true,
+		}, nil).Once()
+		mockService.On("List", testUserID, mock.MatchedBy(func(req model.NotificationListRequest) bool {
+			return unread(req) && req.Cursor == next
+		})).Return(&model.CursorResponse[model.Notification]{
+			Data: []model.Notification{{ID: 1}},
+		}, nil).Once()
+
+		// run: follow the cursor from the first page
+		req, _ := http.NewRequest("GET", "/notifications?status=unread&limit=2", nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var first model.CursorResponse[model.Notification]
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &first))
+
+		req, _ = http.NewRequest("GET", "/notifications?status=unread&limit=2&cursor="+url.QueryEscape(first.Next), nil)
+		w = httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		// assert
+		assert.Equal(t, http.StatusOK, w.Code)
+		var second model.CursorResponse[model.Notification]
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &second))
+		assert.True(t, first.HasMore)
+		assert.Len(t, second.Data, 1)
+		assert.False(t, second.HasMore)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("InvalidStatus", func(t *testing.T) {
+		mockService, r := setupNotificationRouter()
+
+		req, _ := http.NewRequest("GET", "/notifications?status=archived", nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "List", mock.Anything, mock.Anything)
+	})
+
+	t.Run("InvalidLimit", func(t *testing.T) {
+		mockService, r := setupNotificationRouter()
+
+		req, _ := http.NewRequest("GET", "/notifications?limit=1000", nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "List", mock.Anything, mock.Anything)
+	})
+
+	t.Run("InvalidCursor", func(t *testing.T) {
+		mockService, r := setupNotificationRouter()
+		mockService.On("List", testUserID, mock.Anything).Return(nil, apperrors.ErrValidation)
+
+		req, _ := http.NewRequest("GET", "/notifications?cursor=bogus", nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestGetMentions(t *testing.T) {
+	t.Run("FiltersToMentions", func(t *testing.T) {
+		mockService, r := setupNotificationRouter()
+		mockService.On("List", testUserID, mock.MatchedBy(func(req model.NotificationListRequest) bool {
+			return req.Type != nil && *req.Type == model.NotificationMention &&
+				req.Status != nil && *req.Status == model.NotificationUnread && req.Limit == 5
+		})).Return(&model.CursorResponse[model.Notification]{
+			Data: []model.Notification{{ID: 1, UserID: testUserID, Type: model.NotificationMention}},
+		}, nil)
+
+		// type is fixed by the route, a query param can't widen it
+		req, _ := http.NewRequest("GET", "/notifications/mentions?status=unread&limit=5&type=like", nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response model.CursorResponse[model.Notification]
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Len(t, response.Data, 1)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("InvalidLimit", func(t *testing.T) {
+		mockService, r := setupNotificationRouter()
+
+		req, _ := http.NewRequest("GET", "/notifications/mentions?limit=1000", nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "List", mock.Anything, mock.Anything)
+	})
+}
+
+func TestGetUnreadCount(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockService, r := setupNotificationRouter()
+		mockService.On("UnreadCount", testUserID).Return(int64(3), nil)
+
+		req, _ := http.NewRequest("GET", "/notifications/unread-count", nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"count":3}`, w.Body.String())
+	})
+}
+
+func TestMarkNotificationRead(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockService, r := setupNotificationRouter()
+		mockService.On("MarkRead", uint64(5), testUserID).Return(nil)
+
+		req, _ := http.NewRequest("POST", "/notifications/5/read", nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		mockService, r := setupNotificationRouter()
+		mockService.On("MarkRead", uint64(5), testUserID).Return(apperrors.ErrNotFound)
+
+		req, _ := http.NewRequest("POST", "/notifications/5/read", nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("InvalidID", func(t *testing.T) {
+		mockService, r := setupNotificationRouter()
+
+		req, _ := http.NewRequest("POST", "/notifications/abc/read", nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "MarkRead", mock.Anything, mock.Anything)
+	})
+}
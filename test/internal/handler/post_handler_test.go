@@ -1,13 +1,22 @@
 package handler
 
 import (
+	"encoding/json"
+	"errors"
+	"go-gin-api-server/config"
 	"go-gin-api-server/internal/handler"
+	"go-gin-api-server/internal/middleware"
 	"go-gin-api-server/internal/model"
+	"go-gin-api-server/internal/service"
 	"go-gin-api-server/pkg/apperrors"
+	"go-gin-api-server/pkg/jsonschema"
+	mockRepository "go-gin-api-server/test/mocks/repository"
 	mockService "go-gin-api-server/test/mocks/service"
 	"net/http"
 	"net/http/httptest"
 	"strconv"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -34,10 +43,19 @@ func setupPostRouter(postHandler *handler.PostHandler) *gin.Engine {
 	})
 
 	r.GET("/posts", postHandler.GetPosts)
+	r.GET("/posts/limits", postHandler.GetPostLimits)
+	r.GET("/admin/posts", postHandler.GetPostsPaged)
 	r.GET("/posts/:id", postHandler.GetPostByID)
+	r.GET("/posts/slug/:slug", postHandler.GetPostBySlug)
 	r.POST("/posts", postHandler.CreatePost)
 	r.PATCH("/posts/:id", postHandler.UpdatePost)
+	r.PUT("/posts/:id", postHandler.ReplacePost)
 	r.DELETE("/posts/:id", postHandler.DeletePost)
+	r.POST("/posts/validate", postHandler.ValidatePost)
+	r.POST("/posts/preview", postHandler.PreviewPost)
+	r.GET("/posts/:id/revisions", postHandler.GetPostRevisions)
+	r.POST("/posts/:id/hide", postHandler.HidePost)
+	r.DELETE("/admin/posts/:id", postHandler.AdminDeletePost)
 	return r
 }
 
@@ -111,6 +129,158 @@ func TestCreatePost(t *testing.T) {
 		mockService.AssertExpectations(t)
 	})
 
+	t.Run("WithTitle", func(t *testing.T) {
+		mockService, postHandler := setupTestPostHandler()
+		r := setupPostRouter(postHandler)
+
+		expected := createTestPost()
+		mockService.On("Create", mock.MatchedBy(func(p *model.Post) bool {
+			return p.Title != nil && *p.Title == "A title"
+		})).Return(expected, nil)
+
+		req := createTypedJSONRequest(http.MethodPost, "/posts", map[string]interface{}{
+			"title":   "A title",
+			"content": "Test Content",
+		})
+
+		// run
+		response := httptest.NewRecorder()
+		r.ServeHTTP(response, req)
+
+		// assert
+		assert.Equal(t, http.StatusCreated, response.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("InvalidTitle", func(t *testing.T) {
+		mockService, postHandler := setupTestPostHandler()
+		r := setupPostRouter(postHandler)
+
+		mockService.On("Create", mock.Anything).Return(nil, apperrors.ErrPostTitleTooLong)
+
+		req := createTypedJSONRequest(http.MethodPost, "/posts", map[string]interface{}{
+			"title":   "A title",
+			"content": "Test Content",
+		})
+
+		// run
+		response := httptest.NewRecorder()
+		r.ServeHTTP(response, req)
+
+		// assert
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+		assert.Contains(t, response.Body.String(), "Post title is too long")
+	})
+
+	t.Run("TooManyMentions", func(t *testing.T) {
+		// the real service, whose error also wraps ErrValidation
+		repo := mockRepository.NewPostRepositoryMock()
+		postService := service.NewPostServiceWithConfig(repo, config.PostConfig{
+			ContentMinLength:     10,
+			ContentMaxLength:     255,
+			MaxMentions:          2,
+			RejectExcessMentions: true,
+		})
+		r := setupPostRouter(handler.NewPostHandler(postService, zap.NewNop()))
+
+		req := createTypedJSONRequest(http.MethodPost, "/posts", map[string]interface{}{
+			"content": "Hello @alice @bob @carol",
+		})
+
+		// run
+		response := httptest.NewRecorder()
+		r.ServeHTTP(response, req)
+
+		// assert
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+		assert.Contains(t, response.Body.String(), "Post mentions too many users")
+		repo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+
+	t.Run("ConfiguredMinimumBelowTen", func(t *testing.T) {
+		// the real service, so only POST_CONTENT_MIN_LENGTH decides what is too short
+		repo := mockRepository.NewPostRepositoryMock()
+		postService := service.NewPostServiceWithConfig(repo, config.PostConfig{
+			ContentMinLength: 3,
+			ContentMaxLength: 255,
+		})
+		r := setupPostRouter(handler.NewPostHandler(postService, zap.NewNop()))
+		repo.On("Create", mock.Anything).Return(createTestPost(map[string]interface{}{"content": "Hey all"}), nil)
+
+		req := createTypedJSONRequest(http.MethodPost, "/posts", map[string]interface{}{
+			"content": "Hey all",
+		})
+
+		// run
+		response := httptest.NewRecorder()
+		r.ServeHTTP(response, req)
+
+		// assert
+		assert.Equal(t, http.StatusCreated, response.Code)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("LocationHeader", func(t *testing.T) {
+		mockService, postHandler := setupTestPostHandler()
+		r := setupPostRouter(postHandler)
+
+		expected := createTestPost(map[string]interface{}{"id": uint64(42)})
+		mockService.On("Create", mock.Anything).Return(expected, nil)
+
+		req := createTypedJSONRequest(http.MethodPost, "/posts", &model.Post{Content: "Test Content"})
+
+		// run
+		response := httptest.NewRecorder()
+		r.ServeHTTP(response, req)
+
+		// assert
+		assert.Equal(t, http.StatusCreated, response.Code)
+		assert.Equal(t, "/api/v1/posts/42", response.Header().Get("Location"))
+	})
+
+	t.Run("NoLocationOnError", func(t *testing.T) {
+		mockService, postHandler := setupTestPostHandler()
+		r := setupPostRouter(postHandler)
+
+		mockService.On("Create", mock.Anything).Return(nil, apperrors.ErrPostContentTooShort)
+
+		req := createTypedJSONRequest(http.MethodPost, "/posts", &model.Post{Content: "Test Content"})
+
+		// run
+		response := httptest.NewRecorder()
+		r.ServeHTTP(response, req)
+
+		// assert
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+		assert.Empty(t, response.Header().Get("Location"))
+	})
+
+	t.Run("CollectedValidationErrors", func(t *testing.T) {
+		mockService, postHandler := setupTestPostHandler()
+		r := setupPostRouter(postHandler)
+
+		mockService.On("Create", mock.Anything).Return(nil, &apperrors.ValidationErrors{Errs: []error{
+			apperrors.ErrPostContentTooShort,
+			apperrors.ErrPostTitleTooLong,
+		}})
+
+		req := createTypedJSONRequest(http.MethodPost, "/posts", &model.Post{Content: "Test Content"})
+
+		// run
+		response := httptest.NewRecorder()
+		r.ServeHTTP(response, req)
+
+		// assert
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+		var body model.ErrorResponse
+		assert.NoError(t, json.Unmarshal(response.Body.Bytes(), &body))
+		assert.Equal(t, "Validation failed", body.Error)
+		assert.Equal(t, []jsonschema.FieldError{
+			{Field: "content", Message: "Post content is too short"},
+			{Field: "title", Message: "Post title is too long"},
+		}, body.Details)
+	})
+
 	t.Run("BindingError", func(t *testing.T) {
 		mockService, postHandler := setupTestPostHandler()
 		r := setupPostRouter(postHandler)
@@ -171,6 +341,76 @@ func TestUpdatePost(t *testing.T) {
 		mockService.AssertExpectations(t)
 	})
 
+	t.Run("IfMatchPassedToService", func(t *testing.T) {
+		mockService, postHandler := setupTestPostHandler()
+		r := setupPostRouter(postHandler)
+
+		current := createTestPost()
+		expected := createTestPost(map[string]interface{}{"updated_at": current.UpdatedAt.Add(time.Second)})
+		mockService.On("UpdateIfMatch", uint64(1), mock.Anything, current.ETag()).Return(expected, nil)
+
+		req := createTypedJSONRequest(http.MethodPatch, "/posts/1", &model.Post{Content: "Updated Content"})
+		req.Header.Set("If-Match", current.ETag())
+
+		response := httptest.NewRecorder()
+		r.ServeHTTP(response, req)
+
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, expected.ETag(), response.Header().Get("ETag"))
+		mockService.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("StaleIfMatch", func(t *testing.T) {
+		mockService, postHandler := setupTestPostHandler()
+		r := setupPostRouter(postHandler)
+
+		mockService.On("UpdateIfMatch", uint64(1), mock.Anything, `"1-0"`).Return(nil, apperrors.ErrPreconditionFailed)
+
+		req := createTypedJSONRequest(http.MethodPatch, "/posts/1", &model.Post{Content: "Updated Content"})
+		req.Header.Set("If-Match", `"1-0"`)
+
+		response := httptest.NewRecorder()
+		r.ServeHTTP(response, req)
+
+		assert.Equal(t, http.StatusPreconditionFailed, response.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("MissingRequiredIfMatch", func(t *testing.T) {
+		mockService, postHandler := setupTestPostHandler()
+		r := setupPostRouter(postHandler)
+
+		mockService.On("Update", uint64(1), mock.Anything).Return(nil, apperrors.ErrPreconditionRequired)
+
+		req := createTypedJSONRequest(http.MethodPatch, "/posts/1", &model.Post{Content: "Updated Content"})
+
+		response := httptest.NewRecorder()
+		r.ServeHTTP(response, req)
+
+		assert.Equal(t, http.StatusPreconditionRequired, response.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("OmittedFieldsLeftUnchanged", func(t *testing.T) {
+		mockService, postHandler := setupTestPostHandler()
+		r := setupPostRouter(postHandler)
+
+		expected := createTestPost()
+		// an omitted content reaches the service empty, which it skips
+		mockService.On("Update", uint64(1), mock.MatchedBy(func(p *model.Post) bool {
+			return p.Content == ""
+		})).Return(expected, nil)
+
+		req := createTypedJSONRequest(http.MethodPatch, "/posts/1", map[string]interface{}{})
+
+		response := httptest.NewRecorder()
+		r.ServeHTTP(response, req)
+
+		assert.Equal(t, http.StatusOK, response.Code)
+		mockService.AssertExpectations(t)
+	})
+
 	t.Run("BindingError_InvalidID", func(t *testing.T) {
 		mockService, postHandler := setupTestPostHandler()
 		r := setupPostRouter(postHandler)
@@ -205,6 +445,89 @@ func TestUpdatePost(t *testing.T) {
 	})
 }
 
+func TestReplacePost(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockService, postHandler := setupTestPostHandler()
+		r := setupPostRouter(postHandler)
+
+		expected := createTestPost()
+		mockService.On("Replace", uint64(1), mock.MatchedBy(func(p *model.Post) bool {
+			return p.Content == "Replaced Content"
+		})).Return(expected, nil)
+
+		req := createTypedJSONRequest(http.MethodPut, "/posts/1", &model.ReplacePostRequest{
+			Content: "Replaced Content",
+		})
+
+		response := httptest.NewRecorder()
+		r.ServeHTTP(response, req)
+
+		assert.Equal(t, http.StatusOK, response.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("StaleIfMatch", func(t *testing.T) {
+		mockService, postHandler := setupTestPostHandler()
+		r := setupPostRouter(postHandler)
+
+		mockService.On("ReplaceIfMatch", uint64(1), mock.Anything, `"1-0"`).Return(nil, apperrors.ErrPreconditionFailed)
+
+		req := createTypedJSONRequest(http.MethodPut, "/posts/1", &model.ReplacePostRequest{Content: "Replaced Content"})
+		req.Header.Set("If-Match", `"1-0"`)
+
+		response := httptest.NewRecorder()
+		r.ServeHTTP(response, req)
+
+		assert.Equal(t, http.StatusPreconditionFailed, response.Code)
+		mockService.AssertNotCalled(t, "Replace", mock.Anything, mock.Anything)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("BindingError_MissingContent", func(t *testing.T) {
+		mockService, postHandler := setupTestPostHandler()
+		r := setupPostRouter(postHandler)
+
+		// a full replacement requires every editable field
+		req := createTypedJSONRequest(http.MethodPut, "/posts/1", map[string]interface{}{})
+
+		response := httptest.NewRecorder()
+		r.ServeHTTP(response, req)
+
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+		mockService.AssertNotCalled(t, "Replace")
+	})
+
+	t.Run("BindingError_InvalidID", func(t *testing.T) {
+		mockService, postHandler := setupTestPostHandler()
+		r := setupPostRouter(postHandler)
+
+		req := createTypedJSONRequest(http.MethodPut, "/posts/invalid", nil)
+
+		response := httptest.NewRecorder()
+		r.ServeHTTP(response, req)
+
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+		mockService.AssertNotCalled(t, "Replace")
+	})
+
+	t.Run("ServiceError", func(t *testing.T) {
+		mockService, postHandler := setupTestPostHandler()
+		r := setupPostRouter(postHandler)
+
+		mockService.On("Replace", mock.Anything, mock.Anything).Return(nil, apperrors.ErrForbidden)
+
+		req := createTypedJSONRequest(http.MethodPut, "/posts/1", &model.ReplacePostRequest{
+			Content: "Replaced Content",
+		})
+
+		response := httptest.NewRecorder()
+		r.ServeHTTP(response, req)
+
+		assert.Equal(t, http.StatusForbidden, response.Code)
+		mockService.AssertExpectations(t)
+	})
+}
+
 func TestDeletePost(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
 		mockService, postHandler := setupTestPostHandler()
@@ -251,13 +574,45 @@ func TestDeletePost(t *testing.T) {
 	})
 }
 
+func TestPostWriteMissingVsUnowned(t *testing.T) {
+	// missing (or hidden from the caller) posts are 404 like GetPostByID, visible posts
+	// owned by someone else are 403
+	cases := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{"Missing", apperrors.ErrNotFound, http.StatusNotFound},
+		{"Unowned", apperrors.ErrForbidden, http.StatusForbidden},
+	}
+	for _, tc := range cases {
+		for _, method := range []string{http.MethodPatch, http.MethodPut, http.MethodDelete} {
+			t.Run(tc.name+"_"+method, func(t *testing.T) {
+				repo := mockRepository.NewPostRepositoryMock()
+				r := setupPostRouter(handler.NewPostHandler(service.NewPostService(repo), zap.NewNop()))
+				repo.On("CheckPermission", uint64(1), authorID).Return(tc.err)
+
+				req := createTypedJSONRequest(method, "/posts/1", &model.Post{Content: "Updated Content"})
+				response := httptest.NewRecorder()
+				r.ServeHTTP(response, req)
+
+				assert.Equal(t, tc.status, response.Code)
+				repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+				repo.AssertNotCalled(t, "Replace", mock.Anything, mock.Anything)
+				repo.AssertNotCalled(t, "Delete", mock.Anything)
+			})
+		}
+	}
+}
+
 func TestGetPosts(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
 		mockService, postHandler := setupTestPostHandler()
 		r := setupPostRouter(postHandler)
 
-		expectedResponse := &model.CursorResponse[model.Post]{
-			Data:    []model.Post{*createTestPost()},
+		post := *createTestPost()
+		expectedResponse := &model.CursorResponse[model.PostResponse]{
+			Data:    []model.PostResponse{{Post: post, Author: &model.AuthorSummary{ID: post.AuthorID, Name: "Test Author"}}},
 			Next:    "",
 			HasMore: false,
 		}
@@ -269,6 +624,28 @@ func TestGetPosts(t *testing.T) {
 		response := httptest.NewRecorder()
 		r.ServeHTTP(response, req)
 
+		assert.Equal(t, http.StatusOK, response.Code)
+		var body model.CursorResponse[model.PostResponse]
+		assert.NoError(t, json.Unmarshal(response.Body.Bytes(), &body))
+		if assert.Len(t, body.Data, 1) && assert.NotNil(t, body.Data[0].Author) {
+			assert.Equal(t, post.AuthorID, body.Data[0].Author.ID)
+		}
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("PassesViewerForBlocks", func(t *testing.T) {
+		mockService, postHandler := setupTestPostHandler()
+		r := setupPostRouter(postHandler)
+
+		mockService.On("List", mock.MatchedBy(func(req model.PostListRequest) bool {
+			return req.ViewerID == authorID
+		})).Return(&model.CursorResponse[model.PostResponse]{Data: []model.PostResponse{}}, nil)
+
+		req := createTypedJSONRequest(http.MethodGet, "/posts", nil)
+
+		response := httptest.NewRecorder()
+		r.ServeHTTP(response, req)
+
 		assert.Equal(t, http.StatusOK, response.Code)
 		mockService.AssertExpectations(t)
 	})
@@ -287,6 +664,47 @@ func TestGetPosts(t *testing.T) {
 		mockService.AssertNotCalled(t, "List")
 	})
 
+	t.Run("LimitContract", func(t *testing.T) {
+		tests := []struct {
+			query        string
+			expectedCode int
+			boundLimit   int // limit handed to the service, which applies the default for 0
+		}{
+			{query: "", expectedCode: http.StatusOK, boundLimit: 0},
+			{query: "?limit=0", expectedCode: http.StatusOK, boundLimit: 0},
+			{query: "?limit=1", expectedCode: http.StatusOK, boundLimit: 1},
+			{query: "?limit=100", expectedCode: http.StatusOK, boundLimit: 100},
+			{query: "?limit=101", expectedCode: http.StatusBadRequest},
+			{query: "?limit=-1", expectedCode: http.StatusBadRequest},
+			{query: "?limit=abc", expectedCode: http.StatusBadRequest},
+		}
+
+		for _, tt := range tests {
+			t.Run("limit"+tt.query, func(t *testing.T) {
+				mockService, postHandler := setupTestPostHandler()
+				r := setupPostRouter(postHandler)
+
+				if tt.expectedCode == http.StatusOK {
+					mockService.On("List", mock.MatchedBy(func(req model.PostListRequest) bool {
+						return req.Limit == tt.boundLimit
+					})).Return(&model.CursorResponse[model.PostResponse]{Data: []model.PostResponse{}}, nil)
+				}
+
+				req := createTypedJSONRequest(http.MethodGet, "/posts"+tt.query, nil)
+				response := httptest.NewRecorder()
+				r.ServeHTTP(response, req)
+
+				assert.Equal(t, tt.expectedCode, response.Code)
+				if tt.expectedCode == http.StatusOK {
+					mockService.AssertExpectations(t)
+				} else {
+					assert.Contains(t, response.Body.String(), "Invalid request format")
+					mockService.AssertNotCalled(t, "List", mock.Anything)
+				}
+			})
+		}
+	})
+
 	t.Run("ServiceError", func(t *testing.T) {
 		mockService, postHandler := setupTestPostHandler()
 		r := setupPostRouter(postHandler)
@@ -301,53 +719,806 @@ func TestGetPosts(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, response.Code)
 		mockService.AssertExpectations(t)
 	})
-}
 
-func TestGetPostByID(t *testing.T) {
-	t.Run("Success", func(t *testing.T) {
+	t.Run("ConditionalRequest", func(t *testing.T) {
 		mockService, postHandler := setupTestPostHandler()
 		r := setupPostRouter(postHandler)
 
-		expected := createTestPost()
-		mockService.On("GetByID", mock.Anything).Return(expected, nil)
+		updatedAt := time.Date(2024, 1, 1, 8, 0, 0, 500000000, time.UTC)
+		page := func(posts ...model.Post) *model.CursorResponse[model.PostResponse] {
+			data := make([]model.PostResponse, 0, len(posts))
+			for _, post := range posts {
+				data = append(data, model.PostResponse{Post: post})
+			}
+			return &model.CursorResponse[model.PostResponse]{Data: data}
+		}
+		older := model.Post{ID: 1, Content: "Older content", AuthorID: authorID, UpdatedAt: updatedAt}
+		newer := model.Post{ID: 2, Content: "Newer content", AuthorID: authorID, UpdatedAt: updatedAt.Add(2 * time.Second)}
+
+		mockService.On("List", mock.Anything).Return(page(older), nil).Times(2)
+		mockService.On("List", mock.Anything).Return(page(newer, older), nil).Once()
+
+		// first fetch carries Last-Modified
+		first := httptest.NewRecorder()
+		r.ServeHTTP(first, createTypedJSONRequest(http.MethodGet, "/posts", nil))
+		assert.Equal(t, http.StatusOK, first.Code)
+		lastModified := first.Header().Get("Last-Modified")
+		assert.Equal(t, updatedAt.Truncate(time.Second).Format(http.TimeFormat), lastModified)
+
+		// nothing newer: 304 without a body
+		req := createTypedJSONRequest(http.MethodGet, "/posts", nil)
+		req.Header.Set("If-Modified-Since", lastModified)
+		second := httptest.NewRecorder()
+		r.ServeHTTP(second, req)
+		assert.Equal(t, http.StatusNotModified, second.Code)
+		assert.Empty(t, second.Body.String())
+
+		// a newer post invalidates the client's copy
+		req = createTypedJSONRequest(http.MethodGet, "/posts", nil)
+		req.Header.Set("If-Modified-Since", lastModified)
+		third := httptest.NewRecorder()
+		r.ServeHTTP(third, req)
+		assert.Equal(t, http.StatusOK, third.Code)
+		assert.Equal(t, newer.UpdatedAt.Format(http.TimeFormat), third.Header().Get("Last-Modified"))
+		mockService.AssertExpectations(t)
+	})
 
-		NonExistentPostIDStr := strconv.FormatUint(NonExistentPostID, 10)
-		req := createTypedJSONRequest(http.MethodGet, "/posts/"+NonExistentPostIDStr, nil)
+	t.Run("EmptyPageHasNoLastModified", func(t *testing.T) {
+		mockService, postHandler := setupTestPostHandler()
+		r := setupPostRouter(postHandler)
+
+		mockService.On("List", mock.Anything).Return(&model.CursorResponse[model.PostResponse]{Data: []model.PostResponse{}}, nil)
 
+		req := createTypedJSONRequest(http.MethodGet, "/posts", nil)
+		req.Header.Set("If-Modified-Since", time.Now().UTC().Format(http.TimeFormat))
 		response := httptest.NewRecorder()
 		r.ServeHTTP(response, req)
 
 		assert.Equal(t, http.StatusOK, response.Code)
-		mockService.AssertExpectations(t)
+		assert.Empty(t, response.Header().Get("Last-Modified"))
 	})
 
-	t.Run("BindingError_InvalidID", func(t *testing.T) {
+	t.Run("SortAndDateRange", func(t *testing.T) {
 		mockService, postHandler := setupTestPostHandler()
 		r := setupPostRouter(postHandler)
 
-		// invalid id parameter
-		req := createTypedJSONRequest(http.MethodGet, "/posts/invalid", nil)
+		mockService.On("List", mock.MatchedBy(func(req model.PostListRequest) bool {
+			return req.Limit == 5 &&
+				req.Query.Sort == "created_at" &&
+				req.Query.Ascending() &&
+				req.Query.CreatedAfter != nil && req.Query.CreatedAfter.Equal(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)) &&
+				req.Query.CreatedBefore == nil
+		})).Return(&model.CursorResponse[model.PostResponse]{Data: []model.PostResponse{}}, nil)
 
+		req := createTypedJSONRequest(http.MethodGet, "/posts?limit=5&sort=created_at&order=asc&created_after=2024-01-01T00:00:00Z", nil)
 		response := httptest.NewRecorder()
 		r.ServeHTTP(response, req)
 
-		assert.Equal(t, http.StatusBadRequest, response.Code)
-		mockService.AssertNotCalled(t, "GetByID")
+		assert.Equal(t, http.StatusOK, response.Code)
+		mockService.AssertExpectations(t)
 	})
 
-	t.Run("NotFound", func(t *testing.T) {
+	t.Run("Search", func(t *testing.T) {
 		mockService, postHandler := setupTestPostHandler()
 		r := setupPostRouter(postHandler)
 
-		mockService.On("GetByID", mock.Anything).Return(nil, apperrors.ErrNotFound)
-
-		NonExistentPostIDStr := strconv.FormatUint(NonExistentPostID, 10)
-		req := createTypedJSONRequest(http.MethodGet, "/posts/"+NonExistentPostIDStr, nil)
+		mockService.On("List", mock.MatchedBy(func(req model.PostListRequest) bool {
+			return req.Search == "golang tips" && req.Limit == 5
+		})).Return(&model.CursorResponse[model.PostResponse]{Data: []model.PostResponse{}}, nil)
 
+		req := createTypedJSONRequest(http.MethodGet, "/posts?limit=5&q=golang+tips", nil)
 		response := httptest.NewRecorder()
 		r.ServeHTTP(response, req)
 
-		assert.Equal(t, http.StatusNotFound, response.Code)
+		assert.Equal(t, http.StatusOK, response.Code)
 		mockService.AssertExpectations(t)
 	})
+
+	t.Run("InvalidListQuery", func(t *testing.T) {
+		for _, query := range []string{"sort=content", "order=sideways", "created_before=last-week"} {
+			mockService, postHandler := setupTestPostHandler()
+			r := setupPostRouter(postHandler)
+
+			req := createTypedJSONRequest(http.MethodGet, "/posts?"+query, nil)
+			response := httptest.NewRecorder()
+			r.ServeHTTP(response, req)
+
+			assert.Equal(t, http.StatusBadRequest, response.Code, query)
+			mockService.AssertNotCalled(t, "List", mock.Anything)
+		}
+	})
+
+	t.Run("IgnoresModerationParams", func(t *testing.T) {
+		repo := mockRepository.NewPostRepositoryMock()
+		r := setupPostRouter(handler.NewPostHandler(service.NewPostService(repo), zap.NewNop()))
+
+		repo.On("List", mock.MatchedBy(func(opts model.PostListOptions) bool {
+			return !opts.IncludeHidden && !opts.HiddenOnly && !opts.ArchivedOnly
+		})).Return([]model.Post{}, nil)
+
+		req := createTypedJSONRequest(http.MethodGet, "/posts?status=hidden&include_hidden=true&hidden_only=true", nil)
+		response := httptest.NewRecorder()
+		r.ServeHTTP(response, req)
+
+		assert.Equal(t, http.StatusOK, response.Code)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("OwnArchivedPosts", func(t *testing.T) {
+		repo := mockRepository.NewPostRepositoryMock()
+		r := setupPostRouter(handler.NewPostHandler(service.NewPostService(repo), zap.NewNop()))
+
+		repo.On("List", mock.MatchedBy(func(opts model.PostListOptions) bool {
+			return opts.ArchivedOnly && opts.AuthorID != nil && *opts.AuthorID == authorID
+		})).Return([]model.Post{}, nil)
+
+		req := createTypedJSONRequest(http.MethodGet, "/posts?status=archived", nil)
+		response := httptest.NewRecorder()
+		r.ServeHTTP(response, req)
+
+		assert.Equal(t, http.StatusOK, response.Code)
+		repo.AssertExpectations(t)
+	})
+}
+
+func TestPostWriteResponsesIDIsString(t *testing.T) {
+	// beyond JavaScript's Number.MAX_SAFE_INTEGER (2^53 - 1)
+	const unsafeJSPostID uint64 = 1<<53 + 1
+	path := "/posts/" + strconv.FormatUint(unsafeJSPostID, 10)
+	post := createTestPost(map[string]interface{}{"id": unsafeJSPostID})
+	post.Author = &model.User{ID: authorID, Name: "Author"}
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   interface{}
+		setup  func(*mockService.PostServiceMock)
+	}{
+		{"Create", http.MethodPost, "/posts", &model.Post{Content: "Test Content"}, func(m *mockService.PostServiceMock) {
+			m.On("Create", mock.Anything).Return(post, nil)
+		}},
+		{"Update", http.MethodPatch, path, &model.Post{Content: "Updated Content"}, func(m *mockService.PostServiceMock) {
+			m.On("Update", unsafeJSPostID, mock.Anything).Return(post, nil)
+		}},
+		{"Replace", http.MethodPut, path, &model.ReplacePostRequest{Content: "Replaced Content"}, func(m *mockService.PostServiceMock) {
+			m.On("Replace", unsafeJSPostID, mock.Anything).Return(post, nil)
+		}},
+		{"Hide", http.MethodPost, path + "/hide", nil, func(m *mockService.PostServiceMock) {
+			m.On("SetHidden", unsafeJSPostID, true, model.RoleUser).Return(post, nil)
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService, postHandler := setupTestPostHandler()
+			r := setupPostRouter(postHandler)
+			tt.setup(mockService)
+
+			req := createTypedJSONRequest(tt.method, tt.path, tt.body)
+			response := httptest.NewRecorder()
+			r.ServeHTTP(response, req)
+
+			assert.Less(t, response.Code, 300)
+			var body map[string]interface{}
+			assert.NoError(t, json.Unmarshal(response.Body.Bytes(), &body))
+			assert.Equal(t, strconv.FormatUint(unsafeJSPostID, 10), body["id"])
+			assert.Equal(t, "Author", body["author"].(map[string]interface{})["name"])
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGetPostsCompactView(t *testing.T) {
+	t.Run("OmitsAuthorAndTruncatesContent", func(t *testing.T) {
+		repo := mockRepository.NewPostRepositoryMock()
+		postService := service.NewPostServiceWithConfig(repo, config.PostConfig{PreviewLength: 10})
+		r := setupPostRouter(handler.NewPostHandler(postService, zap.NewNop()))
+
+		createdAt := time.Date(2024, 1, 1, 8, 0, 0, 0, time.UTC)
+		// the repository returns preview length + 1 characters
+		repo.On("ListSummaries", mock.Anything, 11).Return([]model.Post{
+			{ID: 2, Content: "A long post", AuthorID: authorID, CreatedAt: createdAt, UpdatedAt: createdAt},
+			{ID: 1, Content: "Short", AuthorID: authorID, CreatedAt: createdAt, UpdatedAt: createdAt},
+		}, nil)
+
+		req := createTypedJSONRequest(http.MethodGet, "/posts?view=compact", nil)
+		response := httptest.NewRecorder()
+		r.ServeHTTP(response, req)
+
+		assert.Equal(t, http.StatusOK, response.Code)
+		var body struct {
+			Data []map[string]interface{} `json:"data"`
+		}
+		assert.NoError(t, json.Unmarshal(response.Body.Bytes(), &body))
+		if assert.Len(t, body.Data, 2) {
+			first := body.Data[0]
+			assert.Equal(t, "2", first["id"])
+			assert.Equal(t, "A long…", first["content_preview"])
+			assert.Equal(t, authorID, first["author_id"])
+			assert.Contains(t, first, "created_at")
+			assert.NotContains(t, first, "author")
+			assert.NotContains(t, first, "content")
+			assert.Equal(t, "Short", body.Data[1]["content_preview"])
+		}
+		repo.AssertNotCalled(t, "List", mock.Anything)
+	})
+
+	t.Run("DefaultViewUnchanged", func(t *testing.T) {
+		mockService, postHandler := setupTestPostHandler()
+		r := setupPostRouter(postHandler)
+		mockService.On("List", mock.Anything).Return(&model.CursorResponse[model.PostResponse]{}, nil)
+
+		for _, query := range []string{"/posts", "/posts?view=full"} {
+			req := createTypedJSONRequest(http.MethodGet, query, nil)
+			response := httptest.NewRecorder()
+			r.ServeHTTP(response, req)
+
+			assert.Equal(t, http.StatusOK, response.Code, query)
+		}
+		mockService.AssertNumberOfCalls(t, "List", 2)
+		mockService.AssertNotCalled(t, "ListCompact", mock.Anything)
+	})
+
+	t.Run("InvalidView", func(t *testing.T) {
+		mockService, postHandler := setupTestPostHandler()
+		r := setupPostRouter(postHandler)
+
+		req := createTypedJSONRequest(http.MethodGet, "/posts?view=tiny", nil)
+		response := httptest.NewRecorder()
+		r.ServeHTTP(response, req)
+
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+		mockService.AssertNotCalled(t, "List", mock.Anything)
+		mockService.AssertNotCalled(t, "ListCompact", mock.Anything)
+	})
+}
+
+func TestPreviewPost(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		mockService, postHandler := setupTestPostHandler()
+		r := setupPostRouter(postHandler)
+		title := "Hello"
+		mockService.On("Preview", mock.MatchedBy(func(p *model.Post) bool {
+			return p.Content == "Hello @alice!" && p.Title != nil && *p.Title == "Hello"
+		})).Return(&model.PostPreviewResponse{
+			Title:    &title,
+			Content:  "Hello @alice!",
+			Slug:     "hello-alice",
+			Mentions: []model.MentionPreview{{Username: "alice", UserID: "user-1"}},
+			Valid:    true,
+		}, nil)
+
+		req := createTypedJSONRequest(http.MethodPost, "/posts/preview", map[string]interface{}{
+			"title":   "Hello",
+			"content": "Hello @alice!",
+		})
+		response := httptest.NewRecorder()
+		r.ServeHTTP(response, req)
+
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.JSONEq(t, `{
+			"title": "Hello",
+			"content": "Hello @alice!",
+			"slug": "hello-alice",
+			"mentions": [{"username": "alice", "user_id": "user-1"}],
+			"valid": true,
+			"errors": []
+		}`, response.Body.String())
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("InvalidDraftIsNotAnError", func(t *testing.T) {
+		mockService, postHandler := setupTestPostHandler()
+		r := setupPostRouter(postHandler)
+		mockService.On("Preview", mock.Anything).Return(&model.PostPreviewResponse{
+			Content:  "short",
+			Mentions: []model.MentionPreview{},
+		}, &apperrors.ValidationErrors{Errs: []error{apperrors.ErrPostContentTooShort, apperrors.ErrPostTitleEmpty}})
+
+		req := createTypedJSONRequest(http.MethodPost, "/posts/preview", map[string]interface{}{"content": "short"})
+		response := httptest.NewRecorder()
+		r.ServeHTTP(response, req)
+
+		assert.Equal(t, http.StatusOK, response.Code)
+		var body model.PostPreviewResponse
+		assert.NoError(t, json.Unmarshal(response.Body.Bytes(), &body))
+		assert.False(t, body.Valid)
+		assert.Equal(t, []string{"Post content is too short", "Post title must not be empty"}, body.Errors)
+	})
+
+	t.Run("LookupFailure", func(t *testing.T) {
+		mockService, postHandler := setupTestPostHandler()
+		r := setupPostRouter(postHandler)
+		mockService.On("Preview", mock.Anything).Return(nil, errors.New("db down"))
+
+		req := createTypedJSONRequest(http.MethodPost, "/posts/preview", map[string]interface{}{"content": "Hello @alice!"})
+		response := httptest.NewRecorder()
+		r.ServeHTTP(response, req)
+
+		assert.Equal(t, http.StatusInternalServerError, response.Code)
+	})
+}
+
+func TestGetPostsPaged(t *testing.T) {
+	t.Run("StatusHidden", func(t *testing.T) {
+		mockService, postHandler := setupTestPostHandler()
+		r := setupPostRouter(postHandler)
+
+		mockService.On("ListPaged", mock.MatchedBy(func(req model.PostModerationListRequest) bool {
+			return req.Status == model.PostStatusHidden
+		})).Return(model.NewPaginatedResponse([]model.PostResponse{}, 0, 1, 10), nil)
+
+		req := createTypedJSONRequest(http.MethodGet, "/admin/posts?status=hidden", nil)
+		response := httptest.NewRecorder()
+		r.ServeHTTP(response, req)
+
+		assert.Equal(t, http.StatusOK, response.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("InvalidStatus", func(t *testing.T) {
+		mockService, postHandler := setupTestPostHandler()
+		r := setupPostRouter(postHandler)
+
+		req := createTypedJSONRequest(http.MethodGet, "/admin/posts?status=draft", nil)
+		response := httptest.NewRecorder()
+		r.ServeHTTP(response, req)
+
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+		mockService.AssertNotCalled(t, "ListPaged", mock.Anything)
+	})
+
+	t.Run("IncludeDeletedPassesCallerRole", func(t *testing.T) {
+		mockService, postHandler := setupTestPostHandler()
+		r := setupPostRouter(postHandler)
+
+		mockService.On("ListPaged", mock.MatchedBy(func(req model.PostModerationListRequest) bool {
+			return req.IncludeDeleted && req.CallerRole == model.RoleUser
+		})).Return(nil, apperrors.ErrForbidden)
+
+		req := createTypedJSONRequest(http.MethodGet, "/admin/posts?include_deleted=true", nil)
+		response := httptest.NewRecorder()
+		r.ServeHTTP(response, req)
+
+		assert.Equal(t, http.StatusForbidden, response.Code)
+		mockService.AssertExpectations(t)
+	})
+}
+
+func TestGetPostLimits(t *testing.T) {
+	t.Run("ReturnsConfiguredLimits", func(t *testing.T) {
+		cfg := config.PostConfig{ContentMinLength: 5, ContentMaxLength: 500}
+		postService := service.NewPostServiceWithConfig(mockRepository.NewPostRepositoryMock(), cfg)
+		r := setupPostRouter(handler.NewPostHandler(postService, zap.NewNop()))
+
+		req := createTypedJSONRequest(http.MethodGet, "/posts/limits", nil)
+		response := httptest.NewRecorder()
+		r.ServeHTTP(response, req)
+
+		assert.Equal(t, http.StatusOK, response.Code)
+		var body model.PostLimitsResponse
+		assert.NoError(t, json.Unmarshal(response.Body.Bytes(), &body))
+		assert.Equal(t, cfg.ContentMinLength, body.ContentMin)
+		assert.Equal(t, cfg.ContentMaxLength, body.ContentMax)
+	})
+}
+
+func TestGetPostByID(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockService, postHandler := setupTestPostHandler()
+		r := setupPostRouter(postHandler)
+
+		expected := &model.PostResponse{Post: *createTestPost()}
+		mockService.On("GetByID", mock.Anything, authorID, model.RoleUser).Return(expected, nil)
+
+		NonExistentPostIDStr := strconv.FormatUint(NonExistentPostID, 10)
+		req := createTypedJSONRequest(http.MethodGet, "/posts/"+NonExistentPostIDStr, nil)
+
+		response := httptest.NewRecorder()
+		r.ServeHTTP(response, req)
+
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, expected.ETag(), response.Header().Get("ETag"))
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("StaleServedWithWarning", func(t *testing.T) {
+		mockService, postHandler := setupTestPostHandler()
+		r := setupPostRouter(postHandler)
+
+		expected := &model.PostResponse{Post: *createTestPost(), Stale: true}
+		mockService.On("GetByID", mock.Anything, authorID, model.RoleUser).Return(expected, nil)
+
+		req := createTypedJSONRequest(http.MethodGet, "/posts/1", nil)
+
+		response := httptest.NewRecorder()
+		r.ServeHTTP(response, req)
+
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, handler.StaleWarning, response.Header().Get("Warning"))
+		assert.NotContains(t, response.Body.String(), "stale")
+	})
+
+	t.Run("DatabaseUnavailable", func(t *testing.T) {
+		mockService, postHandler := setupTestPostHandler()
+		r := setupPostRouter(postHandler)
+
+		mockService.On("GetByID", mock.Anything, authorID, model.RoleUser).Return(nil, apperrors.ErrUnavailable)
+
+		req := createTypedJSONRequest(http.MethodGet, "/posts/1", nil)
+
+		response := httptest.NewRecorder()
+		r.ServeHTTP(response, req)
+
+		assert.Equal(t, http.StatusServiceUnavailable, response.Code)
+		assert.Empty(t, response.Header().Get("Warning"))
+	})
+
+	t.Run("BindingError_InvalidID", func(t *testing.T) {
+		mockService, postHandler := setupTestPostHandler()
+		r := setupPostRouter(postHandler)
+
+		// invalid id parameter
+		req := createTypedJSONRequest(http.MethodGet, "/posts/invalid", nil)
+
+		response := httptest.NewRecorder()
+		r.ServeHTTP(response, req)
+
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+		mockService.AssertNotCalled(t, "GetByID")
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		mockService, postHandler := setupTestPostHandler()
+		r := setupPostRouter(postHandler)
+
+		mockService.On("GetByID", mock.Anything, authorID, model.RoleUser).Return(nil, apperrors.ErrNotFound)
+
+		NonExistentPostIDStr := strconv.FormatUint(NonExistentPostID, 10)
+		req := createTypedJSONRequest(http.MethodGet, "/posts/"+NonExistentPostIDStr, nil)
+
+		response := httptest.NewRecorder()
+		r.ServeHTTP(response, req)
+
+		assert.Equal(t, http.StatusNotFound, response.Code)
+		var body model.ErrorResponse
+		assert.NoError(t, json.Unmarshal(response.Body.Bytes(), &body))
+		assert.Equal(t, "Post not found", body.Error)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("NotFound_ProblemJSON", func(t *testing.T) {
+		mockService, postHandler := setupTestPostHandler()
+		r := setupPostRouter(postHandler)
+
+		mockService.On("GetByID", mock.Anything, authorID, model.RoleUser).Return(nil, apperrors.ErrNotFound)
+
+		NonExistentPostIDStr := strconv.FormatUint(NonExistentPostID, 10)
+		req := createTypedJSONRequest(http.MethodGet, "/posts/"+NonExistentPostIDStr, nil)
+		req.Header.Set("Accept", "application/problem+json")
+
+		response := httptest.NewRecorder()
+		r.ServeHTTP(response, req)
+
+		assert.Equal(t, http.StatusNotFound, response.Code)
+		assert.Contains(t, response.Header().Get("Content-Type"), "application/problem+json")
+		var problem model.ProblemDetails
+		assert.NoError(t, json.Unmarshal(response.Body.Bytes(), &problem))
+		assert.Equal(t, "about:blank", problem.Type)
+		assert.Equal(t, "Not Found", problem.Title)
+		assert.Equal(t, http.StatusNotFound, problem.Status)
+		assert.Equal(t, "Post not found", problem.Detail)
+		assert.Equal(t, "/posts/"+NonExistentPostIDStr, problem.Instance)
+		mockService.AssertExpectations(t)
+	})
+}
+
+func TestValidatePost(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		mockService, postHandler := setupTestPostHandler()
+		r := setupPostRouter(postHandler)
+
+		mockService.On("ValidateContent", "A perfectly normal post").Return(nil)
+
+		req := createTypedJSONRequest(http.MethodPost, "/posts/validate", model.ValidatePostRequest{Content: "A perfectly normal post"})
+		response := httptest.NewRecorder()
+		r.ServeHTTP(response, req)
+
+		assert.Equal(t, http.StatusOK, response.Code)
+		var body model.PostValidationResponse
+		assert.NoError(t, json.Unmarshal(response.Body.Bytes(), &body))
+		assert.True(t, body.Valid)
+		assert.Empty(t, body.Errors)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("TooShort", func(t *testing.T) {
+		mockService, postHandler := setupTestPostHandler()
+		r := setupPostRouter(postHandler)
+
+		mockService.On("ValidateContent", "short").Return([]error{apperrors.ErrPostContentTooShort})
+
+		req := createTypedJSONRequest(http.MethodPost, "/posts/validate", model.ValidatePostRequest{Content: "short"})
+		response := httptest.NewRecorder()
+		r.ServeHTTP(response, req)
+
+		assert.Equal(t, http.StatusOK, response.Code)
+		var body model.PostValidationResponse
+		assert.NoError(t, json.Unmarshal(response.Body.Bytes(), &body))
+		assert.False(t, body.Valid)
+		assert.Equal(t, []string{"Post content is too short"}, body.Errors)
+	})
+
+	t.Run("SensitiveWords", func(t *testing.T) {
+		mockService, postHandler := setupTestPostHandler()
+		r := setupPostRouter(postHandler)
+
+		content := "This post is about violence"
+		mockService.On("ValidateContent", content).Return([]error{apperrors.ErrPostContentSensitiveWords})
+
+		req := createTypedJSONRequest(http.MethodPost, "/posts/validate", model.ValidatePostRequest{Content: content})
+		response := httptest.NewRecorder()
+		r.ServeHTTP(response, req)
+
+		assert.Equal(t, http.StatusOK, response.Code)
+		var body model.PostValidationResponse
+		assert.NoError(t, json.Unmarshal(response.Body.Bytes(), &body))
+		assert.False(t, body.Valid)
+		assert.Equal(t, []string{"Post content contains inappropriate language"}, body.Errors)
+	})
+
+	t.Run("InvalidBody", func(t *testing.T) {
+		mockService, postHandler := setupTestPostHandler()
+		r := setupPostRouter(postHandler)
+
+		req := httptest.NewRequest(http.MethodPost, "/posts/validate", strings.NewReader("{"))
+		req.Header.Set("Content-Type", "application/json")
+		response := httptest.NewRecorder()
+		r.ServeHTTP(response, req)
+
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+		mockService.AssertNotCalled(t, "ValidateContent", mock.Anything)
+	})
+}
+
+func TestGetPostBySlug(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockService, postHandler := setupTestPostHandler()
+		r := setupPostRouter(postHandler)
+
+		expected := &model.PostResponse{Post: *createTestPost()}
+		mockService.On("GetBySlug", "test-content", authorID, model.RoleUser).Return(expected, nil)
+
+		req := createTypedJSONRequest(http.MethodGet, "/posts/slug/test-content", nil)
+		response := httptest.NewRecorder()
+		r.ServeHTTP(response, req)
+
+		assert.Equal(t, http.StatusOK, response.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		mockService, postHandler := setupTestPostHandler()
+		r := setupPostRouter(postHandler)
+
+		mockService.On("GetBySlug", "missing", authorID, model.RoleUser).Return(nil, apperrors.ErrNotFound)
+
+		req := createTypedJSONRequest(http.MethodGet, "/posts/slug/missing", nil)
+		response := httptest.NewRecorder()
+		r.ServeHTTP(response, req)
+
+		assert.Equal(t, http.StatusNotFound, response.Code)
+	})
+}
+
+func TestAdminDeletePost(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockService, postHandler := setupTestPostHandler()
+		r := setupPostRouter(postHandler)
+
+		mockService.On("AdminDelete", uint64(1), authorID, model.RoleUser).Return(nil)
+
+		req := createTypedJSONRequest(http.MethodDelete, "/admin/posts/1", nil)
+		response := httptest.NewRecorder()
+		r.ServeHTTP(response, req)
+
+		assert.Equal(t, http.StatusNoContent, response.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Forbidden", func(t *testing.T) {
+		mockService, postHandler := setupTestPostHandler()
+		r := setupPostRouter(postHandler)
+
+		mockService.On("AdminDelete", uint64(1), authorID, model.RoleUser).Return(apperrors.ErrForbidden)
+
+		req := createTypedJSONRequest(http.MethodDelete, "/admin/posts/1", nil)
+		response := httptest.NewRecorder()
+		r.ServeHTTP(response, req)
+
+		assert.Equal(t, http.StatusForbidden, response.Code)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		mockService, postHandler := setupTestPostHandler()
+		r := setupPostRouter(postHandler)
+
+		mockService.On("AdminDelete", uint64(1), authorID, model.RoleUser).Return(apperrors.ErrNotFound)
+
+		req := createTypedJSONRequest(http.MethodDelete, "/admin/posts/1", nil)
+		response := httptest.NewRecorder()
+		r.ServeHTTP(response, req)
+
+		assert.Equal(t, http.StatusNotFound, response.Code)
+	})
+
+	t.Run("InvalidID", func(t *testing.T) {
+		mockService, postHandler := setupTestPostHandler()
+		r := setupPostRouter(postHandler)
+
+		req := createTypedJSONRequest(http.MethodDelete, "/admin/posts/abc", nil)
+		response := httptest.NewRecorder()
+		r.ServeHTTP(response, req)
+
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+		mockService.AssertNotCalled(t, "AdminDelete", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestHidePost(t *testing.T) {
+	t.Run("HideByDefault", func(t *testing.T) {
+		mockService, postHandler := setupTestPostHandler()
+		r := setupPostRouter(postHandler)
+
+		hidden := createTestPost()
+		hidden.Hidden = true
+		mockService.On("SetHidden", hidden.ID, true, model.RoleUser).Return(hidden, nil)
+
+		req := createTypedJSONRequest(http.MethodPost, "/posts/1/hide", nil)
+		response := httptest.NewRecorder()
+		r.ServeHTTP(response, req)
+
+		assert.Equal(t, http.StatusOK, response.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Unhide", func(t *testing.T) {
+		mockService, postHandler := setupTestPostHandler()
+		r := setupPostRouter(postHandler)
+
+		post := createTestPost()
+		mockService.On("SetHidden", post.ID, false, model.RoleUser).Return(post, nil)
+
+		hidden := false
+		req := createTypedJSONRequest(http.MethodPost, "/posts/1/hide", model.SetPostHiddenRequest{Hidden: &hidden})
+		response := httptest.NewRecorder()
+		r.ServeHTTP(response, req)
+
+		assert.Equal(t, http.StatusOK, response.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("RegularUserForbidden", func(t *testing.T) {
+		mockService, postHandler := setupTestPostHandler()
+		r := setupPostRouter(postHandler)
+
+		mockService.On("SetHidden", uint64(1), true, model.RoleUser).Return(nil, apperrors.ErrForbidden)
+
+		req := createTypedJSONRequest(http.MethodPost, "/posts/1/hide", nil)
+		response := httptest.NewRecorder()
+		r.ServeHTTP(response, req)
+
+		assert.Equal(t, http.StatusForbidden, response.Code)
+	})
+}
+
+func TestGetPostRevisions(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockService, postHandler := setupTestPostHandler()
+		r := setupPostRouter(postHandler)
+
+		revisions := []model.PostRevision{
+			{ID: 2, PostID: 1, Content: "Second Content", CreatedAt: time.Now()},
+			{ID: 1, PostID: 1, Content: "First Content", CreatedAt: time.Now().Add(-time.Hour)},
+		}
+		mockService.On("ListRevisions", uint64(1), authorID, model.RoleUser).Return(revisions, nil)
+
+		req := createTypedJSONRequest(http.MethodGet, "/posts/1/revisions", nil)
+		response := httptest.NewRecorder()
+		r.ServeHTTP(response, req)
+
+		assert.Equal(t, http.StatusOK, response.Code)
+		var body []model.PostRevision
+		assert.NoError(t, json.Unmarshal(response.Body.Bytes(), &body))
+		assert.Len(t, body, 2)
+		assert.Equal(t, "Second Content", body[0].Content)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Forbidden", func(t *testing.T) {
+		mockService, postHandler := setupTestPostHandler()
+		r := setupPostRouter(postHandler)
+
+		mockService.On("ListRevisions", uint64(1), authorID, model.RoleUser).Return(nil, apperrors.ErrForbidden)
+
+		req := createTypedJSONRequest(http.MethodGet, "/posts/1/revisions", nil)
+		response := httptest.NewRecorder()
+		r.ServeHTTP(response, req)
+
+		assert.Equal(t, http.StatusForbidden, response.Code)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		mockService, postHandler := setupTestPostHandler()
+		r := setupPostRouter(postHandler)
+
+		mockService.On("ListRevisions", uint64(1), authorID, model.RoleUser).Return(nil, apperrors.ErrNotFound)
+
+		req := createTypedJSONRequest(http.MethodGet, "/posts/1/revisions", nil)
+		response := httptest.NewRecorder()
+		r.ServeHTTP(response, req)
+
+		assert.Equal(t, http.StatusNotFound, response.Code)
+	})
+
+	t.Run("InvalidID", func(t *testing.T) {
+		mockService, postHandler := setupTestPostHandler()
+		r := setupPostRouter(postHandler)
+
+		req := createTypedJSONRequest(http.MethodGet, "/posts/abc/revisions", nil)
+		response := httptest.NewRecorder()
+		r.ServeHTTP(response, req)
+
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+		mockService.AssertNotCalled(t, "ListRevisions", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestGetPosts_SearchConcurrencyLimit(t *testing.T) {
+	// the real route registration, with room for a single search at a time
+	authMiddleware := middleware.NewAuthMiddleware(mockService.NewAuthServiceMock(), zap.NewNop())
+	mockService, postHandler := setupTestPostHandler()
+	heavyLimit := middleware.NewConcurrencyLimitMiddleware(1, zap.NewNop())
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	postHandler.RegisterRoutes(r, authMiddleware, middleware.NewRBACMiddleware(zap.NewNop()), nil, heavyLimit)
+
+	entered := make(chan struct{}, 1)
+	release := make(chan struct{})
+	mockService.On("List", mock.MatchedBy(func(req model.PostListRequest) bool { return req.Search == "slow" })).
+		Run(func(mock.Arguments) {
+			entered <- struct{}{}
+			<-release
+		}).
+		Return(&model.CursorResponse[model.PostResponse]{}, nil)
+	mockService.On("List", mock.MatchedBy(func(req model.PostListRequest) bool { return req.Search != "slow" })).
+		Return(&model.CursorResponse[model.PostResponse]{}, nil)
+
+	get := func(path string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(http.MethodGet, path, nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	var wg sync.WaitGroup
+	var inFlight *httptest.ResponseRecorder
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		inFlight = get("/api/v1/posts?q=slow")
+	}()
+	<-entered
+
+	// run
+	search := get("/api/v1/posts?q=other")
+	listing := get("/api/v1/posts")
+	close(release)
+	wg.Wait()
+
+	// assert: a second search is shed, a plain listing isn't limited
+	assert.Equal(t, http.StatusServiceUnavailable, search.Code)
+	assert.Contains(t, search.Body.String(), middleware.ErrCodeOverloaded)
+	assert.Equal(t, http.StatusOK, listing.Code)
+	assert.Equal(t, http.StatusOK, inFlight.Code)
 }
@@ -0,0 +1,172 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"go-gin-api-server/internal/handler"
+	"go-gin-api-server/internal/model"
+	"go-gin-api-server/pkg/apperrors"
+	mockService "go-gin-api-server/test/mocks/service"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+// Helper functions
+
+func setupReportRouter() (*mockService.ReportServiceMock, *gin.Engine) {
+	gin.SetMode(gin.TestMode)
+	mockService := mockService.NewReportServiceMock()
+	reportHandler := handler.NewReportHandler(mockService, zap.NewNop())
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("user_role", model.RoleModerator)
+		c.Set("user_id", testUserID)
+		c.Next()
+	})
+	r.POST("/posts/:id/report", reportHandler.CreateReport)
+	r.GET("/reports", reportHandler.GetReports)
+	return mockService, r
+}
+
+func performReportRequest(r *gin.Engine, path string, body string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest("POST", path, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+// Testcases
+
+func TestCreateReport(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockService, r := setupReportRouter()
+		req := model.CreateReportRequest{Reason: "Spam"}
+		mockService.On("Create", uint64(1), testUserID, req).
+			Return(&model.Report{ID: 1, PostID: 1, ReporterID: testUserID, Status: model.ReportPending}, nil)
+
+		w := performReportRequest(r, "/posts/1/report", `{"reason":"Spam"}`)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Contains(t, w.Body.String(), `"status":"pending"`)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Duplicate", func(t *testing.T) {
+		mockService, r := setupReportRouter()
+		mockService.On("Create", uint64(1), testUserID, mock.Anything).Return(nil, apperrors.ErrConflict)
+
+		w := performReportRequest(r, "/posts/1/report", `{"reason":"Spam"}`)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("PostNotFound", func(t *testing.T) {
+		mockService, r := setupReportRouter()
+		mockService.On("Create", uint64(999), testUserID, mock.Anything).Return(nil, apperrors.ErrNotFound)
+
+		w := performReportRequest(r, "/posts/999/report", `{"reason":"Spam"}`)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("MissingReason", func(t *testing.T) {
+		mockService, r := setupReportRouter()
+
+		w := performReportRequest(r, "/posts/1/report", `{}`)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestGetReports(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockService, r := setupReportRouter()
+		expected := &model.CursorResponse[model.Report]{Data: []model.Report{{ID: 1}}}
+		mockService.On("List", mock.MatchedBy(func(req model.ReportListRequest) bool {
+			return req.Status != nil && *req.Status == model.ReportPending
+		})).Return(expected, nil)
+
+		req, _ := http.NewRequest("GET", "/reports?status=pending", nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("PaginatesWithStatusFilter", func(t *testing.T) {
+		mockService, r := setupReportRouter()
+		next := model.EncodeCursor(model.Cursor{ID: "2", CreatedAt: time.Date(2024, 1, 1, 8, 0, 0, 0, time.UTC)})
+		resolved := func(req model.ReportListRequest) bool {
+			return req.Status != nil && *req.Status == model.ReportResolved && req.Limit == 2
+		}
+		mockService.On("List", mock.MatchedBy(func(req model.ReportListRequest) bool {
+			return resolved(req) && req.Cursor == ""
+		})).Return(&model.CursorResponse[model.Report]{
+			Data:    []model.Report{{ID: 1}, {ID: 2}},
+			Next:    next,
+			HasMore: true,
+		}, nil).Once()
+		mockService.On("List", mock.MatchedBy(func(req model.ReportListRequest) bool {
+			return resolved(req) && req.Cursor == next
+		})).Return(&model.CursorResponse[model.Report]{
+			Data: []model.Report{{ID: 3}},
+		}, nil).Once()
+
+		// run: follow the cursor from the first page
+		req, _ := http.NewRequest("GET", "/reports?status=resolved&limit=2", nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var first model.CursorResponse[model.Report]
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &first))
+
+		req, _ = http.NewRequest("GET", "/reports?status=resolved&limit=2&cursor="+url.QueryEscape(first.Next), nil)
+		w = httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		// assert
+		assert.Equal(t, http.StatusOK, w.Code)
+		var second model.CursorResponse[model.Report]
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &second))
+		assert.True(t, first.HasMore)
+		if assert.Len(t, second.Data, 1) {
+			assert.Equal(t, uint64(3), second.Data[0].ID)
+		}
+		assert.False(t, second.HasMore)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("InvalidStatus", func(t *testing.T) {
+		mockService, r := setupReportRouter()
+
+		req, _ := http.NewRequest("GET", "/reports?status=bogus", nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "List", mock.Anything)
+	})
+
+	t.Run("InvalidLimit", func(t *testing.T) {
+		mockService, r := setupReportRouter()
+
+		req, _ := http.NewRequest("GET", "/reports?limit=1000", nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "List", mock.Anything)
+	})
+}
@@ -0,0 +1,119 @@
+package handler
+
+import (
+	"go-gin-api-server/internal/handler"
+	"go-gin-api-server/internal/middleware"
+	"go-gin-api-server/internal/model"
+	"go-gin-api-server/pkg/apperrors"
+	mockService "go-gin-api-server/test/mocks/service"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+// Helper functions
+
+func setupRoleRequestRouter(role model.UserRole) (*mockService.RoleRequestServiceMock, *gin.Engine) {
+	gin.SetMode(gin.TestMode)
+	mockService := mockService.NewRoleRequestServiceMock()
+	roleRequestHandler := handler.NewRoleRequestHandler(mockService, zap.NewNop())
+	rbacMiddleware := middleware.NewRBACMiddleware(zap.NewNop())
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("user_role", role)
+		c.Set("user_id", testUserID)
+		c.Next()
+	})
+	r.POST("/auth/role-requests", roleRequestHandler.CreateRoleRequest)
+	r.POST("/admin/role-requests/:id/approve", rbacMiddleware.RequireAdmin(), roleRequestHandler.ApproveRoleRequest)
+	return mockService, r
+}
+
+func performRoleRequest(r *gin.Engine, path string, body interface{}) *httptest.ResponseRecorder {
+	req := createJSONHTTPRequest("POST", path, body)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+// Testcases
+
+func TestCreateRoleRequest(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockService, r := setupRoleRequestRouter(model.RoleUser)
+		req := model.CreateRoleRequestRequest{Role: model.RoleModerator, Reason: "Active member"}
+		mockService.On("Create", testUserID, req).
+			Return(&model.RoleRequest{ID: 1, UserID: testUserID, Role: model.RoleModerator, Status: model.RoleRequestPending}, nil)
+
+		w := performRoleRequest(r, "/auth/role-requests", req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Contains(t, w.Body.String(), `"status":"pending"`)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("InvalidRole", func(t *testing.T) {
+		mockService, r := setupRoleRequestRouter(model.RoleUser)
+
+		w := performRoleRequest(r, "/auth/role-requests", map[string]string{"role": "superuser"})
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("AlreadyPending", func(t *testing.T) {
+		mockService, r := setupRoleRequestRouter(model.RoleUser)
+		mockService.On("Create", testUserID, mock.Anything).Return(nil, apperrors.ErrConflict)
+
+		w := performRoleRequest(r, "/auth/role-requests", model.CreateRoleRequestRequest{Role: model.RoleModerator})
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+}
+
+func TestApproveRoleRequest(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockService, r := setupRoleRequestRouter(model.RoleAdmin)
+		mockService.On("Approve", uint64(1), testUserID, model.RoleAdmin).
+			Return(&model.RoleRequest{ID: 1, Role: model.RoleModerator, Status: model.RoleRequestApproved}, nil)
+
+		w := performRoleRequest(r, "/admin/role-requests/1/approve", nil)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"status":"approved"`)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("NonAdminForbidden", func(t *testing.T) {
+		mockService, r := setupRoleRequestRouter(model.RoleModerator)
+
+		w := performRoleRequest(r, "/admin/role-requests/1/approve", nil)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		mockService.AssertNotCalled(t, "Approve", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("AlreadyReviewed", func(t *testing.T) {
+		mockService, r := setupRoleRequestRouter(model.RoleAdmin)
+		mockService.On("Approve", uint64(1), testUserID, model.RoleAdmin).Return(nil, apperrors.ErrConflict)
+
+		w := performRoleRequest(r, "/admin/role-requests/1/approve", nil)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("InvalidID", func(t *testing.T) {
+		mockService, r := setupRoleRequestRouter(model.RoleAdmin)
+
+		w := performRoleRequest(r, "/admin/role-requests/abc/approve", nil)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "Approve", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
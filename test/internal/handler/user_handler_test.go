@@ -1,7 +1,9 @@
 package handler
 
 import (
+	"encoding/json"
 	"go-gin-api-server/internal/handler"
+	"go-gin-api-server/internal/middleware"
 	"go-gin-api-server/internal/model"
 	"go-gin-api-server/pkg/apperrors"
 	"go-gin-api-server/pkg/utils"
@@ -24,7 +26,8 @@ const (
 	testName            = "Test User"
 	testUsername        = "test_user"
 	testEmail           = "test_user@test.com"
-	testUserID          = "test-id"
+	testUserID          = "6f1c2b7e-3d4a-4b5c-8e9f-0a1b2c3d4e5f"
+	otherUserID         = "9a8b7c6d-5e4f-4a3b-9c2d-1e0f9a8b7c6d"
 	NonExistentUserID   = "550e8400-e29b-41d4-a716-446655440000"
 	NonExistentUsername = "non-existent-username"
 	NonExistentEmail    = "non-existent-email@test.com"
@@ -39,9 +42,19 @@ func setupTestUserHandler() (*mockService.UserServiceMock, *handler.UserHandler)
 }
 
 func setupUserRouter(handlerFunc gin.HandlerFunc) *gin.Engine {
+	return setupUserRouterAs(handlerFunc, testUserID, model.RoleUser)
+}
+
+func setupUserRouterAs(handlerFunc gin.HandlerFunc, callerID string, callerRole model.UserRole) *gin.Engine {
 	gin.SetMode(gin.TestMode)
 	r := gin.Default()
 
+	r.Use(func(c *gin.Context) {
+		c.Set("user_id", callerID)
+		c.Set("user_role", callerRole)
+		c.Next()
+	})
+
 	// Register custom validators
 	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
 		utils.RegisterCustomValidators(v)
@@ -51,6 +64,7 @@ func setupUserRouter(handlerFunc gin.HandlerFunc) *gin.Engine {
 	r.GET("/users/username/:username", handlerFunc)
 	r.GET("/users/email/:email", handlerFunc)
 	r.GET("/users/profile/:username", handlerFunc)
+	r.POST("/users/profiles", handlerFunc)
 	r.PATCH("/users/:id", handlerFunc)
 	r.DELETE("/users/:id", handlerFunc)
 	return r
@@ -120,7 +134,7 @@ func TestGetUserByID(t *testing.T) {
 
 		mockService.On("GetUserByID", mock.Anything).Return(expectedUser, nil)
 
-		req, _ := http.NewRequest(http.MethodGet, "/users/test-id", nil)
+		req, _ := http.NewRequest(http.MethodGet, "/users/"+testUserID, nil)
 		response := httptest.NewRecorder()
 		r.ServeHTTP(response, req)
 
@@ -129,6 +143,21 @@ func TestGetUserByID(t *testing.T) {
 		mockService.AssertExpectations(t)
 	})
 
+	t.Run("InvalidID", func(t *testing.T) {
+		for _, id := range []string{"test-id", "12345", "550e8400-e29b-41d4-a716-44665544000g"} {
+			mockService, userHandler := setupTestUserHandler()
+			r := setupUserRouter(userHandler.GetUserByID)
+
+			req, _ := http.NewRequest(http.MethodGet, "/users/"+id, nil)
+			response := httptest.NewRecorder()
+			r.ServeHTTP(response, req)
+
+			// assert
+			assert.Equal(t, http.StatusBadRequest, response.Code, id)
+			mockService.AssertNotCalled(t, "GetUserByID", mock.Anything)
+		}
+	})
+
 	t.Run("ServiceError", func(t *testing.T) {
 		mockService, userHandler := setupTestUserHandler()
 		r := setupUserRouter(userHandler.GetUserByID)
@@ -144,6 +173,41 @@ func TestGetUserByID(t *testing.T) {
 		mockService.AssertExpectations(t)
 	})
 
+	t.Run("OtherUser_ReducedProfile", func(t *testing.T) {
+		mockService, userHandler := setupTestUserHandler()
+		r := setupUserRouterAs(userHandler.GetUserByID, otherUserID, model.RoleUser)
+
+		user := createTestUser()
+		mockService.On("GetUserByID", testUserID).Return(user, nil)
+		mockService.On("ToUserProfile", user).Return(&model.UserProfile{Name: user.Name, Username: user.Username})
+
+		req, _ := http.NewRequest(http.MethodGet, "/users/"+testUserID, nil)
+		response := httptest.NewRecorder()
+		r.ServeHTTP(response, req)
+
+		// assert
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Contains(t, response.Body.String(), testUsername)
+		assert.NotContains(t, response.Body.String(), testEmail)
+		assert.NotContains(t, response.Body.String(), testUserID)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Admin_FullRecord", func(t *testing.T) {
+		mockService, userHandler := setupTestUserHandler()
+		r := setupUserRouterAs(userHandler.GetUserByID, otherUserID, model.RoleAdmin)
+
+		mockService.On("GetUserByID", testUserID).Return(createTestUser(), nil)
+
+		req, _ := http.NewRequest(http.MethodGet, "/users/"+testUserID, nil)
+		response := httptest.NewRecorder()
+		r.ServeHTTP(response, req)
+
+		// assert
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Contains(t, response.Body.String(), testEmail)
+		mockService.AssertNotCalled(t, "ToUserProfile", mock.Anything)
+	})
 }
 
 func TestGetUserByUsername(t *testing.T) {
@@ -193,6 +257,24 @@ func TestGetUserByUsername(t *testing.T) {
 		assert.Equal(t, http.StatusNotFound, response.Code)
 		mockService.AssertExpectations(t)
 	})
+
+	t.Run("OtherUser_ReducedProfile", func(t *testing.T) {
+		mockService, userHandler := setupTestUserHandler()
+		r := setupUserRouterAs(userHandler.GetUserByUsername, otherUserID, model.RoleUser)
+
+		user := createTestUser()
+		mockService.On("GetUserByUsername", testUsername).Return(user, nil)
+		mockService.On("ToUserProfile", user).Return(&model.UserProfile{Name: user.Name, Username: user.Username})
+
+		req, _ := http.NewRequest(http.MethodGet, "/users/username/"+testUsername, nil)
+		response := httptest.NewRecorder()
+		r.ServeHTTP(response, req)
+
+		// assert
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.NotContains(t, response.Body.String(), testEmail)
+		mockService.AssertExpectations(t)
+	})
 }
 
 func TestGetUserByEmail(t *testing.T) {
@@ -239,7 +321,54 @@ func TestGetUserByEmail(t *testing.T) {
 		r.ServeHTTP(response, req)
 
 		// assert
-		assert.Equal(t, http.StatusNotFound, response.Code) // ErrNotFound 映射到 404
+		assert.Equal(t, http.StatusForbidden, response.Code) // 非管理員不透露 email 是否存在
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Admin_NotFound", func(t *testing.T) {
+		mockService, userHandler := setupTestUserHandler()
+		r := setupUserRouterAs(userHandler.GetUserByEmail, otherUserID, model.RoleAdmin)
+
+		mockService.On("GetUserByEmail", mock.Anything).Return(nil, apperrors.ErrNotFound)
+
+		req, _ := http.NewRequest(http.MethodGet, "/users/email/"+NonExistentEmail, nil)
+		response := httptest.NewRecorder()
+		r.ServeHTTP(response, req)
+
+		// assert
+		assert.Equal(t, http.StatusNotFound, response.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("OtherUser_Forbidden", func(t *testing.T) {
+		mockService, userHandler := setupTestUserHandler()
+		r := setupUserRouterAs(userHandler.GetUserByEmail, otherUserID, model.RoleUser)
+
+		mockService.On("GetUserByEmail", testEmail).Return(createTestUser(), nil)
+
+		req, _ := http.NewRequest(http.MethodGet, "/users/email/"+testEmail, nil)
+		response := httptest.NewRecorder()
+		r.ServeHTTP(response, req)
+
+		// assert
+		assert.Equal(t, http.StatusForbidden, response.Code)
+		assert.NotContains(t, response.Body.String(), testEmail)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Admin_FullRecord", func(t *testing.T) {
+		mockService, userHandler := setupTestUserHandler()
+		r := setupUserRouterAs(userHandler.GetUserByEmail, otherUserID, model.RoleAdmin)
+
+		mockService.On("GetUserByEmail", testEmail).Return(createTestUser(), nil)
+
+		req, _ := http.NewRequest(http.MethodGet, "/users/email/"+testEmail, nil)
+		response := httptest.NewRecorder()
+		r.ServeHTTP(response, req)
+
+		// assert
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Contains(t, response.Body.String(), testEmail)
 		mockService.AssertExpectations(t)
 	})
 }
@@ -268,6 +397,37 @@ func TestGetUserProfile(t *testing.T) {
 		mockService.AssertExpectations(t)
 	})
 
+	t.Run("StaleServedWithWarning", func(t *testing.T) {
+		mockService, userHandler := setupTestUserHandler()
+		r := setupUserRouter(userHandler.GetUserProfile)
+
+		username := testUsername
+		mockService.On("GetUserProfile", username).Return(&model.UserProfile{Name: testName, Username: &username, Stale: true}, nil)
+
+		req, _ := http.NewRequest(http.MethodGet, "/users/profile/"+username, nil)
+		response := httptest.NewRecorder()
+		r.ServeHTTP(response, req)
+
+		// assert
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, handler.StaleWarning, response.Header().Get("Warning"))
+	})
+
+	t.Run("DatabaseUnavailable", func(t *testing.T) {
+		mockService, userHandler := setupTestUserHandler()
+		r := setupUserRouter(userHandler.GetUserProfile)
+
+		mockService.On("GetUserProfile", testUsername).Return(nil, apperrors.ErrUnavailable)
+
+		req, _ := http.NewRequest(http.MethodGet, "/users/profile/"+testUsername, nil)
+		response := httptest.NewRecorder()
+		r.ServeHTTP(response, req)
+
+		// assert
+		assert.Equal(t, http.StatusServiceUnavailable, response.Code)
+		assert.JSONEq(t, `{"error":"Service temporarily unavailable"}`, response.Body.String())
+	})
+
 	t.Run("BindingError_InvalidUsername", func(t *testing.T) {
 		mockService, userHandler := setupTestUserHandler()
 		r := setupUserRouter(userHandler.GetUserProfile)
@@ -298,6 +458,129 @@ func TestGetUserProfile(t *testing.T) {
 		mockService.AssertExpectations(t)
 	})
 
+	t.Run("OtherUser_ReducedProfile", func(t *testing.T) {
+		mockService, userHandler := setupTestUserHandler()
+		r := setupUserRouterAs(userHandler.GetUserByID, otherUserID, model.RoleUser)
+
+		user := createTestUser()
+		mockService.On("GetUserByID", testUserID).Return(user, nil)
+		mockService.On("ToUserProfile", user).Return(&model.UserProfile{Name: user.Name, Username: user.Username})
+
+		req, _ := http.NewRequest(http.MethodGet, "/users/"+testUserID, nil)
+		response := httptest.NewRecorder()
+		r.ServeHTTP(response, req)
+
+		// assert
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Contains(t, response.Body.String(), testUsername)
+		assert.NotContains(t, response.Body.String(), testEmail)
+		assert.NotContains(t, response.Body.String(), testUserID)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Admin_FullRecord", func(t *testing.T) {
+		mockService, userHandler := setupTestUserHandler()
+		r := setupUserRouterAs(userHandler.GetUserByID, otherUserID, model.RoleAdmin)
+
+		mockService.On("GetUserByID", testUserID).Return(createTestUser(), nil)
+
+		req, _ := http.NewRequest(http.MethodGet, "/users/"+testUserID, nil)
+		response := httptest.NewRecorder()
+		r.ServeHTTP(response, req)
+
+		// assert
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Contains(t, response.Body.String(), testEmail)
+		mockService.AssertNotCalled(t, "ToUserProfile", mock.Anything)
+	})
+}
+
+func TestGetUserProfile_ReadRateLimit(t *testing.T) {
+	// the real route registration, one anonymous and two signed-in reads per window
+	authService := mockService.NewAuthServiceMock()
+	authService.On("ValidateToken", "user-token").Return(&model.Claims{UserID: testUserID, Role: model.RoleUser}, nil)
+	mockUserService, userHandler := setupTestUserHandler()
+	username := testUsername
+	mockUserService.On("GetUserProfile", testUsername).Return(&model.UserProfile{Name: testName, Username: &username}, nil)
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		utils.RegisterCustomValidators(v)
+	}
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	readLimit := middleware.NewTieredRateLimitMiddleware(1, 2, time.Minute, zap.NewNop())
+	userHandler.RegisterRoutes(r, middleware.NewAuthMiddleware(authService, zap.NewNop()), nil, readLimit)
+
+	get := func(token string) int {
+		req, _ := http.NewRequest(http.MethodGet, "/api/v1/users/profile/"+testUsername, nil)
+		req.RemoteAddr = "10.0.0.1:1234"
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	// run & assert: anonymous readers share the IP budget
+	assert.Equal(t, http.StatusOK, get(""))
+	assert.Equal(t, http.StatusTooManyRequests, get(""))
+
+	// a signed-in reader on the same IP has their own, larger budget
+	assert.Equal(t, http.StatusOK, get("user-token"))
+	assert.Equal(t, http.StatusOK, get("user-token"))
+	assert.Equal(t, http.StatusTooManyRequests, get("user-token"))
+}
+
+func TestGetUserProfiles(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockService, userHandler := setupTestUserHandler()
+		r := setupUserRouter(userHandler.GetUserProfiles)
+
+		username := testUsername
+		mockService.On("GetUserProfiles", []string{testUserID, NonExistentUserID}).Return([]model.UserProfile{
+			{ID: testUserID, Name: testName, Username: &username},
+		}, nil)
+
+		req := createTypedJSONRequest(http.MethodPost, "/users/profiles", model.UserProfilesRequest{
+			IDs: []string{testUserID, NonExistentUserID},
+		})
+		response := httptest.NewRecorder()
+		r.ServeHTTP(response, req)
+
+		// assert
+		assert.Equal(t, http.StatusOK, response.Code)
+		var body model.UserProfilesResponse
+		assert.NoError(t, json.Unmarshal(response.Body.Bytes(), &body))
+		if assert.Len(t, body.Data, 1) {
+			assert.Equal(t, testUserID, body.Data[0].ID)
+		}
+		assert.NotContains(t, response.Body.String(), testEmail)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("InvalidRequest", func(t *testing.T) {
+		tooMany := make([]string, 101)
+		for i := range tooMany {
+			tooMany[i] = testUserID
+		}
+
+		for name, ids := range map[string][]string{
+			"Empty":   {},
+			"NotUUID": {testUserID, "test-id"},
+			"TooMany": tooMany,
+		} {
+			mockService, userHandler := setupTestUserHandler()
+			r := setupUserRouter(userHandler.GetUserProfiles)
+
+			req := createTypedJSONRequest(http.MethodPost, "/users/profiles", model.UserProfilesRequest{IDs: ids})
+			response := httptest.NewRecorder()
+			r.ServeHTTP(response, req)
+
+			// assert
+			assert.Equal(t, http.StatusBadRequest, response.Code, name)
+			mockService.AssertNotCalled(t, "GetUserProfiles", mock.Anything)
+		}
+	})
 }
 
 func TestUpdateUserProfile(t *testing.T) {
@@ -327,6 +610,21 @@ func TestUpdateUserProfile(t *testing.T) {
 		mockService.AssertExpectations(t)
 	})
 
+	t.Run("InvalidID", func(t *testing.T) {
+		mockService, userHandler := setupTestUserHandler()
+		r := setupUserRouter(userHandler.UpdateUserProfile)
+
+		req := createTypedJSONRequest(http.MethodPatch, "/users/not-a-uuid", model.UpdateUserProfileRequest{Name: "Updated User"})
+
+		// run
+		response := httptest.NewRecorder()
+		r.ServeHTTP(response, req)
+
+		// assert
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+		mockService.AssertNotCalled(t, "UpdateUserProfile", mock.Anything, mock.Anything)
+	})
+
 	t.Run("ServiceError", func(t *testing.T) {
 		mockService, userHandler := setupTestUserHandler()
 		r := setupUserRouter(userHandler.UpdateUserProfile)
@@ -353,6 +651,97 @@ func TestUpdateUserProfile(t *testing.T) {
 		mockService.AssertExpectations(t)
 	})
 
+	t.Run("Success_ChangeUsername", func(t *testing.T) {
+		mockService, userHandler := setupTestUserHandler()
+		r := setupUserRouter(userHandler.UpdateUserProfile)
+
+		newUsername := "new_username"
+		updatedUser := createTestUser(map[string]interface{}{
+			"username": newUsername,
+		})
+		mockService.On("UpdateUserProfile", testUserID, mock.MatchedBy(func(req model.UpdateUserProfileRequest) bool {
+			return req.Username != nil && *req.Username == newUsername
+		})).Return(updatedUser, nil)
+
+		requestData := model.UpdateUserProfileRequest{
+			Username: &newUsername,
+		}
+
+		req := createTypedJSONRequest(http.MethodPatch, "/users/"+testUserID, requestData)
+
+		// run
+		response := httptest.NewRecorder()
+		r.ServeHTTP(response, req)
+
+		// assert
+		assert.Equal(t, http.StatusOK, response.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("UsernameTaken", func(t *testing.T) {
+		mockService, userHandler := setupTestUserHandler()
+		r := setupUserRouter(userHandler.UpdateUserProfile)
+
+		takenUsername := "taken_username"
+		mockService.On("UpdateUserProfile", mock.Anything, mock.Anything).Return(nil, apperrors.ErrUserExists)
+
+		requestData := model.UpdateUserProfileRequest{
+			Username: &takenUsername,
+		}
+
+		req := createTypedJSONRequest(http.MethodPatch, "/users/"+testUserID, requestData)
+
+		// run
+		response := httptest.NewRecorder()
+		r.ServeHTTP(response, req)
+
+		// assert
+		assert.Equal(t, http.StatusConflict, response.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("ReservedUsername", func(t *testing.T) {
+		mockService, userHandler := setupTestUserHandler()
+		r := setupUserRouter(userHandler.UpdateUserProfile)
+
+		reservedUsername := "admin"
+		mockService.On("UpdateUserProfile", mock.Anything, mock.Anything).Return(nil, apperrors.ErrValidation)
+
+		requestData := model.UpdateUserProfileRequest{
+			Username: &reservedUsername,
+		}
+
+		req := createTypedJSONRequest(http.MethodPatch, "/users/"+testUserID, requestData)
+
+		// run
+		response := httptest.NewRecorder()
+		r.ServeHTTP(response, req)
+
+		// assert
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("InvalidUsernameFormat", func(t *testing.T) {
+		mockService, userHandler := setupTestUserHandler()
+		r := setupUserRouter(userHandler.UpdateUserProfile)
+
+		invalidUsername := "1invalid"
+		requestData := model.UpdateUserProfileRequest{
+			Username: &invalidUsername,
+		}
+
+		req := createTypedJSONRequest(http.MethodPatch, "/users/"+testUserID, requestData)
+
+		// run
+		response := httptest.NewRecorder()
+		r.ServeHTTP(response, req)
+
+		// assert
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+		mockService.AssertNotCalled(t, "UpdateUserProfile")
+	})
+
 	t.Run("NoUpdateFields", func(t *testing.T) {
 		mockService, userHandler := setupTestUserHandler()
 		r := setupUserRouter(userHandler.UpdateUserProfile)
@@ -0,0 +1,110 @@
+package handler
+
+import (
+	"go-gin-api-server/internal/events"
+	"go-gin-api-server/internal/handler"
+	mockService "go-gin-api-server/test/mocks/service"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"golang.org/x/net/websocket"
+)
+
+type testWSMessage struct {
+	Type string                 `json:"type"`
+	Data map[string]interface{} `json:"data"`
+}
+
+// setupWebSocketServer connects as userID; blockedAuthors are blocked with userID in some direction
+func setupWebSocketServer(bus *events.Bus, userID string, blockedAuthors ...string) *httptest.Server {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+
+	r.Use(func(c *gin.Context) {
+		c.Set("user_id", userID)
+		c.Next()
+	})
+
+	blockService := mockService.NewBlockServiceMock()
+	for _, author := range blockedAuthors {
+		blockService.On("IsBlocked", userID, author).Return(true, nil)
+	}
+	blockService.On("IsBlocked", mock.Anything, mock.Anything).Return(false, nil)
+
+	wsHandler := handler.NewWebSocketHandler(bus, blockService, zap.NewNop())
+	r.GET("/ws", wsHandler.Connect)
+	return httptest.NewServer(r)
+}
+
+func dialWebSocket(t *testing.T, server *httptest.Server) *websocket.Conn {
+	url := "ws" + strings.TrimPrefix(server.URL, "http") + "/ws"
+	conn, err := websocket.Dial(url, "", server.URL)
+	require.NoError(t, err)
+
+	// the server confirms the subscription before any event is pushed
+	var hello testWSMessage
+	require.NoError(t, websocket.JSON.Receive(conn, &hello))
+	require.Equal(t, "connected", hello.Type)
+	return conn
+}
+
+func TestWebSocketConnect(t *testing.T) {
+	t.Run("ReceivesPostCreated", func(t *testing.T) {
+		bus := events.NewBus(zap.NewNop())
+		server := setupWebSocketServer(bus, testUserID)
+		defer server.Close()
+
+		conn := dialWebSocket(t, server)
+		defer conn.Close()
+
+		bus.Publish(events.PostCreated{PostID: 42, AuthorID: authorID, Content: "hello"})
+
+		var msg testWSMessage
+		require.NoError(t, conn.SetReadDeadline(time.Now().Add(2*time.Second)))
+		require.NoError(t, websocket.JSON.Receive(conn, &msg))
+		assert.Equal(t, events.TypePostCreated, msg.Type)
+		assert.Equal(t, float64(42), msg.Data["post_id"])
+		assert.Equal(t, "hello", msg.Data["content"])
+	})
+
+	t.Run("OwnPostNotDelivered", func(t *testing.T) {
+		bus := events.NewBus(zap.NewNop())
+		server := setupWebSocketServer(bus, authorID)
+		defer server.Close()
+
+		conn := dialWebSocket(t, server)
+		defer conn.Close()
+
+		bus.Publish(events.PostCreated{PostID: 1, AuthorID: authorID, Content: "mine"})
+		bus.Publish(events.PostCreated{PostID: 2, AuthorID: testUserID, Content: "theirs"})
+
+		var msg testWSMessage
+		require.NoError(t, conn.SetReadDeadline(time.Now().Add(2*time.Second)))
+		require.NoError(t, websocket.JSON.Receive(conn, &msg))
+		assert.Equal(t, float64(2), msg.Data["post_id"])
+	})
+
+	t.Run("BlockedAuthorNotDelivered", func(t *testing.T) {
+		bus := events.NewBus(zap.NewNop())
+		server := setupWebSocketServer(bus, testUserID, otherUserID)
+		defer server.Close()
+
+		conn := dialWebSocket(t, server)
+		defer conn.Close()
+
+		bus.Publish(events.PostCreated{PostID: 1, AuthorID: otherUserID, Content: "blocked"})
+		bus.Publish(events.PostCreated{PostID: 2, AuthorID: authorID, Content: "visible"})
+
+		var msg testWSMessage
+		require.NoError(t, conn.SetReadDeadline(time.Now().Add(2*time.Second)))
+		require.NoError(t, websocket.JSON.Receive(conn, &msg))
+		assert.Equal(t, float64(2), msg.Data["post_id"])
+	})
+}
@@ -0,0 +1,122 @@
+package middleware
+
+import (
+	"go-gin-api-server/config"
+	"go-gin-api-server/internal/middleware"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+// Helper functions
+
+func setupTestAdminIPFilterRouter(cfg config.SecurityConfig, trustedProxies []string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	_ = router.SetTrustedProxies(trustedProxies)
+
+	router.Use(middleware.AdminIPFilterMiddleware(cfg, zap.NewNop()))
+
+	router.GET("/api/v1/admin/health", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"message": "success"})
+	})
+	router.GET("/api/v1/posts", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"message": "success"})
+	})
+
+	return router
+}
+
+func performAdminIPFilterRequest(router *gin.Engine, path, remoteAddr, forwardedFor string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest("GET", path, nil)
+	req.RemoteAddr = remoteAddr
+	if forwardedFor != "" {
+		req.Header.Set("X-Forwarded-For", forwardedFor)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func prefixes(cidrs ...string) []netip.Prefix {
+	result := make([]netip.Prefix, len(cidrs))
+	for i, cidr := range cidrs {
+		result[i] = netip.MustParsePrefix(cidr)
+	}
+	return result
+}
+
+func TestAdminIPFilterMiddleware(t *testing.T) {
+	allowOffice := config.SecurityConfig{AdminAllowCIDRs: prefixes("10.0.0.0/8")}
+
+	t.Run("AllowedCIDRPasses", func(t *testing.T) {
+		router := setupTestAdminIPFilterRouter(allowOffice, nil)
+
+		w := performAdminIPFilterRequest(router, "/api/v1/admin/health", "10.1.2.3:1234", "")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("OutsideAllowListForbidden", func(t *testing.T) {
+		router := setupTestAdminIPFilterRouter(allowOffice, nil)
+
+		w := performAdminIPFilterRequest(router, "/api/v1/admin/health", "203.0.113.7:1234", "")
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.JSONEq(t, `{"error":"Access denied from this IP"}`, w.Body.String())
+	})
+
+	t.Run("DeniedIPForbidden", func(t *testing.T) {
+		cfg := config.SecurityConfig{
+			AdminAllowCIDRs: prefixes("10.0.0.0/8"),
+			AdminDenyCIDRs:  prefixes("10.9.9.9/32"),
+		}
+		router := setupTestAdminIPFilterRouter(cfg, nil)
+
+		denied := performAdminIPFilterRequest(router, "/api/v1/admin/health", "10.9.9.9:1234", "")
+		allowed := performAdminIPFilterRequest(router, "/api/v1/admin/health", "10.9.9.8:1234", "")
+
+		assert.Equal(t, http.StatusForbidden, denied.Code)
+		assert.Equal(t, http.StatusOK, allowed.Code)
+	})
+
+	t.Run("ForwardedForFromTrustedProxy", func(t *testing.T) {
+		router := setupTestAdminIPFilterRouter(allowOffice, []string{"192.168.0.1"})
+
+		allowed := performAdminIPFilterRequest(router, "/api/v1/admin/health", "192.168.0.1:1234", "10.1.2.3")
+		denied := performAdminIPFilterRequest(router, "/api/v1/admin/health", "192.168.0.1:1234", "203.0.113.7")
+
+		assert.Equal(t, http.StatusOK, allowed.Code)
+		assert.Equal(t, http.StatusForbidden, denied.Code)
+	})
+
+	t.Run("ForwardedForFromUntrustedClientIgnored", func(t *testing.T) {
+		router := setupTestAdminIPFilterRouter(allowOffice, []string{"192.168.0.1"})
+
+		// a spoofed header from a direct client doesn't get it past the allowlist
+		w := performAdminIPFilterRequest(router, "/api/v1/admin/health", "203.0.113.7:1234", "10.1.2.3")
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("NonAdminPathUnaffected", func(t *testing.T) {
+		router := setupTestAdminIPFilterRouter(allowOffice, nil)
+
+		w := performAdminIPFilterRequest(router, "/api/v1/posts", "203.0.113.7:1234", "")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("NoListsAllowsAll", func(t *testing.T) {
+		router := setupTestAdminIPFilterRouter(config.SecurityConfig{}, nil)
+
+		w := performAdminIPFilterRequest(router, "/api/v1/admin/health", "203.0.113.7:1234", "")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+}
@@ -0,0 +1,234 @@
+package middleware
+
+import (
+	"go-gin-api-server/internal/middleware"
+	"go-gin-api-server/internal/model"
+	"go-gin-api-server/pkg/apperrors"
+	mockServices "go-gin-api-server/test/mocks/service"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+// Helper functions
+
+// setupTestAPIKeyRouter chains the API key middleware in front of RequireAuth like the server does
+func setupTestAPIKeyRouter() (*mockServices.APIKeyServiceMock, *mockServices.AuthServiceMock, *gin.Engine) {
+	gin.SetMode(gin.TestMode)
+	mockAPIKeyService := mockServices.NewAPIKeyServiceMock()
+	mockAuthService := mockServices.NewAuthServiceMock()
+
+	router := gin.New()
+	router.Use(middleware.NewAPIKeyMiddleware(mockAPIKeyService, zap.NewNop()).Authenticate())
+	router.Use(middleware.NewAuthMiddleware(mockAuthService, zap.NewNop()).RequireAuth())
+
+	handler := func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{
+			"user_id":   c.GetString("user_id"),
+			"user_role": c.MustGet("user_role"),
+		})
+	}
+	router.GET("/protected", handler)
+	router.POST("/protected", handler)
+
+	return mockAPIKeyService, mockAuthService, router
+}
+
+func performAPIKeyRequest(router *gin.Engine, method string, authorization string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(method, "/protected", nil)
+	req.Header.Set("Authorization", authorization)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestAPIKeyMiddleware_Authenticate(t *testing.T) {
+	readKey := &model.APIKey{
+		ID:     1,
+		UserID: "user-123",
+		Scopes: model.APIKeyScopes{model.APIKeyScopeRead},
+		User:   &model.User{ID: "user-123", Role: model.RoleModerator, IsActive: true},
+	}
+
+	t.Run("ValidKey", func(t *testing.T) {
+		mockAPIKeyService, mockAuthService, router := setupTestAPIKeyRouter()
+		mockAPIKeyService.On("Authenticate", "gak_valid").Return(readKey, nil)
+
+		w := performAPIKeyRequest(router, http.MethodGet, "ApiKey gak_valid")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"user_id":"user-123","user_role":"moderator"}`, w.Body.String())
+		mockAPIKeyService.AssertExpectations(t)
+		mockAuthService.AssertNotCalled(t, "ValidateToken", mock.Anything)
+	})
+
+	t.Run("RevokedKey", func(t *testing.T) {
+		mockAPIKeyService, _, router := setupTestAPIKeyRouter()
+		mockAPIKeyService.On("Authenticate", "gak_revoked").Return(nil, apperrors.ErrUnauthorized)
+
+		w := performAPIKeyRequest(router, http.MethodGet, "ApiKey gak_revoked")
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Contains(t, w.Body.String(), "Invalid API key")
+	})
+
+	t.Run("ExpiredKey", func(t *testing.T) {
+		mockAPIKeyService, _, router := setupTestAPIKeyRouter()
+		mockAPIKeyService.On("Authenticate", "gak_expired").Return(nil, apperrors.ErrExpiredToken)
+
+		w := performAPIKeyRequest(router, http.MethodGet, "ApiKey gak_expired")
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.JSONEq(t, `{"error":"API key expired","code":"API_KEY_EXPIRED"}`, w.Body.String())
+	})
+
+	t.Run("ReadScopeCannotWrite", func(t *testing.T) {
+		mockAPIKeyService, _, router := setupTestAPIKeyRouter()
+		mockAPIKeyService.On("Authenticate", "gak_valid").Return(readKey, nil)
+
+		w := performAPIKeyRequest(router, http.MethodPost, "ApiKey gak_valid")
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("WriteScopeDeniedOnUnscopedRoute", func(t *testing.T) {
+		mockAPIKeyService, _, router := setupTestAPIKeyRouter()
+		writeKey := *readKey
+		writeKey.Scopes = model.APIKeyScopes{model.APIKeyScopeRead, model.APIKeyScopeWrite}
+		mockAPIKeyService.On("Authenticate", "gak_write").Return(&writeKey, nil)
+
+		// the route declares no scope, so no key may write to it
+		w := performAPIKeyRequest(router, http.MethodPost, "ApiKey gak_write")
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.JSONEq(t, `{"error":"API keys can't be used on this route"}`, w.Body.String())
+	})
+
+	t.Run("BearerPassesThrough", func(t *testing.T) {
+		mockAPIKeyService, mockAuthService, router := setupTestAPIKeyRouter()
+		mockAuthService.On("ValidateToken", "jwt-token").Return(&model.Claims{UserID: "user-456", Role: model.RoleUser}, nil)
+
+		w := performAPIKeyRequest(router, http.MethodGet, "Bearer jwt-token")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "user-456")
+		mockAPIKeyService.AssertNotCalled(t, "Authenticate", mock.Anything)
+	})
+}
+
+// setupTestScopedPostsRouter mirrors the post routes: GET behind RequireScope(posts:read),
+// POST behind RequireScope(posts:write), plus a protected read route declaring no scope
+func setupTestScopedPostsRouter() (*mockServices.APIKeyServiceMock, *gin.Engine) {
+	gin.SetMode(gin.TestMode)
+	mockAPIKeyService := mockServices.NewAPIKeyServiceMock()
+	authMiddleware := middleware.NewAuthMiddleware(mockServices.NewAuthServiceMock(), zap.NewNop())
+	rbacMiddleware := middleware.NewRBACMiddleware(zap.NewNop())
+
+	router := gin.New()
+	router.Use(middleware.NewAPIKeyMiddleware(mockAPIKeyService, zap.NewNop()).Authenticate())
+	handler := func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"message": "success"})
+	}
+	router.GET("/posts", rbacMiddleware.RequireScope(model.APIKeyScopePostsRead), authMiddleware.OptionalAuth(), handler)
+	router.POST("/posts", rbacMiddleware.RequireScope(model.APIKeyScopePostsWrite), authMiddleware.RequireAuth(), handler)
+	// a read route without a scope, like notifications or the data export
+	router.GET("/notifications", authMiddleware.RequireAuth(), handler)
+	// an admin write route without a scope, like impersonation or token revocation
+	router.POST("/admin/users/:id/impersonate", authMiddleware.RequireAuth(), rbacMiddleware.RequireAdmin(), handler)
+
+	return mockAPIKeyService, router
+}
+
+func TestAPIKeyMiddleware_PostScopes(t *testing.T) {
+	keyWithScopes := func(scopes ...model.APIKeyScope) *model.APIKey {
+		return &model.APIKey{
+			ID:     1,
+			UserID: "user-123",
+			Scopes: model.APIKeyScopes(scopes),
+			User:   &model.User{ID: "user-123", Role: model.RoleUser, IsActive: true},
+		}
+	}
+	perform := func(router *gin.Engine, method string, key string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(method, "/posts", nil)
+		req.Header.Set("Authorization", "ApiKey "+key)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("ReadOnlyKeyCanListButNotCreate", func(t *testing.T) {
+		mockAPIKeyService, router := setupTestScopedPostsRouter()
+		mockAPIKeyService.On("Authenticate", "gak_read").Return(keyWithScopes(model.APIKeyScopePostsRead), nil)
+
+		assert.Equal(t, http.StatusOK, perform(router, http.MethodGet, "gak_read").Code)
+		assert.Equal(t, http.StatusForbidden, perform(router, http.MethodPost, "gak_read").Code)
+	})
+
+	t.Run("PostsWriteKeyCanCreate", func(t *testing.T) {
+		mockAPIKeyService, router := setupTestScopedPostsRouter()
+		mockAPIKeyService.On("Authenticate", "gak_write").Return(keyWithScopes(model.APIKeyScopePostsWrite), nil)
+
+		assert.Equal(t, http.StatusOK, perform(router, http.MethodGet, "gak_write").Code)
+		assert.Equal(t, http.StatusOK, perform(router, http.MethodPost, "gak_write").Code)
+	})
+
+	t.Run("PostsWriteKeyDeniedOnAdminWrite", func(t *testing.T) {
+		mockAPIKeyService, router := setupTestScopedPostsRouter()
+		adminKey := keyWithScopes(model.APIKeyScopePostsWrite)
+		adminKey.User.Role = model.RoleAdmin
+		mockAPIKeyService.On("Authenticate", "gak_admin").Return(adminKey, nil)
+
+		req, _ := http.NewRequest(http.MethodPost, "/admin/users/user-456/impersonate", nil)
+		req.Header.Set("Authorization", "ApiKey gak_admin")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.Equal(t, http.StatusOK, perform(router, http.MethodPost, "gak_admin").Code)
+	})
+
+	t.Run("PostsReadKeyDeniedOnUnscopedRead", func(t *testing.T) {
+		mockAPIKeyService, router := setupTestScopedPostsRouter()
+		mockAPIKeyService.On("Authenticate", "gak_read").Return(keyWithScopes(model.APIKeyScopePostsRead), nil)
+
+		req, _ := http.NewRequest(http.MethodGet, "/notifications", nil)
+		req.Header.Set("Authorization", "ApiKey gak_read")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		// posts:read only reaches routes declaring the posts resource
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("BroadReadKeyReadsUnscopedRoute", func(t *testing.T) {
+		mockAPIKeyService, router := setupTestScopedPostsRouter()
+		mockAPIKeyService.On("Authenticate", "gak_read").Return(keyWithScopes(model.APIKeyScopeRead), nil)
+
+		req, _ := http.NewRequest(http.MethodGet, "/notifications", nil)
+		req.Header.Set("Authorization", "ApiKey gak_read")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, http.StatusOK, perform(router, http.MethodGet, "gak_read").Code)
+	})
+
+	t.Run("OtherResourceKeyCannotReadPosts", func(t *testing.T) {
+		mockAPIKeyService, router := setupTestScopedPostsRouter()
+		mockAPIKeyService.On("Authenticate", "gak_users").Return(keyWithScopes("users:read"), nil)
+
+		assert.Equal(t, http.StatusForbidden, perform(router, http.MethodGet, "gak_users").Code)
+	})
+
+	t.Run("OtherResourceWriteKeyCannotCreate", func(t *testing.T) {
+		mockAPIKeyService, router := setupTestScopedPostsRouter()
+		mockAPIKeyService.On("Authenticate", "gak_users").Return(keyWithScopes("users:write"), nil)
+
+		assert.Equal(t, http.StatusForbidden, perform(router, http.MethodPost, "gak_users").Code)
+	})
+}
@@ -0,0 +1,70 @@
+package middleware
+
+import (
+	"go-gin-api-server/config"
+	"go-gin-api-server/internal/middleware"
+	"go-gin-api-server/pkg/utils"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// Helper functions
+
+func setupTestAPIVersionRouter(cfg config.ServerConfig) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	router.Use(middleware.APIVersionMiddleware(cfg))
+
+	router.GET("/sample", func(c *gin.Context) {
+		utils.RespondJSON(c, http.StatusOK, gin.H{"message": "success"})
+	})
+	router.GET("/error", func(c *gin.Context) {
+		utils.RespondError(c, http.StatusBadRequest, "Validation failed")
+	})
+
+	return router
+}
+
+func performAPIVersionRequest(router *gin.Engine, path string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest("GET", path, nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestAPIVersionMiddleware(t *testing.T) {
+	t.Run("SetsConfiguredVersion", func(t *testing.T) {
+		router := setupTestAPIVersionRouter(config.ServerConfig{APIVersion: "v1.4.2"})
+
+		success := performAPIVersionRequest(router, "/sample")
+		failure := performAPIVersionRequest(router, "/error")
+		notFound := performAPIVersionRequest(router, "/missing")
+
+		assert.Equal(t, "v1.4.2", success.Header().Get("X-API-Version"))
+		assert.Equal(t, "v1.4.2", failure.Header().Get("X-API-Version"))
+		assert.Equal(t, "v1.4.2", notFound.Header().Get("X-API-Version"))
+	})
+
+	t.Run("DefaultTestConfig", func(t *testing.T) {
+		cfg := config.LoadTestConfig()
+		router := setupTestAPIVersionRouter(cfg.Server)
+
+		w := performAPIVersionRequest(router, "/sample")
+
+		assert.Equal(t, cfg.Server.APIVersion, w.Header().Get("X-API-Version"))
+	})
+
+	t.Run("EmptyOmitsHeader", func(t *testing.T) {
+		router := setupTestAPIVersionRouter(config.ServerConfig{})
+
+		w := performAPIVersionRequest(router, "/sample")
+
+		_, present := w.Header()["X-Api-Version"]
+		assert.False(t, present)
+	})
+}
@@ -1,13 +1,20 @@
 package middleware
 
 import (
+	"encoding/json"
+	"errors"
+	"go-gin-api-server/config"
 	"go-gin-api-server/internal/middleware"
 	"go-gin-api-server/internal/model"
 	"go-gin-api-server/pkg/apperrors"
+	"go-gin-api-server/pkg/utils"
+	mockRepository "go-gin-api-server/test/mocks/repository"
 	mockServices "go-gin-api-server/test/mocks/service"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
@@ -34,8 +41,9 @@ func setupTestAuthRouter(middlewareFunc gin.HandlerFunc) *gin.Engine {
 	router.GET("/protected", func(c *gin.Context) {
 		userID, _ := c.Get("user_id")
 		c.JSON(http.StatusOK, gin.H{
-			"message": "success",
-			"user_id": userID,
+			"message":         "success",
+			"user_id":         userID,
+			"impersonator_id": c.GetString("impersonator_id"),
 		})
 	})
 
@@ -71,6 +79,28 @@ func TestAuthMiddleware_RequireAuth(t *testing.T) {
 		mockAuthService.AssertExpectations(t)
 	})
 
+	t.Run("ImpersonationTokenExposesImpersonator", func(t *testing.T) {
+		authMiddleware, mockAuthService := setupTestAuthMiddleware()
+		token := "impersonation-token"
+		claims := &model.Claims{UserID: "user-123", ImpersonatorID: "admin-456"}
+
+		// Setup mock
+		mockAuthService.On("ValidateToken", token).Return(claims, nil)
+
+		// Setup router
+		router := setupTestAuthRouter(authMiddleware.RequireAuth())
+
+		// Create request
+		req, _ := http.NewRequest("GET", "/protected", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"message":"success","user_id":"user-123","impersonator_id":"admin-456"}`, w.Body.String())
+	})
+
 	t.Run("MissingAuthorizationHeader", func(t *testing.T) {
 		authMiddleware, mockAuthService := setupTestAuthMiddleware()
 
@@ -242,6 +272,164 @@ func TestAuthMiddleware_RequireAuth(t *testing.T) {
 	})
 }
 
+func TestAuthMiddleware_RevokedToken(t *testing.T) {
+	setup := func() (*mockServices.AuthServiceMock, *utils.MemoryTokenDenylist, *gin.Engine, *gin.Engine) {
+		mockAuthService := mockServices.NewAuthServiceMock()
+		denylist := utils.NewMemoryTokenDenylist()
+		authMiddleware := middleware.NewAuthMiddlewareWithDenylist(mockAuthService, denylist, zap.NewNop())
+		return mockAuthService, denylist, setupTestAuthRouter(authMiddleware.RequireAuth()), setupTestAuthRouter(authMiddleware.OptionalAuth())
+	}
+	claims := &model.Claims{UserID: "user-123"}
+	claims.ID = "revoked-jti"
+
+	t.Run("RequireAuthRejectsRevokedJTI", func(t *testing.T) {
+		mockAuthService, denylist, router, _ := setup()
+		mockAuthService.On("ValidateToken", "leaked-token").Return(claims, nil)
+		denylist.Revoke("revoked-jti", time.Now().Add(time.Minute))
+
+		req, _ := http.NewRequest("GET", "/protected", nil)
+		req.Header.Set("Authorization", "Bearer leaked-token")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Contains(t, w.Body.String(), "Invalid token")
+		mockAuthService.AssertExpectations(t)
+	})
+
+	t.Run("RequireAuthAcceptsOtherJTI", func(t *testing.T) {
+		mockAuthService, denylist, router, _ := setup()
+		mockAuthService.On("ValidateToken", "leaked-token").Return(claims, nil)
+		denylist.Revoke("other-jti", time.Now().Add(time.Minute))
+
+		req, _ := http.NewRequest("GET", "/protected", nil)
+		req.Header.Set("Authorization", "Bearer leaked-token")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockAuthService.AssertExpectations(t)
+	})
+
+	t.Run("OptionalAuthIgnoresRevokedJTI", func(t *testing.T) {
+		mockAuthService, denylist, _, router := setup()
+		mockAuthService.On("ValidateToken", "leaked-token").Return(claims, nil)
+		denylist.Revoke("revoked-jti", time.Now().Add(time.Minute))
+
+		req, _ := http.NewRequest("GET", "/protected", nil)
+		req.Header.Set("Authorization", "Bearer leaked-token")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.NotContains(t, w.Body.String(), "user-123")
+		mockAuthService.AssertExpectations(t)
+	})
+
+	t.Run("DenylistUnavailableFailsClosed", func(t *testing.T) {
+		mockAuthService := mockServices.NewAuthServiceMock()
+		mockAuthService.On("ValidateToken", "leaked-token").Return(claims, nil)
+		mockDenylist := mockRepository.NewRevokedTokenRepositoryMock()
+		mockDenylist.On("IsRevoked", "revoked-jti").Return(false, errors.New("connection refused"))
+		authMiddleware := middleware.NewAuthMiddlewareWithDenylist(mockAuthService, mockDenylist, zap.NewNop())
+
+		for _, tc := range []struct {
+			name   string
+			router *gin.Engine
+			code   int
+		}{
+			{"RequireAuth", setupTestAuthRouter(authMiddleware.RequireAuth()), http.StatusInternalServerError},
+			{"OptionalAuth", setupTestAuthRouter(authMiddleware.OptionalAuth()), http.StatusOK},
+		} {
+			req, _ := http.NewRequest("GET", "/protected", nil)
+			req.Header.Set("Authorization", "Bearer leaked-token")
+			w := httptest.NewRecorder()
+			tc.router.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.code, w.Code, tc.name)
+			assert.NotContains(t, w.Body.String(), "user-123", tc.name)
+		}
+		mockDenylist.AssertExpectations(t)
+	})
+}
+
+func TestAuthMiddleware_RefreshedTokenInBody(t *testing.T) {
+	setup := func(cfg config.AuthConfig) (*mockServices.AuthServiceMock, *gin.Engine) {
+		mockAuthService := mockServices.NewAuthServiceMock()
+		mockAuthService.On("ValidateToken", expiredTokenValue).Return(nil, apperrors.ErrExpiredToken)
+		mockAuthService.On("RefreshAccessToken", "valid-refresh-token").Return("new-access-token", nil)
+		mockAuthService.On("ValidateToken", "new-access-token").Return(&model.Claims{UserID: "user-123"}, nil)
+
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+		router.Use(middleware.NewAuthMiddlewareWithConfig(mockAuthService, nil, cfg, zap.NewNop()).RequireAuth())
+		router.GET("/object", func(c *gin.Context) {
+			utils.RespondJSON(c, http.StatusOK, gin.H{"user_id": c.GetString("user_id")})
+		})
+		router.GET("/array", func(c *gin.Context) {
+			utils.RespondJSON(c, http.StatusOK, []string{"a", "b"})
+		})
+		router.GET("/token", func(c *gin.Context) {
+			utils.RespondJSON(c, http.StatusOK, model.ImpersonationResponse{AccessToken: "impersonation-token", TokenType: "Bearer"})
+		})
+		return mockAuthService, router
+	}
+	perform := func(router *gin.Engine, path string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("GET", path, nil)
+		req.Header.Set("Authorization", "Bearer "+expiredTokenValue)
+		req.AddCookie(&http.Cookie{Name: "gin_api_refresh_token", Value: "valid-refresh-token"})
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("BodyAndHeader", func(t *testing.T) {
+		mockAuthService, router := setup(config.AuthConfig{RefreshedTokenInBody: true})
+
+		w := perform(router, "/object")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "new-access-token", w.Header().Get("X-New-Access-Token"))
+		var body map[string]string
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		assert.Equal(t, map[string]string{
+			"new_access_token": "new-access-token",
+			"user_id":          "user-123",
+		}, body)
+		mockAuthService.AssertExpectations(t)
+	})
+
+	t.Run("BodyWithTokenTypeNotDuplicated", func(t *testing.T) {
+		_, router := setup(config.AuthConfig{RefreshedTokenInBody: true})
+
+		w := perform(router, "/token")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, 1, strings.Count(w.Body.String(), `"token_type"`))
+		assert.Contains(t, w.Body.String(), `"new_access_token":"new-access-token"`)
+	})
+
+	t.Run("NonObjectBodyKeepsShape", func(t *testing.T) {
+		_, router := setup(config.AuthConfig{RefreshedTokenInBody: true})
+
+		w := perform(router, "/array")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, `["a","b"]`, w.Body.String())
+		assert.Equal(t, "new-access-token", w.Header().Get("X-New-Access-Token"))
+	})
+
+	t.Run("HeaderOnlyByDefault", func(t *testing.T) {
+		_, router := setup(config.AuthConfig{})
+
+		w := perform(router, "/object")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "new-access-token", w.Header().Get("X-New-Access-Token"))
+		assert.Equal(t, `{"user_id":"user-123"}`, w.Body.String())
+	})
+}
+
 func TestAuthMiddleware_OptionalAuth(t *testing.T) {
 	t.Run("ValidToken", func(t *testing.T) {
 		authMiddleware, mockAuthService := setupTestAuthMiddleware()
@@ -0,0 +1,246 @@
+package middleware
+
+import (
+	"encoding/json"
+	"go-gin-api-server/internal/middleware"
+	"go-gin-api-server/internal/model"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+// Helper functions
+
+// setupTestConcurrencyLimitRouter routes /slow through the limiter and blocks each
+// request until release is closed, reporting arrivals on entered
+func setupTestConcurrencyLimitRouter(capacity int64, weight int64) (*gin.Engine, chan struct{}, chan struct{}) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	limiter := middleware.NewConcurrencyLimitMiddleware(capacity, zap.NewNop())
+	entered := make(chan struct{}, 16)
+	release := make(chan struct{})
+
+	router.GET("/slow", limiter.Limit(weight), func(c *gin.Context) {
+		entered <- struct{}{}
+		<-release
+		c.JSON(http.StatusOK, gin.H{"message": "success"})
+	})
+
+	return router, entered, release
+}
+
+func performConcurrencyLimitedRequest(router *gin.Engine) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest("GET", "/slow", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+// saturate starts n requests and waits until all of them hold capacity
+func saturate(router *gin.Engine, entered chan struct{}, n int) (*sync.WaitGroup, []*httptest.ResponseRecorder) {
+	var wg sync.WaitGroup
+	results := make([]*httptest.ResponseRecorder, n)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i] = performConcurrencyLimitedRequest(router)
+		}(i)
+	}
+	for i := 0; i < n; i++ {
+		<-entered
+	}
+	return &wg, results
+}
+
+func TestConcurrencyLimitMiddleware(t *testing.T) {
+	t.Run("SaturatedRejectsWithOverloaded", func(t *testing.T) {
+		router, entered, release := setupTestConcurrencyLimitRouter(2, 1)
+		wg, inFlight := saturate(router, entered, 2)
+
+		// run
+		w := performConcurrencyLimitedRequest(router)
+		close(release)
+		wg.Wait()
+
+		// assert
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+		assert.Equal(t, "1", w.Header().Get("Retry-After"))
+		var response model.ErrorResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, middleware.ErrCodeOverloaded, response.Code)
+		for _, r := range inFlight {
+			assert.Equal(t, http.StatusOK, r.Code)
+		}
+	})
+
+	t.Run("CapacityFreedAfterCompletion", func(t *testing.T) {
+		router, entered, release := setupTestConcurrencyLimitRouter(1, 1)
+		wg, _ := saturate(router, entered, 1)
+		close(release)
+		wg.Wait()
+
+		// run
+		w := performConcurrencyLimitedRequest(router)
+
+		// assert
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("WeightAboveCapacityRunsAlone", func(t *testing.T) {
+		router, entered, release := setupTestConcurrencyLimitRouter(2, 5)
+		wg, _ := saturate(router, entered, 1)
+
+		// run
+		w := performConcurrencyLimitedRequest(router)
+		close(release)
+		wg.Wait()
+
+		// assert
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	})
+
+	t.Run("ProblemJSONCarriesCode", func(t *testing.T) {
+		router, entered, release := setupTestConcurrencyLimitRouter(1, 1)
+		wg, _ := saturate(router, entered, 1)
+
+		// run
+		req, _ := http.NewRequest("GET", "/slow", nil)
+		req.Header.Set("Accept", "application/problem+json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		close(release)
+		wg.Wait()
+
+		// assert
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+		var problem model.ProblemDetails
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+		assert.Equal(t, middleware.ErrCodeOverloaded, problem.Code)
+		assert.Equal(t, http.StatusServiceUnavailable, problem.Status)
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		router, entered, release := setupTestConcurrencyLimitRouter(0, 1)
+		close(release)
+
+		// run: nothing to saturate, every request passes straight through
+		for i := 0; i < 3; i++ {
+			w := performConcurrencyLimitedRequest(router)
+			<-entered
+			assert.Equal(t, http.StatusOK, w.Code)
+		}
+	})
+}
+
+func TestConcurrencyLimitMiddleware_LimitExcept(t *testing.T) {
+	setup := func() (*gin.Engine, chan struct{}, chan struct{}) {
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+
+		limiter := middleware.NewConcurrencyLimitMiddleware(2, zap.NewNop())
+		router.Use(limiter.LimitExcept(1, "/health", "/stream"))
+
+		entered := make(chan struct{}, 16)
+		release := make(chan struct{})
+		router.GET("/slow", func(c *gin.Context) {
+			entered <- struct{}{}
+			<-release
+			c.JSON(http.StatusOK, gin.H{"message": "success"})
+		})
+		// stands in for a WebSocket or streamed export, open until release is closed
+		router.GET("/stream", func(c *gin.Context) {
+			entered <- struct{}{}
+			<-release
+			c.Status(http.StatusOK)
+		})
+		router.GET("/health", func(c *gin.Context) {
+			c.JSON(http.StatusOK, gin.H{"status": "ok"})
+		})
+		return router, entered, release
+	}
+
+	get := func(router *gin.Engine, path string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("GET", path, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("SaturatedRejectsExcessButNotHealth", func(t *testing.T) {
+		router, entered, release := setup()
+		wg, inFlight := saturate(router, entered, 2)
+
+		// run
+		excess := get(router, "/slow")
+		health := get(router, "/health")
+		close(release)
+		wg.Wait()
+
+		// assert
+		assert.Equal(t, http.StatusServiceUnavailable, excess.Code)
+		assert.Equal(t, http.StatusOK, health.Code)
+		for _, r := range inFlight {
+			assert.Equal(t, http.StatusOK, r.Code)
+		}
+	})
+
+	t.Run("HeldOpenStreamsTakeNoCapacity", func(t *testing.T) {
+		router, entered, release := setup()
+		var streams sync.WaitGroup
+		for i := 0; i < 4; i++ {
+			streams.Add(1)
+			go func() {
+				defer streams.Done()
+				get(router, "/stream")
+			}()
+		}
+		for i := 0; i < 4; i++ {
+			<-entered
+		}
+
+		// run: more open streams than capacity, ordinary requests still fill it
+		wg, inFlight := saturate(router, entered, 2)
+		excess := get(router, "/slow")
+		close(release)
+		wg.Wait()
+		streams.Wait()
+
+		// assert
+		for _, r := range inFlight {
+			assert.Equal(t, http.StatusOK, r.Code)
+		}
+		assert.Equal(t, http.StatusServiceUnavailable, excess.Code)
+	})
+
+	t.Run("CapacityFreedAsRequestsComplete", func(t *testing.T) {
+		router, entered, release := setup()
+		wg, _ := saturate(router, entered, 2)
+		assert.Equal(t, http.StatusServiceUnavailable, get(router, "/slow").Code)
+		close(release)
+		wg.Wait()
+
+		// run: release is closed, so these complete without blocking
+		var later sync.WaitGroup
+		results := make([]*httptest.ResponseRecorder, 2)
+		for i := range results {
+			later.Add(1)
+			go func(i int) {
+				defer later.Done()
+				results[i] = get(router, "/slow")
+			}(i)
+		}
+		later.Wait()
+
+		// assert
+		for _, r := range results {
+			assert.Equal(t, http.StatusOK, r.Code)
+		}
+	})
+}
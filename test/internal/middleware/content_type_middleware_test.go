@@ -0,0 +1,113 @@
+package middleware
+
+import (
+	"go-gin-api-server/config"
+	"go-gin-api-server/internal/middleware"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// Helper functions
+
+func setupTestContentTypeRouter(cfg config.SecurityConfig) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	router.Use(middleware.JSONContentTypeMiddleware(cfg))
+
+	handler := func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"message": "success"})
+	}
+	router.GET("/sample", handler)
+	router.POST("/sample", handler)
+	router.PATCH("/sample", handler)
+
+	return router
+}
+
+func TestJSONContentTypeMiddleware(t *testing.T) {
+	cfg := config.LoadTestConfig().Security
+
+	t.Run("JSONPassesThrough", func(t *testing.T) {
+		router := setupTestContentTypeRouter(cfg)
+
+		req, _ := http.NewRequest("POST", "/sample", strings.NewReader(`{"content":"hello"}`))
+		req.Header.Set("Content-Type", "application/json; charset=utf-8")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("StructuredJSONPassesThrough", func(t *testing.T) {
+		router := setupTestContentTypeRouter(cfg)
+
+		req, _ := http.NewRequest("PATCH", "/sample", strings.NewReader(`{"content":"hello"}`))
+		req.Header.Set("Content-Type", "application/merge-patch+json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("TextPlainRejected", func(t *testing.T) {
+		router := setupTestContentTypeRouter(cfg)
+
+		req, _ := http.NewRequest("POST", "/sample", strings.NewReader(`{"content":"hello"}`))
+		req.Header.Set("Content-Type", "text/plain")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnsupportedMediaType, w.Code)
+		assert.Contains(t, w.Body.String(), "Content-Type must be application/json")
+	})
+
+	t.Run("MissingContentTypeRejected", func(t *testing.T) {
+		router := setupTestContentTypeRouter(cfg)
+
+		req, _ := http.NewRequest("PATCH", "/sample", strings.NewReader(`{"content":"hello"}`))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnsupportedMediaType, w.Code)
+	})
+
+	t.Run("EmptyBodyPassesThrough", func(t *testing.T) {
+		router := setupTestContentTypeRouter(cfg)
+
+		req, _ := http.NewRequest("POST", "/sample", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("GetIgnored", func(t *testing.T) {
+		router := setupTestContentTypeRouter(cfg)
+
+		req, _ := http.NewRequest("GET", "/sample", nil)
+		req.Header.Set("Content-Type", "text/plain")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		disabled := cfg
+		disabled.EnforceJSONContentType = false
+		router := setupTestContentTypeRouter(disabled)
+
+		req, _ := http.NewRequest("POST", "/sample", strings.NewReader("hello"))
+		req.Header.Set("Content-Type", "text/plain")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+}
@@ -0,0 +1,179 @@
+package middleware
+
+import (
+	"encoding/json"
+	"go-gin-api-server/internal/middleware"
+	"go-gin-api-server/internal/model"
+	"go-gin-api-server/pkg/jsonschema"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// Helper functions
+
+// setupTestJSONSchemaRouter echoes the body the handler sees after validation
+func setupTestJSONSchemaRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	echo := func(c *gin.Context) {
+		body, _ := io.ReadAll(c.Request.Body)
+		c.Data(http.StatusOK, gin.MIMEJSON, body)
+	}
+	router.POST("/register", middleware.ValidateJSONSchema(model.RegisterRequestSchema, 0), echo)
+	router.POST("/posts", middleware.ValidateJSONSchema(model.CreatePostSchema, 0), echo)
+	router.POST("/small", middleware.ValidateJSONSchema(model.CreatePostSchema, 64), echo)
+
+	return router
+}
+
+func performSchemaRequest(router *gin.Engine, path string, body string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest("POST", path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func parseFieldErrors(t *testing.T, w *httptest.ResponseRecorder) []jsonschema.FieldError {
+	var response model.ErrorResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "Request body does not match schema", response.Error)
+	return response.Details
+}
+
+func TestValidateJSONSchema(t *testing.T) {
+	t.Run("ValidRegisterPassesBodyThrough", func(t *testing.T) {
+		router := setupTestJSONSchemaRouter()
+		body := `{"name":"Test User","username":"testuser","email":"test@example.com","password":"password123","birth_date":"2000-01-01T00:00:00Z"}`
+
+		w := performSchemaRequest(router, "/register", body)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, body, w.Body.String())
+	})
+
+	t.Run("RegisterMissingRequired", func(t *testing.T) {
+		router := setupTestJSONSchemaRouter()
+
+		w := performSchemaRequest(router, "/register", `{"username":"testuser"}`)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, []jsonschema.FieldError{
+			{Field: "name", Message: "is required"},
+			{Field: "password", Message: "is required"},
+		}, parseFieldErrors(t, w))
+	})
+
+	t.Run("RegisterWrongTypes", func(t *testing.T) {
+		router := setupTestJSONSchemaRouter()
+
+		w := performSchemaRequest(router, "/register", `{"name":123,"password":true,"birth_date":"01/01/2000"}`)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, []jsonschema.FieldError{
+			{Field: "birth_date", Message: "must be an RFC 3339 date-time"},
+			{Field: "name", Message: "must be string"},
+			{Field: "password", Message: "must be string"},
+		}, parseFieldErrors(t, w))
+	})
+
+	t.Run("RegisterConstraints", func(t *testing.T) {
+		router := setupTestJSONSchemaRouter()
+
+		w := performSchemaRequest(router, "/register", `{"name":"ab","username":"1user","email":"nope","password":"123"}`)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, []jsonschema.FieldError{
+			{Field: "email", Message: "must be an email address"},
+			{Field: "name", Message: "must be at least 3 characters"},
+			{Field: "username", Message: "must match pattern ^[a-zA-Z][a-zA-Z0-9_-]*$"},
+		}, parseFieldErrors(t, w))
+	})
+
+	t.Run("RegisterPasswordLengthLeftToService", func(t *testing.T) {
+		router := setupTestJSONSchemaRouter()
+
+		// AUTH_PASSWORD_MIN_LENGTH is configurable, the schema doesn't fix a minimum
+		w := performSchemaRequest(router, "/register", `{"name":"Test User","password":"abcd"}`)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("RegisterExtraField", func(t *testing.T) {
+		router := setupTestJSONSchemaRouter()
+
+		w := performSchemaRequest(router, "/register", `{"name":"Test User","password":"password123","role":"admin"}`)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, []jsonschema.FieldError{{Field: "role", Message: "is not allowed"}}, parseFieldErrors(t, w))
+	})
+
+	t.Run("PostCreateViolations", func(t *testing.T) {
+		router := setupTestJSONSchemaRouter()
+
+		w := performSchemaRequest(router, "/posts", `{"content":12,"hidden":true}`)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, []jsonschema.FieldError{
+			{Field: "content", Message: "must be string"},
+			{Field: "hidden", Message: "is not allowed"},
+		}, parseFieldErrors(t, w))
+	})
+
+	t.Run("PostContentLengthLeftToService", func(t *testing.T) {
+		router := setupTestJSONSchemaRouter()
+
+		// POST_CONTENT_MIN_LENGTH is configurable, the schema doesn't fix a minimum
+		w := performSchemaRequest(router, "/posts", `{"content":"short"}`)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("PostCreateNotAnObject", func(t *testing.T) {
+		router := setupTestJSONSchemaRouter()
+
+		w := performSchemaRequest(router, "/posts", `"Test Post Content"`)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, []jsonschema.FieldError{{Field: "", Message: "must be object"}}, parseFieldErrors(t, w))
+	})
+
+	t.Run("BodyOverLimit", func(t *testing.T) {
+		router := setupTestJSONSchemaRouter()
+
+		w := performSchemaRequest(router, "/small", `{"content":"`+strings.Repeat("a", 64)+`"}`)
+
+		assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+		assert.JSONEq(t, `{"error":"Request body too large"}`, w.Body.String())
+	})
+
+	t.Run("BodyWithinLimit", func(t *testing.T) {
+		router := setupTestJSONSchemaRouter()
+
+		w := performSchemaRequest(router, "/small", `{"content":"Test Post Content"}`)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("ProblemJSONCarriesErrors", func(t *testing.T) {
+		router := setupTestJSONSchemaRouter()
+
+		req, _ := http.NewRequest("POST", "/posts", strings.NewReader(`{}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Accept", "application/problem+json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		var problem model.ProblemDetails
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+		assert.Equal(t, []jsonschema.FieldError{{Field: "content", Message: "is required"}}, problem.Errors)
+	})
+}
@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"go-gin-api-server/config"
+	"go-gin-api-server/internal/middleware"
+	"go-gin-api-server/pkg/utils"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// Helper functions
+
+func setupTestPrettyJSONRouter(cfg config.ServerConfig) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	router.Use(middleware.PrettyJSONMiddleware(cfg))
+
+	router.GET("/sample", func(c *gin.Context) {
+		utils.RespondJSON(c, http.StatusOK, gin.H{"message": "success"})
+	})
+	router.GET("/error", func(c *gin.Context) {
+		utils.RespondError(c, http.StatusBadRequest, "Validation failed")
+	})
+
+	return router
+}
+
+func performPrettyJSONRequest(router *gin.Engine, path string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest("GET", path, nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestPrettyJSONMiddleware(t *testing.T) {
+	t.Run("Enabled", func(t *testing.T) {
+		router := setupTestPrettyJSONRouter(config.ServerConfig{PrettyJSON: true})
+
+		success := performPrettyJSONRequest(router, "/sample")
+		failure := performPrettyJSONRequest(router, "/error")
+
+		assert.Equal(t, "{\n    \"message\": \"success\"\n}", success.Body.String())
+		assert.Equal(t, "{\n    \"error\": \"Validation failed\"\n}", failure.Body.String())
+		assert.Contains(t, success.Header().Get("Content-Type"), "application/json")
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		router := setupTestPrettyJSONRouter(config.ServerConfig{})
+
+		success := performPrettyJSONRequest(router, "/sample")
+		failure := performPrettyJSONRequest(router, "/error")
+
+		assert.Equal(t, `{"message":"success"}`, success.Body.String())
+		assert.Equal(t, `{"error":"Validation failed"}`, failure.Body.String())
+	})
+}
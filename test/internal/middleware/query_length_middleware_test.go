@@ -0,0 +1,75 @@
+package middleware
+
+import (
+	"go-gin-api-server/config"
+	"go-gin-api-server/internal/middleware"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// Helper functions
+
+func setupTestQueryLengthRouter(cfg config.SecurityConfig) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	router.Use(middleware.MaxQueryLengthMiddleware(cfg))
+	router.GET("/sample", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"message": "success"})
+	})
+
+	return router
+}
+
+func TestMaxQueryLengthMiddleware(t *testing.T) {
+	cfg := config.LoadTestConfig().Security
+	cfg.MaxQueryBytes = 64
+
+	t.Run("NormalQueryPasses", func(t *testing.T) {
+		router := setupTestQueryLengthRouter(cfg)
+
+		req, _ := http.NewRequest("GET", "/sample?limit=10&cursor=eyJpZCI6IjEifQ==", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("ExactLimitPasses", func(t *testing.T) {
+		router := setupTestQueryLengthRouter(cfg)
+
+		req, _ := http.NewRequest("GET", "/sample?cursor="+strings.Repeat("a", 64-len("cursor=")), nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("OverLengthRejected", func(t *testing.T) {
+		router := setupTestQueryLengthRouter(cfg)
+
+		req, _ := http.NewRequest("GET", "/sample?cursor="+strings.Repeat("a", 4096), nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusRequestURITooLong, w.Code)
+		assert.Contains(t, w.Body.String(), "Query string too long")
+	})
+
+	t.Run("DisabledAllowsAnyLength", func(t *testing.T) {
+		disabled := cfg
+		disabled.MaxQueryBytes = 0
+		router := setupTestQueryLengthRouter(disabled)
+
+		req, _ := http.NewRequest("GET", "/sample?cursor="+strings.Repeat("a", 4096), nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+}
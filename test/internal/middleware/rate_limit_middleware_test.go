@@ -0,0 +1,206 @@
+package middleware
+
+import (
+	"go-gin-api-server/internal/middleware"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+// Helper functions
+
+func setupTestRateLimitRouter(limit int, window time.Duration) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	rateLimit := middleware.NewRateLimitMiddleware(limit, window, zap.NewNop())
+	router.Use(rateLimit.LimitByIP())
+
+	router.GET("/sample", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"message": "success"})
+	})
+
+	return router
+}
+
+func performRateLimitedRequest(router *gin.Engine, remoteAddr string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest("GET", "/sample", nil)
+	req.RemoteAddr = remoteAddr
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestRateLimitMiddleware(t *testing.T) {
+	t.Run("WithinLimit", func(t *testing.T) {
+		router := setupTestRateLimitRouter(3, time.Minute)
+
+		for i := 0; i < 3; i++ {
+			w := performRateLimitedRequest(router, "10.0.0.1:1234")
+			assert.Equal(t, http.StatusOK, w.Code)
+		}
+	})
+
+	t.Run("ExceedsLimit", func(t *testing.T) {
+		router := setupTestRateLimitRouter(2, time.Minute)
+
+		performRateLimitedRequest(router, "10.0.0.1:1234")
+		performRateLimitedRequest(router, "10.0.0.1:1234")
+		w := performRateLimitedRequest(router, "10.0.0.1:1234")
+
+		assert.Equal(t, http.StatusTooManyRequests, w.Code)
+		assert.Contains(t, w.Body.String(), "Too many requests")
+		retryAfter, err := strconv.Atoi(w.Header().Get("Retry-After"))
+		assert.NoError(t, err)
+		assert.True(t, retryAfter >= 1 && retryAfter <= 60, "Retry-After %d outside the window", retryAfter)
+	})
+
+	t.Run("RetryAfterPerLimitedEndpoint", func(t *testing.T) {
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+		// separate limiters with their own windows, like the profile and future auth limits
+		profileLimit := middleware.NewRateLimitMiddleware(1, time.Minute, zap.NewNop())
+		authLimit := middleware.NewRateLimitMiddleware(1, 10*time.Second, zap.NewNop())
+		ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+		router.GET("/profile", profileLimit.LimitByIP(), ok)
+		router.GET("/login", authLimit.LimitByIP(), ok)
+
+		for path, window := range map[string]int{"/profile": 60, "/login": 10} {
+			var w *httptest.ResponseRecorder
+			for i := 0; i < 2; i++ {
+				req, _ := http.NewRequest("GET", path, nil)
+				req.RemoteAddr = "10.0.0.1:1234"
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+			}
+
+			assert.Equal(t, http.StatusTooManyRequests, w.Code, path)
+			retryAfter, err := strconv.Atoi(w.Header().Get("Retry-After"))
+			assert.NoError(t, err, path)
+			assert.True(t, retryAfter >= 1 && retryAfter <= window, "%s: Retry-After %d outside the window", path, retryAfter)
+		}
+	})
+
+	t.Run("SeparateBudgetPerIP", func(t *testing.T) {
+		router := setupTestRateLimitRouter(1, time.Minute)
+
+		assert.Equal(t, http.StatusOK, performRateLimitedRequest(router, "10.0.0.1:1234").Code)
+		assert.Equal(t, http.StatusTooManyRequests, performRateLimitedRequest(router, "10.0.0.1:1234").Code)
+		assert.Equal(t, http.StatusOK, performRateLimitedRequest(router, "10.0.0.2:1234").Code)
+	})
+
+	t.Run("WindowResets", func(t *testing.T) {
+		router := setupTestRateLimitRouter(1, 50*time.Millisecond)
+
+		assert.Equal(t, http.StatusOK, performRateLimitedRequest(router, "10.0.0.1:1234").Code)
+		assert.Equal(t, http.StatusTooManyRequests, performRateLimitedRequest(router, "10.0.0.1:1234").Code)
+
+		time.Sleep(60 * time.Millisecond)
+		assert.Equal(t, http.StatusOK, performRateLimitedRequest(router, "10.0.0.1:1234").Code)
+	})
+
+	t.Run("DisabledWhenLimitIsZero", func(t *testing.T) {
+		router := setupTestRateLimitRouter(0, time.Minute)
+
+		for i := 0; i < 5; i++ {
+			w := performRateLimitedRequest(router, "10.0.0.1:1234")
+			assert.Equal(t, http.StatusOK, w.Code)
+		}
+	})
+}
+
+func setupTestClientRateLimitRouter(anonLimit, authLimit int) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	// stands in for OptionalAuth: a user ID header marks the request as authenticated
+	router.Use(func(c *gin.Context) {
+		if userID := c.GetHeader("X-Test-User"); userID != "" {
+			c.Set("user_id", userID)
+		}
+		c.Next()
+	})
+	rateLimit := middleware.NewTieredRateLimitMiddleware(anonLimit, authLimit, time.Minute, zap.NewNop())
+	router.Use(rateLimit.LimitByClient())
+
+	router.GET("/sample", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"message": "success"})
+	})
+
+	return router
+}
+
+func performClientRequest(router *gin.Engine, remoteAddr, userID string) int {
+	req, _ := http.NewRequest("GET", "/sample", nil)
+	req.RemoteAddr = remoteAddr
+	if userID != "" {
+		req.Header.Set("X-Test-User", userID)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w.Code
+}
+
+func TestRateLimitByClient(t *testing.T) {
+	t.Run("AuthenticatedGetsHigherLimit", func(t *testing.T) {
+		router := setupTestClientRateLimitRouter(2, 5)
+
+		// anonymous: third request from the IP is limited
+		for i := 0; i < 2; i++ {
+			assert.Equal(t, http.StatusOK, performClientRequest(router, "10.0.0.1:1234", ""))
+		}
+		assert.Equal(t, http.StatusTooManyRequests, performClientRequest(router, "10.0.0.1:1234", ""))
+
+		// a signed-in user on the same IP has their own, larger budget
+		for i := 0; i < 5; i++ {
+			assert.Equal(t, http.StatusOK, performClientRequest(router, "10.0.0.1:1234", "user-1"))
+		}
+		assert.Equal(t, http.StatusTooManyRequests, performClientRequest(router, "10.0.0.1:1234", "user-1"))
+	})
+
+	t.Run("UsersCountedSeparately", func(t *testing.T) {
+		router := setupTestClientRateLimitRouter(1, 1)
+
+		assert.Equal(t, http.StatusOK, performClientRequest(router, "10.0.0.1:1234", "user-1"))
+		assert.Equal(t, http.StatusOK, performClientRequest(router, "10.0.0.1:1234", "user-2"))
+		assert.Equal(t, http.StatusTooManyRequests, performClientRequest(router, "10.0.0.2:1234", "user-1"))
+	})
+
+	t.Run("ZeroAuthenticatedLimitDisablesForUsers", func(t *testing.T) {
+		router := setupTestClientRateLimitRouter(1, 0)
+
+		for i := 0; i < 5; i++ {
+			assert.Equal(t, http.StatusOK, performClientRequest(router, "10.0.0.1:1234", "user-1"))
+		}
+		assert.Equal(t, http.StatusOK, performClientRequest(router, "10.0.0.1:1234", ""))
+		assert.Equal(t, http.StatusTooManyRequests, performClientRequest(router, "10.0.0.1:1234", ""))
+	})
+}
+
+func TestSetRetryAfter(t *testing.T) {
+	tests := []struct {
+		retryAfter time.Duration
+		expected   string
+	}{
+		{retryAfter: 1500 * time.Millisecond, expected: "2"},
+		{retryAfter: time.Minute, expected: "60"},
+		{retryAfter: 0, expected: "1"},
+		{retryAfter: -time.Second, expected: "1"},
+	}
+
+	gin.SetMode(gin.TestMode)
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+
+		middleware.SetRetryAfter(c, tt.retryAfter)
+
+		assert.Equal(t, tt.expected, w.Header().Get("Retry-After"), tt.retryAfter.String())
+	}
+}
@@ -95,6 +95,51 @@ func TestRBACMiddleware_RequireAdmin(t *testing.T) {
 	})
 }
 
+// Test RequireModerator
+
+func TestRBACMiddleware_RequireModerator(t *testing.T) {
+	rbacMiddleware := setupTestRBACMiddleware()
+
+	testCases := []struct {
+		name     string
+		role     model.UserRole
+		expected int
+	}{
+		{"Success_Moderator", model.RoleModerator, http.StatusOK},
+		{"Success_Admin", model.RoleAdmin, http.StatusOK},
+		{"Forbidden_User", model.RoleUser, http.StatusForbidden},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			router := setupTestRBACRouter(func(c *gin.Context) {
+				c.Set("user_role", tc.role)
+				c.Next()
+			}, rbacMiddleware.RequireModerator())
+
+			req, _ := http.NewRequest(http.MethodGet, "/protected", nil)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expected, w.Code)
+		})
+	}
+
+	t.Run("Unauthorized_NoRole", func(t *testing.T) {
+		router := setupTestRBACRouter(func(c *gin.Context) {
+			c.Next()
+		}, rbacMiddleware.RequireModerator())
+
+		req, _ := http.NewRequest(http.MethodGet, "/protected", nil)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+}
+
 // Test RequireOwnership
 
 func TestRBACMiddleware_RequireOwnership(t *testing.T) {
@@ -195,3 +240,119 @@ func TestRBACMiddleware_RequireOwnershipOrAdmin(t *testing.T) {
 		assert.Equal(t, http.StatusForbidden, w.Code)
 	})
 }
+
+// Test ForbidImpersonation
+
+func TestRBACMiddleware_ForbidImpersonation(t *testing.T) {
+	rbacMiddleware := setupTestRBACMiddleware()
+
+	t.Run("Success_NormalToken", func(t *testing.T) {
+		router := setupTestRBACRouter(func(c *gin.Context) {
+			c.Set("user_id", "user-123")
+			c.Set("user_role", model.RoleUser)
+			c.Set("impersonator_id", "")
+			c.Next()
+		}, rbacMiddleware.ForbidImpersonation())
+
+		req, _ := http.NewRequest(http.MethodGet, "/protected", nil)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("Forbidden_ImpersonationToken", func(t *testing.T) {
+		router := setupTestRBACRouter(func(c *gin.Context) {
+			c.Set("user_id", "user-123")
+			c.Set("user_role", model.RoleUser)
+			c.Set("impersonator_id", "admin-456")
+			c.Next()
+		}, rbacMiddleware.ForbidImpersonation())
+
+		req, _ := http.NewRequest(http.MethodGet, "/protected", nil)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+}
+
+// Test ForbidAPIKey
+
+func TestRBACMiddleware_ForbidAPIKey(t *testing.T) {
+	rbacMiddleware := setupTestRBACMiddleware()
+
+	t.Run("Success_Token", func(t *testing.T) {
+		router := setupTestRBACRouter(func(c *gin.Context) {
+			c.Set("user_id", "user-123")
+			c.Next()
+		}, rbacMiddleware.ForbidAPIKey())
+
+		req, _ := http.NewRequest(http.MethodGet, "/protected", nil)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("Forbidden_APIKey", func(t *testing.T) {
+		router := setupTestRBACRouter(func(c *gin.Context) {
+			c.Set("user_id", "user-123")
+			c.Set("api_key_id", uint64(1))
+			c.Next()
+		}, rbacMiddleware.ForbidAPIKey())
+
+		req, _ := http.NewRequest(http.MethodGet, "/protected", nil)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+}
+
+// Test RequireScope
+
+func TestRBACMiddleware_RequireScope(t *testing.T) {
+	rbacMiddleware := setupTestRBACMiddleware()
+
+	tests := []struct {
+		name    string
+		context func(c *gin.Context)
+		want    int
+	}{
+		{"Success_JWTSession", func(c *gin.Context) {
+			c.Set("user_id", "user-123")
+		}, http.StatusOK},
+		{"Success_GrantedKey", func(c *gin.Context) {
+			c.Set("api_key_id", uint64(1))
+			c.Set("api_key_scopes", model.APIKeyScopes{model.APIKeyScopePostsWrite})
+		}, http.StatusOK},
+		{"Forbidden_MissingScope", func(c *gin.Context) {
+			c.Set("api_key_id", uint64(1))
+			c.Set("api_key_scopes", model.APIKeyScopes{model.APIKeyScopePostsRead})
+		}, http.StatusForbidden},
+		{"Forbidden_NoScopes", func(c *gin.Context) {
+			c.Set("api_key_id", uint64(1))
+		}, http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := setupTestRBACRouter(func(c *gin.Context) {
+				tt.context(c)
+				c.Next()
+			}, rbacMiddleware.RequireScope(model.APIKeyScopePostsWrite))
+
+			req, _ := http.NewRequest(http.MethodGet, "/protected", nil)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.want, w.Code)
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package middleware
+
+import (
+	"go-gin-api-server/config"
+	"go-gin-api-server/internal/middleware"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// Helper functions
+
+func setupTestSecurityHeadersRouter(cfg config.SecurityConfig) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	router.Use(middleware.SecurityHeadersMiddleware(cfg))
+
+	router.GET("/sample", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"message": "success"})
+	})
+
+	return router
+}
+
+func TestSecurityHeadersMiddleware(t *testing.T) {
+	t.Run("Development_HeadersWithoutHSTS", func(t *testing.T) {
+		cfg := config.LoadTestConfig().Security
+		router := setupTestSecurityHeadersRouter(cfg)
+
+		req, _ := http.NewRequest("GET", "/sample", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "nosniff", w.Header().Get("X-Content-Type-Options"))
+		assert.Equal(t, "DENY", w.Header().Get("X-Frame-Options"))
+		assert.Equal(t, "strict-origin-when-cross-origin", w.Header().Get("Referrer-Policy"))
+		assert.Empty(t, w.Header().Get("Strict-Transport-Security"))
+	})
+
+	t.Run("Production_WithHSTS", func(t *testing.T) {
+		cfg := config.LoadTestConfig().Security
+		cfg.HSTSEnabled = true
+		cfg.HSTSMaxAge = 24 * time.Hour
+		router := setupTestSecurityHeadersRouter(cfg)
+
+		req, _ := http.NewRequest("GET", "/sample", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "nosniff", w.Header().Get("X-Content-Type-Options"))
+		assert.Equal(t, "max-age=86400; includeSubDomains", w.Header().Get("Strict-Transport-Security"))
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		cfg := config.LoadTestConfig().Security
+		cfg.HeadersEnabled = false
+		router := setupTestSecurityHeadersRouter(cfg)
+
+		req, _ := http.NewRequest("GET", "/sample", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Empty(t, w.Header().Get("X-Content-Type-Options"))
+		assert.Empty(t, w.Header().Get("X-Frame-Options"))
+		assert.Empty(t, w.Header().Get("Referrer-Policy"))
+	})
+}
@@ -0,0 +1,82 @@
+package middleware
+
+import (
+	"go-gin-api-server/internal/middleware"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// Helper functions
+
+func setupTestZapRouter(budget time.Duration) (*gin.Engine, *observer.ObservedLogs) {
+	gin.SetMode(gin.TestMode)
+	core, logs := observer.New(zapcore.InfoLevel)
+
+	router := gin.New()
+	router.Use(middleware.GinZapMiddleware(budget, zap.New(core)))
+
+	router.GET("/fast", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"message": "fast"})
+	})
+	router.GET("/slow/:id", func(c *gin.Context) {
+		time.Sleep(20 * time.Millisecond)
+		c.JSON(http.StatusAccepted, gin.H{"message": "slow"})
+	})
+
+	return router, logs
+}
+
+func TestGinZapMiddleware(t *testing.T) {
+	t.Run("SlowRequestWarns", func(t *testing.T) {
+		router, logs := setupTestZapRouter(5 * time.Millisecond)
+
+		req, _ := http.NewRequest("GET", "/slow/42", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		// response is untouched
+		assert.Equal(t, http.StatusAccepted, w.Code)
+		assert.Contains(t, w.Body.String(), "slow")
+
+		warnings := logs.FilterLevelExact(zapcore.WarnLevel).All()
+		if assert.Len(t, warnings, 1) {
+			fields := warnings[0].ContextMap()
+			assert.Equal(t, "slow request", warnings[0].Message)
+			assert.Equal(t, "/slow/:id", fields["route"])
+			assert.Equal(t, "/slow/42", fields["path"])
+			assert.GreaterOrEqual(t, fields["duration"].(time.Duration), 20*time.Millisecond)
+			assert.Equal(t, 5*time.Millisecond, fields["budget"])
+		}
+	})
+
+	t.Run("FastRequestDoesNotWarn", func(t *testing.T) {
+		router, logs := setupTestZapRouter(time.Second)
+
+		req, _ := http.NewRequest("GET", "/fast", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Empty(t, logs.FilterLevelExact(zapcore.WarnLevel).All())
+		assert.Len(t, logs.FilterMessage("request completed").All(), 1)
+	})
+
+	t.Run("ZeroBudgetDisablesWarning", func(t *testing.T) {
+		router, logs := setupTestZapRouter(0)
+
+		req, _ := http.NewRequest("GET", "/slow/42", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusAccepted, w.Code)
+		assert.Empty(t, logs.FilterLevelExact(zapcore.WarnLevel).All())
+	})
+}
@@ -0,0 +1,102 @@
+package migration
+
+import (
+	"errors"
+	"go-gin-api-server/internal/migration"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeSource struct {
+	versions []uint
+	err      error
+}
+
+func (s fakeSource) Versions() ([]uint, error) {
+	return s.versions, s.err
+}
+
+type fakeDriver struct {
+	version uint
+	dirty   bool
+	err     error
+}
+
+func (d fakeDriver) MigrationVersion() (uint, bool, error) {
+	return d.version, d.dirty, d.err
+}
+
+func TestCheck(t *testing.T) {
+	t.Run("UpToDate", func(t *testing.T) {
+		status, err := migration.Check(fakeSource{versions: []uint{1, 2, 3}}, fakeDriver{version: 3})
+
+		assert.NoError(t, err)
+		assert.True(t, status.UpToDate())
+		assert.Equal(t, uint(3), status.Latest)
+		assert.Empty(t, status.Pending)
+		assert.Equal(t, migration.ExitUpToDate, migration.ExitCode(status, err))
+	})
+
+	t.Run("Pending", func(t *testing.T) {
+		status, err := migration.Check(fakeSource{versions: []uint{3, 1, 2}}, fakeDriver{version: 1})
+
+		assert.NoError(t, err)
+		assert.False(t, status.UpToDate())
+		assert.Equal(t, []uint{2, 3}, status.Pending)
+		assert.Equal(t, migration.ExitPending, migration.ExitCode(status, err))
+	})
+
+	t.Run("Dirty", func(t *testing.T) {
+		status, err := migration.Check(fakeSource{versions: []uint{1, 2}}, fakeDriver{version: 2, dirty: true})
+
+		assert.NoError(t, err)
+		assert.Empty(t, status.Pending)
+		assert.Equal(t, migration.ExitPending, migration.ExitCode(status, err))
+	})
+
+	t.Run("DriverError", func(t *testing.T) {
+		status, err := migration.Check(fakeSource{versions: []uint{1}}, fakeDriver{err: errors.New("connection refused")})
+
+		assert.Error(t, err)
+		assert.Equal(t, migration.ExitError, migration.ExitCode(status, err))
+	})
+
+	t.Run("SourceError", func(t *testing.T) {
+		status, err := migration.Check(fakeSource{err: os.ErrNotExist}, fakeDriver{version: 1})
+
+		assert.ErrorIs(t, err, os.ErrNotExist)
+		assert.Equal(t, migration.ExitError, migration.ExitCode(status, err))
+	})
+}
+
+func TestDirSource(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{
+		"001_create_users_table.up.sql",
+		"001_create_users_table.down.sql",
+		"002_create_posts_table.up.sql",
+		"010_add_index.up.sql",
+		"README.md",
+		"notaversion_x.up.sql",
+	} {
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, name), nil, 0o600))
+	}
+
+	versions, err := migration.DirSource(dir).Versions()
+
+	assert.NoError(t, err)
+	assert.Equal(t, []uint{1, 2, 10}, versions)
+}
+
+func TestDirSource_RepositoryMigrations(t *testing.T) {
+	// the shipped migrations are numbered without gaps
+	versions, err := migration.DirSource("../../../migrations").Versions()
+
+	assert.NoError(t, err)
+	for i, version := range versions {
+		assert.Equal(t, uint(i+1), version)
+	}
+}
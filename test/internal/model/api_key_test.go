@@ -0,0 +1,39 @@
+package model
+
+import (
+	"go-gin-api-server/internal/model"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIKeyScopesGrants(t *testing.T) {
+	tests := []struct {
+		name    string
+		granted model.APIKeyScopes
+		scope   model.APIKeyScope
+		want    bool
+	}{
+		{"ExactMatch", model.APIKeyScopes{model.APIKeyScopePostsWrite}, model.APIKeyScopePostsWrite, true},
+		{"WriteImpliesRead", model.APIKeyScopes{model.APIKeyScopePostsWrite}, model.APIKeyScopePostsRead, true},
+		{"ReadDoesNotImplyWrite", model.APIKeyScopes{model.APIKeyScopePostsRead}, model.APIKeyScopePostsWrite, false},
+		{"BroadWriteCoversResource", model.APIKeyScopes{model.APIKeyScopeWrite}, model.APIKeyScopePostsWrite, true},
+		{"BroadReadCoversResourceRead", model.APIKeyScopes{model.APIKeyScopeRead}, model.APIKeyScopePostsRead, true},
+		{"BroadReadNotWrite", model.APIKeyScopes{model.APIKeyScopeRead}, model.APIKeyScopePostsWrite, false},
+		{"OtherResource", model.APIKeyScopes{"users:write"}, model.APIKeyScopePostsWrite, false},
+		{"Empty", model.APIKeyScopes{}, model.APIKeyScopePostsRead, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.granted.Grants(tt.scope))
+		})
+	}
+}
+
+func TestAPIKeyScopesCanWrite(t *testing.T) {
+	assert.True(t, model.APIKeyScopes{model.APIKeyScopeWrite}.CanWrite())
+	assert.True(t, model.APIKeyScopes{model.APIKeyScopeRead, model.APIKeyScopePostsWrite}.CanWrite())
+	assert.False(t, model.APIKeyScopes{model.APIKeyScopeRead, model.APIKeyScopePostsRead}.CanWrite())
+	assert.False(t, model.APIKeyScopes{}.CanWrite())
+}
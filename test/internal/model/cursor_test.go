@@ -0,0 +1,90 @@
+package model
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"go-gin-api-server/internal/model"
+	"go-gin-api-server/pkg/apperrors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func createTestCursor() model.Cursor {
+	return model.Cursor{
+		ID:        "42",
+		CreatedAt: time.Date(2024, 1, 1, 8, 0, 0, 123456000, time.UTC),
+	}
+}
+
+func encodeRaw(data []byte) string {
+	return base64.StdEncoding.EncodeToString(data)
+}
+
+func TestEncodeCursor(t *testing.T) {
+	t.Run("PrefixesVersion", func(t *testing.T) {
+		encoded := model.EncodeCursor(createTestCursor())
+
+		data, err := base64.StdEncoding.DecodeString(encoded)
+		assert.NoError(t, err)
+		assert.Equal(t, model.CursorVersion, data[0])
+	})
+}
+
+func TestDecodeCursor(t *testing.T) {
+	t.Run("V1RoundTrip", func(t *testing.T) {
+		cursor := createTestCursor()
+
+		decoded, err := model.DecodeCursor(model.EncodeCursor(cursor))
+
+		assert.NoError(t, err)
+		assert.Equal(t, cursor.ID, decoded.ID)
+		assert.True(t, cursor.CreatedAt.Equal(decoded.CreatedAt))
+	})
+
+	t.Run("LegacyUnversionedCursor", func(t *testing.T) {
+		cursor := createTestCursor()
+		data, err := json.Marshal(cursor)
+		assert.NoError(t, err)
+
+		decoded, err := model.DecodeCursor(encodeRaw(data))
+
+		assert.NoError(t, err)
+		assert.Equal(t, cursor.ID, decoded.ID)
+		assert.True(t, cursor.CreatedAt.Equal(decoded.CreatedAt))
+	})
+
+	t.Run("UnknownVersion", func(t *testing.T) {
+		data, err := json.Marshal(createTestCursor())
+		assert.NoError(t, err)
+
+		_, err = model.DecodeCursor(encodeRaw(append([]byte{model.CursorVersion + 1}, data...)))
+
+		assert.ErrorIs(t, err, apperrors.ErrValidation)
+	})
+
+	t.Run("GarbageVersion", func(t *testing.T) {
+		_, err := model.DecodeCursor(encodeRaw([]byte{0xff, 'x', 'y'}))
+
+		assert.ErrorIs(t, err, apperrors.ErrValidation)
+	})
+
+	t.Run("MalformedPayload", func(t *testing.T) {
+		_, err := model.DecodeCursor(encodeRaw([]byte{model.CursorVersion, 'n', 'o'}))
+
+		assert.ErrorIs(t, err, apperrors.ErrValidation)
+	})
+
+	t.Run("NotBase64", func(t *testing.T) {
+		_, err := model.DecodeCursor("not-base64!")
+
+		assert.ErrorIs(t, err, apperrors.ErrValidation)
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		_, err := model.DecodeCursor("")
+
+		assert.ErrorIs(t, err, apperrors.ErrValidation)
+	})
+}
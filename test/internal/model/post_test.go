@@ -0,0 +1,101 @@
+package model
+
+import (
+	"encoding/json"
+	"go-gin-api-server/internal/model"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// beyond JavaScript's Number.MAX_SAFE_INTEGER (2^53 - 1)
+const unsafeJSPostID uint64 = 1<<53 + 1
+
+func createTestPostResponse() model.PostResponse {
+	username := "author"
+	return model.PostResponse{
+		Post: model.Post{
+			ID:        unsafeJSPostID,
+			Content:   "Test Content",
+			AuthorID:  "author-e29b-41d4-a716-446655440000",
+			CreatedAt: time.Date(2024, 1, 1, 8, 0, 0, 0, time.UTC),
+			UpdatedAt: time.Date(2024, 1, 1, 8, 0, 0, 0, time.UTC),
+		},
+		Author: &model.AuthorSummary{ID: "author-e29b-41d4-a716-446655440000", Username: &username},
+	}
+}
+
+func TestPostResponseJSON(t *testing.T) {
+	t.Run("IDIsString", func(t *testing.T) {
+		data, err := json.Marshal(createTestPostResponse())
+		assert.NoError(t, err)
+
+		var raw map[string]interface{}
+		assert.NoError(t, json.Unmarshal(data, &raw))
+		assert.Equal(t, strconv.FormatUint(unsafeJSPostID, 10), raw["id"])
+		assert.Equal(t, "Test Content", raw["content"])
+		assert.Equal(t, "author", raw["author"].(map[string]interface{})["username"])
+	})
+
+	t.Run("RoundTrip", func(t *testing.T) {
+		response := createTestPostResponse()
+		data, err := json.Marshal(response)
+		assert.NoError(t, err)
+
+		var decoded model.PostResponse
+		assert.NoError(t, json.Unmarshal(data, &decoded))
+
+		assert.Equal(t, response.ID, decoded.ID)
+		assert.Equal(t, response.Content, decoded.Content)
+		assert.Equal(t, *response.Author.Username, *decoded.Author.Username)
+	})
+
+	t.Run("AcceptsNumericID", func(t *testing.T) {
+		var decoded model.PostResponse
+		assert.NoError(t, json.Unmarshal([]byte(`{"id": 42, "content": "Test Content"}`), &decoded))
+
+		assert.Equal(t, uint64(42), decoded.ID)
+	})
+
+	t.Run("InvalidID", func(t *testing.T) {
+		var decoded model.PostResponse
+		assert.Error(t, json.Unmarshal([]byte(`{"id": "abc"}`), &decoded))
+	})
+
+	t.Run("StringIDRoundTripsThroughCursor", func(t *testing.T) {
+		response := createTestPostResponse()
+		data, err := json.Marshal(response)
+		assert.NoError(t, err)
+
+		var raw map[string]interface{}
+		assert.NoError(t, json.Unmarshal(data, &raw))
+		id := raw["id"].(string)
+
+		decoded, err := model.DecodeCursor(model.EncodeCursor(model.Cursor{ID: id, CreatedAt: response.CreatedAt}))
+		assert.NoError(t, err)
+		parsed, err := strconv.ParseUint(decoded.ID, 10, 64)
+		assert.NoError(t, err)
+		assert.Equal(t, unsafeJSPostID, parsed)
+	})
+}
+
+func TestPostETag(t *testing.T) {
+	post := createTestPostResponse().Post
+
+	t.Run("ChangesWithUpdatedAt", func(t *testing.T) {
+		edited := post
+		edited.UpdatedAt = post.UpdatedAt.Add(time.Microsecond)
+
+		assert.NotEqual(t, post.ETag(), edited.ETag())
+	})
+
+	t.Run("MatchesETag", func(t *testing.T) {
+		assert.True(t, post.MatchesETag(post.ETag()))
+		assert.True(t, post.MatchesETag(`"stale", `+post.ETag()))
+		assert.True(t, post.MatchesETag("*"))
+		assert.False(t, post.MatchesETag(`"stale"`))
+		assert.False(t, post.MatchesETag("W/"+post.ETag())) // If-Match uses strong comparison
+	})
+}
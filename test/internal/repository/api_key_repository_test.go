@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"go-gin-api-server/internal/model"
+	"go-gin-api-server/internal/repository"
+	"go-gin-api-server/pkg/apperrors"
+	"go-gin-api-server/pkg/utils"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestCases
+
+func TestAPIKeyRepository(t *testing.T) {
+	createKey := func(t *testing.T, repo repository.APIKeyRepository, userID string, key string) *model.APIKey {
+		created, err := repo.Create(&model.APIKey{
+			UserID:  userID,
+			Name:    "integration",
+			Prefix:  key[:8],
+			KeyHash: utils.HashAPIKey(key),
+			Scopes:  model.APIKeyScopes{model.APIKeyScopeRead, model.APIKeyScopeWrite},
+		})
+		assert.NoError(t, err)
+		return created
+	}
+
+	t.Run("FindByHashLoadsUser", func(t *testing.T) {
+		tx := setup()
+		defer teardown(tx)
+
+		user := firstCreateTestUser(t, tx, nil)
+		repo := repository.NewAPIKeyRepositoryWithDB(tx)
+		created := createKey(t, repo, user.ID, "gak_findbyhash")
+
+		// run
+		found, err := repo.FindByHash(utils.HashAPIKey("gak_findbyhash"))
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, created.ID, found.ID)
+		assert.Equal(t, model.APIKeyScopes{model.APIKeyScopeRead, model.APIKeyScopeWrite}, found.Scopes)
+		if assert.NotNil(t, found.User) {
+			assert.Equal(t, user.ID, found.User.ID)
+		}
+
+		_, err = repo.FindByHash(utils.HashAPIKey("gak_unknown"))
+		assert.ErrorIs(t, err, apperrors.ErrNotFound)
+	})
+
+	t.Run("RevokeOnlyOwnKeyOnce", func(t *testing.T) {
+		tx := setup()
+		defer teardown(tx)
+
+		user := firstCreateTestUser(t, tx, nil)
+		other := firstCreateTestUser(t, tx, map[string]interface{}{"username": "other_key_owner", "email": "other@test.com"})
+		repo := repository.NewAPIKeyRepositoryWithDB(tx)
+		created := createKey(t, repo, user.ID, "gak_revokeonce")
+
+		// run
+		errOther := repo.Revoke(created.ID, other.ID)
+		errOwner := repo.Revoke(created.ID, user.ID)
+		errAgain := repo.Revoke(created.ID, user.ID)
+
+		// assert
+		assert.ErrorIs(t, errOther, apperrors.ErrNotFound)
+		assert.NoError(t, errOwner)
+		assert.ErrorIs(t, errAgain, apperrors.ErrNotFound)
+
+		found, err := repo.FindByHash(utils.HashAPIKey("gak_revokeonce"))
+		assert.NoError(t, err)
+		assert.NotNil(t, found.RevokedAt)
+	})
+
+	t.Run("ListByUserAndTouch", func(t *testing.T) {
+		tx := setup()
+		defer teardown(tx)
+
+		user := firstCreateTestUser(t, tx, nil)
+		repo := repository.NewAPIKeyRepositoryWithDB(tx)
+		first := createKey(t, repo, user.ID, "gak_listfirst")
+		second := createKey(t, repo, user.ID, "gak_listsecond")
+		usedAt := time.Now()
+
+		// run
+		assert.NoError(t, repo.TouchLastUsed(first.ID, usedAt))
+		keys, err := repo.ListByUser(user.ID)
+
+		// assert
+		assert.NoError(t, err)
+		if assert.Len(t, keys, 2) {
+			assert.Equal(t, second.ID, keys[0].ID)
+			assert.Nil(t, keys[0].LastUsedAt)
+			if assert.NotNil(t, keys[1].LastUsedAt) {
+				assert.WithinDuration(t, usedAt, *keys[1].LastUsedAt, time.Millisecond)
+			}
+		}
+	})
+}
@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"go-gin-api-server/internal/model"
+	"go-gin-api-server/internal/repository"
+	"go-gin-api-server/pkg/apperrors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestCases
+
+func TestBlockRepository(t *testing.T) {
+	t.Run("BlockIsOneWay", func(t *testing.T) {
+		tx := setup()
+		defer teardown(tx)
+
+		blocker := firstCreateTestUser(t, tx, nil)
+		blocked := firstCreateTestUser(t, tx, map[string]interface{}{"username": "blocked_user", "email": "blocked@test.com"})
+		repo := repository.NewBlockRepositoryWithDB(tx)
+
+		// run
+		assert.NoError(t, repo.Block(blocker.ID, blocked.ID))
+		// blocking twice is a no-op
+		assert.NoError(t, repo.Block(blocker.ID, blocked.ID))
+
+		// assert
+		hasBlocked, err := repo.HasBlocked(blocker.ID, blocked.ID)
+		assert.NoError(t, err)
+		assert.True(t, hasBlocked)
+
+		hasBlocked, err = repo.HasBlocked(blocked.ID, blocker.ID)
+		assert.NoError(t, err)
+		assert.False(t, hasBlocked)
+
+		// IsBlocked sees the block from both sides
+		isBlocked, err := repo.IsBlocked(blocker.ID, blocked.ID)
+		assert.NoError(t, err)
+		assert.True(t, isBlocked)
+
+		isBlocked, err = repo.IsBlocked(blocked.ID, blocker.ID)
+		assert.NoError(t, err)
+		assert.True(t, isBlocked)
+
+		ids, err := repo.ListBlockedIDs(blocker.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{blocked.ID}, ids)
+
+		count, err := repo.CountBlocked(blocker.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+
+		// the block is one-way in the listing
+		ids, err = repo.ListBlockedIDs(blocked.ID)
+		assert.NoError(t, err)
+		assert.Empty(t, ids)
+	})
+
+	t.Run("Unblock", func(t *testing.T) {
+		tx := setup()
+		defer teardown(tx)
+
+		blocker := firstCreateTestUser(t, tx, nil)
+		blocked := firstCreateTestUser(t, tx, map[string]interface{}{"username": "blocked_user", "email": "blocked@test.com"})
+		repo := repository.NewBlockRepositoryWithDB(tx)
+		assert.NoError(t, repo.Block(blocker.ID, blocked.ID))
+
+		// run
+		assert.NoError(t, repo.Unblock(blocker.ID, blocked.ID))
+
+		// assert
+		hasBlocked, err := repo.HasBlocked(blocker.ID, blocked.ID)
+		assert.NoError(t, err)
+		assert.False(t, hasBlocked)
+
+		isBlocked, err := repo.IsBlocked(blocked.ID, blocker.ID)
+		assert.NoError(t, err)
+		assert.False(t, isBlocked)
+	})
+
+	t.Run("BlockUnknownUser", func(t *testing.T) {
+		tx := setup()
+		defer teardown(tx)
+
+		blocker := firstCreateTestUser(t, tx, nil)
+		repo := repository.NewBlockRepositoryWithDB(tx)
+
+		// run: a failed statement aborts the transaction, so use a savepoint
+		tx.SavePoint("unknown")
+		err := repo.Block(blocker.ID, NonExistentUserID)
+		tx.RollbackTo("unknown")
+
+		// assert
+		assert.ErrorIs(t, err, apperrors.ErrNotFound)
+	})
+
+	t.Run("BlockedAuthorsExcludedFromList", func(t *testing.T) {
+		tx := setup()
+		defer teardown(tx)
+
+		viewer := firstCreateTestUser(t, tx, nil)
+		blocked := firstCreateTestUser(t, tx, map[string]interface{}{"username": "blocked_user", "email": "blocked@test.com"})
+		other := firstCreateTestUser(t, tx, map[string]interface{}{"username": "other_user", "email": "other@test.com"})
+
+		postRepo := repository.NewPostRepositoryWithDB(tx)
+		_, err := postRepo.Create(createTestPost(blocked.ID, map[string]interface{}{"content": "Post by the blocked user"}))
+		assert.NoError(t, err)
+		_, err = postRepo.Create(createTestPost(other.ID, map[string]interface{}{"content": "Post by another user"}))
+		assert.NoError(t, err)
+		_, err = postRepo.Create(createTestPost(viewer.ID, map[string]interface{}{"content": "Post by the viewer"}))
+		assert.NoError(t, err)
+
+		assert.NoError(t, repository.NewBlockRepositoryWithDB(tx).Block(viewer.ID, blocked.ID))
+
+		authorsOf := func(posts []model.Post) []string {
+			ids := make([]string, 0, len(posts))
+			for _, post := range posts {
+				ids = append(ids, post.AuthorID)
+			}
+			return ids
+		}
+
+		// run: the blocker no longer sees the blocked author
+		posts, err := postRepo.List(model.PostListOptions{Limit: 10, ViewerID: viewer.ID})
+		assert.NoError(t, err)
+		assert.NotContains(t, authorsOf(posts), blocked.ID)
+		assert.Contains(t, authorsOf(posts), other.ID)
+
+		// the blocked user no longer sees the blocker either
+		posts, err = postRepo.List(model.PostListOptions{Limit: 10, ViewerID: blocked.ID})
+		assert.NoError(t, err)
+		assert.NotContains(t, authorsOf(posts), viewer.ID)
+		assert.Contains(t, authorsOf(posts), other.ID)
+
+		// anonymous listings are unaffected
+		posts, err = postRepo.List(model.PostListOptions{Limit: 10})
+		assert.NoError(t, err)
+		assert.Contains(t, authorsOf(posts), blocked.ID)
+	})
+}
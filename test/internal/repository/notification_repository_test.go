@@ -0,0 +1,158 @@
+package repository
+
+import (
+	"go-gin-api-server/internal/model"
+	"go-gin-api-server/internal/repository"
+	"go-gin-api-server/pkg/apperrors"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestCases
+
+func TestNotificationRepository(t *testing.T) {
+	t.Run("CreateListAndMarkRead", func(t *testing.T) {
+		tx := setup()
+		defer teardown(tx)
+
+		recipient := firstCreateTestUser(t, tx, nil)
+		actor := firstCreateTestUser(t, tx, map[string]interface{}{
+			"username": "notification_actor",
+			"email":    "notification_actor@test.com",
+		})
+		post, err := repository.NewPostRepositoryWithDB(tx).Create(createTestPost(recipient.ID))
+		assert.NoError(t, err)
+
+		repo := repository.NewNotificationRepositoryWithDB(tx)
+		for i := 0; i < 3; i++ {
+			_, err := repo.Create(&model.Notification{
+				UserID:  recipient.ID,
+				ActorID: actor.ID,
+				Type:    model.NotificationLike,
+				PostID:  &post.ID,
+			})
+			assert.NoError(t, err)
+		}
+
+		// run
+		page, total, err := repo.ListPagedWithCount(model.NotificationPageOptions{UserID: recipient.ID, Offset: 0, Limit: 2})
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), total)
+		assert.Len(t, page, 2)
+		assert.True(t, !page[0].CreatedAt.Before(page[1].CreatedAt))
+
+		unread, err := repo.CountUnread(recipient.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), unread)
+
+		assert.NoError(t, repo.MarkRead(page[0].ID, recipient.ID))
+		unread, err = repo.CountUnread(recipient.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), unread)
+	})
+
+	t.Run("ListCursorAndReadStatus", func(t *testing.T) {
+		tx := setup()
+		defer teardown(tx)
+
+		recipient := firstCreateTestUser(t, tx, nil)
+		actor := firstCreateTestUser(t, tx, map[string]interface{}{
+			"username": "notification_actor",
+			"email":    "notification_actor@test.com",
+		})
+
+		repo := repository.NewNotificationRepositoryWithDB(tx)
+		var created []*model.Notification
+		for i := 0; i < 3; i++ {
+			notification, err := repo.Create(&model.Notification{UserID: recipient.ID, ActorID: actor.ID, Type: model.NotificationMention})
+			assert.NoError(t, err)
+			created = append(created, notification)
+		}
+		assert.NoError(t, repo.MarkRead(created[0].ID, recipient.ID))
+
+		// run: newest first, the second page continues after the first
+		first, err := repo.List(model.NotificationListOptions{UserID: recipient.ID, Limit: 2})
+		assert.NoError(t, err)
+		assert.Len(t, first, 2)
+		last := first[len(first)-1]
+		second, err := repo.List(model.NotificationListOptions{
+			UserID: recipient.ID,
+			Limit:  2,
+			Cursor: model.Cursor{ID: strconv.FormatUint(last.ID, 10), CreatedAt: last.CreatedAt},
+		})
+		assert.NoError(t, err)
+
+		// assert
+		if assert.Len(t, second, 1) {
+			assert.Equal(t, created[0].ID, second[0].ID)
+		}
+
+		unread := model.NotificationUnread
+		unreadOnly, err := repo.List(model.NotificationListOptions{UserID: recipient.ID, Limit: 10, Status: &unread})
+		assert.NoError(t, err)
+		assert.Len(t, unreadOnly, 2)
+
+		read := model.NotificationRead
+		readOnly, err := repo.List(model.NotificationListOptions{UserID: recipient.ID, Limit: 10, Status: &read})
+		assert.NoError(t, err)
+		if assert.Len(t, readOnly, 1) {
+			assert.Equal(t, created[0].ID, readOnly[0].ID)
+		}
+	})
+
+	t.Run("ListByType", func(t *testing.T) {
+		tx := setup()
+		defer teardown(tx)
+
+		recipient := firstCreateTestUser(t, tx, nil)
+		actor := firstCreateTestUser(t, tx, map[string]interface{}{
+			"username": "notification_actor",
+			"email":    "notification_actor@test.com",
+		})
+
+		repo := repository.NewNotificationRepositoryWithDB(tx)
+		mentioned, err := repo.Create(&model.Notification{UserID: recipient.ID, ActorID: actor.ID, Type: model.NotificationMention})
+		assert.NoError(t, err)
+		_, err = repo.Create(&model.Notification{UserID: recipient.ID, ActorID: actor.ID, Type: model.NotificationLike})
+		assert.NoError(t, err)
+
+		// run
+		mention := model.NotificationMention
+		mentions, err := repo.List(model.NotificationListOptions{UserID: recipient.ID, Type: &mention, Limit: 10})
+
+		// assert: likes stay out of the mentions tab
+		assert.NoError(t, err)
+		if assert.Len(t, mentions, 1) {
+			assert.Equal(t, mentioned.ID, mentions[0].ID)
+		}
+	})
+
+	t.Run("MarkReadOtherUsersNotification", func(t *testing.T) {
+		tx := setup()
+		defer teardown(tx)
+
+		recipient := firstCreateTestUser(t, tx, nil)
+		actor := firstCreateTestUser(t, tx, map[string]interface{}{
+			"username": "notification_actor",
+			"email":    "notification_actor@test.com",
+		})
+
+		repo := repository.NewNotificationRepositoryWithDB(tx)
+		created, err := repo.Create(&model.Notification{
+			UserID:  recipient.ID,
+			ActorID: actor.ID,
+			Type:    model.NotificationMention,
+		})
+		assert.NoError(t, err)
+
+		// run
+		err = repo.MarkRead(created.ID, actor.ID)
+
+		// assert
+		assert.ErrorIs(t, err, apperrors.ErrNotFound)
+	})
+}
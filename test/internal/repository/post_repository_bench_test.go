@@ -0,0 +1,179 @@
+package repository
+
+import (
+	"go-gin-api-server/internal/model"
+	"go-gin-api-server/internal/repository"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// Index comparison for the cursor query (migration 014): run
+//
+//	go test -run '^$' -bench BenchmarkPostList ./test/internal/repository/
+//
+// against a test database migrated to 014 for the "after" numbers, then roll it back one
+// step (dropping idx_posts_created_at_id) and run it again for the "before" baseline.
+//
+// Without a (created_at DESC, id DESC) index the moderation listing (IncludeHidden) has to
+// read and sort every matching row for each page; with it, each page is an index range
+// scan that stops after limit+1 rows, so the cost no longer grows with the table size.
+
+const (
+	benchmarkSeedPosts = 20000
+	benchmarkAuthors   = 50
+)
+
+// seedPosts inserts count posts one second apart, spread round-robin over the authors so
+// their timelines interleave, and refreshes planner statistics so EXPLAIN reflects a
+// realistic table
+func seedPosts(tb testing.TB, tx *gorm.DB, authorIDs []string, count int) {
+	n := len(authorIDs)
+	for i, authorID := range authorIDs {
+		err := tx.Exec(`
+			INSERT INTO posts (content, author_id, created_at, updated_at)
+			SELECT 'Seeded post ' || g, ?, NOW() - (g * ? + ?) * INTERVAL '1 second', NOW() - (g * ? + ?) * INTERVAL '1 second'
+			FROM generate_series(1, ?) AS g`, authorID, n, i, n, i, count/n).Error
+		assert.NoError(tb, err)
+	}
+	assert.NoError(tb, tx.Exec("ANALYZE posts").Error)
+}
+
+// seedAuthors creates n users to spread seeded posts over
+func seedAuthors(tb testing.TB, tx *gorm.DB, n int) []string {
+	authorIDs := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		user := firstCreateTestUser(tb, tx, map[string]interface{}{
+			"username": "author" + strconv.Itoa(i),
+			"email":    "author" + strconv.Itoa(i) + "@test.com",
+		})
+		authorIDs = append(authorIDs, user.ID)
+	}
+	return authorIDs
+}
+
+// explainPostList returns the plan of the keyset query postRepositoryImpl.List issues
+func explainPostList(t *testing.T, tx *gorm.DB, authorID *string, includeHidden bool) string {
+	anchorQuery := tx.Order("created_at DESC, id DESC").Offset(100)
+	if authorID != nil {
+		anchorQuery = anchorQuery.Where("author_id = ?", *authorID)
+	}
+	var anchor model.Post
+	assert.NoError(t, anchorQuery.First(&anchor).Error)
+
+	sql := `EXPLAIN SELECT * FROM posts WHERE `
+	args := []interface{}{}
+	if authorID != nil {
+		sql += `author_id = ? AND `
+		args = append(args, *authorID)
+	}
+	sql += `(created_at, id) < (?, ?) AND deleted_at IS NULL`
+	args = append(args, anchor.CreatedAt, anchor.ID)
+	if !includeHidden {
+		sql += ` AND hidden = false`
+	}
+	sql += ` ORDER BY created_at DESC, id DESC LIMIT 11`
+
+	var lines []string
+	assert.NoError(t, tx.Raw(sql, args...).Scan(&lines).Error)
+	return strings.Join(lines, "\n")
+}
+
+func TestPostListQueryPlan(t *testing.T) {
+	t.Run("ModerationListUsesCursorIndex", func(t *testing.T) {
+		tx := setup()
+		defer teardown(tx)
+
+		authorIDs := seedAuthors(t, tx, 1)
+		seedPosts(t, tx, authorIDs, benchmarkSeedPosts)
+
+		plan := explainPostList(t, tx, nil, true)
+
+		assert.Contains(t, plan, "idx_posts_created_at_id", plan)
+		assert.NotContains(t, plan, "Seq Scan", plan)
+	})
+
+	t.Run("PublicListUsesIndex", func(t *testing.T) {
+		tx := setup()
+		defer teardown(tx)
+
+		authorIDs := seedAuthors(t, tx, 1)
+		seedPosts(t, tx, authorIDs, benchmarkSeedPosts)
+
+		plan := explainPostList(t, tx, nil, false)
+
+		assert.Contains(t, plan, "Index", plan)
+		assert.NotContains(t, plan, "Seq Scan", plan)
+	})
+
+	t.Run("AuthorListUsesAuthorIndex", func(t *testing.T) {
+		tx := setup()
+		defer teardown(tx)
+
+		authorIDs := seedAuthors(t, tx, benchmarkAuthors)
+		seedPosts(t, tx, authorIDs, benchmarkSeedPosts)
+
+		plan := explainPostList(t, tx, &authorIDs[0], false)
+
+		assert.Contains(t, plan, "idx_posts_author_created_at_id", plan)
+		assert.NotContains(t, plan, "Seq Scan", plan)
+		assert.NotContains(t, plan, "Sort", plan)
+	})
+}
+
+func BenchmarkPostList(b *testing.B) {
+	for _, includeHidden := range []bool{false, true} {
+		name := "Public"
+		if includeHidden {
+			name = "IncludeHidden"
+		}
+
+		b.Run(name, func(b *testing.B) {
+			tx := setup()
+			defer teardown(tx)
+
+			authorIDs := seedAuthors(b, tx, 1)
+			seedPosts(b, tx, authorIDs, benchmarkSeedPosts)
+			repo := repository.NewPostRepositoryWithDB(tx)
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				walkPages(b, repo, model.PostListOptions{IncludeHidden: includeHidden})
+			}
+		})
+	}
+}
+
+// BenchmarkPostListByAuthor compares author-filtered listing before and after migration
+// 015 the same way as BenchmarkPostList; without (author_id, created_at DESC, id DESC)
+// each page either scans the author's rows and sorts them, or walks the global cursor
+// index discarding other authors' posts
+func BenchmarkPostListByAuthor(b *testing.B) {
+	tx := setup()
+	defer teardown(tx)
+
+	authorIDs := seedAuthors(b, tx, benchmarkAuthors)
+	seedPosts(b, tx, authorIDs, benchmarkSeedPosts)
+	repo := repository.NewPostRepositoryWithDB(tx)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		walkPages(b, repo, model.PostListOptions{AuthorID: &authorIDs[i%len(authorIDs)]})
+	}
+}
+
+// walkPages reads the first 10 pages of 20, following the cursor like a client would
+func walkPages(b *testing.B, repo repository.PostRepository, opts model.PostListOptions) {
+	opts.Limit = 21
+	for page := 0; page < 10; page++ {
+		posts, err := repo.List(opts)
+		if err != nil {
+			b.Fatal(err)
+		}
+		last := posts[len(posts)-2]
+		opts.Cursor = model.Cursor{ID: strconv.FormatUint(last.ID, 10), CreatedAt: last.CreatedAt}
+	}
+}
@@ -13,7 +13,7 @@ import (
 	"gorm.io/gorm"
 )
 
-func firstCreateTestUser(t *testing.T, tx *gorm.DB, overrides map[string]interface{}) *model.User {
+func firstCreateTestUser(t testing.TB, tx *gorm.DB, overrides map[string]interface{}) *model.User {
 	// First create a user
 	userRepo := repository.NewUserRepositoryWithDB(tx)
 	user := createTestUser(overrides)
@@ -118,6 +118,93 @@ func TestCreatePostAndFindByID(t *testing.T) {
 	})
 }
 
+func TestPostSlug(t *testing.T) {
+	t.Run("CollisionAppendsSuffix", func(t *testing.T) {
+		tx := setup()
+		defer teardown(tx)
+
+		createdUser := firstCreateTestUser(t, tx, nil)
+		repo := repository.NewPostRepositoryWithDB(tx)
+		slug := "test-content"
+
+		first := createTestPost(createdUser.ID)
+		first.Slug = &slug
+		created, err := repo.Create(first)
+		assert.NoError(t, err)
+
+		// run
+		second := createTestPost(createdUser.ID)
+		duplicate := slug
+		second.Slug = &duplicate
+		collided, err := repo.Create(second)
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, slug, *created.Slug)
+		assert.NotEqual(t, slug, *collided.Slug)
+		assert.True(t, strings.HasPrefix(*collided.Slug, slug+"-"))
+		assert.NotZero(t, collided.ID)
+
+		// the failed first attempt must not abort the surrounding transaction
+		found, err := repo.FindByID(collided.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, *collided.Slug, *found.Slug)
+	})
+
+	t.Run("WithoutSlug", func(t *testing.T) {
+		tx := setup()
+		defer teardown(tx)
+
+		createdUser := firstCreateTestUser(t, tx, nil)
+		repo := repository.NewPostRepositoryWithDB(tx)
+
+		// run
+		first, err := repo.Create(createTestPost(createdUser.ID))
+		assert.NoError(t, err)
+		second, err := repo.Create(createTestPost(createdUser.ID))
+
+		// assert: NULL slugs don't collide
+		assert.NoError(t, err)
+		assert.Nil(t, first.Slug)
+		assert.Nil(t, second.Slug)
+	})
+
+	t.Run("FindBySlug", func(t *testing.T) {
+		tx := setup()
+		defer teardown(tx)
+
+		createdUser := firstCreateTestUser(t, tx, nil)
+		repo := repository.NewPostRepositoryWithDB(tx)
+		slug := "find-me-by-slug"
+		post := createTestPost(createdUser.ID)
+		post.Slug = &slug
+		created, err := repo.Create(post)
+		assert.NoError(t, err)
+
+		// run
+		found, err := repo.FindBySlug(slug)
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, created.ID, found.ID)
+		assert.NotNil(t, found.Author)
+	})
+
+	t.Run("FindBySlugNotFound", func(t *testing.T) {
+		tx := setup()
+		defer teardown(tx)
+
+		repo := repository.NewPostRepositoryWithDB(tx)
+
+		// run
+		found, err := repo.FindBySlug("no-such-slug")
+
+		// assert
+		assert.ErrorIs(t, err, apperrors.ErrNotFound)
+		assert.Nil(t, found)
+	})
+}
+
 func TestUpdatePost(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
 		tx := setup()
@@ -146,6 +233,74 @@ func TestUpdatePost(t *testing.T) {
 		assert.True(t, found.UpdatedAt.After(found.CreatedAt))
 	})
 
+	t.Run("IfMatchStaleAfterConcurrentWrite", func(t *testing.T) {
+		tx := setup()
+		defer teardown(tx)
+
+		createdUser := firstCreateTestUser(t, tx, nil)
+		repo := repository.NewPostRepositoryWithDB(tx)
+		created, err := repo.Create(createTestPost(createdUser.ID))
+		assert.NoError(t, err)
+		etag := created.ETag()
+
+		// run: two editors both loaded the post at the same version
+		first, err := repo.UpdateIfMatch(created.ID, &model.Post{Content: "First editor content"}, etag)
+		assert.NoError(t, err)
+		_, err = repo.UpdateIfMatch(created.ID, &model.Post{Content: "Second editor content"}, etag)
+		_, replaceErr := repo.ReplaceIfMatch(created.ID, &model.Post{Content: "Third editor content"}, etag)
+
+		// assert: only the first write lands, the others see the bumped version
+		assert.ErrorIs(t, err, apperrors.ErrPreconditionFailed)
+		assert.ErrorIs(t, replaceErr, apperrors.ErrPreconditionFailed)
+		found, err := repo.FindByID(created.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, "First editor content", found.Content)
+		assert.Equal(t, first.ETag(), found.ETag())
+		revisions, err := repo.ListRevisions(created.ID)
+		assert.NoError(t, err)
+		assert.Len(t, revisions, 1)
+	})
+
+	t.Run("RecordsRevision", func(t *testing.T) {
+		tx := setup()
+		defer teardown(tx)
+
+		createdUser := firstCreateTestUser(t, tx, nil)
+		repo := repository.NewPostRepositoryWithDB(tx)
+		created, err := repo.Create(createTestPost(createdUser.ID))
+		assert.NoError(t, err)
+
+		// run
+		_, err = repo.Update(created.ID, &model.Post{Content: "Updated Content"})
+		assert.NoError(t, err)
+		revisions, err := repo.ListRevisions(created.ID)
+
+		// assert
+		assert.NoError(t, err)
+		assert.Len(t, revisions, 1)
+		assert.Equal(t, created.ID, revisions[0].PostID)
+		assert.Equal(t, created.Content, revisions[0].Content)
+	})
+
+	t.Run("UnchangedContentNoRevision", func(t *testing.T) {
+		tx := setup()
+		defer teardown(tx)
+
+		createdUser := firstCreateTestUser(t, tx, nil)
+		repo := repository.NewPostRepositoryWithDB(tx)
+		created, err := repo.Create(createTestPost(createdUser.ID))
+		assert.NoError(t, err)
+
+		// run
+		_, err = repo.Update(created.ID, &model.Post{Content: created.Content})
+		assert.NoError(t, err)
+		revisions, err := repo.ListRevisions(created.ID)
+
+		// assert
+		assert.NoError(t, err)
+		assert.Empty(t, revisions)
+	})
+
 	t.Run("NotFound", func(t *testing.T) {
 		tx := setup()
 		defer teardown(tx)
@@ -186,6 +341,109 @@ func TestUpdatePost(t *testing.T) {
 	})
 }
 
+func TestReplacePost(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		tx := setup()
+		defer teardown(tx)
+
+		createdUser := firstCreateTestUser(t, tx, nil)
+		repo := repository.NewPostRepositoryWithDB(tx)
+		created, err := repo.Create(createTestPost(createdUser.ID))
+		assert.NoError(t, err)
+
+		// run
+		found, err := repo.Replace(created.ID, &model.Post{Content: "Replaced Content"})
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, "Replaced Content", found.Content)
+		assert.Equal(t, created.AuthorID, found.AuthorID)
+		assert.True(t, found.UpdatedAt.After(found.CreatedAt))
+		revisions, err := repo.ListRevisions(created.ID)
+		assert.NoError(t, err)
+		assert.Len(t, revisions, 1)
+		assert.Equal(t, created.Content, revisions[0].Content)
+	})
+
+	t.Run("PatchKeepsOmittedFieldsPutResetsThem", func(t *testing.T) {
+		tx := setup()
+		defer teardown(tx)
+
+		createdUser := firstCreateTestUser(t, tx, nil)
+		repo := repository.NewPostRepositoryWithDB(tx)
+		created, err := repo.Create(createTestPost(createdUser.ID))
+		assert.NoError(t, err)
+
+		// run: the same zero-value body through both paths
+		patched, err := repo.Update(created.ID, &model.Post{})
+		assert.NoError(t, err)
+		replaced, err := repo.Replace(created.ID, &model.Post{})
+		assert.NoError(t, err)
+
+		// assert
+		assert.Equal(t, created.Content, patched.Content)
+		assert.Empty(t, replaced.Content)
+	})
+
+	t.Run("OmittedTitleCleared", func(t *testing.T) {
+		tx := setup()
+		defer teardown(tx)
+
+		createdUser := firstCreateTestUser(t, tx, nil)
+		repo := repository.NewPostRepositoryWithDB(tx)
+		post := createTestPost(createdUser.ID)
+		title := "A title"
+		post.Title = &title
+		created, err := repo.Create(post)
+		assert.NoError(t, err)
+
+		// run
+		patched, err := repo.Update(created.ID, &model.Post{Content: "Patched Content"})
+		assert.NoError(t, err)
+		replaced, err := repo.Replace(created.ID, &model.Post{Content: "Replaced Content"})
+		assert.NoError(t, err)
+
+		// assert
+		if assert.NotNil(t, patched.Title) {
+			assert.Equal(t, title, *patched.Title)
+		}
+		assert.Nil(t, replaced.Title)
+	})
+
+	t.Run("LeavesNonEditableColumns", func(t *testing.T) {
+		tx := setup()
+		defer teardown(tx)
+
+		createdUser := firstCreateTestUser(t, tx, nil)
+		repo := repository.NewPostRepositoryWithDB(tx)
+		created, err := repo.Create(createTestPost(createdUser.ID))
+		assert.NoError(t, err)
+		_, err = repo.SetHidden(created.ID, true)
+		assert.NoError(t, err)
+
+		// run
+		found, err := repo.Replace(created.ID, &model.Post{Content: "Replaced Content"})
+
+		// assert
+		assert.NoError(t, err)
+		assert.True(t, found.Hidden)
+		assert.Equal(t, created.Slug, found.Slug)
+		assert.Equal(t, created.AuthorID, found.AuthorID)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		tx := setup()
+		defer teardown(tx)
+
+		repo := repository.NewPostRepositoryWithDB(tx)
+
+		found, err := repo.Replace(NonExistentPostID, &model.Post{Content: "Replaced Content"})
+
+		assert.ErrorIs(t, err, apperrors.ErrNotFound)
+		assert.Nil(t, found)
+	})
+}
+
 func TestDeletePost(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
 		tx := setup()
@@ -222,6 +480,31 @@ func TestDeletePost(t *testing.T) {
 		assert.ErrorIs(t, err, apperrors.ErrNotFound)
 	})
 
+	t.Run("AuthorDeleted", func(t *testing.T) {
+		tx := setup()
+		defer teardown(tx)
+
+		createdUser := firstCreateTestUser(t, tx, nil)
+		repo := repository.NewPostRepositoryWithDB(tx)
+		created, err := repo.Create(createTestPost(createdUser.ID))
+		assert.NoError(t, err)
+
+		// run: hard-deleting the author cascades to the posts
+		err = repository.NewUserRepositoryWithDB(tx).Delete(createdUser.ID)
+		assert.NoError(t, err)
+
+		// assert: lookups neither panic nor return an author-less post
+		assert.NotPanics(t, func() {
+			found, err := repo.FindByID(created.ID)
+			assert.ErrorIs(t, err, apperrors.ErrNotFound)
+			assert.Nil(t, found)
+
+			posts, err := repo.List(model.PostListOptions{Limit: 10, AuthorID: &createdUser.ID})
+			assert.NoError(t, err)
+			assert.Empty(t, posts)
+		})
+	})
+
 }
 
 func TestListWithCursor(t *testing.T) {
@@ -450,48 +733,953 @@ func TestListWithCursor(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Len(t, emptyPosts, 0)
 	})
-}
 
-func TestCheckPermission(t *testing.T) {
-	t.Run("Success", func(t *testing.T) {
+	t.Run("Filter by AuthorID pages with cursor", func(t *testing.T) {
+		tx := setup()
+		defer teardown(tx)
+
+		user1 := firstCreateTestUser(t, tx, nil)
+		user2 := firstCreateTestUser(t, tx, map[string]interface{}{
+			"username": "testuser2",
+			"email":    "testuser2@test.com",
+		})
+		repo := repository.NewPostRepositoryWithDB(tx)
+
+		// interleave both authors, pairs sharing a timestamp so the id tie-breaker matters
+		createdAt := time.Now().Add(-1 * time.Hour).Truncate(time.Microsecond)
+		var expected []uint64
+		for i := 0; i < 5; i++ {
+			for _, authorID := range []string{user1.ID, user2.ID} {
+				post, err := repo.Create(createTestPost(authorID))
+				assert.NoError(t, err)
+				assert.NoError(t, tx.Model(post).UpdateColumn("created_at", createdAt.Add(time.Duration(i/2)*time.Second)).Error)
+				if authorID == user1.ID {
+					expected = append([]uint64{post.ID}, expected...)
+				}
+			}
+		}
+
+		// run: walk every page of 2 in both directions
+		walk := func(ascending bool) []uint64 {
+			var ids []uint64
+			opts := model.PostListOptions{Limit: 3, AuthorID: &user1.ID, Ascending: ascending}
+			for {
+				posts, err := repo.List(opts)
+				assert.NoError(t, err)
+				page := posts
+				if len(page) > 2 {
+					page = page[:2]
+				}
+				for _, post := range page {
+					assert.Equal(t, user1.ID, post.AuthorID)
+					ids = append(ids, post.ID)
+				}
+				if len(posts) <= 2 {
+					return ids
+				}
+				last := page[len(page)-1]
+				opts.Cursor = model.Cursor{ID: strconv.FormatUint(last.ID, 10), CreatedAt: last.CreatedAt}
+			}
+		}
+
+		// assert
+		assert.Equal(t, expected, walk(false))
+		reversed := make([]uint64, len(expected))
+		for i, id := range expected {
+			reversed[len(expected)-1-i] = id
+		}
+		assert.Equal(t, reversed, walk(true))
+	})
+
+	t.Run("Ascending with cursor", func(t *testing.T) {
 		tx := setup()
 		defer teardown(tx)
 
-		// Create user and post
 		user := firstCreateTestUser(t, tx, nil)
 		repo := repository.NewPostRepositoryWithDB(tx)
-		post := createTestPost(user.ID)
-		createdPost, err := repo.Create(post)
-		assert.NoError(t, err)
+
+		var created []*model.Post
+		for i := 1; i <= 3; i++ {
+			post, err := repo.Create(createTestPost(user.ID, map[string]interface{}{
+				"content": "Post " + strconv.Itoa(i),
+			}))
+			assert.NoError(t, err)
+			created = append(created, post)
+			time.Sleep(1 * time.Millisecond)
+		}
 
 		// run
-		err = repo.CheckPermission(createdPost.ID, user.ID)
+		first, err := repo.List(model.PostListOptions{Limit: 2, AuthorID: &user.ID, Ascending: true})
+		assert.NoError(t, err)
+		last := first[len(first)-1]
+		second, err := repo.List(model.PostListOptions{
+			Limit:     2,
+			AuthorID:  &user.ID,
+			Ascending: true,
+			Cursor:    model.Cursor{ID: strconv.FormatUint(last.ID, 10), CreatedAt: last.CreatedAt},
+		})
+		assert.NoError(t, err)
 
 		// assert
-		assert.NoError(t, err)
+		assert.Len(t, first, 2)
+		assert.Equal(t, created[0].ID, first[0].ID)
+		assert.Equal(t, created[1].ID, first[1].ID)
+		assert.Len(t, second, 1)
+		assert.Equal(t, created[2].ID, second[0].ID)
 	})
 
-	t.Run("User does not own post", func(t *testing.T) {
+	t.Run("Created date range", func(t *testing.T) {
 		tx := setup()
 		defer teardown(tx)
 
-		// Create two users
-		user1 := firstCreateTestUser(t, tx, nil)
-		user2 := firstCreateTestUser(t, tx, map[string]interface{}{
-			"username": "user2",
-			"email":    "user2@test.com",
+		user := firstCreateTestUser(t, tx, nil)
+		repo := repository.NewPostRepositoryWithDB(tx)
+
+		var created []*model.Post
+		for i := 1; i <= 3; i++ {
+			post, err := repo.Create(createTestPost(user.ID, map[string]interface{}{
+				"content": "Post " + strconv.Itoa(i),
+			}))
+			assert.NoError(t, err)
+			created = append(created, post)
+			time.Sleep(1 * time.Millisecond)
+		}
+
+		// run: both bounds are exclusive, so only the middle post remains
+		posts, err := repo.List(model.PostListOptions{
+			Limit:         10,
+			AuthorID:      &user.ID,
+			CreatedAfter:  &created[0].CreatedAt,
+			CreatedBefore: &created[2].CreatedAt,
 		})
 
-		// Create post by user1
+		// assert
+		assert.NoError(t, err)
+		assert.Len(t, posts, 1)
+		assert.Equal(t, created[1].ID, posts[0].ID)
+	})
+}
+
+func TestListSearch(t *testing.T) {
+	t.Run("MatchesTitleOrContent", func(t *testing.T) {
+		tx := setup()
+		defer teardown(tx)
+
+		createdUser := firstCreateTestUser(t, tx, nil)
 		repo := repository.NewPostRepositoryWithDB(tx)
-		post := createTestPost(user1.ID)
-		createdPost, err := repo.Create(post)
+
+		title := "Golang tips"
+		titled := createTestPost(createdUser.ID, map[string]interface{}{"content": "Some weekend reading"})
+		titled.Title = &title
+		titled, err := repo.Create(titled)
+		assert.NoError(t, err)
+		inContent, err := repo.Create(createTestPost(createdUser.ID, map[string]interface{}{"content": "Learning golang this week"}))
+		assert.NoError(t, err)
+		_, err = repo.Create(createTestPost(createdUser.ID, map[string]interface{}{"content": "Nothing to see here"}))
 		assert.NoError(t, err)
 
 		// run
-		err = repo.CheckPermission(createdPost.ID, user2.ID)
+		posts, err := repo.List(model.PostListOptions{Search: "GOLANG", Limit: 10})
 
 		// assert
-		assert.ErrorIs(t, err, apperrors.ErrForbidden)
+		assert.NoError(t, err)
+		var ids []uint64
+		for _, post := range posts {
+			ids = append(ids, post.ID)
+		}
+		assert.ElementsMatch(t, []uint64{titled.ID, inContent.ID}, ids)
+	})
+
+	t.Run("WildcardsMatchLiterally", func(t *testing.T) {
+		tx := setup()
+		defer teardown(tx)
+
+		createdUser := firstCreateTestUser(t, tx, nil)
+		repo := repository.NewPostRepositoryWithDB(tx)
+		_, err := repo.Create(createTestPost(createdUser.ID, map[string]interface{}{"content": "No percent sign here"}))
+		assert.NoError(t, err)
+
+		// run
+		posts, err := repo.List(model.PostListOptions{Search: "%", Limit: 10})
+
+		// assert
+		assert.NoError(t, err)
+		assert.Empty(t, posts)
+	})
+}
+
+func TestListPagedWithCount(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		tx := setup()
+		defer teardown(tx)
+
+		user := firstCreateTestUser(t, tx, nil)
+		repo := repository.NewPostRepositoryWithDB(tx)
+
+		// Create 5 posts
+		for i := 1; i <= 5; i++ {
+			_, err := repo.Create(createTestPost(user.ID, map[string]interface{}{
+				"content": "Post " + strconv.Itoa(i),
+			}))
+			assert.NoError(t, err)
+			time.Sleep(1 * time.Millisecond)
+		}
+
+		// Independent count
+		var expectedTotal int64
+		assert.NoError(t, tx.Model(&model.Post{}).Count(&expectedTotal).Error)
+
+		// run
+		posts, total, err := repo.ListPagedWithCount(model.PostPageOptions{
+			Offset: 0,
+			Limit:  2,
+		})
+
+		// assert
+		assert.NoError(t, err)
+		assert.Len(t, posts, 2)
+		assert.Equal(t, expectedTotal, total)
+		assert.Equal(t, "Post 5", posts[0].Content)
+		assert.NotNil(t, posts[0].Author, "posts[0].Author should not be nil")
+		assert.Equal(t, user.ID, posts[0].Author.ID)
+	})
+
+	t.Run("Last partial page", func(t *testing.T) {
+		tx := setup()
+		defer teardown(tx)
+
+		user := firstCreateTestUser(t, tx, nil)
+		repo := repository.NewPostRepositoryWithDB(tx)
+
+		for i := 1; i <= 5; i++ {
+			_, err := repo.Create(createTestPost(user.ID, map[string]interface{}{
+				"content": "Post " + strconv.Itoa(i),
+			}))
+			assert.NoError(t, err)
+			time.Sleep(1 * time.Millisecond)
+		}
+
+		var expectedTotal int64
+		assert.NoError(t, tx.Model(&model.Post{}).Count(&expectedTotal).Error)
+
+		// run: third page of size 2 only has one post left
+		posts, total, err := repo.ListPagedWithCount(model.PostPageOptions{
+			Offset: 4,
+			Limit:  2,
+		})
+
+		// assert
+		assert.NoError(t, err)
+		assert.Len(t, posts, 1)
+		assert.Equal(t, expectedTotal, total)
+		assert.Equal(t, "Post 1", posts[0].Content)
+	})
+
+	t.Run("Page past the end", func(t *testing.T) {
+		tx := setup()
+		defer teardown(tx)
+
+		user := firstCreateTestUser(t, tx, nil)
+		repo := repository.NewPostRepositoryWithDB(tx)
+
+		_, err := repo.Create(createTestPost(user.ID))
+		assert.NoError(t, err)
+
+		var expectedTotal int64
+		assert.NoError(t, tx.Model(&model.Post{}).Count(&expectedTotal).Error)
+
+		// run
+		posts, total, err := repo.ListPagedWithCount(model.PostPageOptions{
+			Offset: 10,
+			Limit:  2,
+		})
+
+		// assert
+		assert.NoError(t, err)
+		assert.Len(t, posts, 0)
+		assert.Equal(t, expectedTotal, total)
+	})
+
+	t.Run("Filter by AuthorID", func(t *testing.T) {
+		tx := setup()
+		defer teardown(tx)
+
+		user1 := firstCreateTestUser(t, tx, nil)
+		user2 := firstCreateTestUser(t, tx, map[string]interface{}{
+			"username": "testuser2",
+			"email":    "testuser2@test.com",
+		})
+
+		repo := repository.NewPostRepositoryWithDB(tx)
+		for _, authorID := range []string{user1.ID, user1.ID, user1.ID, user2.ID} {
+			_, err := repo.Create(createTestPost(authorID))
+			assert.NoError(t, err)
+		}
+
+		// run
+		posts, total, err := repo.ListPagedWithCount(model.PostPageOptions{
+			AuthorID: &user1.ID,
+			Offset:   0,
+			Limit:    2,
+		})
+
+		// assert
+		assert.NoError(t, err)
+		assert.Len(t, posts, 2)
+		assert.Equal(t, int64(3), total)
+		for _, post := range posts {
+			assert.Equal(t, user1.ID, post.AuthorID)
+		}
+	})
+
+	t.Run("Negative Offset", func(t *testing.T) {
+		tx := setup()
+		defer teardown(tx)
+
+		repo := repository.NewPostRepositoryWithDB(tx)
+
+		posts, total, err := repo.ListPagedWithCount(model.PostPageOptions{
+			Offset: -1,
+			Limit:  10,
+		})
+
+		assert.ErrorIs(t, err, apperrors.ErrValidation)
+		assert.Nil(t, posts)
+		assert.Equal(t, int64(0), total)
+	})
+
+	t.Run("IncludeDeleted", func(t *testing.T) {
+		tx := setup()
+		defer teardown(tx)
+
+		user := firstCreateTestUser(t, tx, nil)
+		repo := repository.NewPostRepositoryWithDB(tx)
+
+		live, err := repo.Create(createTestPost(user.ID, map[string]interface{}{"content": "Live post"}))
+		assert.NoError(t, err)
+		deleted, err := repo.Create(createTestPost(user.ID, map[string]interface{}{"content": "Deleted post"}))
+		assert.NoError(t, err)
+		assert.NoError(t, repo.Delete(deleted.ID))
+
+		// run
+		scoped, scopedTotal, err := repo.ListPagedWithCount(model.PostPageOptions{AuthorID: &user.ID, Limit: 10})
+		assert.NoError(t, err)
+		unscoped, unscopedTotal, err := repo.ListPagedWithCount(model.PostPageOptions{AuthorID: &user.ID, Limit: 10, IncludeDeleted: true})
+		assert.NoError(t, err)
+		pastEnd, pastEndTotal, err := repo.ListPagedWithCount(model.PostPageOptions{AuthorID: &user.ID, Offset: 10, Limit: 10, IncludeDeleted: true})
+		assert.NoError(t, err)
+
+		// assert
+		if assert.Len(t, scoped, 1) {
+			assert.Equal(t, live.ID, scoped[0].ID)
+		}
+		assert.Equal(t, int64(1), scopedTotal)
+
+		assert.Len(t, unscoped, 2)
+		assert.Equal(t, int64(2), unscopedTotal)
+		for _, post := range unscoped {
+			assert.Equal(t, post.ID == deleted.ID, post.DeletedAt.Valid)
+		}
+
+		assert.Empty(t, pastEnd)
+		assert.Equal(t, int64(2), pastEndTotal)
+	})
+}
+
+// countQueries counts the SELECTs issued on db (preloads included) until the test ends
+func countQueries(t *testing.T, db *gorm.DB) *int {
+	count := 0
+	err := db.Callback().Query().After("gorm:query").Register("test:count_queries", func(*gorm.DB) {
+		count++
+	})
+	assert.NoError(t, err)
+	t.Cleanup(func() {
+		_ = db.Callback().Query().Remove("test:count_queries")
+	})
+	return &count
+}
+
+func TestListSummaries(t *testing.T) {
+	t.Run("PreviewWithoutAuthor", func(t *testing.T) {
+		tx := setup()
+		defer teardown(tx)
+
+		user := firstCreateTestUser(t, tx, nil)
+		repo := repository.NewPostRepositoryWithDB(tx)
+		created, err := repo.Create(createTestPost(user.ID, map[string]interface{}{"content": "Ünïcode content that is long"}))
+		assert.NoError(t, err)
+
+		// run
+		posts, err := repo.ListSummaries(model.PostListOptions{Limit: 10}, 7)
+
+		// assert: characters, not bytes, and no author join
+		assert.NoError(t, err)
+		if assert.Len(t, posts, 1) {
+			assert.Equal(t, created.ID, posts[0].ID)
+			assert.Equal(t, "Ünïcode", posts[0].Content)
+			assert.Equal(t, user.ID, posts[0].AuthorID)
+			assert.Nil(t, posts[0].Author)
+			assert.False(t, posts[0].CreatedAt.IsZero())
+		}
+	})
+
+	t.Run("AppliesListFilters", func(t *testing.T) {
+		tx := setup()
+		defer teardown(tx)
+
+		user1 := firstCreateTestUser(t, tx, nil)
+		user2 := firstCreateTestUser(t, tx, map[string]interface{}{
+			"username": "testuser2",
+			"email":    "testuser2@test.com",
+		})
+		repo := repository.NewPostRepositoryWithDB(tx)
+		_, err := repo.Create(createTestPost(user1.ID))
+		assert.NoError(t, err)
+		_, err = repo.Create(createTestPost(user2.ID))
+		assert.NoError(t, err)
+
+		// run
+		posts, err := repo.ListSummaries(model.PostListOptions{Limit: 10, AuthorID: &user2.ID}, 80)
+
+		// assert
+		assert.NoError(t, err)
+		if assert.Len(t, posts, 1) {
+			assert.Equal(t, user2.ID, posts[0].AuthorID)
+		}
+	})
+}
+
+func TestListAuthorPreload(t *testing.T) {
+	seed := func(t *testing.T, tx *gorm.DB, count int) *model.User {
+		user1 := firstCreateTestUser(t, tx, nil)
+		user2 := firstCreateTestUser(t, tx, map[string]interface{}{
+			"username": "testuser2",
+			"email":    "testuser2@test.com",
+		})
+		repo := repository.NewPostRepositoryWithDB(tx)
+		for i := 0; i < count; i++ {
+			author := user1
+			if i%2 == 1 {
+				author = user2
+			}
+			_, err := repo.Create(createTestPost(author.ID))
+			assert.NoError(t, err)
+		}
+		return user1
+	}
+
+	for _, count := range []int{1, 10, 50} {
+		t.Run("ListIssuesTwoQueries/"+strconv.Itoa(count), func(t *testing.T) {
+			tx := setup()
+			defer teardown(tx)
+
+			seed(t, tx, count)
+			repo := repository.NewPostRepositoryWithDB(tx)
+			queries := countQueries(t, tx)
+
+			// run
+			posts, err := repo.List(model.PostListOptions{Limit: 100})
+
+			// assert: one page query plus one author IN query, whatever the page size
+			assert.NoError(t, err)
+			assert.Len(t, posts, count)
+			assert.Equal(t, 2, *queries)
+		})
+	}
+
+	t.Run("SelectsSummaryColumns", func(t *testing.T) {
+		tx := setup()
+		defer teardown(tx)
+
+		user := seed(t, tx, 2)
+		repo := repository.NewPostRepositoryWithDB(tx)
+
+		// run
+		posts, err := repo.List(model.PostListOptions{Limit: 10, AuthorID: &user.ID})
+
+		// assert
+		assert.NoError(t, err)
+		assert.Len(t, posts, 1)
+		assert.NotNil(t, posts[0].Author)
+		assert.Equal(t, user.ID, posts[0].Author.ID)
+		assert.Equal(t, user.Name, posts[0].Author.Name)
+		assert.Equal(t, *user.Username, *posts[0].Author.Username)
+		assert.Nil(t, posts[0].Author.Email)
+	})
+}
+
+func TestIteratePostsByAuthor(t *testing.T) {
+	t.Run("ThousandsOfPostsInBoundedBatches", func(t *testing.T) {
+		tx := setup()
+		defer teardown(tx)
+
+		authorIDs := seedAuthors(t, tx, 2)
+		seedPosts(t, tx, authorIDs, 5000) // 2500 each
+		repo := repository.NewPostRepositoryWithDB(tx)
+
+		// run
+		calls, total, largest := 0, 0, 0
+		seen := make(map[uint64]bool)
+		err := repo.IteratePostsByAuthor(authorIDs[0], model.Cursor{}, 100, func(batch []model.Post) error {
+			calls++
+			total += len(batch)
+			largest = max(largest, len(batch))
+			for _, post := range batch {
+				assert.Equal(t, authorIDs[0], post.AuthorID)
+				seen[post.ID] = true
+			}
+			return nil
+		})
+
+		// assert: every post once, never more than one batch at a time
+		assert.NoError(t, err)
+		assert.Equal(t, 2500, total)
+		assert.Len(t, seen, 2500)
+		assert.Equal(t, 100, largest)
+		assert.Equal(t, 25, calls)
+	})
+
+	t.Run("IncludesHiddenAndArchivedPosts", func(t *testing.T) {
+		tx := setup()
+		defer teardown(tx)
+
+		createdUser := firstCreateTestUser(t, tx, nil)
+		repo := repository.NewPostRepositoryWithDB(tx)
+		hidden, err := repo.Create(createTestPost(createdUser.ID))
+		assert.NoError(t, err)
+		_, err = repo.SetHidden(hidden.ID, true)
+		assert.NoError(t, err)
+		archived, err := repo.Create(createTestPost(createdUser.ID))
+		assert.NoError(t, err)
+		assert.NoError(t, tx.Model(&model.Post{}).Where("id = ?", archived.ID).UpdateColumn("archived", true).Error)
+		visible, err := repo.Create(createTestPost(createdUser.ID))
+		assert.NoError(t, err)
+
+		// run
+		var ids []uint64
+		err = repo.IteratePostsByAuthor(createdUser.ID, model.Cursor{}, 10, func(batch []model.Post) error {
+			for _, post := range batch {
+				ids = append(ids, post.ID)
+			}
+			return nil
+		})
+
+		// assert
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []uint64{hidden.ID, archived.ID, visible.ID}, ids)
+	})
+
+	t.Run("StopIteration", func(t *testing.T) {
+		tx := setup()
+		defer teardown(tx)
+
+		authorIDs := seedAuthors(t, tx, 1)
+		seedPosts(t, tx, authorIDs, 30)
+		repo := repository.NewPostRepositoryWithDB(tx)
+
+		// run
+		calls := 0
+		err := repo.IteratePostsByAuthor(authorIDs[0], model.Cursor{}, 10, func(batch []model.Post) error {
+			calls++
+			return repository.ErrStopIteration
+		})
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("ResumesAfterCursor", func(t *testing.T) {
+		tx := setup()
+		defer teardown(tx)
+
+		authorIDs := seedAuthors(t, tx, 1)
+		seedPosts(t, tx, authorIDs, 30)
+		repo := repository.NewPostRepositoryWithDB(tx)
+
+		var first []model.Post
+		err := repo.IteratePostsByAuthor(authorIDs[0], model.Cursor{}, 10, func(batch []model.Post) error {
+			first = batch
+			return repository.ErrStopIteration
+		})
+		assert.NoError(t, err)
+		last := first[len(first)-1]
+
+		// run
+		total := 0
+		err = repo.IteratePostsByAuthor(authorIDs[0], model.Cursor{ID: strconv.FormatUint(last.ID, 10), CreatedAt: last.CreatedAt}, 10, func(batch []model.Post) error {
+			for _, post := range batch {
+				assert.True(t, post.CreatedAt.Before(last.CreatedAt))
+			}
+			total += len(batch)
+			return nil
+		})
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, 20, total)
+	})
+
+	t.Run("InvalidBatchSize", func(t *testing.T) {
+		tx := setup()
+		defer teardown(tx)
+
+		repo := repository.NewPostRepositoryWithDB(tx)
+
+		err := repo.IteratePostsByAuthor(NonExistentUserID, model.Cursor{}, 0, func(batch []model.Post) error {
+			return nil
+		})
+
+		assert.ErrorIs(t, err, apperrors.ErrValidation)
+	})
+}
+
+func TestDeleteWithAudit(t *testing.T) {
+	t.Run("SoftDeletesAndRecordsAudit", func(t *testing.T) {
+		tx := setup()
+		defer teardown(tx)
+
+		author := firstCreateTestUser(t, tx, nil)
+		moderator := firstCreateTestUser(t, tx, map[string]interface{}{
+			"username": "moderator",
+			"email":    "moderator@example.com",
+		})
+		repo := repository.NewPostRepositoryWithDB(tx)
+		created, err := repo.Create(createTestPost(author.ID))
+		assert.NoError(t, err)
+
+		// run
+		err = repo.DeleteWithAudit(created.ID, &model.AuditLog{
+			ActorID:    moderator.ID,
+			Action:     model.AuditActionPostDelete,
+			TargetType: model.AuditTargetPost,
+			TargetID:   strconv.FormatUint(created.ID, 10),
+		})
+
+		// assert
+		assert.NoError(t, err)
+
+		_, err = repo.FindByID(created.ID)
+		assert.ErrorIs(t, err, apperrors.ErrNotFound)
+
+		var softDeleted model.Post
+		assert.NoError(t, tx.Unscoped().First(&softDeleted, created.ID).Error)
+		assert.True(t, softDeleted.DeletedAt.Valid)
+
+		var entries []model.AuditLog
+		assert.NoError(t, tx.Where("target_type = ? AND target_id = ?", model.AuditTargetPost, strconv.FormatUint(created.ID, 10)).Find(&entries).Error)
+		if assert.Len(t, entries, 1) {
+			assert.Equal(t, moderator.ID, entries[0].ActorID)
+			assert.Equal(t, model.AuditActionPostDelete, entries[0].Action)
+		}
+	})
+
+	t.Run("NotFoundWritesNoAudit", func(t *testing.T) {
+		tx := setup()
+		defer teardown(tx)
+
+		moderator := firstCreateTestUser(t, tx, nil)
+		repo := repository.NewPostRepositoryWithDB(tx)
+
+		// run
+		err := repo.DeleteWithAudit(NonExistentPostID, &model.AuditLog{
+			ActorID:    moderator.ID,
+			Action:     model.AuditActionPostDelete,
+			TargetType: model.AuditTargetPost,
+			TargetID:   strconv.FormatUint(NonExistentPostID, 10),
+		})
+
+		// assert
+		assert.ErrorIs(t, err, apperrors.ErrNotFound)
+		var count int64
+		assert.NoError(t, tx.Model(&model.AuditLog{}).Where("actor_id = ?", moderator.ID).Count(&count).Error)
+		assert.Zero(t, count)
+	})
+}
+
+func TestArchiveOlderThan(t *testing.T) {
+	t.Run("ArchivesOnlyPostsBeforeCutoff", func(t *testing.T) {
+		tx := setup()
+		defer teardown(tx)
+
+		createdUser := firstCreateTestUser(t, tx, nil)
+		repo := repository.NewPostRepositoryWithDB(tx)
+		old, err := repo.Create(createTestPost(createdUser.ID))
+		assert.NoError(t, err)
+		recent, err := repo.Create(createTestPost(createdUser.ID))
+		assert.NoError(t, err)
+		oldCreatedAt := time.Now().Add(-48 * time.Hour)
+		assert.NoError(t, tx.Model(&model.Post{}).Where("id = ?", old.ID).UpdateColumn("created_at", oldCreatedAt).Error)
+
+		// run
+		archived, err := repo.ArchiveOlderThan(time.Now().Add(-24 * time.Hour))
+		assert.NoError(t, err)
+		again, err := repo.ArchiveOlderThan(time.Now().Add(-24 * time.Hour))
+		assert.NoError(t, err)
+
+		public, err := repo.List(model.PostListOptions{Limit: 10, AuthorID: &createdUser.ID})
+		assert.NoError(t, err)
+		archivedList, err := repo.List(model.PostListOptions{Limit: 10, AuthorID: &createdUser.ID, ArchivedOnly: true})
+		assert.NoError(t, err)
+		found, err := repo.FindByID(old.ID)
+		assert.NoError(t, err)
+
+		// assert
+		assert.Equal(t, int64(1), archived)
+		assert.Zero(t, again)
+		assert.Len(t, public, 1)
+		assert.Equal(t, recent.ID, public[0].ID)
+		assert.Len(t, archivedList, 1)
+		assert.Equal(t, old.ID, archivedList[0].ID)
+		assert.True(t, found.Archived)
+	})
+}
+
+func TestSetHidden(t *testing.T) {
+	t.Run("HiddenExcludedFromPublicList", func(t *testing.T) {
+		tx := setup()
+		defer teardown(tx)
+
+		createdUser := firstCreateTestUser(t, tx, nil)
+		repo := repository.NewPostRepositoryWithDB(tx)
+		visible, err := repo.Create(createTestPost(createdUser.ID))
+		assert.NoError(t, err)
+		hidden, err := repo.Create(createTestPost(createdUser.ID))
+		assert.NoError(t, err)
+
+		// run
+		updated, err := repo.SetHidden(hidden.ID, true)
+		assert.NoError(t, err)
+		assert.True(t, updated.Hidden)
+
+		public, err := repo.List(model.PostListOptions{Limit: 10, AuthorID: &createdUser.ID})
+		assert.NoError(t, err)
+		all, err := repo.List(model.PostListOptions{Limit: 10, AuthorID: &createdUser.ID, IncludeHidden: true})
+		assert.NoError(t, err)
+
+		// assert
+		assert.Len(t, public, 1)
+		assert.Equal(t, visible.ID, public[0].ID)
+		assert.Len(t, all, 2)
+	})
+
+	t.Run("HiddenOnly", func(t *testing.T) {
+		tx := setup()
+		defer teardown(tx)
+
+		createdUser := firstCreateTestUser(t, tx, nil)
+		repo := repository.NewPostRepositoryWithDB(tx)
+		_, err := repo.Create(createTestPost(createdUser.ID))
+		assert.NoError(t, err)
+		hidden, err := repo.Create(createTestPost(createdUser.ID))
+		assert.NoError(t, err)
+		_, err = repo.SetHidden(hidden.ID, true)
+		assert.NoError(t, err)
+
+		// run
+		listed, err := repo.List(model.PostListOptions{Limit: 10, AuthorID: &createdUser.ID, HiddenOnly: true})
+		assert.NoError(t, err)
+		paged, total, err := repo.ListPagedWithCount(model.PostPageOptions{Limit: 10, AuthorID: &createdUser.ID, HiddenOnly: true})
+		assert.NoError(t, err)
+
+		// assert
+		assert.Len(t, listed, 1)
+		assert.Equal(t, hidden.ID, listed[0].ID)
+		assert.Len(t, paged, 1)
+		assert.Equal(t, hidden.ID, paged[0].ID)
+		assert.Equal(t, int64(1), total)
+	})
+
+	t.Run("Unhide", func(t *testing.T) {
+		tx := setup()
+		defer teardown(tx)
+
+		createdUser := firstCreateTestUser(t, tx, nil)
+		repo := repository.NewPostRepositoryWithDB(tx)
+		post, err := repo.Create(createTestPost(createdUser.ID))
+		assert.NoError(t, err)
+		_, err = repo.SetHidden(post.ID, true)
+		assert.NoError(t, err)
+
+		// run
+		updated, err := repo.SetHidden(post.ID, false)
+
+		// assert
+		assert.NoError(t, err)
+		assert.False(t, updated.Hidden)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		tx := setup()
+		defer teardown(tx)
+
+		repo := repository.NewPostRepositoryWithDB(tx)
+
+		// run
+		_, err := repo.SetHidden(NonExistentPostID, true)
+
+		// assert
+		assert.ErrorIs(t, err, apperrors.ErrNotFound)
+	})
+}
+
+func TestListRevisions(t *testing.T) {
+	t.Run("NewestFirst", func(t *testing.T) {
+		tx := setup()
+		defer teardown(tx)
+
+		createdUser := firstCreateTestUser(t, tx, nil)
+		repo := repository.NewPostRepositoryWithDB(tx)
+		created, err := repo.Create(createTestPost(createdUser.ID))
+		assert.NoError(t, err)
+		_, err = repo.Update(created.ID, &model.Post{Content: "Second Content"})
+		assert.NoError(t, err)
+		_, err = repo.Update(created.ID, &model.Post{Content: "Third Content"})
+		assert.NoError(t, err)
+
+		// run
+		revisions, err := repo.ListRevisions(created.ID)
+
+		// assert
+		assert.NoError(t, err)
+		assert.Len(t, revisions, 2)
+		assert.Equal(t, "Second Content", revisions[0].Content)
+		assert.Equal(t, created.Content, revisions[1].Content)
+	})
+
+	t.Run("NoRevisions", func(t *testing.T) {
+		tx := setup()
+		defer teardown(tx)
+
+		repo := repository.NewPostRepositoryWithDB(tx)
+
+		// run
+		revisions, err := repo.ListRevisions(NonExistentPostID)
+
+		// assert
+		assert.NoError(t, err)
+		assert.Empty(t, revisions)
+	})
+}
+
+func TestCheckPermission(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		tx := setup()
+		defer teardown(tx)
+
+		// Create user and post
+		user := firstCreateTestUser(t, tx, nil)
+		repo := repository.NewPostRepositoryWithDB(tx)
+		post := createTestPost(user.ID)
+		createdPost, err := repo.Create(post)
+		assert.NoError(t, err)
+
+		// run
+		err = repo.CheckPermission(createdPost.ID, user.ID)
+
+		// assert
+		assert.NoError(t, err)
+	})
+
+	t.Run("User does not own post", func(t *testing.T) {
+		tx := setup()
+		defer teardown(tx)
+
+		// Create two users
+		user1 := firstCreateTestUser(t, tx, nil)
+		user2 := firstCreateTestUser(t, tx, map[string]interface{}{
+			"username": "user2",
+			"email":    "user2@test.com",
+		})
+
+		// Create post by user1
+		repo := repository.NewPostRepositoryWithDB(tx)
+		post := createTestPost(user1.ID)
+		createdPost, err := repo.Create(post)
+		assert.NoError(t, err)
+
+		// run
+		err = repo.CheckPermission(createdPost.ID, user2.ID)
+
+		// assert
+		assert.ErrorIs(t, err, apperrors.ErrForbidden)
+	})
+
+	t.Run("MissingPostIsNotFound", func(t *testing.T) {
+		tx := setup()
+		defer teardown(tx)
+
+		user := firstCreateTestUser(t, tx, nil)
+		repo := repository.NewPostRepositoryWithDB(tx)
+
+		// run
+		err := repo.CheckPermission(NonExistentPostID, user.ID)
+
+		// assert: same as GetByID, not a 403
+		assert.ErrorIs(t, err, apperrors.ErrNotFound)
+	})
+
+	t.Run("DeletedPostIsNotFound", func(t *testing.T) {
+		tx := setup()
+		defer teardown(tx)
+
+		user := firstCreateTestUser(t, tx, nil)
+		repo := repository.NewPostRepositoryWithDB(tx)
+		createdPost, err := repo.Create(createTestPost(user.ID))
+		assert.NoError(t, err)
+		assert.NoError(t, repo.Delete(createdPost.ID))
+
+		// run: a second delete by the author finds nothing to delete
+		err = repo.CheckPermission(createdPost.ID, user.ID)
+
+		// assert
+		assert.ErrorIs(t, err, apperrors.ErrNotFound)
+	})
+
+	t.Run("HiddenPostOfAnotherUserIsNotFound", func(t *testing.T) {
+		tx := setup()
+		defer teardown(tx)
+
+		user1 := firstCreateTestUser(t, tx, nil)
+		user2 := firstCreateTestUser(t, tx, map[string]interface{}{
+			"username": "user2",
+			"email":    "user2@test.com",
+		})
+		repo := repository.NewPostRepositoryWithDB(tx)
+		createdPost, err := repo.Create(createTestPost(user1.ID))
+		assert.NoError(t, err)
+		_, err = repo.SetHidden(createdPost.ID, true)
+		assert.NoError(t, err)
+
+		// run
+		otherErr := repo.CheckPermission(createdPost.ID, user2.ID)
+		ownerErr := repo.CheckPermission(createdPost.ID, user1.ID)
+
+		// assert: hidden posts don't exist for others, the author still owns theirs
+		assert.ErrorIs(t, otherErr, apperrors.ErrNotFound)
+		assert.NoError(t, ownerErr)
+	})
+
+	t.Run("ArchivedPostNotFoundForOthers", func(t *testing.T) {
+		tx := setup()
+		defer teardown(tx)
+
+		user1 := firstCreateTestUser(t, tx, nil)
+		user2 := firstCreateTestUser(t, tx, map[string]interface{}{
+			"username": "user2",
+			"email":    "user2@test.com",
+		})
+		repo := repository.NewPostRepositoryWithDB(tx)
+		createdPost, err := repo.Create(createTestPost(user1.ID))
+		assert.NoError(t, err)
+		assert.NoError(t, tx.Model(&model.Post{}).Where("id = ?", createdPost.ID).UpdateColumn("archived", true).Error)
+
+		// run
+		otherErr := repo.CheckPermission(createdPost.ID, user2.ID)
+		ownerErr := repo.CheckPermission(createdPost.ID, user1.ID)
+
+		// assert: matches GetByID, which gives others 404 for an archived post
+		assert.ErrorIs(t, otherErr, apperrors.ErrNotFound)
+		assert.NoError(t, ownerErr)
 	})
 }
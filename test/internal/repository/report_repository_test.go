@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"go-gin-api-server/internal/model"
+	"go-gin-api-server/internal/repository"
+	"go-gin-api-server/pkg/apperrors"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestCases
+
+func TestReportRepository(t *testing.T) {
+	t.Run("DuplicateReportConflict", func(t *testing.T) {
+		tx := setup()
+		defer teardown(tx)
+
+		reporter := firstCreateTestUser(t, tx, nil)
+		post, err := repository.NewPostRepositoryWithDB(tx).Create(createTestPost(reporter.ID))
+		assert.NoError(t, err)
+
+		repo := repository.NewReportRepositoryWithDB(tx)
+		_, err = repo.Create(&model.Report{ReporterID: reporter.ID, PostID: post.ID, Reason: "Spam"})
+		assert.NoError(t, err)
+
+		// run: a failed statement aborts the transaction, so use a savepoint
+		tx.SavePoint("duplicate")
+		_, err = repo.Create(&model.Report{ReporterID: reporter.ID, PostID: post.ID, Reason: "Spam again"})
+		tx.RollbackTo("duplicate")
+
+		// assert
+		assert.ErrorIs(t, err, apperrors.ErrConflict)
+
+		reports, err := repo.List(model.ReportListOptions{Limit: 10})
+		assert.NoError(t, err)
+		if assert.Len(t, reports, 1) {
+			assert.Equal(t, model.ReportPending, reports[0].Status)
+		}
+	})
+
+	t.Run("ListCursorAndStatus", func(t *testing.T) {
+		tx := setup()
+		defer teardown(tx)
+
+		reporter := firstCreateTestUser(t, tx, nil)
+		postRepo := repository.NewPostRepositoryWithDB(tx)
+		repo := repository.NewReportRepositoryWithDB(tx)
+
+		var created []*model.Report
+		for i := 0; i < 3; i++ {
+			post, err := postRepo.Create(createTestPost(reporter.ID))
+			assert.NoError(t, err)
+			report, err := repo.Create(&model.Report{ReporterID: reporter.ID, PostID: post.ID, Reason: "Spam", Status: model.ReportPending})
+			assert.NoError(t, err)
+			created = append(created, report)
+		}
+		assert.NoError(t, tx.Model(&model.Report{}).Where("id = ?", created[1].ID).Update("status", model.ReportResolved).Error)
+
+		// run: oldest first, the second page continues after the first
+		first, err := repo.List(model.ReportListOptions{Limit: 2})
+		assert.NoError(t, err)
+		assert.Len(t, first, 2)
+		last := first[len(first)-1]
+		second, err := repo.List(model.ReportListOptions{
+			Limit:  2,
+			Cursor: model.Cursor{ID: strconv.FormatUint(last.ID, 10), CreatedAt: last.CreatedAt},
+		})
+		assert.NoError(t, err)
+
+		// assert
+		if assert.Len(t, second, 1) {
+			assert.Equal(t, created[2].ID, second[0].ID)
+		}
+
+		status := model.ReportPending
+		pending, err := repo.List(model.ReportListOptions{Limit: 10, Status: &status})
+		assert.NoError(t, err)
+		assert.Len(t, pending, 2)
+		for _, report := range pending {
+			assert.NotEqual(t, created[1].ID, report.ID)
+		}
+	})
+
+	t.Run("ListInvalidCursor", func(t *testing.T) {
+		tx := setup()
+		defer teardown(tx)
+
+		_, err := repository.NewReportRepositoryWithDB(tx).List(model.ReportListOptions{Limit: 10, Cursor: model.Cursor{ID: InvalidCursorID}})
+
+		assert.ErrorIs(t, err, apperrors.ErrValidation)
+	})
+}
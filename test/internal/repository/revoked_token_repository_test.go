@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"go-gin-api-server/internal/model"
+	"go-gin-api-server/internal/repository"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestCases
+
+func TestRevokedTokenRepository(t *testing.T) {
+	t.Run("RevokedUntilExpiry", func(t *testing.T) {
+		tx := setup()
+		defer teardown(tx)
+
+		repo := repository.NewRevokedTokenRepositoryWithDB(tx)
+
+		// run
+		err := repo.Revoke("jti-live", time.Now().Add(time.Minute))
+
+		// assert
+		assert.NoError(t, err)
+		revoked, err := repo.IsRevoked("jti-live")
+		assert.NoError(t, err)
+		assert.True(t, revoked)
+
+		revoked, err = repo.IsRevoked("jti-other")
+		assert.NoError(t, err)
+		assert.False(t, revoked)
+	})
+
+	t.Run("ExpiredNotRevokedAndPurged", func(t *testing.T) {
+		tx := setup()
+		defer teardown(tx)
+
+		repo := repository.NewRevokedTokenRepositoryWithDB(tx)
+		assert.NoError(t, repo.Revoke("jti-expired", time.Now().Add(-time.Second)))
+
+		revoked, err := repo.IsRevoked("jti-expired")
+		assert.NoError(t, err)
+		assert.False(t, revoked)
+
+		// run
+		assert.NoError(t, repo.Revoke("jti-live", time.Now().Add(time.Minute)))
+
+		// assert
+		var count int64
+		assert.NoError(t, tx.Model(&model.RevokedToken{}).Where("jti = ?", "jti-expired").Count(&count).Error)
+		assert.Equal(t, int64(0), count)
+	})
+
+	t.Run("RevokeAgainKeepsLaterExpiry", func(t *testing.T) {
+		tx := setup()
+		defer teardown(tx)
+
+		repo := repository.NewRevokedTokenRepositoryWithDB(tx)
+		later := time.Now().Add(time.Hour).UTC().Truncate(time.Microsecond)
+		assert.NoError(t, repo.Revoke("jti-1", later))
+
+		// run
+		assert.NoError(t, repo.Revoke("jti-1", time.Now().Add(time.Minute)))
+
+		// assert
+		var token model.RevokedToken
+		assert.NoError(t, tx.Where("jti = ?", "jti-1").First(&token).Error)
+		assert.True(t, later.Equal(token.ExpiresAt))
+	})
+}
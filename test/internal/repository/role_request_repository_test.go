@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"go-gin-api-server/internal/model"
+	"go-gin-api-server/internal/repository"
+	"go-gin-api-server/pkg/apperrors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestCases
+
+func TestRoleRequestRepository(t *testing.T) {
+	t.Run("ApproveGrantsRole", func(t *testing.T) {
+		tx := setup()
+		defer teardown(tx)
+
+		user := firstCreateTestUser(t, tx, nil)
+		admin := firstCreateTestUser(t, tx, map[string]interface{}{"username": "admin_reviewer", "email": "admin@test.com"})
+
+		repo := repository.NewRoleRequestRepositoryWithDB(tx)
+		request, err := repo.Create(&model.RoleRequest{UserID: user.ID, Role: model.RoleModerator, Status: model.RoleRequestPending})
+		assert.NoError(t, err)
+
+		// run
+		approved, err := repo.Approve(request.ID, admin.ID)
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, model.RoleRequestApproved, approved.Status)
+		assert.Equal(t, admin.ID, *approved.ReviewedBy)
+
+		updated, err := repository.NewUserRepositoryWithDB(tx).FindByID(user.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, model.RoleModerator, updated.Role)
+
+		var logs []model.AuditLog
+		assert.NoError(t, tx.Where("target_id = ?", user.ID).Find(&logs).Error)
+		if assert.Len(t, logs, 1) {
+			assert.Equal(t, model.AuditActionRoleGrant, logs[0].Action)
+			assert.Equal(t, admin.ID, logs[0].ActorID)
+		}
+
+		// a reviewed request can't be approved twice
+		_, err = repo.Approve(request.ID, admin.ID)
+		assert.ErrorIs(t, err, apperrors.ErrConflict)
+	})
+
+	t.Run("DuplicatePendingConflict", func(t *testing.T) {
+		tx := setup()
+		defer teardown(tx)
+
+		user := firstCreateTestUser(t, tx, nil)
+		repo := repository.NewRoleRequestRepositoryWithDB(tx)
+		_, err := repo.Create(&model.RoleRequest{UserID: user.ID, Role: model.RoleModerator, Status: model.RoleRequestPending})
+		assert.NoError(t, err)
+
+		// run: a failed statement aborts the transaction, so use a savepoint
+		tx.SavePoint("duplicate")
+		_, err = repo.Create(&model.RoleRequest{UserID: user.ID, Role: model.RoleAdmin, Status: model.RoleRequestPending})
+		tx.RollbackTo("duplicate")
+
+		// assert
+		assert.ErrorIs(t, err, apperrors.ErrConflict)
+	})
+
+	t.Run("ApproveNotFound", func(t *testing.T) {
+		tx := setup()
+		defer teardown(tx)
+
+		admin := firstCreateTestUser(t, tx, nil)
+
+		// run
+		_, err := repository.NewRoleRequestRepositoryWithDB(tx).Approve(NonExistentPostID, admin.ID)
+
+		// assert
+		assert.ErrorIs(t, err, apperrors.ErrNotFound)
+	})
+}
@@ -108,6 +108,50 @@ func TestCreateUserAndFindByID(t *testing.T) {
 	})
 }
 
+func TestFindProfilesByIDs(t *testing.T) {
+	t.Run("PublicFieldsOfKnownUsers", func(t *testing.T) {
+		tx := setup()
+		defer teardown(tx)
+
+		repo := repository.NewUserRepositoryWithDB(tx)
+		first, err := repo.Create(createTestUser(map[string]interface{}{"username": "first", "email": "first@test.com"}))
+		assert.NoError(t, err)
+		second, err := repo.Create(createTestUser(map[string]interface{}{"username": "second", "email": "second@test.com"}))
+		assert.NoError(t, err)
+
+		// run
+		profiles, err := repo.FindProfilesByIDs([]string{first.ID, NonExistentUserID, second.ID})
+
+		// assert
+		assert.NoError(t, err)
+		assert.Len(t, profiles, 2)
+		byID := map[string]model.UserProfile{}
+		for _, profile := range profiles {
+			byID[profile.ID] = profile
+		}
+		if assert.Contains(t, byID, first.ID) {
+			assert.Equal(t, first.Name, byID[first.ID].Name)
+			assert.Equal(t, first.Username, byID[first.ID].Username)
+			assert.NotNil(t, byID[first.ID].BirthDate)
+		}
+		assert.Contains(t, byID, second.ID)
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		tx := setup()
+		defer teardown(tx)
+
+		repo := repository.NewUserRepositoryWithDB(tx)
+
+		// run
+		profiles, err := repo.FindProfilesByIDs(nil)
+
+		// assert
+		assert.NoError(t, err)
+		assert.Empty(t, profiles)
+	})
+}
+
 func TestCreateUserAndFindByUsername(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
 		tx := setup()
@@ -239,6 +283,28 @@ func TestUpdateUser(t *testing.T) {
 		assert.ErrorIs(t, err, apperrors.ErrNotFound)
 		assert.Nil(t, found)
 	})
+
+	t.Run("ErrUserExists_DuplicateUsername", func(t *testing.T) {
+		tx := setup()
+		defer teardown(tx)
+
+		repo := repository.NewUserRepositoryWithDB(tx)
+		_, err := repo.Create(createTestUser())
+		assert.NoError(t, err)
+		other, err := repo.Create(createTestUser(map[string]interface{}{
+			"username": "other",
+			"email":    "other@test.com",
+		}))
+		assert.NoError(t, err)
+
+		// run: take the first user's username
+		takenUsername := "test"
+		found, err := repo.Update(other.ID, &model.User{Username: &takenUsername})
+
+		// assert
+		assert.ErrorIs(t, err, apperrors.ErrUserExists)
+		assert.Nil(t, found)
+	})
 }
 
 func TestCreateUser(t *testing.T) {
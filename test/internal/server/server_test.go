@@ -0,0 +1,130 @@
+package server
+
+import (
+	"go-gin-api-server/config"
+	"go-gin-api-server/internal/server"
+	"go-gin-api-server/pkg/logger"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewHTTPServer(t *testing.T) {
+	t.Run("ConfiguredFromConfig", func(t *testing.T) {
+		cfg := config.LoadTestConfig()
+		cfg.Port = "9090"
+		cfg.Server = config.ServerConfig{
+			ReadHeaderTimeout: 3 * time.Second,
+			ReadTimeout:       7 * time.Second,
+			WriteTimeout:      11 * time.Second,
+			IdleTimeout:       13 * time.Second,
+			MaxHeaderBytes:    4096,
+		}
+		handler := http.NewServeMux()
+
+		srv := server.NewHTTPServer(cfg, handler)
+
+		assert.Equal(t, ":9090", srv.Addr)
+		assert.Equal(t, handler, srv.Handler)
+		assert.Equal(t, 3*time.Second, srv.ReadHeaderTimeout)
+		assert.Equal(t, 7*time.Second, srv.ReadTimeout)
+		assert.Equal(t, 11*time.Second, srv.WriteTimeout)
+		assert.Equal(t, 13*time.Second, srv.IdleTimeout)
+		assert.Equal(t, 4096, srv.MaxHeaderBytes)
+	})
+
+	t.Run("DefaultsFromTestConfig", func(t *testing.T) {
+		cfg := config.LoadTestConfig()
+
+		srv := server.NewHTTPServer(cfg, http.NewServeMux())
+
+		assert.Equal(t, cfg.Server.ReadHeaderTimeout, srv.ReadHeaderTimeout)
+		assert.Equal(t, 1<<20, srv.MaxHeaderBytes)
+	})
+}
+
+func setupTestServer() http.Handler {
+	logger.Init(config.Test)
+	return server.NewServer(config.LoadTestConfig())
+}
+
+func TestNewServerRouting(t *testing.T) {
+	router := setupTestServer()
+
+	t.Run("TrailingSlashRedirects", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/posts/", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusMovedPermanently, w.Code)
+		assert.Equal(t, "/api/v1/posts", w.Header().Get("Location"))
+	})
+
+	t.Run("TrailingSlashWriteKeepsMethod", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/login/", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusTemporaryRedirect, w.Code)
+		assert.Equal(t, "/api/v1/auth/login", w.Header().Get("Location"))
+	})
+
+	t.Run("MethodNotAllowed", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodDelete, "/api/v1/auth/login", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+		assert.Equal(t, http.MethodPost, w.Header().Get("Allow"))
+		assert.JSONEq(t, `{"error":"Method not allowed"}`, w.Body.String())
+	})
+
+	t.Run("UnknownPathStillNotFound", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/nothing-here", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestNewServerClientIP(t *testing.T) {
+	logger.Init(config.Test)
+	performAdminHealth := func(cfg *config.Config, remoteAddr, forwardedFor string) *httptest.ResponseRecorder {
+		router := server.NewServer(cfg)
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/health", nil)
+		req.RemoteAddr = remoteAddr
+		req.Header.Set("X-Forwarded-For", forwardedFor)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("SpoofedForwardedForIgnoredByDefault", func(t *testing.T) {
+		cfg := config.LoadTestConfig()
+		cfg.Security.TrustedProxies = nil
+		cfg.Security.AdminAllowCIDRs = []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")}
+
+		// run: a direct client claims an allowed address
+		w := performAdminHealth(cfg, "203.0.113.7:1234", "10.1.2.3")
+
+		// assert
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("ForwardedForFromConfiguredProxy", func(t *testing.T) {
+		cfg := config.LoadTestConfig()
+		cfg.Security.TrustedProxies = []string{"192.168.0.1"}
+		cfg.Security.AdminAllowCIDRs = []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")}
+
+		// run
+		w := performAdminHealth(cfg, "192.168.0.1:1234", "10.1.2.3")
+
+		// assert: past the IP filter, stopped by authentication instead
+		assert.NotEqual(t, http.StatusForbidden, w.Code)
+	})
+}
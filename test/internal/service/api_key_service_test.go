@@ -0,0 +1,268 @@
+package service
+
+import (
+	"errors"
+	"go-gin-api-server/config"
+	"go-gin-api-server/internal/model"
+	"go-gin-api-server/internal/service"
+	"go-gin-api-server/pkg/apperrors"
+	"go-gin-api-server/pkg/utils"
+	mockRepository "go-gin-api-server/test/mocks/repository"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Helper functions
+
+func setupTestAPIKeyService() (*mockRepository.APIKeyRepositoryMock, service.APIKeyService) {
+	mockRepo := mockRepository.NewAPIKeyRepositoryMock()
+	return mockRepo, service.NewAPIKeyService(mockRepo)
+}
+
+func createTestAPIKey(overrides ...func(*model.APIKey)) *model.APIKey {
+	key := &model.APIKey{
+		ID:     1,
+		UserID: testUserID,
+		Name:   "billing sync",
+		Scopes: model.APIKeyScopes{model.APIKeyScopeRead},
+		User:   &model.User{ID: testUserID, Role: model.RoleUser, IsActive: true},
+	}
+	for _, override := range overrides {
+		override(key)
+	}
+	return key
+}
+
+// Testcases
+
+func TestCreateAPIKey(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		repo, apiKeyService := setupTestAPIKeyService()
+		var stored *model.APIKey
+		repo.On("Create", mock.Anything).Run(func(args mock.Arguments) {
+			stored = args.Get(0).(*model.APIKey)
+		}).Return(&model.APIKey{ID: 7, UserID: testUserID, Name: "billing sync"}, nil)
+
+		// run
+		response, err := apiKeyService.Create(testUserID, model.CreateAPIKeyRequest{Name: "billing sync"})
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(7), response.ID)
+		assert.True(t, strings.HasPrefix(response.Key, utils.APIKeyPrefix))
+		assert.Equal(t, testUserID, stored.UserID)
+		assert.Equal(t, "billing sync", stored.Name)
+		assert.Equal(t, utils.HashAPIKey(response.Key), stored.KeyHash)
+		assert.True(t, strings.HasPrefix(response.Key, stored.Prefix))
+		assert.Equal(t, model.APIKeyScopes{model.APIKeyScopeRead}, stored.Scopes)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("DefaultTTL", func(t *testing.T) {
+		repo := mockRepository.NewAPIKeyRepositoryMock()
+		apiKeyService := service.NewAPIKeyServiceWithConfig(repo, config.AuthConfig{APIKeyDefaultTTL: 24 * time.Hour})
+		var stored *model.APIKey
+		repo.On("Create", mock.Anything).Run(func(args mock.Arguments) {
+			stored = args.Get(0).(*model.APIKey)
+		}).Return(&model.APIKey{ID: 7}, nil)
+		explicit := time.Now().Add(time.Hour)
+
+		// run
+		_, err := apiKeyService.Create(testUserID, model.CreateAPIKeyRequest{Name: "defaulted"})
+		assert.NoError(t, err)
+		defaulted := stored
+		_, err = apiKeyService.Create(testUserID, model.CreateAPIKeyRequest{Name: "explicit", ExpiresAt: &explicit})
+		assert.NoError(t, err)
+
+		// assert
+		if assert.NotNil(t, defaulted.ExpiresAt) {
+			assert.WithinDuration(t, time.Now().Add(24*time.Hour), *defaulted.ExpiresAt, time.Minute)
+		}
+		assert.Equal(t, explicit, *stored.ExpiresAt)
+	})
+
+	t.Run("NoDefaultTTL", func(t *testing.T) {
+		repo, apiKeyService := setupTestAPIKeyService()
+		var stored *model.APIKey
+		repo.On("Create", mock.Anything).Run(func(args mock.Arguments) {
+			stored = args.Get(0).(*model.APIKey)
+		}).Return(&model.APIKey{ID: 7}, nil)
+
+		// run
+		_, err := apiKeyService.Create(testUserID, model.CreateAPIKeyRequest{Name: "forever"})
+
+		// assert
+		assert.NoError(t, err)
+		assert.Nil(t, stored.ExpiresAt)
+	})
+
+	t.Run("ExpiryInThePast", func(t *testing.T) {
+		repo, apiKeyService := setupTestAPIKeyService()
+		past := time.Now().Add(-time.Hour)
+
+		// run
+		response, err := apiKeyService.Create(testUserID, model.CreateAPIKeyRequest{Name: "old", ExpiresAt: &past})
+
+		// assert
+		assert.ErrorIs(t, err, apperrors.ErrValidation)
+		assert.Nil(t, response)
+		repo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+}
+
+func TestAuthenticateAPIKey(t *testing.T) {
+	const key = "gak_presented"
+
+	t.Run("ValidKey", func(t *testing.T) {
+		repo, apiKeyService := setupTestAPIKeyService()
+		repo.On("FindByHash", utils.HashAPIKey(key)).Return(createTestAPIKey(), nil)
+		repo.On("TouchLastUsed", uint64(1), mock.Anything).Return(nil)
+
+		// run
+		apiKey, err := apiKeyService.Authenticate(key)
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, testUserID, apiKey.UserID)
+		assert.NotNil(t, apiKey.LastUsedAt)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("RecentlyUsedSkipsTouch", func(t *testing.T) {
+		repo, apiKeyService := setupTestAPIKeyService()
+		recently := time.Now().Add(-time.Second)
+		repo.On("FindByHash", utils.HashAPIKey(key)).Return(createTestAPIKey(func(k *model.APIKey) {
+			k.LastUsedAt = &recently
+		}), nil)
+
+		// run
+		_, err := apiKeyService.Authenticate(key)
+
+		// assert
+		assert.NoError(t, err)
+		repo.AssertNotCalled(t, "TouchLastUsed", mock.Anything, mock.Anything)
+	})
+
+	t.Run("TouchFailureIgnored", func(t *testing.T) {
+		repo, apiKeyService := setupTestAPIKeyService()
+		repo.On("FindByHash", utils.HashAPIKey(key)).Return(createTestAPIKey(), nil)
+		repo.On("TouchLastUsed", uint64(1), mock.Anything).Return(errors.New("db down"))
+
+		// run
+		apiKey, err := apiKeyService.Authenticate(key)
+
+		// assert
+		assert.NoError(t, err)
+		assert.NotNil(t, apiKey)
+	})
+
+	t.Run("Rejected", func(t *testing.T) {
+		past := time.Now().Add(-time.Minute)
+		tests := map[string]*model.APIKey{
+			"Revoked": createTestAPIKey(func(k *model.APIKey) { k.RevokedAt = &past }),
+			"RevokedAndExpired": createTestAPIKey(func(k *model.APIKey) {
+				k.RevokedAt = &past
+				k.ExpiresAt = &past
+			}),
+			"InactiveUser": createTestAPIKey(func(k *model.APIKey) { k.User.IsActive = false }),
+			"MissingUser":  createTestAPIKey(func(k *model.APIKey) { k.User = nil }),
+		}
+		for name, stored := range tests {
+			t.Run(name, func(t *testing.T) {
+				repo, apiKeyService := setupTestAPIKeyService()
+				repo.On("FindByHash", utils.HashAPIKey(key)).Return(stored, nil)
+
+				// run
+				apiKey, err := apiKeyService.Authenticate(key)
+
+				// assert
+				assert.ErrorIs(t, err, apperrors.ErrUnauthorized)
+				assert.Nil(t, apiKey)
+				repo.AssertNotCalled(t, "TouchLastUsed", mock.Anything, mock.Anything)
+			})
+		}
+	})
+
+	t.Run("ExpiredKey", func(t *testing.T) {
+		repo, apiKeyService := setupTestAPIKeyService()
+		past := time.Now().Add(-time.Minute)
+		repo.On("FindByHash", utils.HashAPIKey(key)).Return(createTestAPIKey(func(k *model.APIKey) {
+			k.ExpiresAt = &past
+		}), nil)
+
+		// run
+		apiKey, err := apiKeyService.Authenticate(key)
+
+		// assert
+		assert.ErrorIs(t, err, apperrors.ErrExpiredToken)
+		assert.Nil(t, apiKey)
+		repo.AssertNotCalled(t, "TouchLastUsed", mock.Anything, mock.Anything)
+	})
+
+	t.Run("StaleLastUsedIsUpdated", func(t *testing.T) {
+		repo := mockRepository.NewAPIKeyRepositoryMock()
+		apiKeyService := service.NewAPIKeyServiceWithConfig(repo, config.AuthConfig{APIKeyLastUsedInterval: 10 * time.Minute})
+		stale := time.Now().Add(-15 * time.Minute)
+		repo.On("FindByHash", utils.HashAPIKey(key)).Return(createTestAPIKey(func(k *model.APIKey) {
+			k.LastUsedAt = &stale
+		}), nil)
+		repo.On("TouchLastUsed", uint64(1), mock.MatchedBy(func(usedAt time.Time) bool {
+			return usedAt.After(stale)
+		})).Return(nil)
+
+		// run
+		apiKey, err := apiKeyService.Authenticate(key)
+
+		// assert
+		assert.NoError(t, err)
+		assert.True(t, apiKey.LastUsedAt.After(stale))
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("WithinIntervalNotUpdated", func(t *testing.T) {
+		repo := mockRepository.NewAPIKeyRepositoryMock()
+		apiKeyService := service.NewAPIKeyServiceWithConfig(repo, config.AuthConfig{APIKeyLastUsedInterval: 10 * time.Minute})
+		recent := time.Now().Add(-5 * time.Minute)
+		repo.On("FindByHash", utils.HashAPIKey(key)).Return(createTestAPIKey(func(k *model.APIKey) {
+			k.LastUsedAt = &recent
+		}), nil)
+
+		// run
+		apiKey, err := apiKeyService.Authenticate(key)
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, recent, *apiKey.LastUsedAt)
+		repo.AssertNotCalled(t, "TouchLastUsed", mock.Anything, mock.Anything)
+	})
+
+	t.Run("UnknownKey", func(t *testing.T) {
+		repo, apiKeyService := setupTestAPIKeyService()
+		repo.On("FindByHash", utils.HashAPIKey(key)).Return(nil, apperrors.ErrNotFound)
+
+		// run
+		apiKey, err := apiKeyService.Authenticate(key)
+
+		// assert
+		assert.ErrorIs(t, err, apperrors.ErrUnauthorized)
+		assert.Nil(t, apiKey)
+	})
+}
+
+func TestRevokeAPIKey(t *testing.T) {
+	t.Run("NotOwned", func(t *testing.T) {
+		repo, apiKeyService := setupTestAPIKeyService()
+		repo.On("Revoke", uint64(3), testUserID).Return(apperrors.ErrNotFound)
+
+		// run
+		err := apiKeyService.Revoke(3, testUserID)
+
+		// assert
+		assert.ErrorIs(t, err, apperrors.ErrNotFound)
+		repo.AssertExpectations(t)
+	})
+}
@@ -1,6 +1,9 @@
 package service
 
 import (
+	"errors"
+	"go-gin-api-server/config"
+	"go-gin-api-server/internal/events"
 	"go-gin-api-server/internal/model"
 	"go-gin-api-server/internal/service"
 	"go-gin-api-server/pkg/apperrors"
@@ -12,6 +15,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
 )
 
 // Test constants
@@ -79,6 +83,102 @@ func TestAuthService_Register(t *testing.T) {
 		mockAuthRepo.AssertExpectations(t)
 	})
 
+	t.Run("AssignsDefaultRole", func(t *testing.T) {
+		tests := []struct {
+			configured string
+			expected   model.UserRole
+		}{
+			{configured: "user", expected: model.RoleUser},
+			{configured: "moderator", expected: model.RoleModerator},
+			{configured: "admin", expected: model.RoleUser}, // admin is never granted by config
+			{configured: "", expected: model.RoleUser},
+		}
+
+		for _, tt := range tests {
+			mockUserRepo := mockRepository.NewUserRepositoryMock()
+			mockAuthRepo := mockRepository.NewAuthRepositoryMock()
+			jwtMgr := utils.NewJWTManager("test-secret", 15*time.Minute)
+			authService := service.NewAuthServiceWithConfig(mockUserRepo, mockAuthRepo, jwtMgr, config.AuthConfig{DefaultRole: tt.configured})
+
+			mockUserRepo.On("Create", mock.MatchedBy(func(user *model.User) bool {
+				return user.Role == tt.expected
+			})).Return(&model.User{ID: testUserID, Role: tt.expected}, nil)
+			mockAuthRepo.On("CreateCredentials", mock.AnythingOfType("*model.UserCredentials")).Return(&model.UserCredentials{}, nil)
+
+			// run
+			_, err := authService.Register(createTestRegisterRequest())
+
+			// assert
+			assert.NoError(t, err, tt.configured)
+			mockUserRepo.AssertExpectations(t)
+		}
+	})
+
+	t.Run("DisposableEmailDomains", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			cfg      config.AuthConfig
+			email    string
+			rejected bool
+		}{
+			{
+				name:     "DisposableDomainRejected",
+				cfg:      config.AuthConfig{BlockDisposableEmails: true, DisposableEmailDomains: []string{"mailinator.com"}},
+				email:    "someone@MailInator.com",
+				rejected: true,
+			},
+			{
+				name:     "SubdomainRejected",
+				cfg:      config.AuthConfig{BlockDisposableEmails: true, DisposableEmailDomains: []string{"mailinator.com"}},
+				email:    "someone@eu.mailinator.com",
+				rejected: true,
+			},
+			{
+				name:  "NormalDomainAccepted",
+				cfg:   config.AuthConfig{BlockDisposableEmails: true, DisposableEmailDomains: []string{"mailinator.com"}},
+				email: "someone@example.com",
+			},
+			{
+				name:  "EmptyListIsNoOp",
+				cfg:   config.AuthConfig{BlockDisposableEmails: true},
+				email: "someone@mailinator.com",
+			},
+			{
+				name:  "DisabledIsNoOp",
+				cfg:   config.AuthConfig{DisposableEmailDomains: []string{"mailinator.com"}},
+				email: "someone@mailinator.com",
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				mockUserRepo := mockRepository.NewUserRepositoryMock()
+				mockAuthRepo := mockRepository.NewAuthRepositoryMock()
+				jwtMgr := utils.NewJWTManager("test-secret", 15*time.Minute)
+				authService := service.NewAuthServiceWithConfig(mockUserRepo, mockAuthRepo, jwtMgr, tt.cfg)
+				req := createTestRegisterRequest()
+				req.Email = tt.email
+
+				if !tt.rejected {
+					mockUserRepo.On("Create", mock.AnythingOfType("*model.User")).Return(&model.User{ID: testUserID}, nil)
+					mockAuthRepo.On("CreateCredentials", mock.AnythingOfType("*model.UserCredentials")).Return(&model.UserCredentials{}, nil)
+				}
+
+				// run
+				_, err := authService.Register(req)
+
+				// assert
+				if tt.rejected {
+					assert.ErrorIs(t, err, apperrors.ErrValidation)
+					mockUserRepo.AssertNotCalled(t, "Create", mock.Anything)
+				} else {
+					assert.NoError(t, err)
+					mockUserRepo.AssertExpectations(t)
+				}
+			})
+		}
+	})
+
 	t.Run("UserUnderAge", func(t *testing.T) {
 		mockUserRepo, mockAuthRepo, _, authService := setupTestAuthService()
 		birthDate := time.Date(2015, 1, 1, 0, 0, 0, 0, time.UTC) // 9 years old
@@ -120,6 +220,89 @@ func TestAuthService_Register(t *testing.T) {
 		mockUserRepo.AssertNotCalled(t, "Create")
 		mockAuthRepo.AssertNotCalled(t, "CreateCredentials")
 	})
+
+	t.Run("WeakPasswordRejected", func(t *testing.T) {
+		mockUserRepo := mockRepository.NewUserRepositoryMock()
+		mockAuthRepo := mockRepository.NewAuthRepositoryMock()
+		jwtMgr := utils.NewJWTManager("test-secret", 15*time.Minute)
+		authService := service.NewAuthServiceWithConfig(mockUserRepo, mockAuthRepo, jwtMgr, config.AuthConfig{
+			PasswordMinLength:    8,
+			PasswordRequireDigit: true,
+			PasswordRequireUpper: true,
+		})
+		req := &model.RegisterRequest{
+			Name:     "Test User",
+			Username: "testuser",
+			Email:    "test@example.com",
+			Password: "password",
+		}
+
+		// run
+		result, err := authService.Register(req)
+
+		// assert
+		assert.ErrorIs(t, err, apperrors.ErrValidation)
+		assert.ErrorIs(t, err, apperrors.ErrPasswordMissingDigit)
+		assert.ErrorIs(t, err, apperrors.ErrPasswordMissingUpper)
+		assert.NotErrorIs(t, err, apperrors.ErrPasswordTooShort)
+		assert.Nil(t, result)
+
+		mockUserRepo.AssertNotCalled(t, "Create")
+		mockAuthRepo.AssertNotCalled(t, "CreateCredentials")
+	})
+
+	t.Run("PublishesUserRegistered", func(t *testing.T) {
+		mockUserRepo := mockRepository.NewUserRepositoryMock()
+		mockAuthRepo := mockRepository.NewAuthRepositoryMock()
+		jwtMgr := utils.NewJWTManager("test-secret", 15*time.Minute)
+		bus := events.NewBus(zap.NewNop())
+		var received []events.Event
+		bus.Subscribe(func(event events.Event) {
+			received = append(received, event)
+		}, events.TypeUserRegistered)
+		authService := service.NewAuthServiceWithDeps(mockUserRepo, mockAuthRepo, jwtMgr, config.LoadTestConfig().Auth, service.AuthServiceDeps{Bus: bus})
+		req := createTestRegisterRequest()
+
+		mockUserRepo.On("Create", mock.AnythingOfType("*model.User")).Return(&model.User{ID: testUserID, Name: req.Name}, nil)
+		mockAuthRepo.On("CreateCredentials", mock.AnythingOfType("*model.UserCredentials")).Return(&model.UserCredentials{}, nil)
+
+		// run
+		_, err := authService.Register(req)
+		bus.Close()
+
+		// assert
+		assert.NoError(t, err)
+		if assert.Len(t, received, 1) {
+			event := received[0].(events.UserRegistered)
+			assert.Equal(t, testUserID, event.UserID)
+			assert.Equal(t, req.Name, event.Name)
+			assert.Equal(t, req.Username, event.Username)
+		}
+	})
+
+	t.Run("NoEventWhenCredentialsFail", func(t *testing.T) {
+		mockUserRepo := mockRepository.NewUserRepositoryMock()
+		mockAuthRepo := mockRepository.NewAuthRepositoryMock()
+		jwtMgr := utils.NewJWTManager("test-secret", 15*time.Minute)
+		bus := events.NewBus(zap.NewNop())
+		var received []events.Event
+		bus.Subscribe(func(event events.Event) {
+			received = append(received, event)
+		}, events.TypeUserRegistered)
+		authService := service.NewAuthServiceWithDeps(mockUserRepo, mockAuthRepo, jwtMgr, config.LoadTestConfig().Auth, service.AuthServiceDeps{Bus: bus})
+
+		mockUserRepo.On("Create", mock.AnythingOfType("*model.User")).Return(&model.User{ID: testUserID}, nil)
+		mockUserRepo.On("Delete", testUserID).Return(nil)
+		mockAuthRepo.On("CreateCredentials", mock.AnythingOfType("*model.UserCredentials")).Return(nil, apperrors.ErrValidation)
+
+		// run
+		_, err := authService.Register(createTestRegisterRequest())
+		bus.Close()
+
+		// assert
+		assert.Error(t, err)
+		assert.Empty(t, received)
+	})
 }
 
 func TestAuthService_Login(t *testing.T) {
@@ -232,6 +415,127 @@ func TestAuthService_Login(t *testing.T) {
 		mockUserRepo.AssertExpectations(t)
 		mockAuthRepo.AssertExpectations(t)
 	})
+
+	t.Run("SelfDeactivatedUserReactivates", func(t *testing.T) {
+		mockUserRepo, mockAuthRepo, _, authService := setupTestAuthService()
+		req := createTestLoginRequest()
+
+		userID := testUserID
+		deactivatedBy := model.DeactivatedBySelf
+		user := &model.User{ID: userID, IsActive: false, DeactivatedBy: &deactivatedBy}
+		reactivatedUser := &model.User{ID: userID, IsActive: true}
+
+		hashedPassword, _ := utils.HashPassword("password123")
+		credentials := &model.UserCredentials{
+			UserID:   userID,
+			Password: hashedPassword,
+		}
+
+		// Setup mocks
+		mockUserRepo.On("FindByUsername", "testuser").Return(user, nil)
+		mockAuthRepo.On("FindByUserID", userID).Return(credentials, nil)
+		mockUserRepo.On("UpdateStatus", userID, true, (*model.DeactivationSource)(nil)).Return(reactivatedUser, nil)
+
+		// run
+		result, err := authService.Login(req)
+
+		// assert
+		assert.NoError(t, err)
+		assert.NotNil(t, result)
+		assert.NotEmpty(t, result.AccessToken)
+
+		mockUserRepo.AssertExpectations(t)
+		mockAuthRepo.AssertExpectations(t)
+	})
+
+	t.Run("AdminDeactivatedUserCannotReactivate", func(t *testing.T) {
+		mockUserRepo, mockAuthRepo, _, authService := setupTestAuthService()
+		req := createTestLoginRequest()
+
+		userID := testUserID
+		deactivatedBy := model.DeactivatedByAdmin
+		user := &model.User{ID: userID, IsActive: false, DeactivatedBy: &deactivatedBy}
+
+		hashedPassword, _ := utils.HashPassword("password123")
+		credentials := &model.UserCredentials{
+			UserID:   userID,
+			Password: hashedPassword,
+		}
+
+		// Setup mocks
+		mockUserRepo.On("FindByUsername", "testuser").Return(user, nil)
+		mockAuthRepo.On("FindByUserID", userID).Return(credentials, nil)
+
+		// run
+		result, err := authService.Login(req)
+
+		// assert
+		assert.ErrorIs(t, err, apperrors.ErrForbidden)
+		assert.Nil(t, result)
+
+		mockUserRepo.AssertExpectations(t)
+		mockUserRepo.AssertNotCalled(t, "UpdateStatus")
+	})
+
+	t.Run("SelfReactivationDisabled", func(t *testing.T) {
+		mockUserRepo := mockRepository.NewUserRepositoryMock()
+		mockAuthRepo := mockRepository.NewAuthRepositoryMock()
+		jwtMgr := utils.NewJWTManager("test-secret", 15*time.Minute)
+		authService := service.NewAuthServiceWithConfig(mockUserRepo, mockAuthRepo, jwtMgr, config.AuthConfig{
+			SelfReactivateOnLogin: false,
+		})
+		req := createTestLoginRequest()
+
+		userID := testUserID
+		deactivatedBy := model.DeactivatedBySelf
+		user := &model.User{ID: userID, IsActive: false, DeactivatedBy: &deactivatedBy}
+
+		hashedPassword, _ := utils.HashPassword("password123")
+		credentials := &model.UserCredentials{
+			UserID:   userID,
+			Password: hashedPassword,
+		}
+
+		// Setup mocks
+		mockUserRepo.On("FindByUsername", "testuser").Return(user, nil)
+		mockAuthRepo.On("FindByUserID", userID).Return(credentials, nil)
+
+		// run
+		result, err := authService.Login(req)
+
+		// assert
+		assert.ErrorIs(t, err, apperrors.ErrForbidden)
+		assert.Nil(t, result)
+		mockUserRepo.AssertNotCalled(t, "UpdateStatus")
+	})
+
+	t.Run("WrongPasswordDoesNotReactivate", func(t *testing.T) {
+		mockUserRepo, mockAuthRepo, _, authService := setupTestAuthService()
+		req := createTestLoginRequest()
+		req.Password = "wrong-password"
+
+		userID := testUserID
+		deactivatedBy := model.DeactivatedBySelf
+		user := &model.User{ID: userID, IsActive: false, DeactivatedBy: &deactivatedBy}
+
+		hashedPassword, _ := utils.HashPassword("password123")
+		credentials := &model.UserCredentials{
+			UserID:   userID,
+			Password: hashedPassword,
+		}
+
+		// Setup mocks
+		mockUserRepo.On("FindByUsername", "testuser").Return(user, nil)
+		mockAuthRepo.On("FindByUserID", userID).Return(credentials, nil)
+
+		// run
+		result, err := authService.Login(req)
+
+		// assert
+		assert.ErrorIs(t, err, apperrors.ErrUnauthorized)
+		assert.Nil(t, result)
+		mockUserRepo.AssertNotCalled(t, "UpdateStatus")
+	})
 }
 
 func TestAuthService_RefreshToken(t *testing.T) {
@@ -271,6 +575,52 @@ func TestAuthService_RefreshToken(t *testing.T) {
 		assert.Nil(t, result)
 	})
 
+	t.Run("AccessTokenRejected", func(t *testing.T) {
+		mockUserRepo, _, jwtMgr, authService := setupTestAuthService()
+		user := &model.User{ID: testUserID, IsActive: true}
+		accessToken, _ := jwtMgr.GenerateAccessToken(user)
+
+		// run
+		result, err := authService.RefreshToken(accessToken)
+
+		// assert
+		assert.ErrorIs(t, err, apperrors.ErrInvalidToken)
+		assert.Nil(t, result)
+		mockUserRepo.AssertNotCalled(t, "FindByID", mock.Anything)
+	})
+
+	t.Run("ImpersonationTokenRejected", func(t *testing.T) {
+		mockUserRepo, _, jwtMgr, authService := setupTestAuthService()
+		user := &model.User{ID: testUserID, IsActive: true}
+		impersonationToken, _ := jwtMgr.GenerateImpersonationToken(user, testOtherUserID, 15*time.Minute)
+
+		// run
+		result, err := authService.RefreshToken(impersonationToken)
+		accessToken, accessErr := authService.RefreshAccessToken(impersonationToken)
+
+		// assert
+		assert.ErrorIs(t, err, apperrors.ErrInvalidToken)
+		assert.Nil(t, result)
+		assert.ErrorIs(t, accessErr, apperrors.ErrInvalidToken)
+		assert.Empty(t, accessToken)
+		mockUserRepo.AssertNotCalled(t, "FindByID", mock.Anything)
+	})
+
+	t.Run("RevokedToken", func(t *testing.T) {
+		mockUserRepo, _, jwtMgr, authService := setupTestAuthService()
+		user := &model.User{ID: testUserID, IsActive: true}
+		tokenResponse, _ := jwtMgr.GenerateToken(user)
+		assert.NoError(t, authService.RevokeAccessToken(tokenResponse.RefreshToken))
+
+		// run
+		result, err := authService.RefreshToken(tokenResponse.RefreshToken)
+
+		// assert
+		assert.ErrorIs(t, err, apperrors.ErrInvalidToken)
+		assert.Nil(t, result)
+		mockUserRepo.AssertNotCalled(t, "FindByID", mock.Anything)
+	})
+
 	t.Run("MissingToken", func(t *testing.T) {
 		_, _, _, authService := setupTestAuthService()
 
@@ -356,6 +706,18 @@ func TestAuthService_ValidateToken(t *testing.T) {
 		assert.ErrorIs(t, err, apperrors.ErrInvalidToken)
 		assert.Nil(t, claims)
 	})
+
+	t.Run("RefreshTokenRejected", func(t *testing.T) {
+		_, _, jwtMgr, authService := setupTestAuthService()
+		tokenResponse, _ := jwtMgr.GenerateToken(&model.User{ID: testUserID, IsActive: true})
+
+		// run
+		claims, err := authService.ValidateToken(tokenResponse.RefreshToken)
+
+		// assert
+		assert.ErrorIs(t, err, apperrors.ErrInvalidToken)
+		assert.Nil(t, claims)
+	})
 }
 
 func TestAuthService_ActivateUser(t *testing.T) {
@@ -367,7 +729,7 @@ func TestAuthService_ActivateUser(t *testing.T) {
 
 		// Setup mocks
 		mockUserRepo.On("FindByID", mock.Anything).Return(user, nil)
-		mockUserRepo.On("Update", mock.Anything, mock.Anything).Return(updatedUser, nil)
+		mockUserRepo.On("UpdateStatus", userID, true, (*model.DeactivationSource)(nil)).Return(updatedUser, nil)
 
 		// run
 		result, err := authService.ActivateUser(userID)
@@ -391,10 +753,10 @@ func TestAuthService_DeactivateUser(t *testing.T) {
 
 		// Setup mocks
 		mockUserRepo.On("FindByID", mock.Anything).Return(user, nil)
-		mockUserRepo.On("Update", mock.Anything, mock.Anything).Return(updatedUser, nil)
+		mockUserRepo.On("UpdateStatus", userID, false, mock.Anything).Return(updatedUser, nil)
 
 		// run
-		result, err := authService.DeactivateUser(userID)
+		result, err := authService.DeactivateUser(userID, userID)
 
 		// assert
 		assert.NoError(t, err)
@@ -404,6 +766,114 @@ func TestAuthService_DeactivateUser(t *testing.T) {
 
 		mockUserRepo.AssertExpectations(t)
 	})
+
+	t.Run("SelfDeactivationMarkedAsSelf", func(t *testing.T) {
+		mockUserRepo, _, _, authService := setupTestAuthService()
+		userID := testUserID
+		user := &model.User{ID: userID, IsActive: true}
+
+		// Setup mocks
+		mockUserRepo.On("FindByID", userID).Return(user, nil)
+		mockUserRepo.On("UpdateStatus", userID, false, mock.MatchedBy(func(by *model.DeactivationSource) bool {
+			return by != nil && *by == model.DeactivatedBySelf
+		})).Return(&model.User{ID: userID, IsActive: false}, nil)
+
+		// run
+		_, err := authService.DeactivateUser(userID, userID)
+
+		// assert
+		assert.NoError(t, err)
+		mockUserRepo.AssertExpectations(t)
+	})
+
+	t.Run("AdminDeactivationMarkedAsAdmin", func(t *testing.T) {
+		mockUserRepo, _, _, authService := setupTestAuthService()
+		userID := testUserID
+		user := &model.User{ID: userID, IsActive: true}
+
+		// Setup mocks
+		mockUserRepo.On("FindByID", userID).Return(user, nil)
+		mockUserRepo.On("UpdateStatus", userID, false, mock.MatchedBy(func(by *model.DeactivationSource) bool {
+			return by != nil && *by == model.DeactivatedByAdmin
+		})).Return(&model.User{ID: userID, IsActive: false}, nil)
+
+		// run
+		_, err := authService.DeactivateUser(userID, testOtherUserID)
+
+		// assert
+		assert.NoError(t, err)
+		mockUserRepo.AssertExpectations(t)
+	})
+
+	t.Run("AdminOverridesSelfDeactivation", func(t *testing.T) {
+		mockUserRepo, _, _, authService := setupTestAuthService()
+		userID := testUserID
+		bySelf := model.DeactivatedBySelf
+		user := &model.User{ID: userID, IsActive: false, DeactivatedBy: &bySelf}
+
+		// Setup mocks
+		mockUserRepo.On("FindByID", userID).Return(user, nil)
+		mockUserRepo.On("UpdateStatus", userID, false, mock.MatchedBy(func(by *model.DeactivationSource) bool {
+			return by != nil && *by == model.DeactivatedByAdmin
+		})).Return(&model.User{ID: userID, IsActive: false}, nil)
+
+		// run
+		_, err := authService.DeactivateUser(userID, testOtherUserID)
+
+		// assert: the next login can no longer reactivate the account
+		assert.NoError(t, err)
+		mockUserRepo.AssertExpectations(t)
+	})
+
+	t.Run("AlreadyInactiveKeepsMarker", func(t *testing.T) {
+		mockUserRepo, _, _, authService := setupTestAuthService()
+		userID := testUserID
+		byAdmin := model.DeactivatedByAdmin
+		user := &model.User{ID: userID, IsActive: false, DeactivatedBy: &byAdmin}
+
+		// Setup mocks
+		mockUserRepo.On("FindByID", userID).Return(user, nil)
+
+		// run: the user can't turn an admin deactivation into a self one
+		result, err := authService.DeactivateUser(userID, userID)
+		_, adminErr := authService.DeactivateUser(userID, testOtherUserID)
+
+		// assert
+		assert.NoError(t, err)
+		assert.NoError(t, adminErr)
+		assert.Equal(t, user, result)
+		mockUserRepo.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("PublishesUserDeactivated", func(t *testing.T) {
+		mockUserRepo := mockRepository.NewUserRepositoryMock()
+		mockAuthRepo := mockRepository.NewAuthRepositoryMock()
+		jwtMgr := utils.NewJWTManager("test-secret", 15*time.Minute)
+		bus := events.NewBus(zap.NewNop())
+		var received []events.Event
+		bus.Subscribe(func(event events.Event) {
+			received = append(received, event)
+		}, events.TypeUserDeactivated)
+		authService := service.NewAuthServiceWithDeps(mockUserRepo, mockAuthRepo, jwtMgr, config.LoadTestConfig().Auth, service.AuthServiceDeps{Bus: bus})
+
+		userID := testUserID
+		user := &model.User{ID: userID, IsActive: true}
+		mockUserRepo.On("FindByID", userID).Return(user, nil)
+		mockUserRepo.On("UpdateStatus", userID, false, mock.Anything).Return(&model.User{ID: userID, IsActive: false}, nil)
+
+		// run
+		_, err := authService.DeactivateUser(userID, testOtherUserID)
+		bus.Close()
+
+		// assert
+		assert.NoError(t, err)
+		if assert.Len(t, received, 1) {
+			event := received[0].(events.UserDeactivated)
+			assert.Equal(t, userID, event.UserID)
+			assert.Equal(t, testOtherUserID, event.ActorID)
+			assert.Equal(t, model.DeactivatedByAdmin, event.DeactivatedBy)
+		}
+	})
 }
 
 // TestAuthService_ConcurrentRegistration 測試併發註冊場景
@@ -650,3 +1120,163 @@ func TestAuthService_ConcurrentRegistration(t *testing.T) {
 		mockAuthRepo.AssertExpectations(t)
 	})
 }
+
+func TestAuthService_RevokeAccessToken(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockUserRepo, mockAuthRepo := mockRepository.NewUserRepositoryMock(), mockRepository.NewAuthRepositoryMock()
+		jwtMgr := utils.NewJWTManager("test-secret", 15*time.Minute)
+		denylist := utils.NewMemoryTokenDenylist()
+		authService := service.NewAuthServiceWithDeps(mockUserRepo, mockAuthRepo, jwtMgr, config.LoadTestConfig().Auth, service.AuthServiceDeps{Denylist: denylist})
+
+		token, err := jwtMgr.GenerateAccessToken(&model.User{ID: testUserID, Role: model.RoleUser})
+		assert.NoError(t, err)
+		claims, err := authService.ValidateToken(token)
+		assert.NoError(t, err)
+		assert.NotEmpty(t, claims.ID)
+
+		err = authService.RevokeAccessToken(token)
+
+		assert.NoError(t, err)
+		revoked, err := denylist.IsRevoked(claims.ID)
+		assert.NoError(t, err)
+		assert.True(t, revoked)
+	})
+
+	t.Run("KeptUntilTokenOwnExpiry", func(t *testing.T) {
+		jwtMgr := utils.NewJWTManager("test-secret", 15*time.Minute)
+		mockDenylist := mockRepository.NewRevokedTokenRepositoryMock()
+		authService := service.NewAuthServiceWithDeps(mockRepository.NewUserRepositoryMock(), mockRepository.NewAuthRepositoryMock(), jwtMgr, config.LoadTestConfig().Auth, service.AuthServiceDeps{Denylist: mockDenylist})
+
+		// an impersonation token lives far shorter than a normal access token
+		token, err := jwtMgr.GenerateImpersonationToken(&model.User{ID: testUserID, Role: model.RoleUser}, "admin-id", time.Minute)
+		assert.NoError(t, err)
+		claims, err := jwtMgr.ValidateToken(token)
+		assert.NoError(t, err)
+		mockDenylist.On("Revoke", claims.ID, claims.ExpiresAt.Time).Return(nil)
+
+		err = authService.RevokeAccessToken(token)
+
+		assert.NoError(t, err)
+		mockDenylist.AssertExpectations(t)
+	})
+
+	t.Run("ExpiredTokenIsNoop", func(t *testing.T) {
+		jwtMgr := utils.NewJWTManager("test-secret", -time.Minute)
+		mockDenylist := mockRepository.NewRevokedTokenRepositoryMock()
+		authService := service.NewAuthServiceWithDeps(mockRepository.NewUserRepositoryMock(), mockRepository.NewAuthRepositoryMock(), jwtMgr, config.LoadTestConfig().Auth, service.AuthServiceDeps{Denylist: mockDenylist})
+
+		token, err := jwtMgr.GenerateAccessToken(&model.User{ID: testUserID, Role: model.RoleUser})
+		assert.NoError(t, err)
+
+		err = authService.RevokeAccessToken(token)
+
+		assert.NoError(t, err)
+		mockDenylist.AssertNotCalled(t, "Revoke", mock.Anything, mock.Anything)
+	})
+
+	t.Run("InvalidToken", func(t *testing.T) {
+		_, _, _, authService := setupTestAuthService()
+
+		assert.ErrorIs(t, authService.RevokeAccessToken(""), apperrors.ErrValidation)
+		assert.ErrorIs(t, authService.RevokeAccessToken("not-a-jwt"), apperrors.ErrValidation)
+	})
+
+	t.Run("DenylistError", func(t *testing.T) {
+		jwtMgr := utils.NewJWTManager("test-secret", 15*time.Minute)
+		mockDenylist := mockRepository.NewRevokedTokenRepositoryMock()
+		mockDenylist.On("Revoke", mock.Anything, mock.Anything).Return(errors.New("connection refused"))
+		authService := service.NewAuthServiceWithDeps(mockRepository.NewUserRepositoryMock(), mockRepository.NewAuthRepositoryMock(), jwtMgr, config.LoadTestConfig().Auth, service.AuthServiceDeps{Denylist: mockDenylist})
+
+		token, err := jwtMgr.GenerateAccessToken(&model.User{ID: testUserID, Role: model.RoleUser})
+		assert.NoError(t, err)
+
+		assert.Error(t, authService.RevokeAccessToken(token))
+	})
+}
+
+func TestAuthService_Impersonate(t *testing.T) {
+	adminID := "admin-id"
+	setupImpersonation := func(cfg config.AuthConfig) (*mockRepository.UserRepositoryMock, *mockRepository.AuditRepositoryMock, *utils.JWTManager, service.AuthService) {
+		mockUserRepo, mockAuditRepo := mockRepository.NewUserRepositoryMock(), mockRepository.NewAuditRepositoryMock()
+		jwtMgr := utils.NewJWTManager("test-secret", 15*time.Minute)
+		authService := service.NewAuthServiceWithDeps(mockUserRepo, mockRepository.NewAuthRepositoryMock(), jwtMgr, cfg, service.AuthServiceDeps{Audit: mockAuditRepo})
+		return mockUserRepo, mockAuditRepo, jwtMgr, authService
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mockUserRepo, mockAuditRepo, jwtMgr, authService := setupImpersonation(config.AuthConfig{ImpersonationTTL: 5 * time.Minute})
+		target := &model.User{ID: testUserID, Role: model.RoleUser, IsActive: true}
+		mockUserRepo.On("FindByID", testUserID).Return(target, nil)
+		mockAuditRepo.On("Create", &model.AuditLog{
+			ActorID:    adminID,
+			Action:     model.AuditActionImpersonate,
+			TargetType: model.AuditTargetUser,
+			TargetID:   testUserID,
+		}).Return(nil)
+
+		// run
+		response, err := authService.Impersonate(testUserID, adminID)
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, int64(300), response.ExpiresIn)
+		claims, err := jwtMgr.ValidateToken(response.AccessToken)
+		assert.NoError(t, err)
+		assert.Equal(t, testUserID, claims.UserID)
+		assert.Equal(t, adminID, claims.ImpersonatorID)
+		assert.True(t, claims.Impersonated())
+		assert.WithinDuration(t, time.Now().Add(5*time.Minute), claims.ExpiresAt.Time, time.Minute)
+		mockAuditRepo.AssertExpectations(t)
+	})
+
+	t.Run("AdminTargetForbidden", func(t *testing.T) {
+		mockUserRepo, mockAuditRepo, _, authService := setupImpersonation(config.AuthConfig{})
+		mockUserRepo.On("FindByID", testUserID).Return(&model.User{ID: testUserID, Role: model.RoleAdmin}, nil)
+
+		// run
+		response, err := authService.Impersonate(testUserID, adminID)
+
+		// assert
+		assert.ErrorIs(t, err, apperrors.ErrForbidden)
+		assert.Nil(t, response)
+		mockAuditRepo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+
+	t.Run("NoTokenWhenAuditFails", func(t *testing.T) {
+		mockUserRepo, mockAuditRepo, _, authService := setupImpersonation(config.AuthConfig{})
+		mockUserRepo.On("FindByID", testUserID).Return(&model.User{ID: testUserID, Role: model.RoleUser}, nil)
+		mockAuditRepo.On("Create", mock.Anything).Return(errors.New("db down"))
+
+		// run
+		response, err := authService.Impersonate(testUserID, adminID)
+
+		// assert
+		assert.Error(t, err)
+		assert.Nil(t, response)
+	})
+
+	t.Run("RequiresAuditRepository", func(t *testing.T) {
+		mockUserRepo, _, _, authService := setupTestAuthService()
+
+		// run
+		response, err := authService.Impersonate(testUserID, adminID)
+
+		// assert
+		assert.Error(t, err)
+		assert.Nil(t, response)
+		mockUserRepo.AssertNotCalled(t, "FindByID", mock.Anything)
+	})
+
+	t.Run("NormalTokenNotImpersonated", func(t *testing.T) {
+		_, _, jwtMgr, _ := setupImpersonation(config.AuthConfig{})
+		token, err := jwtMgr.GenerateAccessToken(&model.User{ID: testUserID, Role: model.RoleUser})
+		assert.NoError(t, err)
+
+		// run
+		claims, err := jwtMgr.ValidateToken(token)
+
+		// assert
+		assert.NoError(t, err)
+		assert.False(t, claims.Impersonated())
+	})
+}
@@ -0,0 +1,175 @@
+package service
+
+import (
+	"go-gin-api-server/config"
+	"go-gin-api-server/internal/model"
+	"go-gin-api-server/internal/service"
+	"go-gin-api-server/pkg/apperrors"
+	mockRepository "go-gin-api-server/test/mocks/repository"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Helper functions
+
+func setupTestBlockService() (*mockRepository.BlockRepositoryMock, *mockRepository.UserRepositoryMock, service.BlockService) {
+	mockRepo := mockRepository.NewBlockRepositoryMock()
+	mockUserRepo := mockRepository.NewUserRepositoryMock()
+	return mockRepo, mockUserRepo, service.NewBlockService(mockRepo, mockUserRepo)
+}
+
+// Testcases
+
+func TestBlockUser(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		repo, userRepo, blockService := setupTestBlockService()
+		userRepo.On("FindByID", testOtherUserID).Return(&model.User{ID: testOtherUserID}, nil)
+		repo.On("HasBlocked", testUserID, testOtherUserID).Return(false, nil)
+		repo.On("CountBlocked", testUserID).Return(int64(0), nil)
+		repo.On("Block", testUserID, testOtherUserID).Return(nil)
+
+		// run
+		err := blockService.Block(testUserID, testOtherUserID)
+
+		// assert
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("LimitExceeded", func(t *testing.T) {
+		repo := mockRepository.NewBlockRepositoryMock()
+		userRepo := mockRepository.NewUserRepositoryMock()
+		blockService := service.NewBlockServiceWithConfig(repo, userRepo, config.UsersConfig{MaxBlocks: 2})
+		userRepo.On("FindByID", testOtherUserID).Return(&model.User{ID: testOtherUserID}, nil)
+		repo.On("HasBlocked", testUserID, testOtherUserID).Return(false, nil)
+		repo.On("CountBlocked", testUserID).Return(int64(2), nil)
+
+		// run
+		err := blockService.Block(testUserID, testOtherUserID)
+
+		// assert
+		assert.ErrorIs(t, err, apperrors.ErrLimitExceeded)
+		repo.AssertNotCalled(t, "Block", mock.Anything, mock.Anything)
+	})
+
+	t.Run("AlreadyBlockedAtCap", func(t *testing.T) {
+		repo := mockRepository.NewBlockRepositoryMock()
+		userRepo := mockRepository.NewUserRepositoryMock()
+		blockService := service.NewBlockServiceWithConfig(repo, userRepo, config.UsersConfig{MaxBlocks: 2})
+		userRepo.On("FindByID", testOtherUserID).Return(&model.User{ID: testOtherUserID}, nil)
+		repo.On("HasBlocked", testUserID, testOtherUserID).Return(true, nil)
+
+		// run: the list is full, but this block is already in it
+		err := blockService.Block(testUserID, testOtherUserID)
+
+		// assert
+		assert.NoError(t, err)
+		repo.AssertNotCalled(t, "CountBlocked", mock.Anything)
+		repo.AssertNotCalled(t, "Block", mock.Anything, mock.Anything)
+	})
+
+	t.Run("UnblockFreesCapacity", func(t *testing.T) {
+		repo := mockRepository.NewBlockRepositoryMock()
+		userRepo := mockRepository.NewUserRepositoryMock()
+		blockService := service.NewBlockServiceWithConfig(repo, userRepo, config.UsersConfig{MaxBlocks: 2})
+		userRepo.On("FindByID", testOtherUserID).Return(&model.User{ID: testOtherUserID}, nil)
+		repo.On("HasBlocked", testUserID, testOtherUserID).Return(false, nil)
+		repo.On("CountBlocked", testUserID).Return(int64(2), nil).Once()
+		repo.On("Unblock", testUserID, testUserID1).Return(nil)
+		repo.On("CountBlocked", testUserID).Return(int64(1), nil).Once()
+		repo.On("Block", testUserID, testOtherUserID).Return(nil)
+
+		// run: full, unblock one, then there is room again
+		assert.ErrorIs(t, blockService.Block(testUserID, testOtherUserID), apperrors.ErrLimitExceeded)
+		assert.NoError(t, blockService.Unblock(testUserID, testUserID1))
+		err := blockService.Block(testUserID, testOtherUserID)
+
+		// assert
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("ZeroMeansNoCap", func(t *testing.T) {
+		repo := mockRepository.NewBlockRepositoryMock()
+		userRepo := mockRepository.NewUserRepositoryMock()
+		blockService := service.NewBlockServiceWithConfig(repo, userRepo, config.UsersConfig{})
+		userRepo.On("FindByID", testOtherUserID).Return(&model.User{ID: testOtherUserID}, nil)
+		repo.On("Block", testUserID, testOtherUserID).Return(nil)
+
+		// run
+		err := blockService.Block(testUserID, testOtherUserID)
+
+		// assert
+		assert.NoError(t, err)
+		repo.AssertNotCalled(t, "CountBlocked", mock.Anything)
+	})
+
+	t.Run("Self", func(t *testing.T) {
+		repo, userRepo, blockService := setupTestBlockService()
+
+		// run
+		err := blockService.Block(testUserID, testUserID)
+
+		// assert
+		assert.ErrorIs(t, err, apperrors.ErrValidation)
+		userRepo.AssertNotCalled(t, "FindByID", mock.Anything)
+		repo.AssertNotCalled(t, "Block", mock.Anything, mock.Anything)
+	})
+
+	t.Run("UserNotFound", func(t *testing.T) {
+		repo, userRepo, blockService := setupTestBlockService()
+		userRepo.On("FindByID", testOtherUserID).Return(nil, apperrors.ErrNotFound)
+
+		// run
+		err := blockService.Block(testUserID, testOtherUserID)
+
+		// assert
+		assert.ErrorIs(t, err, apperrors.ErrNotFound)
+		repo.AssertNotCalled(t, "Block", mock.Anything, mock.Anything)
+	})
+}
+
+func TestUnblockUser(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		repo, _, blockService := setupTestBlockService()
+		repo.On("Unblock", testUserID, testOtherUserID).Return(nil)
+
+		// run
+		err := blockService.Unblock(testUserID, testOtherUserID)
+
+		// assert
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+}
+
+func TestListBlockedUsers(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		repo, _, blockService := setupTestBlockService()
+		repo.On("ListBlockedIDs", testUserID).Return([]string{testOtherUserID}, nil)
+
+		// run
+		response, err := blockService.ListBlocked(testUserID)
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, []string{testOtherUserID}, response.BlockedIDs)
+	})
+}
+
+func TestIsBlocked(t *testing.T) {
+	t.Run("EitherDirection", func(t *testing.T) {
+		repo, _, blockService := setupTestBlockService()
+		repo.On("IsBlocked", testOtherUserID, testUserID).Return(true, nil)
+
+		// run: testUserID blocked testOtherUserID, asked from the blocked side
+		blocked, err := blockService.IsBlocked(testOtherUserID, testUserID)
+
+		// assert
+		assert.NoError(t, err)
+		assert.True(t, blocked)
+		repo.AssertExpectations(t)
+	})
+}
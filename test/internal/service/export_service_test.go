@@ -0,0 +1,226 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"go-gin-api-server/config"
+	"go-gin-api-server/internal/model"
+	"go-gin-api-server/internal/service"
+	"go-gin-api-server/pkg/apperrors"
+	mockRepository "go-gin-api-server/test/mocks/repository"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Helper functions
+
+func setupTestExportService(cfg config.ExportConfig) (*mockRepository.UserRepositoryMock, *mockRepository.PostRepositoryMock, *mockRepository.NotificationRepositoryMock, service.ExportService) {
+	userRepo := mockRepository.NewUserRepositoryMock()
+	postRepo := mockRepository.NewPostRepositoryMock()
+	notificationRepo := mockRepository.NewNotificationRepositoryMock()
+	return userRepo, postRepo, notificationRepo, service.NewExportServiceWithConfig(userRepo, postRepo, notificationRepo, cfg)
+}
+
+func createTestExportPosts(n int) []model.Post {
+	posts := make([]model.Post, 0, n)
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < n; i++ {
+		posts = append(posts, model.Post{
+			ID:        uint64(n - i),
+			Content:   "Exported post content",
+			AuthorID:  authorID,
+			CreatedAt: createdAt.Add(-time.Duration(i) * time.Minute),
+		})
+	}
+	return posts
+}
+
+func createTestExportNotifications(n int) []model.Notification {
+	notifications := make([]model.Notification, 0, n)
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < n; i++ {
+		notifications = append(notifications, model.Notification{
+			ID:        uint64(n - i),
+			UserID:    authorID,
+			CreatedAt: createdAt.Add(-time.Duration(i) * time.Minute),
+		})
+	}
+	return notifications
+}
+
+func notificationCursor(notification model.Notification) model.Cursor {
+	return model.Cursor{ID: strconv.FormatUint(notification.ID, 10), CreatedAt: notification.CreatedAt}
+}
+
+func decodeTestExport(t *testing.T, buf *bytes.Buffer) model.UserExport {
+	var export model.UserExport
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &export))
+	return export
+}
+
+// Testcases
+
+func TestExport(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		userRepo, postRepo, notificationRepo, exportService := setupTestExportService(config.ExportConfig{BatchSize: 100})
+		userRepo.On("FindByID", authorID).Return(&model.User{ID: authorID, Name: "Author"}, nil)
+		postRepo.On("IteratePostsByAuthor", authorID, model.Cursor{}, 100).Return([][]model.Post{createTestExportPosts(2)}, nil)
+		notificationRepo.On("List", mock.Anything).Return(createTestExportNotifications(1), nil)
+
+		// run
+		var buf bytes.Buffer
+		err := exportService.Export(authorID, "", &buf)
+
+		// assert
+		assert.NoError(t, err)
+		export := decodeTestExport(t, &buf)
+		assert.Equal(t, authorID, export.Profile.ID)
+		assert.Len(t, export.Posts, 2)
+		assert.Len(t, export.Notifications, 1)
+		assert.False(t, export.ExportedAt.IsZero())
+		assert.Empty(t, export.Next)
+	})
+
+	t.Run("EmptySections", func(t *testing.T) {
+		userRepo, postRepo, notificationRepo, exportService := setupTestExportService(config.ExportConfig{BatchSize: 100})
+		userRepo.On("FindByID", authorID).Return(&model.User{ID: authorID}, nil)
+		postRepo.On("IteratePostsByAuthor", authorID, model.Cursor{}, 100).Return(nil, nil)
+		notificationRepo.On("List", mock.Anything).Return([]model.Notification{}, nil)
+
+		// run
+		var buf bytes.Buffer
+		err := exportService.Export(authorID, "", &buf)
+
+		// assert: empty arrays, not null
+		assert.NoError(t, err)
+		assert.Contains(t, buf.String(), `"posts":[]`)
+		assert.Contains(t, buf.String(), `"notifications":[]`)
+	})
+
+	t.Run("StreamsEveryBatch", func(t *testing.T) {
+		userRepo, postRepo, notificationRepo, exportService := setupTestExportService(config.ExportConfig{BatchSize: 2})
+		userRepo.On("FindByID", authorID).Return(&model.User{ID: authorID}, nil)
+		posts := createTestExportPosts(5)
+		postRepo.On("IteratePostsByAuthor", authorID, model.Cursor{}, 2).
+			Return([][]model.Post{posts[:2], posts[2:4], posts[4:]}, nil)
+		notifications := createTestExportNotifications(3)
+		notificationRepo.On("List", model.NotificationListOptions{UserID: authorID, Limit: 2}).
+			Return(notifications[:2], nil)
+		notificationRepo.On("List", model.NotificationListOptions{UserID: authorID, Cursor: notificationCursor(notifications[1]), Limit: 2}).
+			Return(notifications[2:], nil)
+
+		// run
+		var buf bytes.Buffer
+		err := exportService.Export(authorID, "", &buf)
+
+		// assert
+		assert.NoError(t, err)
+		export := decodeTestExport(t, &buf)
+		assert.Len(t, export.Posts, 5)
+		assert.Len(t, export.Notifications, 3)
+		notificationRepo.AssertNumberOfCalls(t, "List", 2)
+	})
+
+	t.Run("PageSizeReturnsContinuation", func(t *testing.T) {
+		userRepo, postRepo, notificationRepo, exportService := setupTestExportService(config.ExportConfig{BatchSize: 2, PageSize: 3})
+		userRepo.On("FindByID", authorID).Return(&model.User{ID: authorID}, nil)
+		posts := createTestExportPosts(5)
+		postRepo.On("IteratePostsByAuthor", authorID, model.Cursor{}, 2).
+			Return([][]model.Post{posts[:2], posts[2:4], posts[4:]}, nil)
+
+		// run
+		var buf bytes.Buffer
+		err := exportService.Export(authorID, "", &buf)
+
+		// assert: the page stops mid-batch and resumes after the last post written
+		assert.NoError(t, err)
+		export := decodeTestExport(t, &buf)
+		assert.Len(t, export.Posts, 3)
+		assert.Empty(t, export.Notifications)
+		continuation, err := model.DecodeExportContinuation(export.Next)
+		assert.NoError(t, err)
+		assert.Equal(t, model.ExportSectionPosts, continuation.Section)
+		assert.Equal(t, strconv.FormatUint(posts[2].ID, 10), continuation.Cursor.ID)
+		assert.True(t, posts[2].CreatedAt.Equal(continuation.Cursor.CreatedAt))
+		notificationRepo.AssertNotCalled(t, "List", mock.Anything)
+	})
+
+	t.Run("ResumesFromContinuation", func(t *testing.T) {
+		userRepo, postRepo, notificationRepo, exportService := setupTestExportService(config.ExportConfig{BatchSize: 2, PageSize: 3})
+		userRepo.On("FindByID", authorID).Return(&model.User{ID: authorID}, nil)
+		notifications := createTestExportNotifications(5)
+		from := notificationCursor(notifications[3])
+		notificationRepo.On("List", model.NotificationListOptions{UserID: authorID, Cursor: from, Limit: 2}).
+			Return(notifications[4:], nil)
+		token := model.EncodeExportContinuation(model.ExportContinuation{Section: model.ExportSectionNotifications, Cursor: from})
+
+		// run
+		var buf bytes.Buffer
+		err := exportService.Export(authorID, token, &buf)
+
+		// assert: the posts section is already done
+		assert.NoError(t, err)
+		export := decodeTestExport(t, &buf)
+		assert.Empty(t, export.Posts)
+		assert.Len(t, export.Notifications, 1)
+		assert.Empty(t, export.Next)
+		postRepo.AssertNotCalled(t, "IteratePostsByAuthor", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("NotificationPageReturnsKeysetContinuation", func(t *testing.T) {
+		userRepo, postRepo, notificationRepo, exportService := setupTestExportService(config.ExportConfig{BatchSize: 2, PageSize: 3})
+		userRepo.On("FindByID", authorID).Return(&model.User{ID: authorID}, nil)
+		postRepo.On("IteratePostsByAuthor", authorID, model.Cursor{}, 2).Return([][]model.Post{createTestExportPosts(1)}, nil)
+		notifications := createTestExportNotifications(4)
+		notificationRepo.On("List", model.NotificationListOptions{UserID: authorID, Limit: 2}).
+			Return(notifications[:2], nil)
+		notificationRepo.On("List", model.NotificationListOptions{UserID: authorID, Cursor: notificationCursor(notifications[1]), Limit: 2}).
+			Return(notifications[2:], nil)
+
+		// run
+		var buf bytes.Buffer
+		err := exportService.Export(authorID, "", &buf)
+
+		// assert: the page stops after the second notification and resumes after it
+		assert.NoError(t, err)
+		export := decodeTestExport(t, &buf)
+		assert.Len(t, export.Posts, 1)
+		assert.Len(t, export.Notifications, 2)
+		continuation, err := model.DecodeExportContinuation(export.Next)
+		assert.NoError(t, err)
+		assert.Equal(t, model.ExportSectionNotifications, continuation.Section)
+		assert.Equal(t, strconv.FormatUint(notifications[1].ID, 10), continuation.Cursor.ID)
+		assert.True(t, notifications[1].CreatedAt.Equal(continuation.Cursor.CreatedAt))
+	})
+
+	t.Run("InvalidContinuation", func(t *testing.T) {
+		userRepo, _, _, exportService := setupTestExportService(config.ExportConfig{BatchSize: 100})
+
+		// run
+		var buf bytes.Buffer
+		err := exportService.Export(authorID, "not-a-token", &buf)
+
+		// assert
+		assert.ErrorIs(t, err, apperrors.ErrValidation)
+		assert.Zero(t, buf.Len())
+		userRepo.AssertNotCalled(t, "FindByID", mock.Anything)
+	})
+
+	t.Run("UserNotFoundWritesNothing", func(t *testing.T) {
+		userRepo, postRepo, _, exportService := setupTestExportService(config.ExportConfig{BatchSize: 100})
+		userRepo.On("FindByID", authorID).Return(nil, apperrors.ErrNotFound)
+
+		// run
+		var buf bytes.Buffer
+		err := exportService.Export(authorID, "", &buf)
+
+		// assert
+		assert.ErrorIs(t, err, apperrors.ErrNotFound)
+		assert.Zero(t, buf.Len())
+		postRepo.AssertNotCalled(t, "IteratePostsByAuthor", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,77 @@
+package service
+
+import (
+	"database/sql"
+	"errors"
+	"go-gin-api-server/config"
+	"go-gin-api-server/internal/model"
+	"go-gin-api-server/internal/service"
+	mockRepository "go-gin-api-server/test/mocks/repository"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Helper functions
+
+func setupTestHealthService(cfg *config.Config) (*mockRepository.HealthRepositoryMock, service.HealthService) {
+	mockRepo := mockRepository.NewHealthRepositoryMock()
+	return mockRepo, service.NewHealthService(mockRepo, cfg)
+}
+
+// Testcases
+
+func TestHealthDetails(t *testing.T) {
+	t.Run("OK", func(t *testing.T) {
+		cfg := config.LoadTestConfig()
+		cfg.Welcome.Enabled = true
+		repo, healthService := setupTestHealthService(cfg)
+		repo.On("PoolStats").Return(sql.DBStats{MaxOpenConnections: 10, OpenConnections: 3, InUse: 1, Idle: 2}, nil)
+		repo.On("MigrationVersion").Return(uint(15), false, nil)
+		repo.On("ServerVersion").Return("16.2", nil)
+
+		// run
+		details := healthService.Details()
+
+		// assert
+		assert.Equal(t, model.HealthStatusOK, details.Status)
+		assert.Equal(t, 3, details.Database.OpenConnections)
+		assert.Equal(t, 1, details.Database.InUse)
+		assert.Equal(t, 2, details.Database.Idle)
+		assert.Equal(t, uint(15), details.Database.MigrationVersion)
+		assert.Equal(t, "16.2", details.Versions["postgres"])
+		assert.NotEmpty(t, details.Versions["go"])
+		assert.True(t, details.Features["welcome_post"])
+		assert.False(t, details.Features["profile_cache"]) // zero TTL in the test config
+		assert.Empty(t, details.Errors)
+	})
+
+	t.Run("DirtyMigrationDegraded", func(t *testing.T) {
+		repo, healthService := setupTestHealthService(config.LoadTestConfig())
+		repo.On("PoolStats").Return(sql.DBStats{}, nil)
+		repo.On("MigrationVersion").Return(uint(15), true, nil)
+		repo.On("ServerVersion").Return("16.2", nil)
+
+		// run
+		details := healthService.Details()
+
+		// assert
+		assert.Equal(t, model.HealthStatusDegraded, details.Status)
+		assert.True(t, details.Database.MigrationDirty)
+	})
+
+	t.Run("LookupFailureDegraded", func(t *testing.T) {
+		repo, healthService := setupTestHealthService(config.LoadTestConfig())
+		repo.On("PoolStats").Return(sql.DBStats{OpenConnections: 1}, nil)
+		repo.On("MigrationVersion").Return(uint(0), false, errors.New("relation \"schema_migrations\" does not exist"))
+		repo.On("ServerVersion").Return("16.2", nil)
+
+		// run
+		details := healthService.Details()
+
+		// assert: the rest of the report is still filled in
+		assert.Equal(t, model.HealthStatusDegraded, details.Status)
+		assert.Len(t, details.Errors, 1)
+		assert.Equal(t, 1, details.Database.OpenConnections)
+	})
+}
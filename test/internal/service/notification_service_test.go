@@ -0,0 +1,191 @@
+package service
+
+import (
+	"fmt"
+	"go-gin-api-server/config"
+	"go-gin-api-server/internal/events"
+	"go-gin-api-server/internal/model"
+	"go-gin-api-server/internal/service"
+	"go-gin-api-server/pkg/apperrors"
+	mockRepository "go-gin-api-server/test/mocks/repository"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Helper functions
+
+func setupTestNotificationService() (*mockRepository.NotificationRepositoryMock, *mockRepository.UserRepositoryMock, service.NotificationService) {
+	mockRepo := mockRepository.NewNotificationRepositoryMock()
+	mockUserRepo := mockRepository.NewUserRepositoryMock()
+	return mockRepo, mockUserRepo, service.NewNotificationService(mockRepo, mockUserRepo)
+}
+
+const (
+	likerID = "liker-e29b-41d4-a716-446655440000"
+)
+
+// Testcases
+
+func TestNotificationHandleEvent_PostLiked(t *testing.T) {
+	t.Run("NotifiesAuthorOnce", func(t *testing.T) {
+		repo, _, notificationService := setupTestNotificationService()
+		repo.On("Create", mock.MatchedBy(func(n *model.Notification) bool {
+			return n.UserID == authorID &&
+				n.ActorID == likerID &&
+				n.Type == model.NotificationLike &&
+				n.PostID != nil && *n.PostID == 1
+		})).Return(&model.Notification{ID: 1}, nil)
+
+		// run
+		err := notificationService.HandleEvent(events.PostLiked{PostID: 1, AuthorID: authorID, UserID: likerID})
+
+		// assert
+		assert.NoError(t, err)
+		repo.AssertNumberOfCalls(t, "Create", 1)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("SelfLikeIgnored", func(t *testing.T) {
+		repo, _, notificationService := setupTestNotificationService()
+
+		// run
+		err := notificationService.HandleEvent(events.PostLiked{PostID: 1, AuthorID: authorID, UserID: authorID})
+
+		// assert
+		assert.NoError(t, err)
+		repo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+}
+
+func TestNotificationHandleEvent_PostCreated(t *testing.T) {
+	t.Run("NotifiesMentionedUsersOnce", func(t *testing.T) {
+		repo, userRepo, notificationService := setupTestNotificationService()
+		mentioned := &model.User{ID: likerID}
+		userRepo.On("FindByUsername", "alice").Return(mentioned, nil)
+		userRepo.On("FindByUsername", "ghost").Return(nil, apperrors.ErrNotFound)
+		repo.On("Create", mock.MatchedBy(func(n *model.Notification) bool {
+			return n.UserID == likerID && n.ActorID == authorID && n.Type == model.NotificationMention
+		})).Return(&model.Notification{ID: 1}, nil)
+
+		// run
+		err := notificationService.HandleEvent(events.PostCreated{
+			PostID:   1,
+			AuthorID: authorID,
+			Content:  "hello @alice and @ghost, again @alice (mail me at bob@example.com)",
+		})
+
+		// assert
+		assert.NoError(t, err)
+		repo.AssertNumberOfCalls(t, "Create", 1)
+		userRepo.AssertNotCalled(t, "FindByUsername", "example")
+		userRepo.AssertExpectations(t)
+	})
+
+	t.Run("MentionsTruncatedToConfiguredCap", func(t *testing.T) {
+		repo := mockRepository.NewNotificationRepositoryMock()
+		userRepo := mockRepository.NewUserRepositoryMock()
+		notificationService := service.NewNotificationServiceWithConfig(repo, userRepo, config.PostConfig{MaxMentions: 10})
+		userRepo.On("FindByUsername", mock.Anything).Return(&model.User{ID: likerID}, nil)
+		repo.On("Create", mock.Anything).Return(&model.Notification{ID: 1}, nil)
+
+		mentions := make([]string, 100)
+		for i := range mentions {
+			mentions[i] = fmt.Sprintf("@user%d", i)
+		}
+
+		// run
+		err := notificationService.HandleEvent(events.PostCreated{PostID: 1, AuthorID: authorID, Content: strings.Join(mentions, " ")})
+
+		// assert: only the first ten are looked up and notified
+		assert.NoError(t, err)
+		userRepo.AssertNumberOfCalls(t, "FindByUsername", 10)
+		userRepo.AssertCalled(t, "FindByUsername", "user9")
+		userRepo.AssertNotCalled(t, "FindByUsername", "user10")
+		repo.AssertNumberOfCalls(t, "Create", 10)
+	})
+
+	t.Run("SelfMentionIgnored", func(t *testing.T) {
+		repo, userRepo, notificationService := setupTestNotificationService()
+		userRepo.On("FindByUsername", "me").Return(&model.User{ID: authorID}, nil)
+
+		// run
+		err := notificationService.HandleEvent(events.PostCreated{PostID: 1, AuthorID: authorID, Content: "note to @me"})
+
+		// assert
+		assert.NoError(t, err)
+		repo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+}
+
+func TestNotificationList(t *testing.T) {
+	t.Run("AppliesDefaultsAndScopesToUser", func(t *testing.T) {
+		repo, _, notificationService := setupTestNotificationService()
+		notifications := []model.Notification{{ID: 2, UserID: authorID}, {ID: 1, UserID: authorID}}
+		repo.On("List", model.NotificationListOptions{UserID: authorID, Limit: 11}).
+			Return(notifications, nil)
+
+		// run
+		response, err := notificationService.List(authorID, model.NotificationListRequest{})
+
+		// assert
+		assert.NoError(t, err)
+		assert.Len(t, response.Data, 2)
+		assert.False(t, response.HasMore)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("PassesStatusAndCursor", func(t *testing.T) {
+		repo, _, notificationService := setupTestNotificationService()
+		unread := model.NotificationUnread
+		cursor := model.Cursor{ID: "7", CreatedAt: time.Date(2024, 1, 1, 8, 0, 0, 0, time.UTC)}
+		repo.On("List", model.NotificationListOptions{UserID: authorID, Status: &unread, Cursor: cursor, Limit: 3}).
+			Return([]model.Notification{{ID: 6}, {ID: 5}, {ID: 4}}, nil)
+
+		// run
+		response, err := notificationService.List(authorID, model.NotificationListRequest{
+			CursorRequest: model.CursorRequest{Limit: 2, Cursor: model.EncodeCursor(cursor)},
+			Status:        &unread,
+		})
+
+		// assert
+		assert.NoError(t, err)
+		assert.Len(t, response.Data, 2)
+		assert.True(t, response.HasMore)
+		next, err := model.DecodeCursor(response.Next)
+		assert.NoError(t, err)
+		assert.Equal(t, "5", next.ID)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("PassesTypeFilter", func(t *testing.T) {
+		repo, _, notificationService := setupTestNotificationService()
+		mention := model.NotificationMention
+		repo.On("List", model.NotificationListOptions{UserID: authorID, Type: &mention, Limit: 11}).
+			Return([]model.Notification{{ID: 1, Type: model.NotificationMention}}, nil)
+
+		// run
+		response, err := notificationService.List(authorID, model.NotificationListRequest{Type: &mention})
+
+		// assert
+		assert.NoError(t, err)
+		assert.Len(t, response.Data, 1)
+		repo.AssertExpectations(t)
+	})
+}
+
+func TestNotificationMarkRead(t *testing.T) {
+	t.Run("NotFound", func(t *testing.T) {
+		repo, _, notificationService := setupTestNotificationService()
+		repo.On("MarkRead", uint64(99), authorID).Return(apperrors.ErrNotFound)
+
+		// run
+		err := notificationService.MarkRead(99, authorID)
+
+		// assert
+		assert.ErrorIs(t, err, apperrors.ErrNotFound)
+	})
+}
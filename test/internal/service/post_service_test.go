@@ -1,16 +1,23 @@
 package service
 
 import (
+	"fmt"
+	"go-gin-api-server/config"
+	"go-gin-api-server/internal/events"
 	"go-gin-api-server/internal/model"
 	"go-gin-api-server/internal/service"
 	"go-gin-api-server/pkg/apperrors"
+	"go-gin-api-server/pkg/querybind"
 	mockRepository "go-gin-api-server/test/mocks/repository"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
 )
 
 // Helper functions
@@ -21,6 +28,18 @@ func setupTestPostService() (*mockRepository.PostRepositoryMock, service.PostSer
 	return mockRepo, service
 }
 
+// setupTestPostServiceWithEvents returns a service wired to a bus whose events are collected
+// into the returned slice; call bus.Close() to flush before asserting on it
+func setupTestPostServiceWithEvents() (*mockRepository.PostRepositoryMock, service.PostService, *events.Bus, *[]events.Event) {
+	mockRepo := mockRepository.NewPostRepositoryMock()
+	bus := events.NewBus(zap.NewNop())
+	received := &[]events.Event{}
+	bus.Subscribe(func(event events.Event) {
+		*received = append(*received, event)
+	}, events.TypePostCreated)
+	return mockRepo, service.NewPostServiceWithEvents(mockRepo, config.LoadTestConfig().Post, bus), bus, received
+}
+
 func createTestPost(overrides ...map[string]interface{}) *model.Post {
 	// Default
 	id := uint64(1)
@@ -68,6 +87,146 @@ var (
 
 // Testcases
 
+// mentionsContent mentions n distinct users
+func mentionsContent(n int) string {
+	mentions := make([]string, n)
+	for i := range mentions {
+		mentions[i] = fmt.Sprintf("@user%d", i)
+	}
+	return strings.Join(mentions, " ")
+}
+
+func TestCreatePostMentionCap(t *testing.T) {
+	newService := func(reject bool) (*mockRepository.PostRepositoryMock, service.PostService) {
+		repo := mockRepository.NewPostRepositoryMock()
+		return repo, service.NewPostServiceWithConfig(repo, config.PostConfig{
+			ContentMinLength:     10,
+			ContentMaxLength:     2000,
+			MaxMentions:          10,
+			RejectExcessMentions: reject,
+		})
+	}
+
+	t.Run("RejectMode", func(t *testing.T) {
+		repo, service := newService(true)
+
+		// run
+		_, err := service.Create(createTestPost(map[string]interface{}{"content": mentionsContent(100)}))
+
+		// assert
+		assert.ErrorIs(t, err, apperrors.ErrValidation)
+		assert.ErrorIs(t, err, apperrors.ErrPostTooManyMentions)
+		repo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+
+	t.Run("RejectModeAtLimit", func(t *testing.T) {
+		repo, service := newService(true)
+		post := createTestPost(map[string]interface{}{"content": mentionsContent(10)})
+		repo.On("Create", mock.Anything).Return(post, nil)
+
+		// run
+		_, err := service.Create(post)
+
+		// assert
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("TruncateMode", func(t *testing.T) {
+		repo, service := newService(false)
+		content := mentionsContent(100)
+		post := createTestPost(map[string]interface{}{"content": content})
+		// the post is stored as written, the notification fan-out does the truncation
+		repo.On("Create", mock.MatchedBy(func(p *model.Post) bool {
+			return p.Content == content
+		})).Return(post, nil)
+
+		// run
+		_, err := service.Create(post)
+
+		// assert
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+}
+
+func TestPreviewPost(t *testing.T) {
+	setup := func() (*mockRepository.PostRepositoryMock, *mockRepository.UserRepositoryMock, service.PostService) {
+		repo := mockRepository.NewPostRepositoryMock()
+		userRepo := mockRepository.NewUserRepositoryMock()
+		return repo, userRepo, service.NewPostServiceWithUsers(repo, userRepo, config.PostConfig{
+			ContentMinLength: 10,
+			ContentMaxLength: 255,
+			TitleMaxLength:   100,
+			MaxMentions:      10,
+		}, nil)
+	}
+
+	t.Run("MatchesCreate", func(t *testing.T) {
+		repo, userRepo, service := setup()
+		userRepo.On("FindByUsername", "alice").Return(&model.User{ID: likerID}, nil)
+		userRepo.On("FindByUsername", "ghost").Return(nil, apperrors.ErrNotFound)
+		var stored model.Post
+		repo.On("Create", mock.MatchedBy(func(p *model.Post) bool {
+			stored = *p
+			return true
+		})).Return(createTestPost(), nil)
+
+		title := "  Hello World  "
+		content := "  Hello @alice and @ghost, welcome!  "
+		newDraft := func() *model.Post {
+			draftTitle := title
+			return &model.Post{Title: &draftTitle, Content: content, AuthorID: authorID}
+		}
+
+		// run
+		preview, previewErr := service.Preview(newDraft())
+		_, createErr := service.Create(newDraft())
+
+		// assert: the preview shows exactly what Create stored
+		assert.NoError(t, previewErr)
+		assert.NoError(t, createErr)
+		assert.True(t, preview.Valid)
+		assert.Equal(t, stored.Content, preview.Content)
+		if assert.NotNil(t, preview.Title) && assert.NotNil(t, stored.Title) {
+			assert.Equal(t, *stored.Title, *preview.Title)
+		}
+		if assert.NotNil(t, stored.Slug) {
+			assert.Equal(t, *stored.Slug, preview.Slug)
+		}
+		assert.Equal(t, []model.MentionPreview{
+			{Username: "alice", UserID: likerID},
+			{Username: "ghost"},
+		}, preview.Mentions)
+		repo.AssertNumberOfCalls(t, "Create", 1)
+	})
+
+	t.Run("InvalidDraft", func(t *testing.T) {
+		repo, _, service := setup()
+
+		// run
+		preview, err := service.Preview(&model.Post{Content: "short"})
+
+		// assert: same error Create would return, nothing stored
+		assert.ErrorIs(t, err, apperrors.ErrPostContentTooShort)
+		assert.False(t, preview.Valid)
+		assert.Empty(t, preview.Slug)
+		assert.Equal(t, "short", preview.Content)
+		repo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+
+	t.Run("WithoutUserRepository", func(t *testing.T) {
+		_, service := setupTestPostService()
+
+		// run
+		preview, err := service.Preview(&model.Post{Content: "Hello @alice, welcome!"})
+
+		// assert: mentions are listed but not resolved
+		assert.NoError(t, err)
+		assert.Equal(t, []model.MentionPreview{{Username: "alice"}}, preview.Mentions)
+	})
+}
+
 func TestCreatePost(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
 		repo, service := setupTestPostService()
@@ -83,6 +242,142 @@ func TestCreatePost(t *testing.T) {
 		repo.AssertExpectations(t)
 	})
 
+	t.Run("WithTitle", func(t *testing.T) {
+		repo, service := setupTestPostService()
+		post := createTestPost()
+		title := "  My first title  "
+		post.Title = &title
+		repo.On("Create", mock.MatchedBy(func(p *model.Post) bool {
+			return p.Title != nil && *p.Title == "My first title"
+		})).Return(post, nil)
+
+		// run
+		_, err := service.Create(post)
+
+		// assert
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("WithoutTitle", func(t *testing.T) {
+		repo, service := setupTestPostService()
+		post := createTestPost()
+		repo.On("Create", mock.MatchedBy(func(p *model.Post) bool {
+			return p.Title == nil
+		})).Return(post, nil)
+
+		// run
+		_, err := service.Create(post)
+
+		// assert
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("InvalidTitle", func(t *testing.T) {
+		tests := []struct {
+			title    string
+			expected error
+		}{
+			{title: "   ", expected: apperrors.ErrPostTitleEmpty},
+			{title: strings.Repeat("a", 101), expected: apperrors.ErrPostTitleTooLong},
+			{title: "two\nlines", expected: apperrors.ErrPostTitleControlChars},
+		}
+
+		for _, tt := range tests {
+			repo, service := setupTestPostService()
+			post := createTestPost()
+			post.Title = &tt.title
+
+			// run
+			created, err := service.Create(post)
+
+			// assert
+			assert.ErrorIs(t, err, tt.expected)
+			assert.Nil(t, created)
+			repo.AssertNotCalled(t, "Create", mock.Anything)
+		}
+	})
+
+	t.Run("GeneratesSlug", func(t *testing.T) {
+		repo, service := setupTestPostService()
+		post := createTestPost(map[string]interface{}{"content": "Hello, World! My first post"})
+		repo.On("Create", mock.MatchedBy(func(p *model.Post) bool {
+			return p.Slug != nil && *p.Slug == "hello-world-my-first-post"
+		})).Return(post, nil)
+
+		// run
+		_, err := service.Create(post)
+
+		// assert
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("DefaultSlugForNonASCIIContent", func(t *testing.T) {
+		repo, service := setupTestPostService()
+		post := createTestPost(map[string]interface{}{"content": "這是一篇沒有英文字母的貼文"})
+		repo.On("Create", mock.MatchedBy(func(p *model.Post) bool {
+			return p.Slug != nil && *p.Slug == "post"
+		})).Return(post, nil)
+
+		// run
+		_, err := service.Create(post)
+
+		// assert
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("PublishesPostCreated", func(t *testing.T) {
+		repo, service, bus, received := setupTestPostServiceWithEvents()
+		expected := createTestPost()
+		repo.On("Create", mock.Anything).Return(expected, nil)
+
+		// run
+		_, err := service.Create(expected)
+		bus.Close()
+
+		// assert
+		assert.NoError(t, err)
+		if assert.Len(t, *received, 1) {
+			event := (*received)[0].(events.PostCreated)
+			assert.Equal(t, expected.ID, event.PostID)
+			assert.Equal(t, expected.AuthorID, event.AuthorID)
+		}
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("NoEventOnValidationFailure", func(t *testing.T) {
+		repo, service, bus, received := setupTestPostServiceWithEvents()
+		post := createTestPost(map[string]interface{}{
+			"content": "short",
+		})
+
+		// run
+		_, err := service.Create(post)
+		bus.Close()
+
+		// assert
+		assert.ErrorIs(t, err, apperrors.ErrPostContentTooShort)
+		assert.Empty(t, *received)
+		repo.AssertNotCalled(t, "Create")
+	})
+
+	t.Run("NoEventOnRepositoryError", func(t *testing.T) {
+		repo, service, bus, received := setupTestPostServiceWithEvents()
+		post := createTestPost()
+		repo.On("Create", mock.Anything).Return(nil, assert.AnError)
+
+		// run
+		_, err := service.Create(post)
+		bus.Close()
+
+		// assert
+		assert.Error(t, err)
+		assert.Empty(t, *received)
+	})
+
 	t.Run("Content too short", func(t *testing.T) {
 		_, service := setupTestPostService()
 		post := createTestPost(map[string]interface{}{
@@ -112,6 +407,44 @@ func TestCreatePost(t *testing.T) {
 		_, err := service.Create(post)
 		assert.ErrorIs(t, err, apperrors.ErrPostContentSensitiveWords)
 	})
+
+	t.Run("FailFastByDefault", func(t *testing.T) {
+		_, service := setupTestPostService()
+		post := createTestPost(map[string]interface{}{
+			"content": "violence", // too short and contains sensitive words
+		})
+
+		_, err := service.Create(post)
+		assert.ErrorIs(t, err, apperrors.ErrPostContentTooShort)
+		assert.NotErrorIs(t, err, apperrors.ErrPostContentSensitiveWords)
+	})
+
+	t.Run("CollectValidationErrors", func(t *testing.T) {
+		repo := mockRepository.NewPostRepositoryMock()
+		service := service.NewPostServiceWithConfig(repo, config.PostConfig{
+			ContentMinLength:        10,
+			ContentMaxLength:        255,
+			TitleMaxLength:          100,
+			CollectValidationErrors: true,
+		})
+		title := "   "
+		post := createTestPost(map[string]interface{}{
+			"content": "violence", // too short and contains sensitive words
+		})
+		post.Title = &title
+
+		_, err := service.Create(post)
+
+		var validationErrs *apperrors.ValidationErrors
+		assert.ErrorAs(t, err, &validationErrs)
+		assert.Equal(t, []error{
+			apperrors.ErrPostContentTooShort,
+			apperrors.ErrPostContentSensitiveWords,
+			apperrors.ErrPostTitleEmpty,
+		}, validationErrs.Errs)
+		assert.ErrorIs(t, err, apperrors.ErrValidation)
+		repo.AssertNotCalled(t, "Create", mock.Anything)
+	})
 }
 
 func TestUpdatePost(t *testing.T) {
@@ -123,6 +456,7 @@ func TestUpdatePost(t *testing.T) {
 			"updated_at": created.CreatedAt.Add(time.Second),
 		})
 		repo.On("CheckPermission", mock.Anything, mock.Anything).Return(nil)
+		repo.On("FindByID", created.ID).Return(created, nil)
 		repo.On("Update", mock.Anything, mock.Anything).Return(expected, nil)
 
 		// run
@@ -138,6 +472,151 @@ func TestUpdatePost(t *testing.T) {
 		repo.AssertExpectations(t)
 	})
 
+	t.Run("IfMatchCurrentVersion", func(t *testing.T) {
+		repo, service := setupTestPostService()
+		current := createTestPost()
+		expected := createTestPost(map[string]interface{}{"content": "Updated Content"})
+		repo.On("CheckPermission", current.ID, authorID).Return(nil)
+		repo.On("FindByID", current.ID).Return(current, nil)
+		repo.On("UpdateIfMatch", current.ID, mock.Anything, current.ETag()).Return(expected, nil)
+
+		// run
+		updated, err := service.UpdateIfMatch(current.ID, &model.Post{Content: "Updated Content"}, authorID, current.ETag())
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, expected, updated)
+		repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("IfMatchStaleVersion", func(t *testing.T) {
+		repo, service := setupTestPostService()
+		current := createTestPost()
+		repo.On("CheckPermission", current.ID, authorID).Return(nil)
+		repo.On("FindByID", current.ID).Return(current, nil)
+		// another edit landed after FindByID, only the locked row in the write can tell
+		repo.On("UpdateIfMatch", current.ID, mock.Anything, current.ETag()).Return(nil, apperrors.ErrPreconditionFailed)
+
+		// run
+		_, err := service.UpdateIfMatch(current.ID, &model.Post{Content: "Updated Content"}, authorID, current.ETag())
+
+		// assert
+		assert.ErrorIs(t, err, apperrors.ErrPreconditionFailed)
+		repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("IfMatchStaleVersionNoOp", func(t *testing.T) {
+		repo, service := setupTestPostService()
+		current := createTestPost()
+		stale := createTestPost(map[string]interface{}{"updated_at": current.UpdatedAt.Add(-time.Second)})
+		repo.On("CheckPermission", current.ID, authorID).Return(nil)
+		repo.On("FindByID", current.ID).Return(current, nil)
+
+		// run: nothing changes, so no write checks the tag
+		_, err := service.UpdateIfMatch(current.ID, &model.Post{Content: current.Content}, authorID, stale.ETag())
+
+		// assert
+		assert.ErrorIs(t, err, apperrors.ErrPreconditionFailed)
+		repo.AssertNotCalled(t, "UpdateIfMatch", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("RequireIfMatch", func(t *testing.T) {
+		repo := mockRepository.NewPostRepositoryMock()
+		service := service.NewPostServiceWithConfig(repo, config.PostConfig{
+			ContentMinLength: 10,
+			ContentMaxLength: 255,
+			RequireIfMatch:   true,
+		})
+		current := createTestPost()
+		repo.On("CheckPermission", current.ID, authorID).Return(nil)
+		repo.On("FindByID", current.ID).Return(current, nil)
+		repo.On("UpdateIfMatch", current.ID, mock.Anything, current.ETag()).Return(current, nil)
+
+		// run: without a header the update is refused, with the current ETag it goes through
+		_, missingErr := service.Update(current.ID, &model.Post{Content: "Updated Content"}, authorID)
+		_, matchErr := service.UpdateIfMatch(current.ID, &model.Post{Content: "Updated Content"}, authorID, current.ETag())
+
+		// assert
+		assert.ErrorIs(t, missingErr, apperrors.ErrPreconditionRequired)
+		assert.NoError(t, matchErr)
+		repo.AssertNumberOfCalls(t, "UpdateIfMatch", 1)
+		repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("SlugIsNotUpdatable", func(t *testing.T) {
+		repo, service := setupTestPostService()
+		current := createTestPost()
+		slug := "new-slug"
+		updated := createTestPost(map[string]interface{}{"content": "Updated Content"})
+		updated.Slug = &slug
+		repo.On("CheckPermission", current.ID, authorID).Return(nil)
+		repo.On("FindByID", current.ID).Return(current, nil)
+		repo.On("Update", current.ID, mock.MatchedBy(func(p *model.Post) bool {
+			return p.Slug == nil
+		})).Return(updated, nil)
+
+		// run
+		_, err := service.Update(current.ID, updated, authorID)
+
+		// assert
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("IdenticalContentNoOp", func(t *testing.T) {
+		repo, service := setupTestPostService()
+		current := createTestPost()
+		unchanged := createTestPost(map[string]interface{}{
+			"content": "  " + current.Content + "\n", // same after normalization
+		})
+		repo.On("CheckPermission", current.ID, authorID).Return(nil)
+		repo.On("FindByID", current.ID).Return(current, nil)
+
+		// run
+		updated, err := service.Update(current.ID, unchanged, authorID)
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, current, updated)
+		repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("TitleOnly", func(t *testing.T) {
+		repo, service := setupTestPostService()
+		current := createTestPost()
+		title := "New title"
+		repo.On("CheckPermission", current.ID, authorID).Return(nil)
+		repo.On("FindByID", current.ID).Return(current, nil)
+		repo.On("Update", current.ID, mock.MatchedBy(func(p *model.Post) bool {
+			// content is omitted so the struct update leaves it alone
+			return p.Content == "" && p.Title != nil && *p.Title == title
+		})).Return(current, nil)
+
+		// run
+		_, err := service.Update(current.ID, &model.Post{Title: &title}, authorID)
+
+		// assert
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		repo, service := setupTestPostService()
+		updated := createTestPost(map[string]interface{}{
+			"content": "Updated Content",
+		})
+		repo.On("CheckPermission", NonExistentPostID, authorID).Return(nil)
+		repo.On("FindByID", NonExistentPostID).Return(nil, apperrors.ErrNotFound)
+
+		// run
+		_, err := service.Update(NonExistentPostID, updated, authorID)
+
+		// assert
+		assert.ErrorIs(t, err, apperrors.ErrNotFound)
+		repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
 	t.Run("ErrorForbidden", func(t *testing.T) {
 		repo, service := setupTestPostService()
 		created := createTestPost()
@@ -202,67 +681,649 @@ func TestUpdatePost(t *testing.T) {
 	})
 }
 
-func TestDeletePost(t *testing.T) {
+func TestReplacePost(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
 		repo, service := setupTestPostService()
-		created := createTestPost()
-		repo.On("CheckPermission", mock.Anything, mock.Anything).Return(nil)
-		repo.On("Delete", mock.Anything).Return(nil)
+		current := createTestPost()
+		replaced := createTestPost(map[string]interface{}{"content": "Replaced Content"})
+		repo.On("CheckPermission", current.ID, authorID).Return(nil)
+		repo.On("FindByID", current.ID).Return(current, nil)
+		repo.On("Replace", current.ID, mock.MatchedBy(func(p *model.Post) bool {
+			// only editable fields reach the repository
+			return p.Content == "Replaced Content" && p.Slug == nil && p.AuthorID == "" && !p.Hidden
+		})).Return(replaced, nil)
+
+		// run
+		result, err := service.Replace(current.ID, replaced, authorID)
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, replaced.Content, result.Content)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("IfMatchPassedToRepository", func(t *testing.T) {
+		repo, service := setupTestPostService()
+		current := createTestPost()
+		repo.On("CheckPermission", current.ID, authorID).Return(nil)
+		repo.On("FindByID", current.ID).Return(current, nil)
+		repo.On("ReplaceIfMatch", current.ID, mock.Anything, current.ETag()).Return(nil, apperrors.ErrPreconditionFailed)
+
+		// run
+		_, err := service.ReplaceIfMatch(current.ID, &model.Post{Content: "Replaced Content"}, authorID, current.ETag())
+
+		// assert
+		assert.ErrorIs(t, err, apperrors.ErrPreconditionFailed)
+		repo.AssertNotCalled(t, "Replace", mock.Anything, mock.Anything)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("RequireIfMatch", func(t *testing.T) {
+		repo := mockRepository.NewPostRepositoryMock()
+		service := service.NewPostServiceWithConfig(repo, config.PostConfig{
+			ContentMinLength: 10,
+			ContentMaxLength: 255,
+			RequireIfMatch:   true,
+		})
+		current := createTestPost()
+
+		// run
+		_, err := service.Replace(current.ID, &model.Post{Content: "Replaced Content"}, authorID)
+
+		// assert
+		assert.ErrorIs(t, err, apperrors.ErrPreconditionRequired)
+		repo.AssertNotCalled(t, "Replace", mock.Anything, mock.Anything)
+	})
+
+	t.Run("ContentRequired", func(t *testing.T) {
+		repo, service := setupTestPostService()
+		current := createTestPost()
+		repo.On("CheckPermission", current.ID, authorID).Return(nil)
+
+		// run: an empty content is not "omitted" for a full replacement
+		_, err := service.Replace(current.ID, &model.Post{}, authorID)
+
+		// assert
+		assert.ErrorIs(t, err, apperrors.ErrPostContentTooShort)
+		repo.AssertNotCalled(t, "Replace", mock.Anything, mock.Anything)
+	})
+
+	t.Run("IdenticalContentNoOp", func(t *testing.T) {
+		repo, service := setupTestPostService()
+		current := createTestPost()
+		repo.On("CheckPermission", current.ID, authorID).Return(nil)
+		repo.On("FindByID", current.ID).Return(current, nil)
+
+		// run
+		result, err := service.Replace(current.ID, &model.Post{Content: current.Content}, authorID)
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, current, result)
+		repo.AssertNotCalled(t, "Replace", mock.Anything, mock.Anything)
+	})
+
+	t.Run("OmittedTitleClearsIt", func(t *testing.T) {
+		repo, service := setupTestPostService()
+		current := createTestPost()
+		title := "Old title"
+		current.Title = &title
+		repo.On("CheckPermission", current.ID, authorID).Return(nil)
+		repo.On("FindByID", current.ID).Return(current, nil)
+		repo.On("Replace", current.ID, mock.MatchedBy(func(p *model.Post) bool {
+			return p.Title == nil && p.Content == current.Content
+		})).Return(current, nil)
+
+		// run: same content, but the title is gone
+		_, err := service.Replace(current.ID, &model.Post{Content: current.Content}, authorID)
+
+		// assert
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("ErrorForbidden", func(t *testing.T) {
+		repo, service := setupTestPostService()
+		current := createTestPost()
+		repo.On("CheckPermission", mock.Anything, mock.Anything).Return(apperrors.ErrForbidden)
+
+		// run
+		result, err := service.Replace(current.ID, current, authorID)
+
+		// assert
+		assert.ErrorIs(t, err, apperrors.ErrForbidden)
+		assert.Nil(t, result)
+		repo.AssertNotCalled(t, "Replace", mock.Anything, mock.Anything)
+	})
+}
+
+func TestDeletePost(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		repo, service := setupTestPostService()
+		created := createTestPost()
+		repo.On("CheckPermission", mock.Anything, mock.Anything).Return(nil)
+		repo.On("Delete", mock.Anything).Return(nil)
+
+		// run
+		err := service.Delete(created.ID, created.AuthorID)
+
+		// assert
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("ErrorForbidden", func(t *testing.T) {
+		repo, service := setupTestPostService()
+		created := createTestPost()
+		repo.On("CheckPermission", mock.Anything, mock.Anything).Return(apperrors.ErrForbidden)
+		repo.On("Delete", mock.Anything).Return(nil)
+
+		// run
+		err := service.Delete(created.ID, created.AuthorID)
+
+		// assert
+		assert.ErrorIs(t, err, apperrors.ErrForbidden)
+		repo.AssertNotCalled(t, "Delete")
+	})
+
+	t.Run("ErrorNotFound", func(t *testing.T) {
+		repo, service := setupTestPostService()
+		repo.On("CheckPermission", NonExistentPostID, authorID).Return(apperrors.ErrNotFound)
+
+		// run
+		err := service.Delete(NonExistentPostID, authorID)
+
+		// assert
+		assert.ErrorIs(t, err, apperrors.ErrNotFound)
+		repo.AssertNotCalled(t, "Delete", mock.Anything)
+	})
+}
+
+func TestGetPostByID(t *testing.T) {
+	t.Run("WithAuthor", func(t *testing.T) {
+		repo, service := setupTestPostService()
+		created := createTestPost()
+		username := "author"
+		created.Author = &model.User{ID: authorID, Name: "Author", Username: &username}
+		repo.On("FindByID", created.ID).Return(created, nil)
+
+		// run
+		found, err := service.GetByID(created.ID, "", "")
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, "Author", found.Author.Name)
+		assert.Equal(t, &username, found.Author.Username)
+	})
+
+	t.Run("MissingAuthorPlaceholder", func(t *testing.T) {
+		repo, service := setupTestPostService()
+		created := createTestPost()
+		created.Author = nil
+		repo.On("FindByID", created.ID).Return(created, nil)
+
+		// run
+		found, err := service.GetByID(created.ID, "", "")
+
+		// assert
+		assert.NoError(t, err)
+		if assert.NotNil(t, found.Author) {
+			assert.Equal(t, created.AuthorID, found.Author.ID)
+			assert.Equal(t, model.DeletedAuthorName, found.Author.Name)
+			assert.Nil(t, found.Author.Username)
+		}
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		repo, service := setupTestPostService()
+		created := createTestPost()
+		repo.On("FindByID", mock.Anything).Return(created, nil)
+
+		// run
+		found, err := service.GetByID(created.ID, "", "")
+
+		// assert
+		assert.NoError(t, err)
+		assert.NotNil(t, found)
+		assert.Equal(t, created.ID, found.Post.ID)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("ErrorNotFound", func(t *testing.T) {
+		repo, service := setupTestPostService()
+		repo.On("FindByID", mock.Anything).Return(nil, apperrors.ErrNotFound)
+
+		// run
+		found, err := service.GetByID(NonExistentPostID, "", "")
+
+		// assert
+		assert.ErrorIs(t, err, apperrors.ErrNotFound)
+		assert.Nil(t, found)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("HiddenNotFoundForPublic", func(t *testing.T) {
+		repo, service := setupTestPostService()
+		hidden := createTestPost()
+		hidden.Hidden = true
+		repo.On("FindByID", hidden.ID).Return(hidden, nil)
+
+		// run: anonymous viewer and an unrelated user
+		anonymous, anonErr := service.GetByID(hidden.ID, "", "")
+		other, otherErr := service.GetByID(hidden.ID, "other-user-id", model.RoleUser)
+
+		// assert
+		assert.ErrorIs(t, anonErr, apperrors.ErrNotFound)
+		assert.Nil(t, anonymous)
+		assert.ErrorIs(t, otherErr, apperrors.ErrNotFound)
+		assert.Nil(t, other)
+	})
+
+	t.Run("HiddenVisibleToAuthorWithWarning", func(t *testing.T) {
+		repo, service := setupTestPostService()
+		hidden := createTestPost()
+		hidden.Hidden = true
+		repo.On("FindByID", hidden.ID).Return(hidden, nil)
+
+		// run
+		found, err := service.GetByID(hidden.ID, hidden.AuthorID, model.RoleUser)
+
+		// assert
+		assert.NoError(t, err)
+		assert.True(t, found.Hidden)
+		assert.Equal(t, model.HiddenPostWarning, found.Warning)
+	})
+
+	t.Run("HiddenVisibleToModeratorWithWarning", func(t *testing.T) {
+		repo, service := setupTestPostService()
+		hidden := createTestPost()
+		hidden.Hidden = true
+		repo.On("FindByID", hidden.ID).Return(hidden, nil)
+
+		// run
+		found, err := service.GetByID(hidden.ID, "moderator-id", model.RoleModerator)
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, model.HiddenPostWarning, found.Warning)
+	})
+
+	t.Run("ArchivedVisibleOnlyToAuthor", func(t *testing.T) {
+		repo, service := setupTestPostService()
+		archived := createTestPost()
+		archived.Archived = true
+		repo.On("FindByID", archived.ID).Return(archived, nil)
+
+		// run
+		other, otherErr := service.GetByID(archived.ID, "other-user-id", model.RoleUser)
+		own, ownErr := service.GetByID(archived.ID, archived.AuthorID, model.RoleUser)
+
+		// assert
+		assert.ErrorIs(t, otherErr, apperrors.ErrNotFound)
+		assert.Nil(t, other)
+		assert.NoError(t, ownErr)
+		assert.True(t, own.Archived)
+	})
+
+	t.Run("VisiblePostHasNoWarning", func(t *testing.T) {
+		repo, service := setupTestPostService()
+		post := createTestPost()
+		repo.On("FindByID", post.ID).Return(post, nil)
+
+		// run
+		found, err := service.GetByID(post.ID, "", "")
+
+		// assert
+		assert.NoError(t, err)
+		assert.Empty(t, found.Warning)
+	})
+}
+
+func TestGetPostBySlug(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		repo, service := setupTestPostService()
+		post := createTestPost()
+		repo.On("FindBySlug", "test-content").Return(post, nil)
+
+		// run
+		found, err := service.GetBySlug("test-content", "", "")
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, post.ID, found.ID)
+	})
+
+	t.Run("HiddenNotFoundForPublic", func(t *testing.T) {
+		repo, service := setupTestPostService()
+		post := createTestPost()
+		post.Hidden = true
+		repo.On("FindBySlug", "test-content").Return(post, nil)
+
+		// run
+		_, err := service.GetBySlug("test-content", "", "")
+
+		// assert
+		assert.ErrorIs(t, err, apperrors.ErrNotFound)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		repo, service := setupTestPostService()
+		repo.On("FindBySlug", "missing").Return(nil, apperrors.ErrNotFound)
+
+		// run
+		_, err := service.GetBySlug("missing", "", "")
+
+		// assert
+		assert.ErrorIs(t, err, apperrors.ErrNotFound)
+	})
+}
+
+func TestSetPostHidden(t *testing.T) {
+	t.Run("ModeratorCanHide", func(t *testing.T) {
+		repo, service := setupTestPostService()
+		hidden := createTestPost()
+		hidden.Hidden = true
+		repo.On("SetHidden", hidden.ID, true).Return(hidden, nil)
+
+		// run
+		updated, err := service.SetHidden(hidden.ID, true, model.RoleModerator)
+
+		// assert
+		assert.NoError(t, err)
+		assert.True(t, updated.Hidden)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("AdminCanUnhide", func(t *testing.T) {
+		repo, service := setupTestPostService()
+		post := createTestPost()
+		repo.On("SetHidden", post.ID, false).Return(post, nil)
+
+		// run
+		updated, err := service.SetHidden(post.ID, false, model.RoleAdmin)
+
+		// assert
+		assert.NoError(t, err)
+		assert.False(t, updated.Hidden)
+	})
+
+	t.Run("RegularUserForbidden", func(t *testing.T) {
+		repo, service := setupTestPostService()
+
+		// run
+		updated, err := service.SetHidden(1, true, model.RoleUser)
+
+		// assert
+		assert.ErrorIs(t, err, apperrors.ErrForbidden)
+		assert.Nil(t, updated)
+		repo.AssertNotCalled(t, "SetHidden", mock.Anything, mock.Anything)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		repo, service := setupTestPostService()
+		repo.On("SetHidden", NonExistentPostID, true).Return(nil, apperrors.ErrNotFound)
+
+		// run
+		_, err := service.SetHidden(NonExistentPostID, true, model.RoleModerator)
+
+		// assert
+		assert.ErrorIs(t, err, apperrors.ErrNotFound)
+	})
+}
+
+func TestValidatePostContent(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		repo, service := setupTestPostService()
+
+		// run
+		errs := service.ValidateContent("A perfectly normal post")
+
+		// assert
+		assert.Empty(t, errs)
+		repo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+
+	t.Run("TooShort", func(t *testing.T) {
+		_, service := setupTestPostService()
+
+		// run
+		errs := service.ValidateContent("short")
+
+		// assert
+		assert.Equal(t, []error{apperrors.ErrPostContentTooShort}, errs)
+	})
+
+	t.Run("SensitiveWords", func(t *testing.T) {
+		_, service := setupTestPostService()
+
+		// run
+		errs := service.ValidateContent("This post is about violence")
+
+		// assert
+		assert.Equal(t, []error{apperrors.ErrPostContentSensitiveWords}, errs)
+	})
+
+	t.Run("ControlChars", func(t *testing.T) {
+		_, service := setupTestPostService()
+
+		// run
+		errs := service.ValidateContent("Line one\nline two\x00")
+
+		// assert
+		assert.Equal(t, []error{apperrors.ErrPostContentControlChars}, errs)
+	})
+
+	t.Run("ReportsAllFailures", func(t *testing.T) {
+		_, service := setupTestPostService()
+
+		// run
+		errs := service.ValidateContent("violence")
+
+		// assert
+		assert.Equal(t, []error{apperrors.ErrPostContentTooShort, apperrors.ErrPostContentSensitiveWords}, errs)
+	})
+
+	t.Run("ConfiguredLengthLimits", func(t *testing.T) {
+		repo := mockRepository.NewPostRepositoryMock()
+		service := service.NewPostServiceWithConfig(repo, config.PostConfig{ContentMinLength: 3, ContentMaxLength: 8})
+
+		// run / assert
+		assert.Empty(t, service.ValidateContent("short"))
+		assert.Equal(t, []error{apperrors.ErrPostContentTooLong}, service.ValidateContent("much too long"))
+		assert.Equal(t, model.PostLimitsResponse{ContentMin: 3, ContentMax: 8}, service.Limits())
+	})
+
+	t.Run("CreateRejectsControlChars", func(t *testing.T) {
+		repo, service := setupTestPostService()
+		post := createTestPost(map[string]interface{}{"content": "Hello\x07 world, ring the bell"})
+
+		// run
+		_, err := service.Create(post)
+
+		// assert
+		assert.ErrorIs(t, err, apperrors.ErrPostContentControlChars)
+		repo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+}
+
+func TestListPostRevisions(t *testing.T) {
+	revisions := []model.PostRevision{
+		{ID: 1, PostID: 1, Content: "Old Content", CreatedAt: time.Now()},
+	}
+
+	t.Run("AuthorCanView", func(t *testing.T) {
+		repo, service := setupTestPostService()
+		post := createTestPost()
+		repo.On("FindByID", post.ID).Return(post, nil)
+		repo.On("ListRevisions", post.ID).Return(revisions, nil)
+
+		// run
+		found, err := service.ListRevisions(post.ID, authorID, model.RoleUser)
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, revisions, found)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("ModeratorCanView", func(t *testing.T) {
+		repo, service := setupTestPostService()
+		post := createTestPost()
+		repo.On("FindByID", post.ID).Return(post, nil)
+		repo.On("ListRevisions", post.ID).Return(revisions, nil)
+
+		// run
+		found, err := service.ListRevisions(post.ID, "moderator-id", model.RoleModerator)
+
+		// assert
+		assert.NoError(t, err)
+		assert.Len(t, found, 1)
+	})
+
+	t.Run("OtherUserForbidden", func(t *testing.T) {
+		repo, service := setupTestPostService()
+		post := createTestPost()
+		repo.On("FindByID", post.ID).Return(post, nil)
+
+		// run
+		found, err := service.ListRevisions(post.ID, "other-user-id", model.RoleUser)
+
+		// assert
+		assert.ErrorIs(t, err, apperrors.ErrForbidden)
+		assert.Nil(t, found)
+		repo.AssertNotCalled(t, "ListRevisions", mock.Anything)
+	})
+
+	t.Run("HiddenPostNotFoundForOtherUser", func(t *testing.T) {
+		repo, service := setupTestPostService()
+		post := createTestPost()
+		post.Hidden = true
+		repo.On("FindByID", post.ID).Return(post, nil)
+
+		// run
+		found, err := service.ListRevisions(post.ID, "other-user-id", model.RoleUser)
+
+		// assert: same as GetByID, a 403 would confirm the hidden post exists
+		assert.ErrorIs(t, err, apperrors.ErrNotFound)
+		assert.Nil(t, found)
+		repo.AssertNotCalled(t, "ListRevisions", mock.Anything)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		repo, service := setupTestPostService()
+		repo.On("FindByID", NonExistentPostID).Return(nil, apperrors.ErrNotFound)
+
+		// run
+		_, err := service.ListRevisions(NonExistentPostID, authorID, model.RoleUser)
+
+		// assert
+		assert.ErrorIs(t, err, apperrors.ErrNotFound)
+	})
+}
+
+func TestAdminDeletePost(t *testing.T) {
+	const actorID = "moderator-e29b-41d4-a716-446655440000"
+	isAuditEntry := func(id uint64) interface{} {
+		return mock.MatchedBy(func(entry *model.AuditLog) bool {
+			return entry.ActorID == actorID &&
+				entry.Action == model.AuditActionPostDelete &&
+				entry.TargetType == model.AuditTargetPost &&
+				entry.TargetID == strconv.FormatUint(id, 10)
+		})
+	}
+
+	t.Run("AdminSuccess", func(t *testing.T) {
+		repo, service := setupTestPostService()
+		repo.On("DeleteWithAudit", uint64(1), isAuditEntry(1)).Return(nil)
+
+		// run
+		err := service.AdminDelete(1, actorID, model.RoleAdmin)
+
+		// assert
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+		repo.AssertNotCalled(t, "CheckPermission", mock.Anything, mock.Anything)
+	})
+
+	t.Run("ModeratorSuccess", func(t *testing.T) {
+		repo, service := setupTestPostService()
+		repo.On("DeleteWithAudit", uint64(1), isAuditEntry(1)).Return(nil)
 
 		// run
-		err := service.Delete(created.ID, created.AuthorID)
+		err := service.AdminDelete(1, actorID, model.RoleModerator)
 
 		// assert
 		assert.NoError(t, err)
 		repo.AssertExpectations(t)
 	})
 
-	t.Run("ErrorForbidden", func(t *testing.T) {
+	t.Run("RegularUserForbidden", func(t *testing.T) {
 		repo, service := setupTestPostService()
-		created := createTestPost()
-		repo.On("CheckPermission", mock.Anything, mock.Anything).Return(apperrors.ErrForbidden)
-		repo.On("Delete", mock.Anything).Return(nil)
 
 		// run
-		err := service.Delete(created.ID, created.AuthorID)
+		err := service.AdminDelete(1, actorID, model.RoleUser)
 
 		// assert
 		assert.ErrorIs(t, err, apperrors.ErrForbidden)
-		repo.AssertNotCalled(t, "Delete")
+		repo.AssertNotCalled(t, "DeleteWithAudit", mock.Anything, mock.Anything)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		repo, service := setupTestPostService()
+		repo.On("DeleteWithAudit", NonExistentPostID, mock.Anything).Return(apperrors.ErrNotFound)
+
+		// run
+		err := service.AdminDelete(NonExistentPostID, actorID, model.RoleAdmin)
+
+		// assert
+		assert.ErrorIs(t, err, apperrors.ErrNotFound)
 	})
 }
 
-func TestGetPostByID(t *testing.T) {
-	t.Run("Success", func(t *testing.T) {
+func TestListPosts(t *testing.T) {
+	t.Run("Passes viewer for blocks", func(t *testing.T) {
 		repo, service := setupTestPostService()
-		created := createTestPost()
-		repo.On("FindByID", mock.Anything).Return(created, nil)
+		repo.On("List", model.PostListOptions{Limit: 11, ViewerID: testUserID}).Return([]model.Post{}, nil)
 
 		// run
-		found, err := service.GetByID(created.ID)
+		_, err := service.List(model.PostListRequest{
+			CursorRequest: model.CursorRequest{Limit: 10},
+			ViewerID:      testUserID,
+		})
 
 		// assert
 		assert.NoError(t, err)
-		assert.NotNil(t, found)
-		assert.Equal(t, created.ID, found.Post.ID)
 		repo.AssertExpectations(t)
 	})
 
-	t.Run("ErrorNotFound", func(t *testing.T) {
+	t.Run("Passes sort and date range", func(t *testing.T) {
 		repo, service := setupTestPostService()
-		repo.On("FindByID", mock.Anything).Return(nil, apperrors.ErrNotFound)
+		after := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		before := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+		expectedOpts := model.PostListOptions{
+			Limit:         11,
+			Ascending:     true,
+			CreatedAfter:  &after,
+			CreatedBefore: &before,
+		}
+		repo.On("List", expectedOpts).Return([]model.Post{}, nil)
 
 		// run
-		found, err := service.GetByID(NonExistentPostID)
+		_, err := service.List(model.PostListRequest{
+			CursorRequest: model.CursorRequest{Limit: 10},
+			Query: querybind.Query{
+				Sort:          "created_at",
+				Order:         querybind.Asc,
+				CreatedAfter:  &after,
+				CreatedBefore: &before,
+			},
+		})
 
 		// assert
-		assert.ErrorIs(t, err, apperrors.ErrNotFound)
-		assert.Nil(t, found)
+		assert.NoError(t, err)
 		repo.AssertExpectations(t)
 	})
-}
 
-func TestListPosts(t *testing.T) {
 	t.Run("Valid limit with no results", func(t *testing.T) {
 		repo, service := setupTestPostService()
 		expectedOpts := model.PostListOptions{
@@ -279,7 +1340,7 @@ func TestListPosts(t *testing.T) {
 		}
 
 		// run
-		result, err := service.List(request)
+		result, err := service.List(model.PostListRequest{CursorRequest: request})
 
 		// assert
 		assert.NoError(t, err)
@@ -323,7 +1384,7 @@ func TestListPosts(t *testing.T) {
 		}
 
 		// run
-		result, err := service.List(request)
+		result, err := service.List(model.PostListRequest{CursorRequest: request})
 
 		// assert
 		assert.NoError(t, err)
@@ -390,7 +1451,7 @@ func TestListPosts(t *testing.T) {
 		}
 
 		// run
-		result, err := service.List(request)
+		result, err := service.List(model.PostListRequest{CursorRequest: request})
 
 		// assert
 		assert.NoError(t, err)
@@ -407,6 +1468,24 @@ func TestListPosts(t *testing.T) {
 		repo.AssertExpectations(t)
 	})
 
+	t.Run("Cursor page emptied by deletion", func(t *testing.T) {
+		repo, service := setupTestPostService()
+		// the look-ahead row reported by the previous page was deleted in between
+		repo.On("List", mock.Anything).Return([]model.Post{}, nil)
+
+		request := model.CursorRequest{
+			Cursor: model.EncodeCursor(model.Cursor{ID: "5", CreatedAt: time.Now()}),
+			Limit:  2,
+		}
+		result, err := service.List(model.PostListRequest{CursorRequest: request})
+
+		assert.NoError(t, err)
+		assert.NotNil(t, result.Data)
+		assert.Empty(t, result.Data)
+		assert.False(t, result.HasMore)
+		assert.Empty(t, result.Next)
+	})
+
 	t.Run("Invalid cursor", func(t *testing.T) {
 		_, service := setupTestPostService()
 		request := model.CursorRequest{
@@ -414,7 +1493,7 @@ func TestListPosts(t *testing.T) {
 			Limit:    10,
 			AuthorID: nil,
 		}
-		_, err := service.List(request)
+		_, err := service.List(model.PostListRequest{CursorRequest: request})
 		assert.ErrorIs(t, err, apperrors.ErrValidation)
 	})
 
@@ -434,7 +1513,7 @@ func TestListPosts(t *testing.T) {
 			AuthorID: nil,
 		}
 
-		result, err := service.List(request)
+		result, err := service.List(model.PostListRequest{CursorRequest: request})
 		assert.NoError(t, err)
 		assert.NotNil(t, result)
 		repo.AssertExpectations(t)
@@ -456,9 +1535,517 @@ func TestListPosts(t *testing.T) {
 			AuthorID: nil,
 		}
 
-		result, err := service.List(request)
+		result, err := service.List(model.PostListRequest{CursorRequest: request})
 		assert.NoError(t, err)
 		assert.NotNil(t, result)
 		repo.AssertExpectations(t)
 	})
+
+	t.Run("SearchIsTrimmed", func(t *testing.T) {
+		repo, service := setupTestPostService()
+		repo.On("List", mock.MatchedBy(func(opts model.PostListOptions) bool {
+			return opts.Search == "golang"
+		})).Return([]model.Post{}, nil)
+
+		// run
+		_, err := service.List(model.PostListRequest{Search: "  golang "})
+
+		// assert
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("SearchResultsCarryPreview", func(t *testing.T) {
+		repo := mockRepository.NewPostRepositoryMock()
+		service := service.NewPostServiceWithConfig(repo, config.PostConfig{PreviewLength: 12})
+		repo.On("List", mock.Anything).Return([]model.Post{
+			{ID: 2, Content: "Learning golang generics today", Author: &model.User{ID: authorID}},
+			{ID: 1, Content: "golang rocks", Author: &model.User{ID: authorID}},
+		}, nil)
+
+		// run
+		result, err := service.List(model.PostListRequest{Search: "golang"})
+
+		// assert
+		assert.NoError(t, err)
+		if assert.Len(t, result.Data, 2) {
+			assert.Equal(t, "Learning…", result.Data[0].ContentPreview)
+			assert.Equal(t, "golang rocks", result.Data[1].ContentPreview)
+		}
+	})
+
+	t.Run("NoPreviewWithoutSearch", func(t *testing.T) {
+		repo, service := setupTestPostService()
+		repo.On("List", mock.Anything).Return([]model.Post{
+			{ID: 1, Content: "Learning golang generics today", Author: &model.User{ID: authorID}},
+		}, nil)
+
+		// run
+		result, err := service.List(model.PostListRequest{})
+
+		// assert
+		assert.NoError(t, err)
+		if assert.Len(t, result.Data, 1) {
+			assert.Empty(t, result.Data[0].ContentPreview)
+		}
+	})
+}
+
+func TestListArchivedPosts(t *testing.T) {
+	t.Run("OwnArchivedPosts", func(t *testing.T) {
+		repo, service := setupTestPostService()
+		viewerID := authorID
+		repo.On("List", model.PostListOptions{
+			Limit:        11,
+			AuthorID:     &viewerID,
+			ViewerID:     authorID,
+			ArchivedOnly: true,
+		}).Return([]model.Post{*createTestPost()}, nil)
+
+		// run
+		response, err := service.List(model.PostListRequest{
+			CursorRequest: model.CursorRequest{Limit: 10},
+			ViewerID:      authorID,
+			Status:        model.PostStatusArchived,
+		})
+
+		// assert
+		assert.NoError(t, err)
+		assert.Len(t, response.Data, 1)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("AnonymousUnauthorized", func(t *testing.T) {
+		repo, service := setupTestPostService()
+
+		// run
+		response, err := service.List(model.PostListRequest{Status: model.PostStatusArchived})
+
+		// assert
+		assert.ErrorIs(t, err, apperrors.ErrUnauthorized)
+		assert.Nil(t, response)
+		repo.AssertNotCalled(t, "List", mock.Anything)
+	})
+
+	t.Run("OtherAuthorForbidden", func(t *testing.T) {
+		repo, service := setupTestPostService()
+		otherID := "other-user-id"
+
+		// run
+		response, err := service.List(model.PostListRequest{
+			CursorRequest: model.CursorRequest{AuthorID: &otherID},
+			ViewerID:      authorID,
+			Status:        model.PostStatusArchived,
+		})
+
+		// assert
+		assert.ErrorIs(t, err, apperrors.ErrForbidden)
+		assert.Nil(t, response)
+		repo.AssertNotCalled(t, "List", mock.Anything)
+	})
+}
+
+func TestArchivePosts(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("ArchivesPastRetention", func(t *testing.T) {
+		repo := mockRepository.NewPostRepositoryMock()
+		service := service.NewPostServiceWithConfig(repo, config.PostConfig{ArchiveAfter: 30 * 24 * time.Hour})
+		repo.On("ArchiveOlderThan", time.Date(2024, 5, 2, 12, 0, 0, 0, time.UTC)).Return(int64(3), nil)
+
+		// run
+		archived, err := service.ArchivePosts(now)
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), archived)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("DisabledByDefault", func(t *testing.T) {
+		repo, service := setupTestPostService()
+
+		// run
+		archived, err := service.ArchivePosts(now)
+
+		// assert
+		assert.NoError(t, err)
+		assert.Zero(t, archived)
+		repo.AssertNotCalled(t, "ArchiveOlderThan", mock.Anything)
+	})
+}
+
+func TestListPostsCompact(t *testing.T) {
+	newService := func() (*mockRepository.PostRepositoryMock, service.PostService) {
+		repo := mockRepository.NewPostRepositoryMock()
+		return repo, service.NewPostServiceWithConfig(repo, config.PostConfig{PreviewLength: 5})
+	}
+
+	t.Run("TruncatesToPreviewLength", func(t *testing.T) {
+		repo, service := newService()
+		repo.On("ListSummaries", model.PostListOptions{Limit: 11, ViewerID: testUserID}, 6).Return([]model.Post{
+			{ID: 3, Content: "Hello world"},
+			{ID: 2, Content: "Hi   there"}, // spaces before the cut are dropped
+			{ID: 1, Content: "héllo"},      // exactly the length in characters, not bytes
+		}, nil)
+
+		// run
+		response, err := service.ListCompact(model.PostListRequest{
+			CursorRequest: model.CursorRequest{Limit: 10},
+			ViewerID:      testUserID,
+		})
+
+		// assert
+		assert.NoError(t, err)
+		if assert.Len(t, response.Data, 3) {
+			assert.Equal(t, "Hello…", response.Data[0].ContentPreview)
+			assert.Equal(t, "Hi…", response.Data[1].ContentPreview)
+			assert.Equal(t, "héllo", response.Data[2].ContentPreview)
+		}
+		assert.False(t, response.HasMore)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("NextCursor", func(t *testing.T) {
+		repo, service := newService()
+		createdAt := time.Date(2024, 1, 1, 8, 0, 0, 0, time.UTC)
+		repo.On("ListSummaries", model.PostListOptions{Limit: 2}, 6).Return([]model.Post{
+			{ID: 2, CreatedAt: createdAt},
+			{ID: 1, CreatedAt: createdAt},
+		}, nil)
+
+		// run
+		response, err := service.ListCompact(model.PostListRequest{CursorRequest: model.CursorRequest{Limit: 1}})
+
+		// assert
+		assert.NoError(t, err)
+		assert.True(t, response.HasMore)
+		cursor, err := model.DecodeCursor(response.Next)
+		assert.NoError(t, err)
+		assert.Equal(t, "2", cursor.ID)
+	})
+}
+
+func TestListPostsPaged(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		repo, service := setupTestPostService()
+
+		posts := []model.Post{
+			*createTestPost(map[string]interface{}{"id": uint64(3)}),
+			*createTestPost(map[string]interface{}{"id": uint64(4)}),
+		}
+		expectedOpts := model.PostPageOptions{
+			AuthorID:      nil,
+			Offset:        2, // (page - 1) * page_size
+			Limit:         2,
+			IncludeHidden: true,
+		}
+		repo.On("ListPagedWithCount", expectedOpts).Return(posts, int64(5), nil)
+
+		request := model.PostModerationListRequest{
+			PaginationRequest: model.PaginationRequest{
+				Page:     2,
+				PageSize: 2,
+			},
+		}
+
+		// run
+		result, err := service.ListPaged(request)
+
+		// assert
+		assert.NoError(t, err)
+		assert.Len(t, result.Data, 2)
+		assert.Equal(t, 5, result.Total)
+		assert.Equal(t, 2, result.Page)
+		assert.Equal(t, 2, result.PageSize)
+		assert.Equal(t, 3, result.TotalPages)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Defaults when zero", func(t *testing.T) {
+		repo, service := setupTestPostService()
+		expectedOpts := model.PostPageOptions{
+			AuthorID:      nil,
+			Offset:        0,
+			Limit:         10, // default page size
+			IncludeHidden: true,
+		}
+		repo.On("ListPagedWithCount", expectedOpts).Return([]model.Post{}, int64(0), nil)
+
+		// run
+		result, err := service.ListPaged(model.PostModerationListRequest{})
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, []model.PostResponse{}, result.Data)
+		assert.Equal(t, 0, result.Total)
+		assert.Equal(t, 0, result.TotalPages)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Repository error", func(t *testing.T) {
+		repo, service := setupTestPostService()
+		repo.On("ListPagedWithCount", mock.Anything).Return(nil, int64(0), apperrors.ErrValidation)
+
+		// run
+		result, err := service.ListPaged(model.PostModerationListRequest{PaginationRequest: model.PaginationRequest{Page: 1, PageSize: 10}})
+
+		// assert
+		assert.ErrorIs(t, err, apperrors.ErrValidation)
+		assert.Nil(t, result)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Status filters", func(t *testing.T) {
+		tests := []struct {
+			status        model.PostStatus
+			includeHidden bool
+			hiddenOnly    bool
+		}{
+			{model.PostStatusAll, true, false},
+			{model.PostStatusHidden, true, true},
+			{model.PostStatusVisible, false, false},
+		}
+
+		for _, tt := range tests {
+			t.Run(string(tt.status), func(t *testing.T) {
+				repo, service := setupTestPostService()
+				expectedOpts := model.PostPageOptions{
+					Offset:        0,
+					Limit:         10,
+					IncludeHidden: tt.includeHidden,
+					HiddenOnly:    tt.hiddenOnly,
+				}
+				repo.On("ListPagedWithCount", expectedOpts).Return([]model.Post{}, int64(0), nil)
+
+				// run
+				_, err := service.ListPaged(model.PostModerationListRequest{Status: tt.status})
+
+				// assert
+				assert.NoError(t, err)
+				repo.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("IncludeDeleted admin", func(t *testing.T) {
+		repo, service := setupTestPostService()
+		deleted := createTestPost(map[string]interface{}{"id": uint64(7)})
+		deletedAt := time.Now().UTC()
+		deleted.DeletedAt = gorm.DeletedAt{Time: deletedAt, Valid: true}
+		live := createTestPost(map[string]interface{}{"id": uint64(8)})
+		expectedOpts := model.PostPageOptions{
+			Offset:         0,
+			Limit:          10,
+			IncludeHidden:  true,
+			IncludeDeleted: true,
+		}
+		repo.On("ListPagedWithCount", expectedOpts).Return([]model.Post{*deleted, *live}, int64(2), nil)
+
+		// run
+		result, err := service.ListPaged(model.PostModerationListRequest{IncludeDeleted: true, CallerRole: model.RoleAdmin})
+
+		// assert
+		assert.NoError(t, err)
+		if assert.Len(t, result.Data, 2) {
+			assert.True(t, result.Data[0].Deleted)
+			assert.Equal(t, deletedAt, *result.Data[0].DeletedAt)
+			assert.False(t, result.Data[1].Deleted)
+			assert.Nil(t, result.Data[1].DeletedAt)
+		}
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("IncludeDeleted forbidden for non-admins", func(t *testing.T) {
+		for _, role := range []model.UserRole{model.RoleModerator, model.RoleUser, ""} {
+			repo, service := setupTestPostService()
+
+			// run
+			result, err := service.ListPaged(model.PostModerationListRequest{IncludeDeleted: true, CallerRole: role})
+
+			// assert
+			assert.ErrorIs(t, err, apperrors.ErrForbidden)
+			assert.Nil(t, result)
+			repo.AssertNotCalled(t, "ListPagedWithCount", mock.Anything)
+		}
+	})
+
+	t.Run("Admin without flag excludes deleted", func(t *testing.T) {
+		repo, service := setupTestPostService()
+		expectedOpts := model.PostPageOptions{Offset: 0, Limit: 10, IncludeHidden: true}
+		repo.On("ListPagedWithCount", expectedOpts).Return([]model.Post{}, int64(0), nil)
+
+		// run
+		_, err := service.ListPaged(model.PostModerationListRequest{CallerRole: model.RoleAdmin})
+
+		// assert
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+}
+
+func TestGetPostByIDStaleFallback(t *testing.T) {
+	dbErr := fmt.Errorf("dial tcp: connection refused")
+	newService := func(staleTTL time.Duration) (*mockRepository.PostRepositoryMock, service.PostService) {
+		repo := mockRepository.NewPostRepositoryMock()
+		return repo, service.NewPostServiceWithConfig(repo, config.PostConfig{StaleTTL: staleTTL})
+	}
+
+	t.Run("ServesLastGoodReadWhenDatabaseFails", func(t *testing.T) {
+		repo, service := newService(time.Minute)
+		post := createTestPost()
+		repo.On("FindByID", post.ID).Return(post, nil).Once()
+		repo.On("FindByID", post.ID).Return(nil, dbErr).Once()
+
+		// run
+		fresh, err := service.GetByID(post.ID, "", "")
+		assert.NoError(t, err)
+		stale, err := service.GetByID(post.ID, "", "")
+
+		// assert
+		assert.NoError(t, err)
+		assert.False(t, fresh.Stale)
+		assert.True(t, stale.Stale)
+		assert.Equal(t, post.Content, stale.Content)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("NoCachedValueUnavailable", func(t *testing.T) {
+		repo, service := newService(time.Minute)
+		repo.On("FindByID", uint64(1)).Return(nil, dbErr)
+
+		// run
+		found, err := service.GetByID(1, "", "")
+
+		// assert
+		assert.ErrorIs(t, err, apperrors.ErrUnavailable)
+		assert.Nil(t, found)
+	})
+
+	t.Run("StaleHiddenPostStillHidden", func(t *testing.T) {
+		repo, service := newService(time.Minute)
+		post := createTestPost()
+		post.Hidden = true
+		repo.On("FindByID", post.ID).Return(post, nil).Once()
+		repo.On("FindByID", post.ID).Return(nil, dbErr).Once()
+
+		// run
+		_, err := service.GetByID(post.ID, post.AuthorID, model.RoleUser)
+		assert.NoError(t, err)
+		_, err = service.GetByID(post.ID, "", "")
+
+		// assert
+		assert.ErrorIs(t, err, apperrors.ErrNotFound)
+	})
+
+	t.Run("DeleteForgetsPost", func(t *testing.T) {
+		repo, service := newService(time.Minute)
+		post := createTestPost()
+		repo.On("FindByID", post.ID).Return(post, nil).Once()
+		repo.On("FindByID", post.ID).Return(nil, dbErr).Once()
+		repo.On("CheckPermission", post.ID, post.AuthorID).Return(nil)
+		repo.On("Delete", post.ID).Return(nil)
+
+		// run
+		_, err := service.GetByID(post.ID, "", "")
+		assert.NoError(t, err)
+		assert.NoError(t, service.Delete(post.ID, post.AuthorID))
+		_, err = service.GetByID(post.ID, "", "")
+
+		// assert
+		assert.ErrorIs(t, err, apperrors.ErrUnavailable)
+	})
+
+	t.Run("WritesForgetPost", func(t *testing.T) {
+		updated := createTestPost(map[string]interface{}{"content": "Updated Content"})
+		writes := []struct {
+			name  string
+			reads int // FindByID calls that see the post: the cached read, plus the write's own
+			setup func(repo *mockRepository.PostRepositoryMock)
+			write func(service service.PostService) error
+		}{
+			{"Update", 2, func(repo *mockRepository.PostRepositoryMock) {
+				repo.On("Update", updated.ID, mock.Anything).Return(updated, nil)
+			}, func(service service.PostService) error {
+				_, err := service.Update(updated.ID, &model.Post{Content: "Updated Content"}, authorID)
+				return err
+			}},
+			{"Replace", 2, func(repo *mockRepository.PostRepositoryMock) {
+				repo.On("Replace", updated.ID, mock.Anything).Return(updated, nil)
+			}, func(service service.PostService) error {
+				_, err := service.Replace(updated.ID, &model.Post{Content: "Updated Content"}, authorID)
+				return err
+			}},
+			{"SetHidden", 1, func(repo *mockRepository.PostRepositoryMock) {
+				repo.On("SetHidden", updated.ID, true).Return(updated, nil)
+			}, func(service service.PostService) error {
+				_, err := service.SetHidden(updated.ID, true, model.RoleModerator)
+				return err
+			}},
+		}
+		for _, tt := range writes {
+			t.Run(tt.name, func(t *testing.T) {
+				repo := mockRepository.NewPostRepositoryMock()
+				service := service.NewPostServiceWithConfig(repo, config.PostConfig{
+					ContentMinLength: 10,
+					ContentMaxLength: 255,
+					StaleTTL:         time.Minute,
+				})
+				post := createTestPost()
+				repo.On("FindByID", post.ID).Return(post, nil).Times(tt.reads)
+				repo.On("FindByID", post.ID).Return(nil, dbErr).Once()
+				repo.On("CheckPermission", post.ID, authorID).Return(nil)
+				tt.setup(repo)
+
+				// run: the read caches the post, the write must drop it
+				_, err := service.GetByID(post.ID, "", "")
+				assert.NoError(t, err)
+				assert.NoError(t, tt.write(service))
+				_, err = service.GetByID(post.ID, "", "")
+
+				// assert
+				assert.ErrorIs(t, err, apperrors.ErrUnavailable)
+			})
+		}
+	})
+
+	t.Run("ArchiveForgetsPosts", func(t *testing.T) {
+		repo := mockRepository.NewPostRepositoryMock()
+		service := service.NewPostServiceWithConfig(repo, config.PostConfig{
+			ContentMinLength: 10,
+			ContentMaxLength: 255,
+			StaleTTL:         time.Minute,
+			ArchiveAfter:     24 * time.Hour,
+		})
+		post := createTestPost()
+		repo.On("FindByID", post.ID).Return(post, nil).Once()
+		repo.On("FindByID", post.ID).Return(nil, dbErr).Once()
+		repo.On("ArchiveOlderThan", mock.Anything).Return(int64(1), nil)
+
+		// run: the read caches the post, archiving may have archived it
+		_, err := service.GetByID(post.ID, "", "")
+		assert.NoError(t, err)
+		_, err = service.ArchivePosts(time.Now())
+		assert.NoError(t, err)
+		_, err = service.GetByID(post.ID, "", "")
+
+		// assert: an archived post must not be served to non-owners from the fallback
+		assert.ErrorIs(t, err, apperrors.ErrUnavailable)
+	})
+
+	t.Run("DisabledPassesErrorThrough", func(t *testing.T) {
+		repo, service := newService(0)
+		post := createTestPost()
+		repo.On("FindByID", post.ID).Return(post, nil).Once()
+		repo.On("FindByID", post.ID).Return(nil, dbErr).Once()
+
+		// run
+		_, err := service.GetByID(post.ID, "", "")
+		assert.NoError(t, err)
+		_, err = service.GetByID(post.ID, "", "")
+
+		// assert
+		assert.Equal(t, dbErr, err)
+		assert.NotErrorIs(t, err, apperrors.ErrUnavailable)
+	})
 }
@@ -0,0 +1,121 @@
+package service
+
+import (
+	"go-gin-api-server/internal/model"
+	"go-gin-api-server/internal/service"
+	"go-gin-api-server/pkg/apperrors"
+	mockRepository "go-gin-api-server/test/mocks/repository"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Helper functions
+
+func setupTestReportService() (*mockRepository.ReportRepositoryMock, *mockRepository.PostRepositoryMock, service.ReportService) {
+	mockRepo := mockRepository.NewReportRepositoryMock()
+	mockPostRepo := mockRepository.NewPostRepositoryMock()
+	return mockRepo, mockPostRepo, service.NewReportService(mockRepo, mockPostRepo)
+}
+
+const reporterID = "reporter-e29b-41d4-a716-446655440000"
+
+// Testcases
+
+func TestCreateReport(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		repo, postRepo, reportService := setupTestReportService()
+		postRepo.On("FindByID", uint64(1)).Return(createTestPost(), nil)
+		repo.On("Create", mock.MatchedBy(func(r *model.Report) bool {
+			return r.PostID == 1 && r.ReporterID == reporterID && r.Status == model.ReportPending
+		})).Return(&model.Report{ID: 1, PostID: 1, ReporterID: reporterID, Status: model.ReportPending}, nil)
+
+		// run
+		report, err := reportService.Create(1, reporterID, model.CreateReportRequest{Reason: "Spam"})
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(1), report.ID)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Duplicate", func(t *testing.T) {
+		repo, postRepo, reportService := setupTestReportService()
+		postRepo.On("FindByID", uint64(1)).Return(createTestPost(), nil)
+		repo.On("Create", mock.Anything).Return(nil, apperrors.ErrConflict)
+
+		// run
+		report, err := reportService.Create(1, reporterID, model.CreateReportRequest{Reason: "Spam"})
+
+		// assert
+		assert.ErrorIs(t, err, apperrors.ErrConflict)
+		assert.Nil(t, report)
+	})
+
+	t.Run("PostNotFound", func(t *testing.T) {
+		repo, postRepo, reportService := setupTestReportService()
+		postRepo.On("FindByID", NonExistentPostID).Return(nil, apperrors.ErrNotFound)
+
+		// run
+		report, err := reportService.Create(NonExistentPostID, reporterID, model.CreateReportRequest{Reason: "Spam"})
+
+		// assert
+		assert.ErrorIs(t, err, apperrors.ErrNotFound)
+		assert.Nil(t, report)
+		repo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+}
+
+func TestListReports(t *testing.T) {
+	t.Run("FiltersByStatus", func(t *testing.T) {
+		repo, _, reportService := setupTestReportService()
+		status := model.ReportPending
+		repo.On("List", model.ReportListOptions{Status: &status, Limit: 11}).
+			Return([]model.Report{{ID: 11}}, nil)
+
+		// run
+		response, err := reportService.List(model.ReportListRequest{
+			CursorRequest: model.CursorRequest{Limit: 10},
+			Status:        &status,
+		})
+
+		// assert
+		assert.NoError(t, err)
+		assert.Len(t, response.Data, 1)
+		assert.False(t, response.HasMore)
+		assert.Empty(t, response.Next)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("NextCursorFromLastReport", func(t *testing.T) {
+		repo, _, reportService := setupTestReportService()
+		createdAt := time.Date(2024, 1, 1, 8, 0, 0, 0, time.UTC)
+		repo.On("List", model.ReportListOptions{Limit: 3}).
+			Return([]model.Report{{ID: 1, CreatedAt: createdAt}, {ID: 2, CreatedAt: createdAt}, {ID: 3, CreatedAt: createdAt}}, nil)
+
+		// run
+		response, err := reportService.List(model.ReportListRequest{CursorRequest: model.CursorRequest{Limit: 2}})
+
+		// assert
+		assert.NoError(t, err)
+		assert.Len(t, response.Data, 2)
+		assert.True(t, response.HasMore)
+		cursor, err := model.DecodeCursor(response.Next)
+		assert.NoError(t, err)
+		assert.Equal(t, "2", cursor.ID)
+		assert.True(t, createdAt.Equal(cursor.CreatedAt))
+	})
+
+	t.Run("InvalidCursor", func(t *testing.T) {
+		repo, _, reportService := setupTestReportService()
+
+		// run
+		_, err := reportService.List(model.ReportListRequest{CursorRequest: model.CursorRequest{Cursor: "not-a-cursor"}})
+
+		// assert
+		assert.ErrorIs(t, err, apperrors.ErrValidation)
+		repo.AssertNotCalled(t, "List", mock.Anything)
+	})
+}
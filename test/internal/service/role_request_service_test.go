@@ -0,0 +1,94 @@
+package service
+
+import (
+	"go-gin-api-server/internal/model"
+	"go-gin-api-server/internal/service"
+	"go-gin-api-server/pkg/apperrors"
+	mockRepository "go-gin-api-server/test/mocks/repository"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Helper functions
+
+func setupTestRoleRequestService() (*mockRepository.RoleRequestRepositoryMock, *mockRepository.UserRepositoryMock, service.RoleRequestService) {
+	mockRepo := mockRepository.NewRoleRequestRepositoryMock()
+	mockUserRepo := mockRepository.NewUserRepositoryMock()
+	return mockRepo, mockUserRepo, service.NewRoleRequestService(mockRepo, mockUserRepo)
+}
+
+// Testcases
+
+func TestCreateRoleRequest(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		repo, userRepo, roleRequestService := setupTestRoleRequestService()
+		userRepo.On("FindByID", testUserID).Return(&model.User{ID: testUserID, Role: model.RoleUser}, nil)
+		repo.On("Create", mock.MatchedBy(func(r *model.RoleRequest) bool {
+			return r.UserID == testUserID && r.Role == model.RoleModerator && r.Status == model.RoleRequestPending
+		})).Return(&model.RoleRequest{ID: 1, UserID: testUserID, Role: model.RoleModerator, Status: model.RoleRequestPending}, nil)
+
+		// run
+		request, err := roleRequestService.Create(testUserID, model.CreateRoleRequestRequest{Role: model.RoleModerator})
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(1), request.ID)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("NotAboveCurrentRole", func(t *testing.T) {
+		repo, userRepo, roleRequestService := setupTestRoleRequestService()
+		userRepo.On("FindByID", testUserID).Return(&model.User{ID: testUserID, Role: model.RoleModerator}, nil)
+
+		// run
+		request, err := roleRequestService.Create(testUserID, model.CreateRoleRequestRequest{Role: model.RoleModerator})
+
+		// assert
+		assert.ErrorIs(t, err, apperrors.ErrValidation)
+		assert.Nil(t, request)
+		repo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+
+	t.Run("AlreadyPending", func(t *testing.T) {
+		repo, userRepo, roleRequestService := setupTestRoleRequestService()
+		userRepo.On("FindByID", testUserID).Return(&model.User{ID: testUserID, Role: model.RoleUser}, nil)
+		repo.On("Create", mock.Anything).Return(nil, apperrors.ErrConflict)
+
+		// run
+		request, err := roleRequestService.Create(testUserID, model.CreateRoleRequestRequest{Role: model.RoleAdmin})
+
+		// assert
+		assert.ErrorIs(t, err, apperrors.ErrConflict)
+		assert.Nil(t, request)
+	})
+}
+
+func TestApproveRoleRequest(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		repo, _, roleRequestService := setupTestRoleRequestService()
+		repo.On("Approve", uint64(1), testOtherUserID).
+			Return(&model.RoleRequest{ID: 1, UserID: testUserID, Role: model.RoleModerator, Status: model.RoleRequestApproved}, nil)
+
+		// run
+		request, err := roleRequestService.Approve(1, testOtherUserID, model.RoleAdmin)
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, model.RoleRequestApproved, request.Status)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("NonAdminForbidden", func(t *testing.T) {
+		repo, _, roleRequestService := setupTestRoleRequestService()
+
+		// run
+		request, err := roleRequestService.Approve(1, testOtherUserID, model.RoleModerator)
+
+		// assert
+		assert.ErrorIs(t, err, apperrors.ErrForbidden)
+		assert.Nil(t, request)
+		repo.AssertNotCalled(t, "Approve", mock.Anything, mock.Anything)
+	})
+}
@@ -1,10 +1,14 @@
 package service
 
 import (
+	"errors"
+	"go-gin-api-server/config"
 	"go-gin-api-server/internal/model"
 	"go-gin-api-server/internal/service"
 	"go-gin-api-server/pkg/apperrors"
+	"go-gin-api-server/pkg/cache"
 	mockRepository "go-gin-api-server/test/mocks/repository"
+	"strings"
 	"testing"
 	"time"
 
@@ -20,6 +24,13 @@ func setupTestUserService() (*mockRepository.UserRepositoryMock, service.UserSer
 	return mockRepo, mockService
 }
 
+func setupTestCachedUserService() (*mockRepository.UserRepositoryMock, service.UserService) {
+	mockRepo := mockRepository.NewUserRepositoryMock()
+	cfg := config.ProfileConfig{ShowBirthDate: true}
+	mockService := service.NewUserServiceWithCache(mockRepo, cfg, cache.NewMemoryCache[*model.UserProfile](time.Minute))
+	return mockRepo, mockService
+}
+
 func createTestUser() *model.User {
 	birthDate := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
 	username := "mock_user"
@@ -156,6 +167,105 @@ func TestUpdateUserProfile(t *testing.T) {
 		assert.Nil(t, created)
 		repo.AssertNotCalled(t, "Update")
 	})
+
+	t.Run("Success_ChangeUsername", func(t *testing.T) {
+		repo, mockService := setupTestUserService()
+		created := createTestUser()
+		created.ID = testUserID
+		newUsername := "new_username"
+		expected := &model.User{
+			ID:       created.ID,
+			Name:     created.Name,
+			Username: &newUsername,
+		}
+
+		repo.On("FindByUsername", newUsername).Return(nil, apperrors.ErrNotFound)
+		repo.On("Update", created.ID, mock.MatchedBy(func(u *model.User) bool {
+			return u.Username != nil && *u.Username == newUsername
+		})).Return(expected, nil)
+
+		// run
+		updated, err := mockService.UpdateUserProfile(created.ID, model.UpdateUserProfileRequest{
+			Username: &newUsername,
+		})
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, newUsername, *updated.Username)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Success_KeepOwnUsername", func(t *testing.T) {
+		repo, mockService := setupTestUserService()
+		created := createTestUser()
+		created.ID = testUserID
+
+		repo.On("FindByUsername", *created.Username).Return(created, nil)
+		repo.On("Update", created.ID, mock.Anything).Return(created, nil)
+
+		// run
+		updated, err := mockService.UpdateUserProfile(created.ID, model.UpdateUserProfileRequest{
+			Username: created.Username,
+		})
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, created.Username, updated.Username)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("ErrorUsernameTaken", func(t *testing.T) {
+		repo, mockService := setupTestUserService()
+		other := createTestUser()
+		other.ID = testOtherUserID
+
+		repo.On("FindByUsername", *other.Username).Return(other, nil)
+
+		// run
+		updated, err := mockService.UpdateUserProfile(testUserID, model.UpdateUserProfileRequest{
+			Username: other.Username,
+		})
+
+		// assert
+		assert.ErrorIs(t, err, apperrors.ErrUserExists)
+		assert.Nil(t, updated)
+		repo.AssertNotCalled(t, "Update")
+	})
+
+	t.Run("ErrorUsernameTakenConcurrently", func(t *testing.T) {
+		repo, mockService := setupTestUserService()
+		newUsername := "new_username"
+
+		// pre-check passes, but the unique index rejects the write
+		repo.On("FindByUsername", newUsername).Return(nil, apperrors.ErrNotFound)
+		repo.On("Update", testUserID, mock.Anything).Return(nil, apperrors.ErrUserExists)
+
+		// run
+		updated, err := mockService.UpdateUserProfile(testUserID, model.UpdateUserProfileRequest{
+			Username: &newUsername,
+		})
+
+		// assert
+		assert.ErrorIs(t, err, apperrors.ErrUserExists)
+		assert.Nil(t, updated)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("ErrorReservedUsername", func(t *testing.T) {
+		repo, mockService := setupTestUserService()
+		reservedUsername := "admin"
+
+		// run
+		updated, err := mockService.UpdateUserProfile(testUserID, model.UpdateUserProfileRequest{
+			Username: &reservedUsername,
+		})
+
+		// assert
+		assert.ErrorIs(t, err, apperrors.ErrValidation)
+		assert.Nil(t, updated)
+		repo.AssertNotCalled(t, "FindByUsername")
+		repo.AssertNotCalled(t, "Update")
+	})
 }
 
 func TestDeleteUser(t *testing.T) {
@@ -271,6 +381,38 @@ func TestGetUserProfile(t *testing.T) {
 		repo.AssertExpectations(t)
 	})
 
+	t.Run("BirthDateVisible", func(t *testing.T) {
+		repo := mockRepository.NewUserRepositoryMock()
+		mockService := service.NewUserServiceWithConfig(repo, config.ProfileConfig{ShowBirthDate: true})
+		expected := createTestUser()
+		repo.On("FindByUsername", *expected.Username).Return(expected, nil)
+
+		// run
+		user, err := mockService.GetUserProfile(*expected.Username)
+
+		// assert
+		assert.NoError(t, err)
+		assert.NotNil(t, user.BirthDate)
+		assert.Equal(t, expected.BirthDate, user.BirthDate)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("BirthDateHidden", func(t *testing.T) {
+		repo := mockRepository.NewUserRepositoryMock()
+		mockService := service.NewUserServiceWithConfig(repo, config.ProfileConfig{ShowBirthDate: false})
+		expected := createTestUser()
+		repo.On("FindByUsername", *expected.Username).Return(expected, nil)
+
+		// run
+		user, err := mockService.GetUserProfile(*expected.Username)
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, expected.Name, user.Name)
+		assert.Nil(t, user.BirthDate)
+		repo.AssertExpectations(t)
+	})
+
 	t.Run("NotFound", func(t *testing.T) {
 		repo, mockService := setupTestUserService()
 		repo.On("FindByUsername", mock.Anything).Return(nil, apperrors.ErrNotFound)
@@ -284,3 +426,222 @@ func TestGetUserProfile(t *testing.T) {
 		repo.AssertExpectations(t)
 	})
 }
+
+func TestGetUserProfiles(t *testing.T) {
+	firstID := "6f1c2b7e-3d4a-4b5c-8e9f-0a1b2c3d4e5f"
+	secondID := "9a8b7c6d-5e4f-4a3b-9c2d-1e0f9a8b7c6d"
+	birthDate := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("RequestOrderWithoutDuplicates", func(t *testing.T) {
+		repo, mockService := setupTestUserService()
+		repo.On("FindProfilesByIDs", []string{firstID, NonExistentUserID, secondID}).Return([]model.UserProfile{
+			{ID: secondID, Name: "Second"},
+			{ID: firstID, Name: "First"},
+		}, nil)
+
+		// run
+		profiles, err := mockService.GetUserProfiles([]string{firstID, NonExistentUserID, secondID, firstID})
+
+		// assert
+		assert.NoError(t, err)
+		if assert.Len(t, profiles, 2) {
+			assert.Equal(t, "First", profiles[0].Name)
+			assert.Equal(t, "Second", profiles[1].Name)
+		}
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("MixedCaseIDs", func(t *testing.T) {
+		repo, mockService := setupTestUserService()
+		repo.On("FindProfilesByIDs", []string{firstID}).Return([]model.UserProfile{
+			{ID: firstID, Name: "First"},
+		}, nil)
+
+		// run: the uuid validator accepts uppercase, the database returns lowercase
+		profiles, err := mockService.GetUserProfiles([]string{strings.ToUpper(firstID), firstID})
+
+		// assert
+		assert.NoError(t, err)
+		if assert.Len(t, profiles, 1) {
+			assert.Equal(t, "First", profiles[0].Name)
+		}
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("BirthDateHidden", func(t *testing.T) {
+		repo := mockRepository.NewUserRepositoryMock()
+		mockService := service.NewUserServiceWithConfig(repo, config.ProfileConfig{ShowBirthDate: false})
+		repo.On("FindProfilesByIDs", []string{firstID}).Return([]model.UserProfile{
+			{ID: firstID, Name: "First", BirthDate: &birthDate},
+		}, nil)
+
+		// run
+		profiles, err := mockService.GetUserProfiles([]string{firstID})
+
+		// assert
+		assert.NoError(t, err)
+		if assert.Len(t, profiles, 1) {
+			assert.Nil(t, profiles[0].BirthDate)
+		}
+	})
+}
+
+func TestToUserProfile(t *testing.T) {
+	t.Run("OmitsPrivateFields", func(t *testing.T) {
+		repo := mockRepository.NewUserRepositoryMock()
+		mockService := service.NewUserServiceWithConfig(repo, config.ProfileConfig{ShowBirthDate: false})
+		user := createTestUser()
+
+		// run
+		profile := mockService.ToUserProfile(user)
+
+		// assert
+		assert.Equal(t, user.Name, profile.Name)
+		assert.Equal(t, user.Username, profile.Username)
+		assert.Nil(t, profile.BirthDate)
+	})
+
+	t.Run("BirthDateVisible", func(t *testing.T) {
+		repo := mockRepository.NewUserRepositoryMock()
+		mockService := service.NewUserServiceWithConfig(repo, config.ProfileConfig{ShowBirthDate: true})
+		user := createTestUser()
+
+		// run
+		profile := mockService.ToUserProfile(user)
+
+		// assert
+		assert.Equal(t, user.BirthDate, profile.BirthDate)
+	})
+}
+
+func TestGetUserProfileCache(t *testing.T) {
+	t.Run("SecondLookupServedFromCache", func(t *testing.T) {
+		repo, mockService := setupTestCachedUserService()
+		expected := createTestUser()
+		repo.On("FindByUsername", *expected.Username).Return(expected, nil)
+
+		// run
+		first, err := mockService.GetUserProfile(*expected.Username)
+		assert.NoError(t, err)
+		second, err := mockService.GetUserProfile(*expected.Username)
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, first, second)
+		repo.AssertNumberOfCalls(t, "FindByUsername", 1)
+	})
+
+	t.Run("NotFoundIsNotCached", func(t *testing.T) {
+		repo, mockService := setupTestCachedUserService()
+		repo.On("FindByUsername", NonExistentUsername).Return(nil, apperrors.ErrNotFound)
+
+		// run
+		_, err := mockService.GetUserProfile(NonExistentUsername)
+		assert.ErrorIs(t, err, apperrors.ErrNotFound)
+		_, err = mockService.GetUserProfile(NonExistentUsername)
+
+		// assert
+		assert.ErrorIs(t, err, apperrors.ErrNotFound)
+		repo.AssertNumberOfCalls(t, "FindByUsername", 2)
+	})
+
+	t.Run("UpdateInvalidatesEntry", func(t *testing.T) {
+		repo, mockService := setupTestCachedUserService()
+		user := createTestUser()
+		updatedUser := *user
+		updatedUser.Name = "Updated Name"
+
+		repo.On("FindByUsername", *user.Username).Return(user, nil).Once()
+		repo.On("FindByUsername", *user.Username).Return(&updatedUser, nil).Once()
+		repo.On("Update", user.ID, mock.Anything).Return(&updatedUser, nil)
+
+		// run
+		before, err := mockService.GetUserProfile(*user.Username)
+		assert.NoError(t, err)
+		_, err = mockService.UpdateUserProfile(user.ID, model.UpdateUserProfileRequest{Name: "Updated Name"})
+		assert.NoError(t, err)
+		after, err := mockService.GetUserProfile(*user.Username)
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, user.Name, before.Name)
+		assert.Equal(t, "Updated Name", after.Name)
+		repo.AssertNumberOfCalls(t, "FindByUsername", 2)
+	})
+
+	t.Run("DeleteInvalidatesEntry", func(t *testing.T) {
+		repo, mockService := setupTestCachedUserService()
+		user := createTestUser()
+
+		repo.On("FindByUsername", *user.Username).Return(user, nil).Once()
+		repo.On("FindByUsername", *user.Username).Return(nil, apperrors.ErrNotFound).Once()
+		repo.On("Delete", user.ID).Return(nil)
+
+		// run
+		_, err := mockService.GetUserProfile(*user.Username)
+		assert.NoError(t, err)
+		assert.NoError(t, mockService.DeleteUser(user.ID))
+		profile, err := mockService.GetUserProfile(*user.Username)
+
+		// assert
+		assert.ErrorIs(t, err, apperrors.ErrNotFound)
+		assert.Nil(t, profile)
+		repo.AssertNumberOfCalls(t, "FindByUsername", 2)
+	})
+}
+
+func TestGetUserProfileStaleFallback(t *testing.T) {
+	dbErr := errors.New("dial tcp: connection refused")
+	newService := func() (*mockRepository.UserRepositoryMock, service.UserService) {
+		repo := mockRepository.NewUserRepositoryMock()
+		return repo, service.NewUserServiceWithConfig(repo, config.ProfileConfig{ShowBirthDate: true, StaleTTL: time.Minute})
+	}
+
+	t.Run("ServesLastGoodReadWhenDatabaseFails", func(t *testing.T) {
+		repo, mockService := newService()
+		user := createTestUser()
+		repo.On("FindByUsername", *user.Username).Return(user, nil).Once()
+		repo.On("FindByUsername", *user.Username).Return(nil, dbErr).Once()
+
+		// run
+		fresh, err := mockService.GetUserProfile(*user.Username)
+		assert.NoError(t, err)
+		stale, err := mockService.GetUserProfile(*user.Username)
+
+		// assert
+		assert.NoError(t, err)
+		assert.False(t, fresh.Stale, "the remembered profile itself is not flagged")
+		assert.True(t, stale.Stale)
+		assert.Equal(t, user.Name, stale.Name)
+	})
+
+	t.Run("NoCachedValueUnavailable", func(t *testing.T) {
+		repo, mockService := newService()
+		repo.On("FindByUsername", "someone").Return(nil, dbErr)
+
+		// run
+		profile, err := mockService.GetUserProfile("someone")
+
+		// assert
+		assert.ErrorIs(t, err, apperrors.ErrUnavailable)
+		assert.Nil(t, profile)
+	})
+
+	t.Run("NotFoundForgetsProfile", func(t *testing.T) {
+		repo, mockService := newService()
+		user := createTestUser()
+		repo.On("FindByUsername", *user.Username).Return(user, nil).Once()
+		repo.On("FindByUsername", *user.Username).Return(nil, apperrors.ErrNotFound).Once()
+		repo.On("FindByUsername", *user.Username).Return(nil, dbErr).Once()
+
+		// run
+		_, err := mockService.GetUserProfile(*user.Username)
+		assert.NoError(t, err)
+		_, err = mockService.GetUserProfile(*user.Username)
+		assert.ErrorIs(t, err, apperrors.ErrNotFound)
+		_, err = mockService.GetUserProfile(*user.Username)
+
+		// assert
+		assert.ErrorIs(t, err, apperrors.ErrUnavailable)
+	})
+}
@@ -0,0 +1,108 @@
+package service
+
+import (
+	"go-gin-api-server/config"
+	"go-gin-api-server/internal/events"
+	"go-gin-api-server/internal/model"
+	"go-gin-api-server/internal/service"
+	mockService "go-gin-api-server/test/mocks/service"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const testSystemUserID = "system-e29b-41d4-a716-446655440000"
+
+func createTestUserRegistered() events.UserRegistered {
+	return events.UserRegistered{
+		UserID:       testUserID,
+		Name:         "Test User",
+		Username:     "testuser",
+		RegisteredAt: time.Now(),
+	}
+}
+
+func TestWelcomePostService_HandleEvent(t *testing.T) {
+	t.Run("PostsAsNewUser", func(t *testing.T) {
+		postService := mockService.NewPostServiceMock()
+		welcome, err := service.NewWelcomePostService(postService, config.WelcomePostConfig{
+			Enabled:  true,
+			Template: "Hello, I'm {{.Name}} (@{{.Username}})",
+		})
+		assert.NoError(t, err)
+
+		postService.On("Create", mock.MatchedBy(func(post *model.Post) bool {
+			return post.AuthorID == testUserID && post.Content == "Hello, I'm Test User (@testuser)"
+		})).Return(&model.Post{ID: 1}, nil)
+
+		// run
+		err = welcome.HandleEvent(createTestUserRegistered())
+
+		// assert
+		assert.NoError(t, err)
+		postService.AssertExpectations(t)
+	})
+
+	t.Run("PostsAsSystemAccount", func(t *testing.T) {
+		postService := mockService.NewPostServiceMock()
+		welcome, err := service.NewWelcomePostService(postService, config.WelcomePostConfig{
+			Enabled:  true,
+			Template: "Please welcome @{{.Username}}!",
+			AuthorID: testSystemUserID,
+		})
+		assert.NoError(t, err)
+
+		postService.On("Create", mock.MatchedBy(func(post *model.Post) bool {
+			return post.AuthorID == testSystemUserID && post.Content == "Please welcome @testuser!"
+		})).Return(&model.Post{ID: 1}, nil)
+
+		// run
+		err = welcome.HandleEvent(createTestUserRegistered())
+
+		// assert
+		assert.NoError(t, err)
+		postService.AssertExpectations(t)
+	})
+
+	t.Run("IgnoresOtherEvents", func(t *testing.T) {
+		postService := mockService.NewPostServiceMock()
+		welcome, err := service.NewWelcomePostService(postService, config.LoadTestConfig().Welcome)
+		assert.NoError(t, err)
+
+		// run
+		err = welcome.HandleEvent(events.PostCreated{PostID: 1, AuthorID: testUserID})
+
+		// assert
+		assert.NoError(t, err)
+		postService.AssertNotCalled(t, "Create", mock.Anything)
+	})
+
+	t.Run("InvalidTemplate", func(t *testing.T) {
+		welcome, err := service.NewWelcomePostService(mockService.NewPostServiceMock(), config.WelcomePostConfig{
+			Enabled:  true,
+			Template: "Hello {{.Name",
+		})
+
+		// assert
+		assert.Error(t, err)
+		assert.Nil(t, welcome)
+	})
+
+	t.Run("UnknownTemplateField", func(t *testing.T) {
+		postService := mockService.NewPostServiceMock()
+		welcome, err := service.NewWelcomePostService(postService, config.WelcomePostConfig{
+			Enabled:  true,
+			Template: "Hello {{.Email}}",
+		})
+		assert.NoError(t, err)
+
+		// run
+		err = welcome.HandleEvent(createTestUserRegistered())
+
+		// assert
+		assert.Error(t, err)
+		postService.AssertNotCalled(t, "Create", mock.Anything)
+	})
+}
@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"go-gin-api-server/internal/model"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type APIKeyRepositoryMock struct {
+	mock.Mock
+}
+
+func NewAPIKeyRepositoryMock() *APIKeyRepositoryMock {
+	return &APIKeyRepositoryMock{}
+}
+
+func (m *APIKeyRepositoryMock) Create(key *model.APIKey) (*model.APIKey, error) {
+	args := m.Called(key)
+	if r := args.Get(0); r != nil {
+		result, ok := r.(*model.APIKey)
+		if !ok {
+			return nil, args.Error(1)
+		}
+		return result, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *APIKeyRepositoryMock) ListByUser(userID string) ([]model.APIKey, error) {
+	args := m.Called(userID)
+	if r := args.Get(0); r != nil {
+		result, ok := r.([]model.APIKey)
+		if !ok {
+			return nil, args.Error(1)
+		}
+		return result, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *APIKeyRepositoryMock) FindByHash(keyHash string) (*model.APIKey, error) {
+	args := m.Called(keyHash)
+	if r := args.Get(0); r != nil {
+		result, ok := r.(*model.APIKey)
+		if !ok {
+			return nil, args.Error(1)
+		}
+		return result, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *APIKeyRepositoryMock) Revoke(id uint64, userID string) error {
+	args := m.Called(id, userID)
+	return args.Error(0)
+}
+
+func (m *APIKeyRepositoryMock) TouchLastUsed(id uint64, usedAt time.Time) error {
+	args := m.Called(id, usedAt)
+	return args.Error(0)
+}
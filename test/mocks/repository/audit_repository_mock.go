@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"go-gin-api-server/internal/model"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type AuditRepositoryMock struct {
+	mock.Mock
+}
+
+func NewAuditRepositoryMock() *AuditRepositoryMock {
+	return &AuditRepositoryMock{}
+}
+
+func (m *AuditRepositoryMock) Create(entry *model.AuditLog) error {
+	args := m.Called(entry)
+	return args.Error(0)
+}
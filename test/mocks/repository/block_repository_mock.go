@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"github.com/stretchr/testify/mock"
+)
+
+type BlockRepositoryMock struct {
+	mock.Mock
+}
+
+func NewBlockRepositoryMock() *BlockRepositoryMock {
+	return &BlockRepositoryMock{}
+}
+
+func (m *BlockRepositoryMock) Block(blockerID, blockedID string) error {
+	args := m.Called(blockerID, blockedID)
+	return args.Error(0)
+}
+
+func (m *BlockRepositoryMock) Unblock(blockerID, blockedID string) error {
+	args := m.Called(blockerID, blockedID)
+	return args.Error(0)
+}
+
+func (m *BlockRepositoryMock) IsBlocked(userID, otherID string) (bool, error) {
+	args := m.Called(userID, otherID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *BlockRepositoryMock) HasBlocked(blockerID, blockedID string) (bool, error) {
+	args := m.Called(blockerID, blockedID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *BlockRepositoryMock) ListBlockedIDs(blockerID string) ([]string, error) {
+	args := m.Called(blockerID)
+	if ids := args.Get(0); ids != nil {
+		return ids.([]string), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *BlockRepositoryMock) CountBlocked(blockerID string) (int64, error) {
+	args := m.Called(blockerID)
+	return args.Get(0).(int64), args.Error(1)
+}
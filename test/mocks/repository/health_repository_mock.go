@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"database/sql"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type HealthRepositoryMock struct {
+	mock.Mock
+}
+
+func NewHealthRepositoryMock() *HealthRepositoryMock {
+	return &HealthRepositoryMock{}
+}
+
+func (m *HealthRepositoryMock) PoolStats() (sql.DBStats, error) {
+	args := m.Called()
+	return args.Get(0).(sql.DBStats), args.Error(1)
+}
+
+func (m *HealthRepositoryMock) MigrationVersion() (uint, bool, error) {
+	args := m.Called()
+	return args.Get(0).(uint), args.Bool(1), args.Error(2)
+}
+
+func (m *HealthRepositoryMock) ServerVersion() (string, error) {
+	args := m.Called()
+	return args.String(0), args.Error(1)
+}
@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"go-gin-api-server/internal/model"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type NotificationRepositoryMock struct {
+	mock.Mock
+}
+
+func NewNotificationRepositoryMock() *NotificationRepositoryMock {
+	return &NotificationRepositoryMock{}
+}
+
+func (m *NotificationRepositoryMock) Create(notification *model.Notification) (*model.Notification, error) {
+	args := m.Called(notification)
+	if n := args.Get(0); n != nil {
+		result, ok := n.(*model.Notification)
+		if !ok {
+			return nil, args.Error(1)
+		}
+		return result, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *NotificationRepositoryMock) List(opts model.NotificationListOptions) ([]model.Notification, error) {
+	args := m.Called(opts)
+	if list := args.Get(0); list != nil {
+		result, ok := list.([]model.Notification)
+		if !ok {
+			return nil, args.Error(1)
+		}
+		return result, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *NotificationRepositoryMock) ListPagedWithCount(opts model.NotificationPageOptions) ([]model.Notification, int64, error) {
+	args := m.Called(opts)
+	if list := args.Get(0); list != nil {
+		result, ok := list.([]model.Notification)
+		if !ok {
+			return nil, 0, args.Error(2)
+		}
+		return result, args.Get(1).(int64), args.Error(2)
+	}
+	return nil, 0, args.Error(2)
+}
+
+func (m *NotificationRepositoryMock) CountUnread(userID string) (int64, error) {
+	args := m.Called(userID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *NotificationRepositoryMock) MarkRead(id uint64, userID string) error {
+	args := m.Called(id, userID)
+	return args.Error(0)
+}
@@ -1,7 +1,10 @@
 package repository
 
 import (
+	"errors"
 	"go-gin-api-server/internal/model"
+	"go-gin-api-server/internal/repository"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 )
@@ -30,6 +33,18 @@ func (m *PostRepositoryMock) Create(post *model.Post) (*model.Post, error) {
 	return nil, err
 }
 
+func (m *PostRepositoryMock) ListSummaries(opts model.PostListOptions, contentLength int) ([]model.Post, error) {
+	args := m.Called(opts, contentLength)
+	if posts := args.Get(0); posts != nil {
+		postResult, ok := posts.([]model.Post)
+		if !ok {
+			return nil, args.Error(1)
+		}
+		return postResult, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
 func (m *PostRepositoryMock) List(opts model.PostListOptions) ([]model.Post, error) {
 	args := m.Called(opts)
 	if posts := args.Get(0); posts != nil {
@@ -43,6 +58,35 @@ func (m *PostRepositoryMock) List(opts model.PostListOptions) ([]model.Post, err
 	return nil, args.Error(1)
 }
 
+func (m *PostRepositoryMock) ListPagedWithCount(opts model.PostPageOptions) ([]model.Post, int64, error) {
+	args := m.Called(opts)
+	if posts := args.Get(0); posts != nil {
+		postResult, ok := posts.([]model.Post)
+		if !ok {
+			return nil, 0, args.Error(2)
+		}
+		return postResult, args.Get(1).(int64), args.Error(2)
+	}
+	return nil, 0, args.Error(2)
+}
+
+// IteratePostsByAuthor hands fn each [][]model.Post batch given as the first return value,
+// honouring ErrStopIteration like the real repository
+func (m *PostRepositoryMock) IteratePostsByAuthor(authorID string, from model.Cursor, batchSize int, fn func(batch []model.Post) error) error {
+	args := m.Called(authorID, from, batchSize)
+	if batches, ok := args.Get(0).([][]model.Post); ok {
+		for _, batch := range batches {
+			if err := fn(batch); err != nil {
+				if errors.Is(err, repository.ErrStopIteration) {
+					return nil
+				}
+				return err
+			}
+		}
+	}
+	return args.Error(1)
+}
+
 func (m *PostRepositoryMock) FindByID(id uint64) (*model.Post, error) {
 	args := m.Called(id)
 	if post := args.Get(0); post != nil {
@@ -57,6 +101,30 @@ func (m *PostRepositoryMock) FindByID(id uint64) (*model.Post, error) {
 	return nil, err
 }
 
+func (m *PostRepositoryMock) FindBySlug(slug string) (*model.Post, error) {
+	args := m.Called(slug)
+	if post := args.Get(0); post != nil {
+		postResult, ok := post.(*model.Post)
+		if !ok {
+			return nil, args.Error(1)
+		}
+		return postResult, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *PostRepositoryMock) SetHidden(id uint64, hidden bool) (*model.Post, error) {
+	args := m.Called(id, hidden)
+	if p := args.Get(0); p != nil {
+		postResult, ok := p.(*model.Post)
+		if !ok {
+			return nil, args.Error(1)
+		}
+		return postResult, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
 func (m *PostRepositoryMock) Update(id uint64, post *model.Post) (*model.Post, error) {
 	args := m.Called(id, post)
 	if u := args.Get(0); u != nil {
@@ -71,6 +139,42 @@ func (m *PostRepositoryMock) Update(id uint64, post *model.Post) (*model.Post, e
 	return nil, err
 }
 
+func (m *PostRepositoryMock) Replace(id uint64, post *model.Post) (*model.Post, error) {
+	args := m.Called(id, post)
+	if p := args.Get(0); p != nil {
+		postResult, ok := p.(*model.Post)
+		if !ok {
+			return nil, args.Error(1)
+		}
+		return postResult, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *PostRepositoryMock) UpdateIfMatch(id uint64, post *model.Post, ifMatch string) (*model.Post, error) {
+	args := m.Called(id, post, ifMatch)
+	if p := args.Get(0); p != nil {
+		postResult, ok := p.(*model.Post)
+		if !ok {
+			return nil, args.Error(1)
+		}
+		return postResult, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *PostRepositoryMock) ReplaceIfMatch(id uint64, post *model.Post, ifMatch string) (*model.Post, error) {
+	args := m.Called(id, post, ifMatch)
+	if p := args.Get(0); p != nil {
+		postResult, ok := p.(*model.Post)
+		if !ok {
+			return nil, args.Error(1)
+		}
+		return postResult, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
 func (m *PostRepositoryMock) Delete(id uint64) error {
 	args := m.Called(id)
 	return args.Error(0)
@@ -80,3 +184,25 @@ func (m *PostRepositoryMock) CheckPermission(id uint64, userID string) error {
 	args := m.Called(id, userID)
 	return args.Error(0)
 }
+
+func (m *PostRepositoryMock) ListRevisions(postID uint64) ([]model.PostRevision, error) {
+	args := m.Called(postID)
+	if r := args.Get(0); r != nil {
+		revisions, ok := r.([]model.PostRevision)
+		if !ok {
+			return nil, args.Error(1)
+		}
+		return revisions, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *PostRepositoryMock) ArchiveOlderThan(cutoff time.Time) (int64, error) {
+	args := m.Called(cutoff)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *PostRepositoryMock) DeleteWithAudit(id uint64, entry *model.AuditLog) error {
+	args := m.Called(id, entry)
+	return args.Error(0)
+}
@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"go-gin-api-server/internal/model"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type ReportRepositoryMock struct {
+	mock.Mock
+}
+
+func NewReportRepositoryMock() *ReportRepositoryMock {
+	return &ReportRepositoryMock{}
+}
+
+func (m *ReportRepositoryMock) Create(report *model.Report) (*model.Report, error) {
+	args := m.Called(report)
+	if r := args.Get(0); r != nil {
+		result, ok := r.(*model.Report)
+		if !ok {
+			return nil, args.Error(1)
+		}
+		return result, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *ReportRepositoryMock) List(opts model.ReportListOptions) ([]model.Report, error) {
+	args := m.Called(opts)
+	if list := args.Get(0); list != nil {
+		result, ok := list.([]model.Report)
+		if !ok {
+			return nil, args.Error(1)
+		}
+		return result, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type RevokedTokenRepositoryMock struct {
+	mock.Mock
+}
+
+func NewRevokedTokenRepositoryMock() *RevokedTokenRepositoryMock {
+	return &RevokedTokenRepositoryMock{}
+}
+
+func (m *RevokedTokenRepositoryMock) Revoke(jti string, expiresAt time.Time) error {
+	args := m.Called(jti, expiresAt)
+	return args.Error(0)
+}
+
+func (m *RevokedTokenRepositoryMock) IsRevoked(jti string) (bool, error) {
+	args := m.Called(jti)
+	return args.Bool(0), args.Error(1)
+}
@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"go-gin-api-server/internal/model"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type RoleRequestRepositoryMock struct {
+	mock.Mock
+}
+
+func NewRoleRequestRepositoryMock() *RoleRequestRepositoryMock {
+	return &RoleRequestRepositoryMock{}
+}
+
+func (m *RoleRequestRepositoryMock) Create(request *model.RoleRequest) (*model.RoleRequest, error) {
+	args := m.Called(request)
+	if r := args.Get(0); r != nil {
+		result, ok := r.(*model.RoleRequest)
+		if !ok {
+			return nil, args.Error(1)
+		}
+		return result, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *RoleRequestRepositoryMock) Approve(id uint64, reviewerID string) (*model.RoleRequest, error) {
+	args := m.Called(id, reviewerID)
+	if r := args.Get(0); r != nil {
+		result, ok := r.(*model.RoleRequest)
+		if !ok {
+			return nil, args.Error(1)
+		}
+		return result, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
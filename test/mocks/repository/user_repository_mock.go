@@ -72,6 +72,18 @@ func (m *UserRepositoryMock) FindByEmail(email string) (*model.User, error) {
 	return nil, err
 }
 
+func (m *UserRepositoryMock) FindProfilesByIDs(ids []string) ([]model.UserProfile, error) {
+	args := m.Called(ids)
+	if profiles := args.Get(0); profiles != nil {
+		profilesResult, ok := profiles.([]model.UserProfile)
+		if !ok {
+			return nil, args.Error(1)
+		}
+		return profilesResult, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
 func (m *UserRepositoryMock) Update(id string, user *model.User) (*model.User, error) {
 	args := m.Called(id, user)
 	if u := args.Get(0); u != nil {
@@ -86,6 +98,20 @@ func (m *UserRepositoryMock) Update(id string, user *model.User) (*model.User, e
 	return nil, err
 }
 
+func (m *UserRepositoryMock) UpdateStatus(id string, isActive bool, deactivatedBy *model.DeactivationSource) (*model.User, error) {
+	args := m.Called(id, isActive, deactivatedBy)
+	if u := args.Get(0); u != nil {
+		userResult, ok := u.(*model.User)
+		if !ok {
+			return nil, args.Error(1)
+		}
+		err := args.Error(1)
+		return userResult, err
+	}
+	err := args.Error(1)
+	return nil, err
+}
+
 func (m *UserRepositoryMock) Delete(id string) error {
 	args := m.Called(id)
 	return args.Error(0)
@@ -0,0 +1,56 @@
+package service
+
+import (
+	"go-gin-api-server/internal/model"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type APIKeyServiceMock struct {
+	mock.Mock
+}
+
+func NewAPIKeyServiceMock() *APIKeyServiceMock {
+	return &APIKeyServiceMock{}
+}
+
+func (m *APIKeyServiceMock) Create(userID string, req model.CreateAPIKeyRequest) (*model.CreateAPIKeyResponse, error) {
+	args := m.Called(userID, req)
+	if r := args.Get(0); r != nil {
+		result, ok := r.(*model.CreateAPIKeyResponse)
+		if !ok {
+			return nil, args.Error(1)
+		}
+		return result, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *APIKeyServiceMock) List(userID string) (*model.APIKeyListResponse, error) {
+	args := m.Called(userID)
+	if r := args.Get(0); r != nil {
+		result, ok := r.(*model.APIKeyListResponse)
+		if !ok {
+			return nil, args.Error(1)
+		}
+		return result, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *APIKeyServiceMock) Revoke(id uint64, userID string) error {
+	args := m.Called(id, userID)
+	return args.Error(0)
+}
+
+func (m *APIKeyServiceMock) Authenticate(key string) (*model.APIKey, error) {
+	args := m.Called(key)
+	if r := args.Get(0); r != nil {
+		result, ok := r.(*model.APIKey)
+		if !ok {
+			return nil, args.Error(1)
+		}
+		return result, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
@@ -52,6 +52,23 @@ func (m *AuthServiceMock) RefreshToken(refreshToken string) (*model.TokenRespons
 	return nil, args.Error(1)
 }
 
+func (m *AuthServiceMock) RevokeAccessToken(accessToken string) error {
+	args := m.Called(accessToken)
+	return args.Error(0)
+}
+
+func (m *AuthServiceMock) Impersonate(userID string, adminID string) (*model.ImpersonationResponse, error) {
+	args := m.Called(userID, adminID)
+	if resp := args.Get(0); resp != nil {
+		response, ok := resp.(*model.ImpersonationResponse)
+		if !ok {
+			return nil, args.Error(1)
+		}
+		return response, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
 func (m *AuthServiceMock) RefreshAccessToken(refreshToken string) (string, error) {
 	args := m.Called(refreshToken)
 	token := args.String(0)
@@ -82,8 +99,8 @@ func (m *AuthServiceMock) ActivateUser(userID string) (*model.User, error) {
 	return nil, args.Error(1)
 }
 
-func (m *AuthServiceMock) DeactivateUser(userID string) (*model.User, error) {
-	args := m.Called(userID)
+func (m *AuthServiceMock) DeactivateUser(userID string, actorID string) (*model.User, error) {
+	args := m.Called(userID, actorID)
 	if user := args.Get(0); user != nil {
 		userResult, ok := user.(*model.User)
 		if !ok {
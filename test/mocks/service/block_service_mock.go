@@ -0,0 +1,42 @@
+package service
+
+import (
+	"go-gin-api-server/internal/model"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type BlockServiceMock struct {
+	mock.Mock
+}
+
+func NewBlockServiceMock() *BlockServiceMock {
+	return &BlockServiceMock{}
+}
+
+func (m *BlockServiceMock) Block(blockerID, blockedID string) error {
+	args := m.Called(blockerID, blockedID)
+	return args.Error(0)
+}
+
+func (m *BlockServiceMock) Unblock(blockerID, blockedID string) error {
+	args := m.Called(blockerID, blockedID)
+	return args.Error(0)
+}
+
+func (m *BlockServiceMock) ListBlocked(blockerID string) (*model.BlockedUsersResponse, error) {
+	args := m.Called(blockerID)
+	if r := args.Get(0); r != nil {
+		result, ok := r.(*model.BlockedUsersResponse)
+		if !ok {
+			return nil, args.Error(1)
+		}
+		return result, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *BlockServiceMock) IsBlocked(userID, otherID string) (bool, error) {
+	args := m.Called(userID, otherID)
+	return args.Bool(0), args.Error(1)
+}
@@ -0,0 +1,26 @@
+package service
+
+import (
+	"io"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type ExportServiceMock struct {
+	mock.Mock
+}
+
+func NewExportServiceMock() *ExportServiceMock {
+	return &ExportServiceMock{}
+}
+
+// Export writes the string given as the second return value, if any, before returning the error
+func (m *ExportServiceMock) Export(userID string, continuation string, w io.Writer) error {
+	args := m.Called(userID, continuation, w)
+	if body, ok := args.Get(1).(string); ok && body != "" {
+		if _, err := io.WriteString(w, body); err != nil {
+			return err
+		}
+	}
+	return args.Error(0)
+}
@@ -0,0 +1,20 @@
+package service
+
+import (
+	"go-gin-api-server/internal/model"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type HealthServiceMock struct {
+	mock.Mock
+}
+
+func NewHealthServiceMock() *HealthServiceMock {
+	return &HealthServiceMock{}
+}
+
+func (m *HealthServiceMock) Details() *model.HealthDetails {
+	args := m.Called()
+	return args.Get(0).(*model.HealthDetails)
+}
@@ -0,0 +1,43 @@
+package service
+
+import (
+	"go-gin-api-server/internal/events"
+	"go-gin-api-server/internal/model"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type NotificationServiceMock struct {
+	mock.Mock
+}
+
+func NewNotificationServiceMock() *NotificationServiceMock {
+	return &NotificationServiceMock{}
+}
+
+func (m *NotificationServiceMock) List(userID string, request model.NotificationListRequest) (*model.CursorResponse[model.Notification], error) {
+	args := m.Called(userID, request)
+	if list := args.Get(0); list != nil {
+		result, ok := list.(*model.CursorResponse[model.Notification])
+		if !ok {
+			return nil, args.Error(1)
+		}
+		return result, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *NotificationServiceMock) UnreadCount(userID string) (int64, error) {
+	args := m.Called(userID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *NotificationServiceMock) MarkRead(id uint64, userID string) error {
+	args := m.Called(id, userID)
+	return args.Error(0)
+}
+
+func (m *NotificationServiceMock) HandleEvent(event events.Event) error {
+	args := m.Called(event)
+	return args.Error(0)
+}
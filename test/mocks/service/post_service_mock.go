@@ -2,6 +2,7 @@ package service
 
 import (
 	"go-gin-api-server/internal/model"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 )
@@ -26,7 +27,36 @@ func (m *PostServiceMock) Create(post *model.Post) (*model.Post, error) {
 	return nil, args.Error(1)
 }
 
-func (m *PostServiceMock) List(request model.CursorRequest) (*model.CursorResponse[model.PostResponse], error) {
+func (m *PostServiceMock) ListCompact(request model.PostListRequest) (*model.CursorResponse[model.PostSummary], error) {
+	args := m.Called(request)
+	if list := args.Get(0); list != nil {
+		listResult, ok := list.(*model.CursorResponse[model.PostSummary])
+		if !ok {
+			return nil, args.Error(1)
+		}
+		return listResult, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *PostServiceMock) ArchivePosts(now time.Time) (int64, error) {
+	args := m.Called(now)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *PostServiceMock) Preview(post *model.Post) (*model.PostPreviewResponse, error) {
+	args := m.Called(post)
+	if p := args.Get(0); p != nil {
+		previewResult, ok := p.(*model.PostPreviewResponse)
+		if !ok {
+			return nil, args.Error(1)
+		}
+		return previewResult, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *PostServiceMock) List(request model.PostListRequest) (*model.CursorResponse[model.PostResponse], error) {
 	args := m.Called(request)
 	if list := args.Get(0); list != nil {
 		listResult, ok := list.(*model.CursorResponse[model.PostResponse])
@@ -38,8 +68,32 @@ func (m *PostServiceMock) List(request model.CursorRequest) (*model.CursorRespon
 	return nil, args.Error(1)
 }
 
-func (m *PostServiceMock) GetByID(id uint64) (*model.PostResponse, error) {
-	args := m.Called(id)
+func (m *PostServiceMock) ListPaged(request model.PostModerationListRequest) (*model.PaginatedResponse[model.PostResponse], error) {
+	args := m.Called(request)
+	if list := args.Get(0); list != nil {
+		listResult, ok := list.(*model.PaginatedResponse[model.PostResponse])
+		if !ok {
+			return nil, args.Error(1)
+		}
+		return listResult, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *PostServiceMock) GetByID(id uint64, viewerID string, viewerRole model.UserRole) (*model.PostResponse, error) {
+	args := m.Called(id, viewerID, viewerRole)
+	if p := args.Get(0); p != nil {
+		postResult, ok := p.(*model.PostResponse)
+		if !ok {
+			return nil, args.Error(1)
+		}
+		return postResult, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *PostServiceMock) GetBySlug(slug string, viewerID string, viewerRole model.UserRole) (*model.PostResponse, error) {
+	args := m.Called(slug, viewerID, viewerRole)
 	if p := args.Get(0); p != nil {
 		postResult, ok := p.(*model.PostResponse)
 		if !ok {
@@ -62,7 +116,93 @@ func (m *PostServiceMock) Update(id uint64, post *model.Post, currentUserID stri
 	return nil, args.Error(1)
 }
 
+func (m *PostServiceMock) UpdateIfMatch(id uint64, post *model.Post, currentUserID string, ifMatch string) (*model.Post, error) {
+	args := m.Called(id, post, ifMatch)
+	if p := args.Get(0); p != nil {
+		postResult, ok := p.(*model.Post)
+		if !ok {
+			return nil, args.Error(1)
+		}
+		return postResult, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *PostServiceMock) Replace(id uint64, post *model.Post, currentUserID string) (*model.Post, error) {
+	args := m.Called(id, post)
+	if p := args.Get(0); p != nil {
+		postResult, ok := p.(*model.Post)
+		if !ok {
+			return nil, args.Error(1)
+		}
+		return postResult, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *PostServiceMock) ReplaceIfMatch(id uint64, post *model.Post, currentUserID string, ifMatch string) (*model.Post, error) {
+	args := m.Called(id, post, ifMatch)
+	if p := args.Get(0); p != nil {
+		postResult, ok := p.(*model.Post)
+		if !ok {
+			return nil, args.Error(1)
+		}
+		return postResult, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
 func (m *PostServiceMock) Delete(id uint64, currentUserID string) error {
 	args := m.Called(id)
 	return args.Error(0)
 }
+
+func (m *PostServiceMock) SetHidden(id uint64, hidden bool, callerRole model.UserRole) (*model.Post, error) {
+	args := m.Called(id, hidden, callerRole)
+	if p := args.Get(0); p != nil {
+		postResult, ok := p.(*model.Post)
+		if !ok {
+			return nil, args.Error(1)
+		}
+		return postResult, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *PostServiceMock) ListRevisions(id uint64, viewerID string, viewerRole model.UserRole) ([]model.PostRevision, error) {
+	args := m.Called(id, viewerID, viewerRole)
+	if r := args.Get(0); r != nil {
+		revisions, ok := r.([]model.PostRevision)
+		if !ok {
+			return nil, args.Error(1)
+		}
+		return revisions, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *PostServiceMock) ValidateContent(content string) []error {
+	args := m.Called(content)
+	if errs := args.Get(0); errs != nil {
+		result, ok := errs.([]error)
+		if !ok {
+			return nil
+		}
+		return result
+	}
+	return nil
+}
+
+func (m *PostServiceMock) AdminDelete(id uint64, actorID string, role model.UserRole) error {
+	args := m.Called(id, actorID, role)
+	return args.Error(0)
+}
+
+func (m *PostServiceMock) Limits() model.PostLimitsResponse {
+	args := m.Called()
+	limits, ok := args.Get(0).(model.PostLimitsResponse)
+	if !ok {
+		return model.PostLimitsResponse{}
+	}
+	return limits
+}
@@ -0,0 +1,39 @@
+package service
+
+import (
+	"go-gin-api-server/internal/model"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type ReportServiceMock struct {
+	mock.Mock
+}
+
+func NewReportServiceMock() *ReportServiceMock {
+	return &ReportServiceMock{}
+}
+
+func (m *ReportServiceMock) Create(postID uint64, reporterID string, req model.CreateReportRequest) (*model.Report, error) {
+	args := m.Called(postID, reporterID, req)
+	if r := args.Get(0); r != nil {
+		result, ok := r.(*model.Report)
+		if !ok {
+			return nil, args.Error(1)
+		}
+		return result, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *ReportServiceMock) List(request model.ReportListRequest) (*model.CursorResponse[model.Report], error) {
+	args := m.Called(request)
+	if list := args.Get(0); list != nil {
+		result, ok := list.(*model.CursorResponse[model.Report])
+		if !ok {
+			return nil, args.Error(1)
+		}
+		return result, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
@@ -0,0 +1,39 @@
+package service
+
+import (
+	"go-gin-api-server/internal/model"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type RoleRequestServiceMock struct {
+	mock.Mock
+}
+
+func NewRoleRequestServiceMock() *RoleRequestServiceMock {
+	return &RoleRequestServiceMock{}
+}
+
+func (m *RoleRequestServiceMock) Create(userID string, req model.CreateRoleRequestRequest) (*model.RoleRequest, error) {
+	args := m.Called(userID, req)
+	if r := args.Get(0); r != nil {
+		result, ok := r.(*model.RoleRequest)
+		if !ok {
+			return nil, args.Error(1)
+		}
+		return result, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *RoleRequestServiceMock) Approve(id uint64, reviewerID string, reviewerRole model.UserRole) (*model.RoleRequest, error) {
+	args := m.Called(id, reviewerID, reviewerRole)
+	if r := args.Get(0); r != nil {
+		result, ok := r.(*model.RoleRequest)
+		if !ok {
+			return nil, args.Error(1)
+		}
+		return result, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
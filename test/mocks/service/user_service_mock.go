@@ -87,6 +87,30 @@ func (m *UserServiceMock) GetUserProfile(username string) (*model.UserProfile, e
 	return nil, args.Error(1)
 }
 
+func (m *UserServiceMock) GetUserProfiles(ids []string) ([]model.UserProfile, error) {
+	args := m.Called(ids)
+	if profiles := args.Get(0); profiles != nil {
+		profilesResult, ok := profiles.([]model.UserProfile)
+		if !ok {
+			return nil, args.Error(1)
+		}
+		return profilesResult, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *UserServiceMock) ToUserProfile(user *model.User) *model.UserProfile {
+	args := m.Called(user)
+	if profile := args.Get(0); profile != nil {
+		profileResult, ok := profile.(*model.UserProfile)
+		if !ok {
+			return nil
+		}
+		return profileResult
+	}
+	return nil
+}
+
 func (m *UserServiceMock) DeleteUser(userID string) error {
 	args := m.Called(userID)
 	return args.Error(0)
@@ -0,0 +1,153 @@
+package jsonschema
+
+import (
+	"go-gin-api-server/pkg/jsonschema"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testSchema = `{
+	"type": "object",
+	"required": ["name", "age"],
+	"additionalProperties": false,
+	"properties": {
+		"name": {"type": "string", "minLength": 2, "maxLength": 5, "pattern": "^[a-z]+$"},
+		"age": {"type": "integer", "minimum": 0, "maximum": 150},
+		"email": {"type": "string", "format": "email"},
+		"born": {"type": ["string", "null"], "format": "date-time"},
+		"role": {"type": "string", "enum": ["user", "admin"]},
+		"tags": {"type": "array", "items": {"type": "string"}},
+		"address": {
+			"type": "object",
+			"required": ["city"],
+			"properties": {"city": {"type": "string"}}
+		}
+	}
+}`
+
+func validate(t *testing.T, body string) []jsonschema.FieldError {
+	schema, err := jsonschema.Compile([]byte(testSchema))
+	assert.NoError(t, err)
+	return schema.Validate([]byte(body))
+}
+
+func TestCompile(t *testing.T) {
+	t.Run("UnknownKeyword", func(t *testing.T) {
+		_, err := jsonschema.Compile([]byte(`{"type": "object", "minProperties": 1}`))
+		assert.Error(t, err)
+	})
+
+	t.Run("UnknownType", func(t *testing.T) {
+		_, err := jsonschema.Compile([]byte(`{"type": "text"}`))
+		assert.Error(t, err)
+	})
+
+	t.Run("UnsupportedFormat", func(t *testing.T) {
+		_, err := jsonschema.Compile([]byte(`{"properties": {"site": {"type": "string", "format": "uri"}}}`))
+		assert.Error(t, err)
+	})
+
+	t.Run("InvalidPattern", func(t *testing.T) {
+		_, err := jsonschema.Compile([]byte(`{"type": "string", "pattern": "("}`))
+		assert.Error(t, err)
+	})
+
+	t.Run("MustCompilePanics", func(t *testing.T) {
+		assert.Panics(t, func() { jsonschema.MustCompile(`{"type": 1}`) })
+	})
+}
+
+func TestValidate(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		errs := validate(t, `{
+			"name": "alice", "age": 30, "email": "alice@example.com",
+			"born": "1990-01-01T00:00:00Z", "role": "admin", "tags": ["a"],
+			"address": {"city": "Taipei"}
+		}`)
+
+		assert.Empty(t, errs)
+	})
+
+	t.Run("NullAllowedByTypeList", func(t *testing.T) {
+		assert.Empty(t, validate(t, `{"name": "bob", "age": 1, "born": null}`))
+	})
+
+	t.Run("MissingRequired", func(t *testing.T) {
+		errs := validate(t, `{}`)
+
+		assert.Equal(t, []jsonschema.FieldError{
+			{Field: "name", Message: "is required"},
+			{Field: "age", Message: "is required"},
+		}, errs)
+	})
+
+	t.Run("WrongTypes", func(t *testing.T) {
+		errs := validate(t, `{"name": 1, "age": 1.5, "tags": "a"}`)
+
+		assert.ElementsMatch(t, []jsonschema.FieldError{
+			{Field: "age", Message: "must be integer"},
+			{Field: "name", Message: "must be string"},
+			{Field: "tags", Message: "must be array"},
+		}, errs)
+	})
+
+	t.Run("AdditionalProperty", func(t *testing.T) {
+		errs := validate(t, `{"name": "bob", "age": 1, "admin": true}`)
+
+		assert.Equal(t, []jsonschema.FieldError{{Field: "admin", Message: "is not allowed"}}, errs)
+	})
+
+	t.Run("StringConstraints", func(t *testing.T) {
+		assert.Equal(t, []jsonschema.FieldError{{Field: "name", Message: "must be at least 2 characters"}},
+			validate(t, `{"name": "a", "age": 1}`))
+		assert.Equal(t, []jsonschema.FieldError{{Field: "name", Message: "must be at most 5 characters"}},
+			validate(t, `{"name": "abcdef", "age": 1}`))
+		assert.Equal(t, []jsonschema.FieldError{{Field: "name", Message: "must match pattern ^[a-z]+$"}},
+			validate(t, `{"name": "AB", "age": 1}`))
+	})
+
+	t.Run("LengthCountsCharacters", func(t *testing.T) {
+		assert.Empty(t, validate(t, `{"name": "ab", "age": 1}`))
+		assert.NotEmpty(t, validate(t, `{"name": "é", "age": 1}`))
+	})
+
+	t.Run("NumberBounds", func(t *testing.T) {
+		assert.Equal(t, []jsonschema.FieldError{{Field: "age", Message: "must be at least 0"}},
+			validate(t, `{"name": "bob", "age": -1}`))
+		assert.Equal(t, []jsonschema.FieldError{{Field: "age", Message: "must be at most 150"}},
+			validate(t, `{"name": "bob", "age": 151}`))
+	})
+
+	t.Run("Formats", func(t *testing.T) {
+		errs := validate(t, `{"name": "bob", "age": 1, "email": "not-an-email", "born": "yesterday"}`)
+
+		assert.Equal(t, []jsonschema.FieldError{
+			{Field: "born", Message: "must be an RFC 3339 date-time"},
+			{Field: "email", Message: "must be an email address"},
+		}, errs)
+	})
+
+	t.Run("Enum", func(t *testing.T) {
+		errs := validate(t, `{"name": "bob", "age": 1, "role": "root"}`)
+
+		assert.Equal(t, []jsonschema.FieldError{{Field: "role", Message: "must be one of user, admin"}}, errs)
+	})
+
+	t.Run("NestedPaths", func(t *testing.T) {
+		errs := validate(t, `{"name": "bob", "age": 1, "tags": ["a", 2], "address": {}}`)
+
+		assert.Equal(t, []jsonschema.FieldError{
+			{Field: "address.city", Message: "is required"},
+			{Field: "tags[1]", Message: "must be string"},
+		}, errs)
+	})
+
+	t.Run("NotAnObject", func(t *testing.T) {
+		assert.Equal(t, []jsonschema.FieldError{{Field: "", Message: "must be object"}}, validate(t, `[]`))
+	})
+
+	t.Run("InvalidJSON", func(t *testing.T) {
+		assert.Equal(t, []jsonschema.FieldError{{Field: "", Message: "must be valid JSON"}}, validate(t, `{"name":`))
+	})
+}
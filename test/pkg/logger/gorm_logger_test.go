@@ -0,0 +1,128 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"go-gin-api-server/pkg/logger"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+// Helper functions
+
+// openDryRunDB builds statements without a database connection; GORM still traces them
+func openDryRunDB(t *testing.T, level string, slowThreshold time.Duration, logParams bool) (*gorm.DB, *observer.ObservedLogs) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost dbname=unused"}), &gorm.Config{
+		Logger:               logger.NewGormLogger(zap.New(core), logger.ParseGormLogLevel(level), slowThreshold, logParams),
+		DryRun:               true,
+		DisableAutomaticPing: true,
+	})
+	assert.NoError(t, err)
+	return db, logs
+}
+
+type sample struct {
+	ID   uint64
+	Name string
+}
+
+// Testcases
+
+func TestGormLogger(t *testing.T) {
+	t.Run("DebugLogsQueries", func(t *testing.T) {
+		db, logs := openDryRunDB(t, "debug", 0, true)
+
+		db.Where("name = ?", "gopher").Find(&[]sample{})
+
+		entries := logs.FilterMessage("SQL Query").All()
+		if assert.Len(t, entries, 1) {
+			assert.Equal(t, zapcore.DebugLevel, entries[0].Level)
+			assert.Contains(t, entries[0].ContextMap()["sql"], "name = 'gopher'")
+		}
+	})
+
+	t.Run("ParamsRedactedByDefault", func(t *testing.T) {
+		db, logs := openDryRunDB(t, "debug", 0, false)
+
+		db.Where("email = ?", "gopher@example.com").Find(&[]sample{})
+
+		entries := logs.FilterMessage("SQL Query").All()
+		if assert.Len(t, entries, 1) {
+			sql := entries[0].ContextMap()["sql"].(string)
+			assert.Contains(t, sql, "email = $1")
+			assert.NotContains(t, sql, "gopher@example.com")
+		}
+	})
+
+	t.Run("SlowQueryWarnsWithRedactedSQL", func(t *testing.T) {
+		// a 1ns threshold makes every statement slow
+		db, logs := openDryRunDB(t, "warn", time.Nanosecond, false)
+
+		db.Where("email = ?", "gopher@example.com").Find(&[]sample{})
+
+		entries := logs.FilterMessage("Slow SQL Query").All()
+		if assert.Len(t, entries, 1) {
+			assert.Equal(t, zapcore.WarnLevel, entries[0].Level)
+			fields := entries[0].ContextMap()
+			assert.Contains(t, fields["sql"], "email = $1")
+			assert.NotContains(t, fields["sql"], "gopher@example.com")
+			assert.Contains(t, fields, "duration")
+		}
+	})
+
+	t.Run("ErrorLevelSkipsQueries", func(t *testing.T) {
+		db, logs := openDryRunDB(t, "error", 0, false)
+
+		db.Where("name = ?", "gopher").Find(&[]sample{})
+
+		assert.Zero(t, logs.Len())
+	})
+
+	t.Run("SilentLogsNothing", func(t *testing.T) {
+		db, logs := openDryRunDB(t, "silent", 0, false)
+
+		db.Where("name = ?", "gopher").Find(&[]sample{})
+
+		assert.Zero(t, logs.Len())
+	})
+}
+
+func TestGormLoggerTrace(t *testing.T) {
+	fc := func() (string, int64) { return "SELECT 1", 1 }
+
+	t.Run("SlowQueryWarns", func(t *testing.T) {
+		core, logs := observer.New(zapcore.DebugLevel)
+		l := logger.NewGormLogger(zap.New(core), gormlogger.Warn, 10*time.Millisecond, false)
+
+		l.Trace(context.Background(), time.Now().Add(-50*time.Millisecond), fc, nil)
+		l.Trace(context.Background(), time.Now(), fc, nil) // fast, below Info
+
+		entries := logs.All()
+		if assert.Len(t, entries, 1) {
+			assert.Equal(t, "Slow SQL Query", entries[0].Message)
+			assert.Equal(t, zapcore.WarnLevel, entries[0].Level)
+		}
+	})
+
+	t.Run("ErrorsLoggedButNotFoundIgnored", func(t *testing.T) {
+		core, logs := observer.New(zapcore.DebugLevel)
+		l := logger.NewGormLogger(zap.New(core), gormlogger.Error, 0, false)
+
+		l.Trace(context.Background(), time.Now(), fc, errors.New("connection reset"))
+		l.Trace(context.Background(), time.Now(), fc, gorm.ErrRecordNotFound)
+
+		entries := logs.All()
+		if assert.Len(t, entries, 1) {
+			assert.Equal(t, "SQL Error", entries[0].Message)
+		}
+	})
+}
@@ -0,0 +1,153 @@
+package querybind
+
+import (
+	"go-gin-api-server/pkg/apperrors"
+	"go-gin-api-server/pkg/querybind"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testOptions() querybind.Options {
+	return querybind.Options{
+		SortKeys:   []string{"created_at", "updated_at"},
+		FilterKeys: []string{"status"},
+	}
+}
+
+func parse(t *testing.T, rawQuery string) (querybind.Query, error) {
+	values, err := url.ParseQuery(rawQuery)
+	assert.NoError(t, err)
+	return querybind.Parse(values, testOptions())
+}
+
+func TestParse(t *testing.T) {
+	t.Run("Defaults", func(t *testing.T) {
+		query, err := parse(t, "")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "created_at", query.Sort)
+		assert.Equal(t, querybind.Desc, query.Order)
+		assert.False(t, query.Ascending())
+		assert.Nil(t, query.CreatedAfter)
+		assert.Nil(t, query.CreatedBefore)
+		assert.Empty(t, query.Filters)
+	})
+
+	t.Run("DefaultOrderOption", func(t *testing.T) {
+		query, err := querybind.Parse(url.Values{}, querybind.Options{DefaultOrder: querybind.Asc})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "", query.Sort)
+		assert.True(t, query.Ascending())
+	})
+
+	t.Run("Sort", func(t *testing.T) {
+		query, err := parse(t, "sort=updated_at")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "updated_at", query.Sort)
+	})
+
+	t.Run("UnknownSortKey", func(t *testing.T) {
+		_, err := parse(t, "sort=password")
+
+		assert.ErrorIs(t, err, apperrors.ErrValidation)
+	})
+
+	t.Run("Order", func(t *testing.T) {
+		for raw, expected := range map[string]querybind.Order{
+			"asc":  querybind.Asc,
+			"ASC":  querybind.Asc,
+			"desc": querybind.Desc,
+			"Desc": querybind.Desc,
+		} {
+			query, err := parse(t, "order="+raw)
+
+			assert.NoError(t, err, raw)
+			assert.Equal(t, expected, query.Order, raw)
+		}
+	})
+
+	t.Run("InvalidOrder", func(t *testing.T) {
+		_, err := parse(t, "order=up")
+
+		assert.ErrorIs(t, err, apperrors.ErrValidation)
+	})
+
+	t.Run("CreatedAfter", func(t *testing.T) {
+		query, err := parse(t, "created_after=2024-01-01T08:00:00%2B08:00")
+
+		assert.NoError(t, err)
+		assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), *query.CreatedAfter)
+		assert.Nil(t, query.CreatedBefore)
+	})
+
+	t.Run("CreatedBeforeWithFraction", func(t *testing.T) {
+		query, err := parse(t, "created_before=2024-01-01T00:00:00.123456Z")
+
+		assert.NoError(t, err)
+		assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 123456000, time.UTC), *query.CreatedBefore)
+	})
+
+	t.Run("InvalidTimestamps", func(t *testing.T) {
+		for _, raw := range []string{
+			"created_after=yesterday",
+			"created_after=2024-01-01",
+			"created_before=1704067200",
+			"created_before=2024-13-01T00:00:00Z",
+		} {
+			_, err := parse(t, raw)
+
+			assert.ErrorIs(t, err, apperrors.ErrValidation, raw)
+		}
+	})
+
+	t.Run("DateRange", func(t *testing.T) {
+		query, err := parse(t, "created_after=2024-01-01T00:00:00Z&created_before=2024-02-01T00:00:00Z")
+
+		assert.NoError(t, err)
+		assert.True(t, query.CreatedAfter.Before(*query.CreatedBefore))
+	})
+
+	t.Run("EmptyDateRange", func(t *testing.T) {
+		_, err := parse(t, "created_after=2024-02-01T00:00:00Z&created_before=2024-01-01T00:00:00Z")
+		assert.ErrorIs(t, err, apperrors.ErrValidation)
+
+		_, err = parse(t, "created_after=2024-01-01T00:00:00Z&created_before=2024-01-01T00:00:00Z")
+		assert.ErrorIs(t, err, apperrors.ErrValidation)
+	})
+
+	t.Run("Filters", func(t *testing.T) {
+		query, err := parse(t, "status=hidden&cursor=abc&limit=10")
+
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{"status": "hidden"}, query.Filters)
+	})
+
+	t.Run("EmptyFilterIgnored", func(t *testing.T) {
+		query, err := parse(t, "status=")
+
+		assert.NoError(t, err)
+		assert.Empty(t, query.Filters)
+	})
+
+	t.Run("Combined", func(t *testing.T) {
+		query, err := parse(t, "sort=updated_at&order=asc&created_after=2024-01-01T00:00:00Z&created_before=2024-02-01T00:00:00Z&status=visible")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "updated_at", query.Sort)
+		assert.True(t, query.Ascending())
+		assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), *query.CreatedAfter)
+		assert.Equal(t, time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), *query.CreatedBefore)
+		assert.Equal(t, "visible", query.Filters["status"])
+	})
+
+	t.Run("CombinedWithOneInvalid", func(t *testing.T) {
+		_, err := parse(t, "sort=updated_at&order=asc&created_after=not-a-time")
+
+		assert.ErrorIs(t, err, apperrors.ErrValidation)
+	})
+}
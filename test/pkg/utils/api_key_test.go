@@ -0,0 +1,33 @@
+package utils
+
+import (
+	"go-gin-api-server/pkg/utils"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateAPIKey(t *testing.T) {
+	t.Run("PrefixedAndUnique", func(t *testing.T) {
+		key, prefix, err := utils.GenerateAPIKey()
+		assert.NoError(t, err)
+		other, _, err := utils.GenerateAPIKey()
+		assert.NoError(t, err)
+
+		assert.True(t, strings.HasPrefix(key, utils.APIKeyPrefix))
+		assert.True(t, strings.HasPrefix(key, prefix))
+		assert.Less(t, len(prefix), len(key))
+		assert.NotEqual(t, key, other)
+	})
+}
+
+func TestHashAPIKey(t *testing.T) {
+	t.Run("StableHexDigest", func(t *testing.T) {
+		hash := utils.HashAPIKey("gak_example")
+
+		assert.Len(t, hash, 64)
+		assert.Equal(t, hash, utils.HashAPIKey("gak_example"))
+		assert.NotEqual(t, hash, utils.HashAPIKey("gak_other"))
+	})
+}
@@ -0,0 +1,37 @@
+package utils
+
+import (
+	"go-gin-api-server/pkg/utils"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExcerpt(t *testing.T) {
+	t.Run("ShorterThanLimitUnchanged", func(t *testing.T) {
+		assert.Equal(t, "Hello world", utils.Excerpt("Hello world", 11))
+		assert.Equal(t, "Hello world", utils.Excerpt("Hello world", 80))
+		assert.Equal(t, "", utils.Excerpt("", 10))
+	})
+
+	t.Run("CutsAtWordBoundary", func(t *testing.T) {
+		assert.Equal(t, "Hello…", utils.Excerpt("Hello wonderful world", 10))
+		assert.Equal(t, "Hello wonderful…", utils.Excerpt("Hello wonderful world", 15))
+		assert.Equal(t, "Hi…", utils.Excerpt("Hi   there", 5))
+	})
+
+	t.Run("LongFirstWordIsCut", func(t *testing.T) {
+		assert.Equal(t, "Supercal…", utils.Excerpt("Supercalifragilistic word", 8))
+	})
+
+	t.Run("Multibyte", func(t *testing.T) {
+		// counts characters, not bytes, and never splits one
+		assert.Equal(t, "héllo", utils.Excerpt("héllo", 5))
+		assert.Equal(t, "這是中文…", utils.Excerpt("這是中文內容", 4))
+		assert.Equal(t, "café…", utils.Excerpt("café 咖啡 crème", 6))
+	})
+
+	t.Run("NonPositiveLimitUnchanged", func(t *testing.T) {
+		assert.Equal(t, "Hello world", utils.Excerpt("Hello world", 0))
+	})
+}
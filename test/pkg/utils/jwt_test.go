@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -36,6 +37,17 @@ func TestJWTManager_GenerateToken(t *testing.T) {
 		assert.Equal(t, int64(900), tokenResponse.ExpiresIn) // 15 minutes in seconds
 	})
 
+	t.Run("RefreshExpiration", func(t *testing.T) {
+		jwtMgr := utils.NewJWTManagerWithRefresh("test-secret", 15*time.Minute, 48*time.Hour)
+
+		tokenResponse, err := jwtMgr.GenerateToken(&model.User{ID: "user-123"})
+		assert.NoError(t, err)
+		claims, err := jwtMgr.ValidateToken(tokenResponse.RefreshToken)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 48*time.Hour, claims.ExpiresAt.Sub(claims.IssuedAt.Time))
+	})
+
 	t.Run("EmptySecretKey", func(t *testing.T) {
 		jwtMgr := utils.NewJWTManager("", 15*time.Minute)
 		user := &model.User{
@@ -244,3 +256,106 @@ func TestJWTManager_TokenConsistency(t *testing.T) {
 		assert.Equal(t, user.ID, claims.Subject)
 	})
 }
+
+func TestJWTManager_KeyRotation(t *testing.T) {
+	oldKey := utils.SigningKey{ID: "2024-01", Secret: "old-secret"}
+	newKey := utils.SigningKey{ID: "2024-07", Secret: "new-secret"}
+	user := &model.User{ID: "user-123"}
+
+	t.Run("SetsKidHeader", func(t *testing.T) {
+		jwtMgr := utils.NewJWTManagerWithKeys(newKey, nil, 15*time.Minute, time.Hour)
+
+		tokenString, err := jwtMgr.GenerateAccessToken(user)
+		assert.NoError(t, err)
+
+		token, _, err := jwt.NewParser().ParseUnverified(tokenString, &model.Claims{})
+		assert.NoError(t, err)
+		assert.Equal(t, newKey.ID, token.Header["kid"])
+	})
+
+	t.Run("OldTokenValidAfterRotation", func(t *testing.T) {
+		before := utils.NewJWTManagerWithKeys(oldKey, nil, 15*time.Minute, time.Hour)
+		tokens, err := before.GenerateToken(user)
+		assert.NoError(t, err)
+
+		// rotate: new primary, the old key kept for verification
+		after := utils.NewJWTManagerWithKeys(newKey, []utils.SigningKey{oldKey}, 15*time.Minute, time.Hour)
+
+		for _, tokenString := range []string{tokens.AccessToken, tokens.RefreshToken} {
+			claims, err := after.ValidateToken(tokenString)
+			assert.NoError(t, err)
+			assert.Equal(t, user.ID, claims.UserID)
+		}
+
+		// new tokens are signed with the new key
+		fresh, err := after.GenerateAccessToken(user)
+		assert.NoError(t, err)
+		_, err = before.ValidateToken(fresh)
+		assert.ErrorIs(t, err, apperrors.ErrInvalidToken)
+	})
+
+	t.Run("RetiredKeyRejected", func(t *testing.T) {
+		before := utils.NewJWTManagerWithKeys(oldKey, nil, 15*time.Minute, time.Hour)
+		tokenString, err := before.GenerateAccessToken(user)
+		assert.NoError(t, err)
+
+		// the old key has been dropped from the verification set
+		after := utils.NewJWTManagerWithKeys(newKey, nil, 15*time.Minute, time.Hour)
+		claims, err := after.ValidateToken(tokenString)
+
+		assert.ErrorIs(t, err, apperrors.ErrInvalidToken)
+		assert.Nil(t, claims)
+	})
+
+	t.Run("TokenWithoutKidUsesPrimary", func(t *testing.T) {
+		// issued before key IDs were configured
+		legacy := utils.NewJWTManager(newKey.Secret, 15*time.Minute)
+		tokenString, err := legacy.GenerateAccessToken(user)
+		assert.NoError(t, err)
+
+		jwtMgr := utils.NewJWTManagerWithKeys(newKey, []utils.SigningKey{oldKey}, 15*time.Minute, time.Hour)
+		claims, err := jwtMgr.ValidateToken(tokenString)
+
+		assert.NoError(t, err)
+		assert.Equal(t, user.ID, claims.UserID)
+	})
+
+	t.Run("TokenWithoutKidSignedByOldSecretAfterRotation", func(t *testing.T) {
+		// the default deployment: JWT_KEY_ID was never set, so no token carries a kid
+		legacy := utils.NewJWTManager(oldKey.Secret, 15*time.Minute)
+		tokens, err := legacy.GenerateToken(user)
+		assert.NoError(t, err)
+
+		// first rotation: the old secret is listed without a name
+		jwtMgr := utils.NewJWTManagerWithKeys(newKey, []utils.SigningKey{{Secret: oldKey.Secret}}, 15*time.Minute, time.Hour)
+
+		for _, tokenString := range []string{tokens.AccessToken, tokens.RefreshToken} {
+			claims, err := jwtMgr.ValidateToken(tokenString)
+			assert.NoError(t, err)
+			assert.Equal(t, user.ID, claims.UserID)
+		}
+	})
+
+	t.Run("TokenWithoutKidUnknownSecretRejected", func(t *testing.T) {
+		forged := utils.NewJWTManager("some-other-secret", 15*time.Minute)
+		tokenString, err := forged.GenerateAccessToken(user)
+		assert.NoError(t, err)
+
+		jwtMgr := utils.NewJWTManagerWithKeys(newKey, []utils.SigningKey{oldKey}, 15*time.Minute, time.Hour)
+		claims, err := jwtMgr.ValidateToken(tokenString)
+
+		assert.ErrorIs(t, err, apperrors.ErrInvalidToken)
+		assert.Nil(t, claims)
+	})
+
+	t.Run("ExpiredTokenWithoutKid", func(t *testing.T) {
+		legacy := utils.NewJWTManager(oldKey.Secret, -time.Minute)
+		tokenString, err := legacy.GenerateAccessToken(user)
+		assert.NoError(t, err)
+
+		jwtMgr := utils.NewJWTManagerWithKeys(newKey, []utils.SigningKey{{Secret: oldKey.Secret}}, 15*time.Minute, time.Hour)
+		_, err = jwtMgr.ValidateToken(tokenString)
+
+		assert.ErrorIs(t, err, apperrors.ErrExpiredToken)
+	})
+}
@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"go-gin-api-server/pkg/apperrors"
 	"go-gin-api-server/pkg/utils"
 	"testing"
 
@@ -57,3 +58,57 @@ func TestPasswordRoundTrip(t *testing.T) {
 		assert.NoError(t, err)
 	})
 }
+
+func TestValidatePasswordStrength(t *testing.T) {
+	strict := utils.PasswordPolicy{MinLength: 10, RequireDigit: true, RequireUpper: true, RequireSymbol: true}
+
+	tests := []struct {
+		name     string
+		password string
+		unmet    []error
+	}{
+		{name: "Compliant", password: "Str0ng!Password"},
+		{name: "TooShort", password: "Sh0rt!", unmet: []error{apperrors.ErrPasswordTooShort}},
+		{name: "MissingDigit", password: "NoDigits!Here", unmet: []error{apperrors.ErrPasswordMissingDigit}},
+		{name: "MissingUpper", password: "no-upper-123", unmet: []error{apperrors.ErrPasswordMissingUpper}},
+		{name: "MissingSymbol", password: "NoSymbol1234", unmet: []error{apperrors.ErrPasswordMissingSymbol}},
+		{
+			name:     "ReportsEveryUnmetRule",
+			password: "weak",
+			unmet: []error{
+				apperrors.ErrPasswordTooShort,
+				apperrors.ErrPasswordMissingDigit,
+				apperrors.ErrPasswordMissingUpper,
+				apperrors.ErrPasswordMissingSymbol,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := utils.ValidatePasswordStrength(tt.password, strict)
+
+			if len(tt.unmet) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, apperrors.ErrValidation)
+			var validationErrs *apperrors.ValidationErrors
+			if assert.ErrorAs(t, err, &validationErrs) {
+				assert.Equal(t, tt.unmet, validationErrs.Errs)
+			}
+		})
+	}
+
+	t.Run("LengthCountsCharacters", func(t *testing.T) {
+		err := utils.ValidatePasswordStrength("密碼密碼密碼", utils.PasswordPolicy{MinLength: 6})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("ZeroPolicyAcceptsAnything", func(t *testing.T) {
+		err := utils.ValidatePasswordStrength("a", utils.PasswordPolicy{})
+
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,27 @@
+package utils
+
+import (
+	"go-gin-api-server/pkg/utils"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlugify(t *testing.T) {
+	t.Run("Basic", func(t *testing.T) {
+		assert.Equal(t, "hello-world", utils.Slugify("Hello World", 64))
+	})
+
+	t.Run("CollapsesSeparators", func(t *testing.T) {
+		assert.Equal(t, "go-is-fun-2024", utils.Slugify("  Go -- is   FUN!! (2024) ", 64))
+	})
+
+	t.Run("DropsNonASCII", func(t *testing.T) {
+		assert.Equal(t, "caf-go", utils.Slugify("Café 咖啡 Go", 64))
+		assert.Equal(t, "", utils.Slugify("這是中文", 64))
+	})
+
+	t.Run("TruncatesWithoutTrailingHyphen", func(t *testing.T) {
+		assert.Equal(t, "abc", utils.Slugify("abc def", 4))
+	})
+}
@@ -0,0 +1,65 @@
+package utils
+
+import (
+	"go-gin-api-server/pkg/utils"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func assertRevoked(t *testing.T, denylist utils.TokenDenylist, jti string) {
+	t.Helper()
+	revoked, err := denylist.IsRevoked(jti)
+	assert.NoError(t, err)
+	assert.True(t, revoked)
+}
+
+func assertNotRevoked(t *testing.T, denylist utils.TokenDenylist, jti string) {
+	t.Helper()
+	revoked, err := denylist.IsRevoked(jti)
+	assert.NoError(t, err)
+	assert.False(t, revoked)
+}
+
+func TestMemoryTokenDenylist(t *testing.T) {
+	t.Run("RevokedUntilExpiry", func(t *testing.T) {
+		denylist := utils.NewMemoryTokenDenylist()
+		denylist.Revoke("jti-1", time.Now().Add(time.Minute))
+
+		assertRevoked(t, denylist, "jti-1")
+		assertNotRevoked(t, denylist, "jti-2")
+		assertNotRevoked(t, denylist, "")
+	})
+
+	t.Run("ExpiredEntriesArePurged", func(t *testing.T) {
+		denylist := utils.NewMemoryTokenDenylist()
+		denylist.Revoke("expired", time.Now().Add(10*time.Millisecond))
+		denylist.Revoke("live", time.Now().Add(time.Minute))
+		assert.Equal(t, 2, denylist.Len())
+
+		time.Sleep(20 * time.Millisecond)
+		denylist.Purge()
+
+		assert.Equal(t, 1, denylist.Len())
+		assertNotRevoked(t, denylist, "expired")
+		assertRevoked(t, denylist, "live")
+	})
+
+	t.Run("ExpiredEntryNotRevoked", func(t *testing.T) {
+		denylist := utils.NewMemoryTokenDenylist()
+		denylist.Revoke("jti-1", time.Now().Add(-time.Second))
+
+		assertNotRevoked(t, denylist, "jti-1")
+		assert.Equal(t, 0, denylist.Len())
+	})
+
+	t.Run("RevokeAgainKeepsLaterExpiry", func(t *testing.T) {
+		denylist := utils.NewMemoryTokenDenylist()
+		denylist.Revoke("jti-1", time.Now().Add(time.Minute))
+		denylist.Revoke("jti-1", time.Now().Add(10*time.Millisecond))
+
+		time.Sleep(20 * time.Millisecond)
+		assertRevoked(t, denylist, "jti-1")
+	})
+}